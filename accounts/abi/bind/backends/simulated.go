@@ -67,7 +67,7 @@ type SimulatedBackend struct {
 // for testing purposes.
 func NewSimulatedBackend(alloc core.GenesisAlloc, gasLimit uint64) *SimulatedBackend {
 	database := memorydb.NewMemDatabase()
-	simConfig := &params.ChainConfig{big.NewInt(1337), nil, "", false}
+	simConfig := &params.ChainConfig{big.NewInt(1337), nil, "", false, ""}
 	genesis := core.Genesis{Config: simConfig, GasLimit: gasLimit, Alloc: alloc}
 	genesis.MustCommit(database)
 	blockchain, _, _ := core.NewBlockChain(database, nil, nil, genesis.Config, nil, vm.Config{}, nil)
@@ -420,6 +420,8 @@ func (m callmsg) Gas() uint64          { return m.CallMsg.Gas }
 func (m callmsg) Value() *big.Int      { return m.CallMsg.Value }
 func (m callmsg) Data() []byte         { return m.CallMsg.Data }
 
+func (m callmsg) Sponsor() *common.Address { return nil }
+
 func (m callmsg) TxType() uint64          { return 0 }
 func (m callmsg) SetTo(to common.Address) {}
 func (m callmsg) SetData(b []byte)        {}
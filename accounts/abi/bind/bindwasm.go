@@ -0,0 +1,163 @@
+package bind
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Venachain/Venachain/life/utils"
+	"golang.org/x/tools/imports"
+)
+
+// BindWasm generates a Go wrapper around the life ABI of WASM contracts, the
+// counterpart of Bind for the contracts executed by the life interpreter. The
+// bytecodes are the raw WASM codes, hex encoded into the binding.
+func BindWasm(types []string, abis []string, bytecodes [][]byte, pkg string) (string, error) {
+	// Process each individual contract requested binding
+	contracts := make(map[string]*tmplWasmContract)
+
+	for i := 0; i < len(types); i++ {
+		wasmABI, err := ParseWasmABI(abis[i])
+		if err != nil {
+			return "", err
+		}
+		// Strip any whitespace from the JSON ABI
+		strippedABI := strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, abis[i])
+
+		var (
+			calls     = make(map[string]*tmplWasmMethod)
+			transacts = make(map[string]*tmplWasmMethod)
+			events    = make(map[string]*tmplWasmMethod)
+		)
+		for _, original := range wasmABI.AbiArr {
+			// Skip the entries without a name to bind them to, e.g. anonymous events
+			if wasmIdentifier(original.Name) == "" {
+				continue
+			}
+			method, err := bindWasmMethod(original)
+			if err != nil {
+				return "", fmt.Errorf("%s: %v", types[i], err)
+			}
+			switch {
+			case strings.EqualFold(original.Type, "event"):
+				// Events are unpacked into structs, name the fields as UnpackEvent does
+				for j := range method.Inputs {
+					method.Inputs[j].Name = wasmArgName(original.Inputs[j].Name, j)
+				}
+				events[original.Name] = method
+			case original.Constant == "true":
+				calls[original.Name] = method
+			default:
+				transacts[original.Name] = method
+			}
+		}
+		var bin string
+		if i < len(bytecodes) && len(bytecodes[i]) > 0 {
+			bin = "0x" + hex.EncodeToString(bytecodes[i])
+		}
+		contracts[types[i]] = &tmplWasmContract{
+			Type:      capitalise(types[i]),
+			InputABI:  strings.Replace(strippedABI, "\"", "\\\"", -1),
+			InputBin:  bin,
+			Calls:     calls,
+			Transacts: transacts,
+			Events:    events,
+		}
+	}
+	// Generate the contract template data content and render it
+	data := &tmplWasmData{
+		Package:   pkg,
+		Contracts: contracts,
+	}
+	buffer := new(bytes.Buffer)
+
+	tmpl := template.Must(template.New("").Parse(tmplSourceWasmGo))
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return "", err
+	}
+	// Pass the code through goimports to clean it up and double check
+	code, err := imports.Process(".", buffer.Bytes(), nil)
+	if err != nil {
+		return "", fmt.Errorf("%v\n%s", err, buffer)
+	}
+	return string(code), nil
+}
+
+// bindWasmMethod converts a function or an event of the life ABI to its
+// template data.
+func bindWasmMethod(original utils.AbiStruct) (*tmplWasmMethod, error) {
+	method := &tmplWasmMethod{
+		Original: original.Name,
+		Name:     wasmIdentifier(original.Name),
+		Inputs:   make([]tmplWasmArg, len(original.Inputs)),
+	}
+	params := make([]string, len(original.Inputs))
+	for i, input := range original.Inputs {
+		kind, err := bindTypeWasmGo(input.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s argument %d: %v", original.Name, i, err)
+		}
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		} else if token.Lookup(name).IsKeyword() {
+			name = "_" + name
+		}
+		method.Inputs[i] = tmplWasmArg{Name: name, Type: kind}
+		params[i] = strings.TrimSpace(input.Type + " " + input.Name)
+	}
+	method.Signature = fmt.Sprintf("%s(%s)", original.Name, strings.Join(params, ", "))
+
+	if len(original.Outputs) > 0 && original.Outputs[0].Type != "void" {
+		kind, err := bindTypeWasmGo(original.Outputs[0].Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s output: %v", original.Name, err)
+		}
+		method.Output = kind
+		method.Signature += " " + original.Outputs[0].Type
+	}
+	return method, nil
+}
+
+// wasmIdentifier converts the name of a function or an event to an exported Go
+// identifier, the names of the life ABI may contain spaces and punctuation, e.g.
+// "[CNS] Notify".
+func wasmIdentifier(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	for i, word := range words {
+		words[i] = capitalise(word)
+	}
+	return strings.Join(words, "")
+}
+
+// bindTypeWasmGo converts a life ABI type to the Go type it is bound to. The
+// 128 bits integers don't fit a Go integer and are mapped to big.Int, the
+// float128 are kept as their raw 16 bytes.
+func bindTypeWasmGo(kind string) (string, error) {
+	switch kind {
+	case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64", "float32", "float64", "bool", "string":
+		return kind, nil
+	case "int":
+		return "int32", nil
+	case "uint":
+		return "uint32", nil
+	case "int128_s", "uint128_s", "int256_s", "uint256_s":
+		return "string", nil
+	case "int128", "uint128":
+		return "*big.Int", nil
+	case "float128":
+		return "[]byte", nil
+	}
+	return "", fmt.Errorf("unsupported type %q", kind)
+}
@@ -49,6 +49,39 @@ type tmplEvent struct {
 	Normalized abi.Event // Normalized version of the parsed fields
 }
 
+// tmplWasmData is the data structure required to fill the WASM binding template.
+type tmplWasmData struct {
+	Package   string                       // Name of the package to place the generated file in
+	Contracts map[string]*tmplWasmContract // List of contracts to generate into this file
+}
+
+// tmplWasmContract contains the data needed to generate an individual WASM
+// contract binding.
+type tmplWasmContract struct {
+	Type      string                     // Type name of the main contract binding
+	InputABI  string                     // JSON ABI used as the input to generate the binding from
+	InputBin  string                     // Optional hex encoded WASM code used to generate deploy code from
+	Calls     map[string]*tmplWasmMethod // Contract calls that only read state data
+	Transacts map[string]*tmplWasmMethod // Contract calls that write state data
+	Events    map[string]*tmplWasmMethod // Contract events accessors
+}
+
+// tmplWasmMethod is a function or an event of the life ABI with the names and
+// the Go types of its arguments.
+type tmplWasmMethod struct {
+	Original  string        // Original name of the function or event
+	Name      string        // Capitalised name of the Go method
+	Signature string        // Human readable signature of the function or event
+	Inputs    []tmplWasmArg // Arguments of the function or event
+	Output    string        // Go type of the return value, empty if none
+}
+
+// tmplWasmArg is an argument of a WASM function or event.
+type tmplWasmArg struct {
+	Name string // Go name of the argument
+	Type string // Go type of the argument
+}
+
 // tmplSource is language to template mapping containing all the supported
 // programming languages the package can generate to.
 var tmplSource = map[Lang]string{
@@ -520,3 +553,291 @@ import org.venachain.internal.*;
 	}
 {{end}}
 `
+
+// tmplSourceWasmGo is the Go source template use to generate the WASM contract
+// binding based on.
+const tmplSourceWasmGo = `
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+{{range $contract := .Contracts}}
+	// {{.Type}}ABI is the input ABI used to generate the binding from.
+	const {{.Type}}ABI = "{{.InputABI}}"
+
+	{{if .InputBin}}
+		// {{.Type}}Bin is the WASM code used for deploying new contracts.
+		const {{.Type}}Bin = ` + "`" + `{{.InputBin}}` + "`" + `
+
+		// Deploy{{.Type}} deploys a new WASM contract, binding an instance of {{.Type}} to it.
+		func Deploy{{.Type}}(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *{{.Type}}, error) {
+		  parsed, err := bind.ParseWasmABI({{.Type}}ABI)
+		  if err != nil {
+		    return common.Address{}, nil, nil, err
+		  }
+		  address, tx, contract, err := bind.DeployWasmContract(auth, parsed, common.FromHex({{.Type}}Bin), backend)
+		  if err != nil {
+		    return common.Address{}, nil, nil, err
+		  }
+		  return address, tx, &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
+		}
+	{{end}}
+
+	// {{.Type}} is an auto generated Go binding around a WASM contract.
+	type {{.Type}} struct {
+	  {{.Type}}Caller     // Read-only binding to the contract
+	  {{.Type}}Transactor // Write-only binding to the contract
+	  {{.Type}}Filterer   // Log filterer for contract events
+	}
+
+	// {{.Type}}Caller is an auto generated read-only Go binding around a WASM contract.
+	type {{.Type}}Caller struct {
+	  contract *bind.WasmBoundContract // Generic contract wrapper for the low level calls
+	}
+
+	// {{.Type}}Transactor is an auto generated write-only Go binding around a WASM contract.
+	type {{.Type}}Transactor struct {
+	  contract *bind.WasmBoundContract // Generic contract wrapper for the low level calls
+	}
+
+	// {{.Type}}Filterer is an auto generated log filtering Go binding around a WASM contract events.
+	type {{.Type}}Filterer struct {
+	  contract *bind.WasmBoundContract // Generic contract wrapper for the low level calls
+	}
+
+	// {{.Type}}Session is an auto generated Go binding around a WASM contract,
+	// with pre-set call and transact options.
+	type {{.Type}}Session struct {
+	  Contract     *{{.Type}}        // Generic contract binding to set the session for
+	  CallOpts     bind.CallOpts     // Call options to use throughout this session
+	  TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+	}
+
+	// {{.Type}}CallerSession is an auto generated read-only Go binding around a WASM contract,
+	// with pre-set call options.
+	type {{.Type}}CallerSession struct {
+	  Contract *{{.Type}}Caller // Generic contract caller binding to set the session for
+	  CallOpts bind.CallOpts    // Call options to use throughout this session
+	}
+
+	// {{.Type}}TransactorSession is an auto generated write-only Go binding around a WASM contract,
+	// with pre-set transact options.
+	type {{.Type}}TransactorSession struct {
+	  Contract     *{{.Type}}Transactor // Generic contract transactor binding to set the session for
+	  TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+	}
+
+	// New{{.Type}} creates a new instance of {{.Type}}, bound to a specific deployed contract.
+	func New{{.Type}}(address common.Address, backend bind.ContractBackend) (*{{.Type}}, error) {
+	  contract, err := bind{{.Type}}(address, backend, backend, backend)
+	  if err != nil {
+	    return nil, err
+	  }
+	  return &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
+	}
+
+	// New{{.Type}}Caller creates a new read-only instance of {{.Type}}, bound to a specific deployed contract.
+	func New{{.Type}}Caller(address common.Address, caller bind.ContractCaller) (*{{.Type}}Caller, error) {
+	  contract, err := bind{{.Type}}(address, caller, nil, nil)
+	  if err != nil {
+	    return nil, err
+	  }
+	  return &{{.Type}}Caller{contract: contract}, nil
+	}
+
+	// New{{.Type}}Transactor creates a new write-only instance of {{.Type}}, bound to a specific deployed contract.
+	func New{{.Type}}Transactor(address common.Address, transactor bind.ContractTransactor) (*{{.Type}}Transactor, error) {
+	  contract, err := bind{{.Type}}(address, nil, transactor, nil)
+	  if err != nil {
+	    return nil, err
+	  }
+	  return &{{.Type}}Transactor{contract: contract}, nil
+	}
+
+	// New{{.Type}}Filterer creates a new log filterer instance of {{.Type}}, bound to a specific deployed contract.
+	func New{{.Type}}Filterer(address common.Address, filterer bind.ContractFilterer) (*{{.Type}}Filterer, error) {
+	  contract, err := bind{{.Type}}(address, nil, nil, filterer)
+	  if err != nil {
+	    return nil, err
+	  }
+	  return &{{.Type}}Filterer{contract: contract}, nil
+	}
+
+	// bind{{.Type}} binds a generic wrapper to an already deployed contract.
+	func bind{{.Type}}(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.WasmBoundContract, error) {
+	  parsed, err := bind.ParseWasmABI({{.Type}}ABI)
+	  if err != nil {
+	    return nil, err
+	  }
+	  return bind.NewWasmBoundContract(address, parsed, caller, transactor, filterer), nil
+	}
+
+	{{range .Calls}}
+		// {{.Name}} is a free data retrieval call binding the contract function {{.Original}}.
+		//
+		// Wasm: {{.Signature}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Caller) {{.Name}}(opts *bind.CallOpts {{range .Inputs}}, {{.Name}} {{.Type}} {{end}}) ({{if .Output}}{{.Output}},{{end}} error) {
+			{{if .Output}}ret := new({{.Output}})
+			err := _{{$contract.Type}}.contract.Call(opts, ret, "{{.Original}}" {{range .Inputs}}, {{.Name}}{{end}})
+			return *ret, err{{else}}return _{{$contract.Type}}.contract.Call(opts, nil, "{{.Original}}" {{range .Inputs}}, {{.Name}}{{end}}){{end}}
+		}
+
+		// {{.Name}} is a free data retrieval call binding the contract function {{.Original}}.
+		//
+		// Wasm: {{.Signature}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Session) {{.Name}}({{range $i, $_ := .Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{.Type}} {{end}}) ({{if .Output}}{{.Output}},{{end}} error) {
+		  return _{{$contract.Type}}.Contract.{{.Name}}(&_{{$contract.Type}}.CallOpts {{range .Inputs}}, {{.Name}}{{end}})
+		}
+
+		// {{.Name}} is a free data retrieval call binding the contract function {{.Original}}.
+		//
+		// Wasm: {{.Signature}}
+		func (_{{$contract.Type}} *{{$contract.Type}}CallerSession) {{.Name}}({{range $i, $_ := .Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{.Type}} {{end}}) ({{if .Output}}{{.Output}},{{end}} error) {
+		  return _{{$contract.Type}}.Contract.{{.Name}}(&_{{$contract.Type}}.CallOpts {{range .Inputs}}, {{.Name}}{{end}})
+		}
+	{{end}}
+
+	{{range .Transacts}}
+		// {{.Name}} is a paid mutator transaction binding the contract function {{.Original}}.
+		//
+		// Wasm: {{.Signature}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Transactor) {{.Name}}(opts *bind.TransactOpts {{range .Inputs}}, {{.Name}} {{.Type}} {{end}}) (*types.Transaction, error) {
+			return _{{$contract.Type}}.contract.Transact(opts, "{{.Original}}" {{range .Inputs}}, {{.Name}}{{end}})
+		}
+
+		// {{.Name}} is a paid mutator transaction binding the contract function {{.Original}}.
+		//
+		// Wasm: {{.Signature}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Session) {{.Name}}({{range $i, $_ := .Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{.Type}} {{end}}) (*types.Transaction, error) {
+		  return _{{$contract.Type}}.Contract.{{.Name}}(&_{{$contract.Type}}.TransactOpts {{range .Inputs}}, {{.Name}}{{end}})
+		}
+
+		// {{.Name}} is a paid mutator transaction binding the contract function {{.Original}}.
+		//
+		// Wasm: {{.Signature}}
+		func (_{{$contract.Type}} *{{$contract.Type}}TransactorSession) {{.Name}}({{range $i, $_ := .Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{.Type}} {{end}}) (*types.Transaction, error) {
+		  return _{{$contract.Type}}.Contract.{{.Name}}(&_{{$contract.Type}}.TransactOpts {{range .Inputs}}, {{.Name}}{{end}})
+		}
+	{{end}}
+
+	{{range .Events}}
+		// {{$contract.Type}}{{.Name}}Iterator is returned from Filter{{.Name}} and is used to iterate over the raw logs and unpacked data for {{.Name}} events raised by the {{$contract.Type}} contract.
+		type {{$contract.Type}}{{.Name}}Iterator struct {
+			Event *{{$contract.Type}}{{.Name}} // Event containing the contract specifics and raw log
+
+			contract *bind.WasmBoundContract // Generic contract to use for unpacking event data
+			event    string                  // Event name to use for unpacking event data
+
+			logs chan types.Log        // Log channel receiving the found contract events
+			sub  ethereum.Subscription // Subscription for errors, completion and termination
+			done bool                  // Whether the subscription completed delivering logs
+			fail error                 // Occurred error to stop iteration
+		}
+		// Next advances the iterator to the subsequent event, returning whether there
+		// are any more events found. In case of a retrieval or parsing error, false is
+		// returned and Error() can be queried for the exact failure.
+		func (it *{{$contract.Type}}{{.Name}}Iterator) Next() bool {
+			// If the iterator failed, stop iterating
+			if (it.fail != nil) {
+				return false
+			}
+			// If the iterator completed, deliver directly whatever's available
+			if (it.done) {
+				select {
+				case log := <-it.logs:
+					it.Event = new({{$contract.Type}}{{.Name}})
+					if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+						it.fail = err
+						return false
+					}
+					it.Event.Raw = log
+					return true
+
+				default:
+					return false
+				}
+			}
+			// Iterator still in progress, wait for either a data or an error event
+			select {
+			case log := <-it.logs:
+				it.Event = new({{$contract.Type}}{{.Name}})
+				if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+					it.fail = err
+					return false
+				}
+				it.Event.Raw = log
+				return true
+
+			case err := <-it.sub.Err():
+				it.done = true
+				it.fail = err
+				return it.Next()
+			}
+		}
+		// Error returns any retrieval or parsing error occurred during filtering.
+		func (it *{{$contract.Type}}{{.Name}}Iterator) Error() error {
+			return it.fail
+		}
+		// Close terminates the iteration process, releasing any pending underlying
+		// resources.
+		func (it *{{$contract.Type}}{{.Name}}Iterator) Close() error {
+			it.sub.Unsubscribe()
+			return nil
+		}
+
+		// {{$contract.Type}}{{.Name}} represents a {{.Name}} event raised by the {{$contract.Type}} contract.
+		type {{$contract.Type}}{{.Name}} struct { {{range .Inputs}}
+			{{.Name}} {{.Type}}; {{end}}
+			Raw types.Log // Blockchain specific contextual infos
+		}
+
+		// Filter{{.Name}} is a free log retrieval operation binding the contract event {{.Original}}.
+		//
+		// Wasm: {{.Signature}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Filterer) Filter{{.Name}}(opts *bind.FilterOpts) (*{{$contract.Type}}{{.Name}}Iterator, error) {
+			logs, sub, err := _{{$contract.Type}}.contract.FilterLogs(opts, "{{.Original}}")
+			if err != nil {
+				return nil, err
+			}
+			return &{{$contract.Type}}{{.Name}}Iterator{contract: _{{$contract.Type}}.contract, event: "{{.Original}}", logs: logs, sub: sub}, nil
+		}
+
+		// Watch{{.Name}} is a free log subscription operation binding the contract event {{.Original}}.
+		//
+		// Wasm: {{.Signature}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Filterer) Watch{{.Name}}(opts *bind.WatchOpts, sink chan<- *{{$contract.Type}}{{.Name}}) (event.Subscription, error) {
+			logs, sub, err := _{{$contract.Type}}.contract.WatchLogs(opts, "{{.Original}}")
+			if err != nil {
+				return nil, err
+			}
+			return event.NewSubscription(func(quit <-chan struct{}) error {
+				defer sub.Unsubscribe()
+				for {
+					select {
+					case log := <-logs:
+						// New log arrived, parse the event and forward to the user
+						event := new({{$contract.Type}}{{.Name}})
+						if err := _{{$contract.Type}}.contract.UnpackLog(event, "{{.Original}}", log); err != nil {
+							return err
+						}
+						event.Raw = log
+
+						select {
+						case sink <- event:
+						case err := <-sub.Err():
+							return err
+						case <-quit:
+							return nil
+						}
+					case err := <-sub.Err():
+						return err
+					case <-quit:
+						return nil
+					}
+				}
+			}), nil
+		}
+	{{end}}
+{{end}}
+`
@@ -0,0 +1,413 @@
+package bind
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/Venachain/Venachain/common"
+	cmath "github.com/Venachain/Venachain/common/math"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/event"
+	"github.com/Venachain/Venachain/life/utils"
+	"github.com/Venachain/Venachain/rlp"
+)
+
+const (
+	wasmDeployTxType = 0 // Transaction type of the WASM contract deployments, as sent by vcl
+	wasmCallTxType   = 2 // Transaction type of the WASM contract executions
+)
+
+var (
+	errWasmNoMethod = errors.New("wasm abi: method not found")
+	errWasmNoEvent  = errors.New("wasm abi: event not found")
+)
+
+// WasmABI is the life ABI of a WASM contract, along with the JSON it was parsed
+// from which is deployed with the contract code.
+type WasmABI struct {
+	utils.WasmAbi
+	raw []byte
+}
+
+// ParseWasmABI parses the life ABI JSON of a WASM contract.
+func ParseWasmABI(abiJSON string) (*WasmABI, error) {
+	abi := &WasmABI{raw: []byte(abiJSON)}
+	if err := abi.FromJson(abi.raw); err != nil {
+		return nil, err
+	}
+	return abi, nil
+}
+
+// Method returns the function of the ABI with the name.
+func (abi *WasmABI) Method(name string) (*utils.AbiStruct, bool) {
+	return abi.find(name, "function")
+}
+
+// Event returns the event of the ABI with the name.
+func (abi *WasmABI) Event(name string) (*utils.AbiStruct, bool) {
+	return abi.find(name, "event")
+}
+
+func (abi *WasmABI) find(name, kind string) (*utils.AbiStruct, bool) {
+	for i, v := range abi.AbiArr {
+		if v.Name == name && strings.EqualFold(v.Type, kind) {
+			return &abi.AbiArr[i], true
+		}
+	}
+	return nil, false
+}
+
+// WasmEventID returns the topic of the logs emitted for the event.
+func WasmEventID(name string) common.Hash {
+	return crypto.Keccak256Hash([]byte(name))
+}
+
+// Pack encodes the call of the method with the arguments as the RLP list
+// [txType][funcName][args...] parsed by the life interpreter.
+func (abi *WasmABI) Pack(method string, args ...interface{}) ([]byte, error) {
+	fn, ok := abi.Method(method)
+	if !ok {
+		return nil, fmt.Errorf("%v: %s", errWasmNoMethod, method)
+	}
+	if len(args) != len(fn.Inputs) {
+		return nil, fmt.Errorf("wasm abi: %s takes %d arguments, got %d", method, len(fn.Inputs), len(args))
+	}
+	data := [][]byte{common.Int64ToBytes(wasmCallTxType), []byte(method)}
+	for i, input := range fn.Inputs {
+		b, err := packWasmArg(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("wasm abi: %s argument %d: %v", method, i, err)
+		}
+		data = append(data, b)
+	}
+	return rlp.EncodeToBytes(data)
+}
+
+// Unpack decodes the value returned by the method into v, a pointer to the Go
+// type bound to the return type.
+func (abi *WasmABI) Unpack(v interface{}, method string, output []byte) error {
+	value, err := abi.UnpackValue(method, output)
+	if err != nil || value == nil {
+		return err
+	}
+	return setWasmValue(reflect.ValueOf(v).Elem(), value)
+}
+
+// UnpackValue decodes the value returned by the method, the integers are
+// returned as int64 or uint64 and the 128 bits integers as *big.Int. It
+// returns nil for the void methods.
+func (abi *WasmABI) UnpackValue(method string, output []byte) (interface{}, error) {
+	fn, ok := abi.Method(method)
+	if !ok {
+		return nil, fmt.Errorf("%v: %s", errWasmNoMethod, method)
+	}
+	if len(fn.Outputs) == 0 || fn.Outputs[0].Type == "void" {
+		return nil, nil
+	}
+	return unpackWasmOutput(fn.Outputs[0].Type, output)
+}
+
+// UnpackEvent decodes the RLP list of the event arguments in the log data into
+// the fields of the struct out, named after the capitalised argument names.
+func (abi *WasmABI) UnpackEvent(out interface{}, name string, data []byte) error {
+	ev, _ := abi.Event(name)
+	values, err := abi.UnpackEventValues(name, data)
+	if err != nil {
+		return err
+	}
+	s := reflect.ValueOf(out).Elem()
+	for i, input := range ev.Inputs {
+		field := s.FieldByName(wasmArgName(input.Name, i))
+		if !field.IsValid() {
+			return fmt.Errorf("wasm abi: event %s has no field for argument %d", name, i)
+		}
+		if err := setWasmValue(field, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnpackEventValues decodes the RLP list of the event arguments in the log
+// data, in the order of the event inputs.
+func (abi *WasmABI) UnpackEventValues(name string, data []byte) ([]interface{}, error) {
+	ev, ok := abi.Event(name)
+	if !ok {
+		return nil, fmt.Errorf("%v: %s", errWasmNoEvent, name)
+	}
+	var fields [][]byte
+	if err := rlp.DecodeBytes(data, &fields); err != nil {
+		return nil, err
+	}
+	if len(fields) != len(ev.Inputs) {
+		return nil, fmt.Errorf("wasm abi: event %s has %d arguments, got %d", name, len(ev.Inputs), len(fields))
+	}
+	values := make([]interface{}, len(fields))
+	for i, input := range ev.Inputs {
+		value, err := unpackWasmArg(input.Type, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// wasmArgName returns the Go name of the argument at index.
+func wasmArgName(name string, index int) string {
+	if name = wasmIdentifier(name); name == "" {
+		return fmt.Sprintf("Arg%d", index)
+	}
+	return name
+}
+
+// packWasmArg encodes the argument the way parseInputFromAbi decodes it, fixed
+// size big endian integers and raw strings.
+func packWasmArg(t string, arg interface{}) ([]byte, error) {
+	v := reflect.ValueOf(arg)
+	switch t {
+	case "string", "int128_s", "uint128_s", "int256_s", "uint256_s":
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("want string for %s, got %T", t, arg)
+		}
+		return []byte(v.String()), nil
+	case "bool":
+		if v.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("want bool, got %T", arg)
+		}
+		if v.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case "float32":
+		if v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+			return nil, fmt.Errorf("want float32, got %T", arg)
+		}
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, math.Float32bits(float32(v.Float())))
+		return b, nil
+	case "float64":
+		if v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+			return nil, fmt.Errorf("want float64, got %T", arg)
+		}
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, math.Float64bits(v.Float()))
+		return b, nil
+	case "int128", "uint128":
+		n, ok := arg.(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("want *big.Int for %s, got %T", t, arg)
+		}
+		return cmath.PaddedBigBytes(new(big.Int).And(n, maxUint128), 16), nil
+	case "float128":
+		b, ok := arg.([]byte)
+		if !ok || len(b) != 16 {
+			return nil, fmt.Errorf("want 16 bytes for float128, got %T", arg)
+		}
+		return b, nil
+	}
+	size, ok := wasmIntSizes[t]
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+	var n uint64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = uint64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = v.Uint()
+	default:
+		return nil, fmt.Errorf("want integer for %s, got %T", t, arg)
+	}
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b[8-size:], nil
+}
+
+// unpackWasmOutput decodes the value returned by a message call, the life
+// interpreter aligns the numbers to 32 bytes and encodes the strings as the
+// solidity ABI does.
+func unpackWasmOutput(t string, output []byte) (interface{}, error) {
+	switch t {
+	case "string", "int128_s", "uint128_s", "int256_s", "uint256_s":
+		if len(output) < 64 {
+			return "", nil
+		}
+		size := binary.BigEndian.Uint64(output[56:64])
+		if size > uint64(len(output)-64) {
+			return nil, fmt.Errorf("wasm abi: string of %d bytes overflows the %d bytes output", size, len(output))
+		}
+		return string(output[64 : 64+size]), nil
+	case "float32":
+		return math.Float32frombits(uint32(binary.BigEndian.Uint64(lastBytes(output, 8)))), nil
+	}
+	if _, ok := wasmIntSizes[t]; ok {
+		// The integers are returned as 64 bits words aligned to 32 bytes
+		return unpackWasmArg(t, lastBytes(output, 8))
+	}
+	return unpackWasmArg(t, lastBytes(output, 16))
+}
+
+// unpackWasmArg decodes the big endian encoding of a value.
+func unpackWasmArg(t string, b []byte) (interface{}, error) {
+	switch t {
+	case "string", "int128_s", "uint128_s", "int256_s", "uint256_s":
+		return string(b), nil
+	case "bool":
+		return len(b) > 0 && b[len(b)-1] != 0, nil
+	case "float32":
+		return math.Float32frombits(binary.BigEndian.Uint32(lastBytes(b, 4))), nil
+	case "float64":
+		return math.Float64frombits(binary.BigEndian.Uint64(lastBytes(b, 8))), nil
+	case "uint128":
+		return new(big.Int).SetBytes(lastBytes(b, 16)), nil
+	case "int128":
+		n := new(big.Int).SetBytes(lastBytes(b, 16))
+		if n.Bit(127) == 1 {
+			n.Sub(n, new(big.Int).Lsh(common.Big1, 128))
+		}
+		return n, nil
+	case "float128":
+		return lastBytes(b, 16), nil
+	}
+	if _, ok := wasmIntSizes[t]; !ok {
+		return nil, fmt.Errorf("wasm abi: unsupported type %s", t)
+	}
+	n := binary.BigEndian.Uint64(lastBytes(b, 8))
+	if strings.HasPrefix(t, "int") {
+		return int64(n), nil
+	}
+	return n, nil
+}
+
+// setWasmValue assigns the decoded value to dst, converting the numbers to the
+// bound Go type.
+func setWasmValue(dst reflect.Value, value interface{}) error {
+	v := reflect.ValueOf(value)
+	if !v.Type().ConvertibleTo(dst.Type()) {
+		return fmt.Errorf("wasm abi: cannot unpack %T into %v", value, dst.Type())
+	}
+	dst.Set(v.Convert(dst.Type()))
+	return nil
+}
+
+// lastBytes returns the n last bytes of b, left padded with zeros.
+func lastBytes(b []byte, n int) []byte {
+	if len(b) >= n {
+		return b[len(b)-n:]
+	}
+	return common.LeftPadBytes(b, n)
+}
+
+var (
+	maxUint128 = new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 128), common.Big1)
+
+	// wasmIntSizes are the sizes of the integer parameters of the life ABI
+	wasmIntSizes = map[string]int{
+		"int8": 1, "uint8": 1,
+		"int16": 2, "uint16": 2,
+		"int32": 4, "uint32": 4, "int": 4, "uint": 4,
+		"int64": 8, "uint64": 8,
+	}
+)
+
+// WasmBoundContract is the base wrapper object that reflects a WASM contract on
+// the chain, it contains the methods used by the bindings generated with the
+// --wasm-abi flag of abigen.
+type WasmBoundContract struct {
+	contract *BoundContract // Address and backends of the contract
+	abi      *WasmABI       // Life ABI of the contract
+}
+
+// NewWasmBoundContract creates a low level WASM contract interface through which
+// calls and transactions may be made through.
+func NewWasmBoundContract(address common.Address, wasmABI *WasmABI, caller ContractCaller, transactor ContractTransactor, filterer ContractFilterer) *WasmBoundContract {
+	return &WasmBoundContract{
+		contract: &BoundContract{
+			address:    address,
+			caller:     caller,
+			transactor: transactor,
+			filterer:   filterer,
+		},
+		abi: wasmABI,
+	}
+}
+
+// DeployWasmContract deploys the WASM code along with its ABI and binds the
+// deployment address with a Go wrapper.
+func DeployWasmContract(opts *TransactOpts, wasmABI *WasmABI, code []byte, backend ContractBackend) (common.Address, *types.Transaction, *WasmBoundContract, error) {
+	c := NewWasmBoundContract(common.Address{}, wasmABI, backend, backend, backend)
+
+	input, err := rlp.EncodeToBytes([][]byte{common.Int64ToBytes(wasmDeployTxType), code, wasmABI.raw})
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	tx, err := c.contract.transact(opts, nil, input)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	c.contract.address = crypto.CreateAddress(opts.From, tx.Nonce())
+	return c.contract.address, tx, c, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the return value to result.
+func (c *WasmBoundContract) Call(opts *CallOpts, result interface{}, method string, params ...interface{}) error {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+	input, err := c.abi.Pack(method, params...)
+	if err != nil {
+		return err
+	}
+	output, err := c.contract.call(opts, input)
+	if err != nil {
+		return err
+	}
+	return c.abi.Unpack(result, method, output)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (c *WasmBoundContract) Transact(opts *TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	input, err := c.abi.Pack(method, params...)
+	if err != nil {
+		return nil, err
+	}
+	return c.contract.transact(opts, &c.contract.address, input)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract.
+func (c *WasmBoundContract) Transfer(opts *TransactOpts) (*types.Transaction, error) {
+	return c.contract.Transfer(opts)
+}
+
+// FilterLogs filters the logs of the contract event for past blocks. The WASM
+// events only have the topic of their name.
+func (c *WasmBoundContract) FilterLogs(opts *FilterOpts, name string) (chan types.Log, event.Subscription, error) {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(FilterOpts)
+	}
+	return c.contract.filterLogs(opts, [][]interface{}{{WasmEventID(name)}})
+}
+
+// WatchLogs subscribes to the logs of the contract event for future blocks.
+func (c *WasmBoundContract) WatchLogs(opts *WatchOpts, name string) (chan types.Log, event.Subscription, error) {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(WatchOpts)
+	}
+	return c.contract.watchLogs(opts, [][]interface{}{{WasmEventID(name)}})
+}
+
+// UnpackLog unpacks a retrieved log of the event into the provided output structure.
+func (c *WasmBoundContract) UnpackLog(out interface{}, event string, log types.Log) error {
+	return c.abi.UnpackEvent(out, event, log.Data)
+}
@@ -0,0 +1,187 @@
+package bind
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/rlp"
+)
+
+const testWasmABI = `[
+	{"name": "transfer", "inputs": [{"name": "to", "type": "string"}, {"name": "amount", "type": "uint64"}, {"name": "fee", "type": "int32"}, {"name": "memo", "type": "bool"}, {"name": "value", "type": "int128"}], "outputs": [{"name": "", "type": "int32"}], "constant": "false", "type": "function"},
+	{"name": "balanceOf", "inputs": [{"name": "owner", "type": "string"}], "outputs": [{"name": "", "type": "uint64"}], "constant": "true", "type": "function"},
+	{"name": "name", "inputs": [], "outputs": [{"name": "", "type": "string"}], "constant": "true", "type": "function"},
+	{"name": "delta", "inputs": [], "outputs": [{"name": "", "type": "int64"}], "constant": "true", "type": "function"},
+	{"name": "Transfer", "inputs": [{"name": "to", "type": "string"}, {"name": "", "type": "int32"}], "type": "event"},
+	{"name": "[TOKEN] Notify", "inputs": [{"name": "code", "type": "uint64"}, {"name": "msg", "type": "string"}], "type": "event"}
+]`
+
+func TestWasmPack(t *testing.T) {
+	abi, err := ParseWasmABI(testWasmABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input, err := abi.Pack("transfer", "0xabc", uint64(258), int32(-1), true, big.NewInt(-2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := rlp.EncodeToBytes([][]byte{
+		common.Int64ToBytes(wasmCallTxType),
+		[]byte("transfer"),
+		[]byte("0xabc"),
+		{0, 0, 0, 0, 0, 0, 1, 2},
+		{0xff, 0xff, 0xff, 0xff},
+		{1},
+		common.FromHex("0xfffffffffffffffffffffffffffffffe"),
+	})
+	if !bytes.Equal(input, want) {
+		t.Errorf("input mismatch:\nhave %x\nwant %x", input, want)
+	}
+	if _, err := abi.Pack("transfer", "0xabc"); err == nil {
+		t.Error("expected an error for missing arguments")
+	}
+	if _, err := abi.Pack("balanceOf", 1); err == nil {
+		t.Error("expected an error for mismatched argument type")
+	}
+	if _, err := abi.Pack("Transfer", "0xabc", 1); err == nil {
+		t.Error("expected an error for packing an event")
+	}
+}
+
+func TestWasmUnpack(t *testing.T) {
+	abi, err := ParseWasmABI(testWasmABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fee int32
+	if err := abi.Unpack(&fee, "transfer", common.FromHex("0x000000000000000000000000000000000000000000000000fffffffffffffffd")); err != nil {
+		t.Fatal(err)
+	}
+	if fee != -3 {
+		t.Errorf("int32 mismatch: have %d, want -3", fee)
+	}
+	var delta int64
+	if err := abi.Unpack(&delta, "delta", common.FromHex("0x000000000000000000000000000000000000000000000000fffffffffffffffd")); err != nil {
+		t.Fatal(err)
+	}
+	if delta != -3 {
+		t.Errorf("int64 mismatch: have %d, want -3", delta)
+	}
+	var balance uint64
+	if err := abi.Unpack(&balance, "balanceOf", common.LeftPadBytes([]byte{1, 2}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	if balance != 258 {
+		t.Errorf("uint64 mismatch: have %d, want 258", balance)
+	}
+	output := append(common.LeftPadBytes([]byte{32}, 32), common.LeftPadBytes([]byte{5}, 32)...)
+	output = append(output, common.RightPadBytes([]byte("token"), 32)...)
+
+	var name string
+	if err := abi.Unpack(&name, "name", output); err != nil {
+		t.Fatal(err)
+	}
+	if name != "token" {
+		t.Errorf("string mismatch: have %q, want %q", name, "token")
+	}
+	if err := abi.Unpack(&name, "name", output[:66]); err == nil {
+		t.Error("expected an error for a truncated string")
+	}
+	if value, err := abi.UnpackValue("name", output); err != nil || value != "token" {
+		t.Errorf("value mismatch: have %v, %v", value, err)
+	}
+}
+
+func TestWasmUnpackEvent(t *testing.T) {
+	abi, err := ParseWasmABI(testWasmABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := rlp.EncodeToBytes([][]byte{[]byte("0xabc"), {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}})
+
+	var transfer struct {
+		To   string
+		Arg1 int32
+	}
+	if err := abi.UnpackEvent(&transfer, "Transfer", data); err != nil {
+		t.Fatal(err)
+	}
+	if transfer.To != "0xabc" || transfer.Arg1 != -2 {
+		t.Errorf("event mismatch: have %+v", transfer)
+	}
+	data, _ = rlp.EncodeToBytes([][]byte{{0x2a}, []byte("done")})
+
+	var notify struct {
+		Code uint64
+		Msg  string
+	}
+	if err := abi.UnpackEvent(&notify, "[TOKEN] Notify", data); err != nil {
+		t.Fatal(err)
+	}
+	if notify.Code != 42 || notify.Msg != "done" {
+		t.Errorf("event mismatch: have %+v", notify)
+	}
+	values, err := abi.UnpackEventValues("[TOKEN] Notify", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || values[0] != uint64(42) || values[1] != "done" {
+		t.Errorf("event values mismatch: have %v", values)
+	}
+	if id := WasmEventID("[TOKEN] Notify"); id != crypto.Keccak256Hash([]byte("[TOKEN] Notify")) {
+		t.Errorf("event id mismatch: have %x", id)
+	}
+}
+
+func TestBindWasm(t *testing.T) {
+	code, err := BindWasm([]string{"token"}, []string{testWasmABI}, [][]byte{[]byte("\x00asm")}, "bindtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"const TokenBin = `0x0061736d`",
+		"func DeployToken(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Token, error)",
+		"func (_Token *TokenCaller) BalanceOf(opts *bind.CallOpts, owner string) (uint64, error)",
+		"func (_Token *TokenCallerSession) Name() (string, error)",
+		"func (_Token *TokenTransactor) Transfer(opts *bind.TransactOpts, to string, amount uint64, fee int32, memo bool, value *big.Int) (*types.Transaction, error)",
+		"func (_Token *TokenFilterer) FilterTransfer(opts *bind.FilterOpts) (*TokenTransferIterator, error)",
+		"func (_Token *TokenFilterer) WatchTOKENNotify(opts *bind.WatchOpts, sink chan<- *TokenTOKENNotify) (event.Subscription, error)",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("binding lacks %q", want)
+		}
+	}
+	if _, err := BindWasm([]string{"token"}, []string{`[{"name": "f", "inputs": [{"name": "a", "type": "map"}], "type": "function"}]`}, nil, "bindtest"); err == nil {
+		t.Error("expected an error for an unsupported type")
+	}
+
+	// Compile the generated binding against the library, the workspace lives
+	// inside the module so the imports resolve to the local sources
+	gocmd := runtime.GOROOT() + "/bin/go"
+	if !common.FileExist(gocmd) {
+		t.Skip("go sdk not found for testing")
+	}
+	pkg, err := ioutil.TempDir(".", "bindtest")
+	if err != nil {
+		t.Fatalf("failed to create temporary workspace: %v", err)
+	}
+	defer os.RemoveAll(pkg)
+
+	if err := ioutil.WriteFile(filepath.Join(pkg, "token.go"), []byte(code), 0600); err != nil {
+		t.Fatalf("failed to write binding: %v", err)
+	}
+	cmd := exec.Command(gocmd, "vet", ".")
+	cmd.Dir = pkg
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to compile binding: %v\n%s", err, out)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/Venachain/Venachain/accounts"
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/crypto/sm2"
 	"github.com/pborman/uuid"
 )
 
@@ -147,7 +148,7 @@ func NewKeyForDirectICAP(rand io.Reader) *Key {
 		panic("key generation: could not read from random source: " + err.Error())
 	}
 	reader := bytes.NewReader(randBytes)
-	privateKeyECDSA, err := ecdsa.GenerateKey(crypto.S256(), reader)
+	privateKeyECDSA, err := generateECDSAKey(reader)
 	if err != nil {
 		panic("key generation: ecdsa.GenerateKey failed: " + err.Error())
 	}
@@ -158,8 +159,17 @@ func NewKeyForDirectICAP(rand io.Reader) *Key {
 	return key
 }
 
+// generateECDSAKey generates a private key on the curve of the chain's
+// crypto suite.
+func generateECDSAKey(rand io.Reader) (*ecdsa.PrivateKey, error) {
+	if crypto.IsSMSuite() {
+		return sm2.GenerateKey(rand)
+	}
+	return ecdsa.GenerateKey(crypto.S256(), rand)
+}
+
 func newKey(rand io.Reader) (*Key, error) {
-	privateKeyECDSA, err := ecdsa.GenerateKey(crypto.S256(), rand)
+	privateKeyECDSA, err := generateECDSAKey(rand)
 	if err != nil {
 		return nil, err
 	}
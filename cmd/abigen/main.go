@@ -30,9 +30,11 @@ import (
 
 var (
 	abiFlag = flag.String("abi", "", "Path to the Ethereum contract ABI json to bind, - for STDIN")
-	binFlag = flag.String("bin", "", "Path to the Ethereum contract bytecode (generate deploy method)")
+	binFlag = flag.String("bin", "", "Path to the Ethereum contract bytecode or WASM code (generate deploy method)")
 	typFlag = flag.String("type", "", "Struct name for the binding (default = package name)")
 
+	wasmAbiFlag = flag.String("wasm-abi", "", "Path to the WASM contract ABI json to bind")
+
 	solFlag  = flag.String("sol", "", "Path to the Ethereum contract Solidity source to build and bind")
 	solcFlag = flag.String("solc", "solc", "Solidity compiler to use if source builds are requested")
 	excFlag  = flag.String("exc", "", "Comma separated types to exclude from binding")
@@ -46,8 +48,11 @@ func main() {
 	// Parse and ensure all needed inputs are specified
 	flag.Parse()
 
-	if *abiFlag == "" && *solFlag == "" {
-		fmt.Printf("No contract ABI (--abi) or Solidity source (--sol) specified\n")
+	if *abiFlag == "" && *solFlag == "" && *wasmAbiFlag == "" {
+		fmt.Printf("No contract ABI (--abi), Solidity source (--sol) or WASM contract ABI (--wasm-abi) specified\n")
+		os.Exit(-1)
+	} else if *wasmAbiFlag != "" && (*abiFlag != "" || *solFlag != "") {
+		fmt.Printf("WASM contract ABI (--wasm-abi) flag is mutually exclusive with the contract ABI (--abi) and Solidity source (--sol) flags\n")
 		os.Exit(-1)
 	} else if (*abiFlag != "" || *binFlag != "" || *typFlag != "") && *solFlag != "" {
 		fmt.Printf("Contract ABI (--abi), bytecode (--bin) and type (--type) flags are mutually exclusive with the Solidity source (--sol) flag\n")
@@ -69,6 +74,15 @@ func main() {
 		fmt.Printf("Unsupported destination language \"%s\" (--lang)\n", *langFlag)
 		os.Exit(-1)
 	}
+	// WASM contracts have their own ABI and are only bound to Go
+	if *wasmAbiFlag != "" {
+		if lang != bind.LangGo {
+			fmt.Printf("WASM contract ABI (--wasm-abi) is only supported for the go language (--lang)\n")
+			os.Exit(-1)
+		}
+		bindWasm()
+		return
+	}
 	// If the entire solidity code was specified, build and bind based on that
 	var (
 		abis  []string
@@ -139,7 +153,38 @@ func main() {
 		fmt.Printf("Failed to generate ABI binding: %v\n", err)
 		os.Exit(-1)
 	}
-	// Either flush it out to a file or display on the standard output
+	writeBinding(code)
+}
+
+// bindWasm generates the binding of the WASM contract ABI and code.
+func bindWasm() {
+	abi, err := ioutil.ReadFile(*wasmAbiFlag)
+	if err != nil {
+		fmt.Printf("Failed to read input WASM ABI: %v\n", err)
+		os.Exit(-1)
+	}
+	var code []byte
+	if *binFlag != "" {
+		if code, err = ioutil.ReadFile(*binFlag); err != nil {
+			fmt.Printf("Failed to read input WASM code: %v\n", err)
+			os.Exit(-1)
+		}
+	}
+	kind := *typFlag
+	if kind == "" {
+		kind = *pkgFlag
+	}
+	binding, err := bind.BindWasm([]string{kind}, []string{string(abi)}, [][]byte{code}, *pkgFlag)
+	if err != nil {
+		fmt.Printf("Failed to generate WASM ABI binding: %v\n", err)
+		os.Exit(-1)
+	}
+	writeBinding(binding)
+}
+
+// writeBinding either flushes the binding out to a file or displays it on the
+// standard output.
+func writeBinding(code string) {
 	if *outFlag == "" {
 		fmt.Printf("%s\n", code)
 		return
@@ -2,12 +2,19 @@ package main
 
 import (
 	"data-manager/config"
+	"data-manager/db"
+	dbCtx "data-manager/db/context"
+	"data-manager/store"
 	"data-manager/syncer"
 	_ "data-manager/web/controller"
 	"data-manager/web/engine"
 )
 
 func main() {
+	config.Load()
+	db.Connect()
+	store.Default = store.NewMongo(dbCtx.New(db.DefaultDB))
+
 	syncer.DefaultSyncer.Run()
 
 	engine.Default.Run(config.Config.HttpConf.Addr())
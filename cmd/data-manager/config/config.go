@@ -69,14 +69,27 @@ func (this *dbConf) Uri() string {
 }
 
 type syncConf struct {
-	Interval int      `toml:"interval"`
-	Urls     []string `toml:"urls"`
+	Interval  int      `toml:"interval"`
+	Urls      []string `toml:"urls"`
+	BatchSize int      `toml:"batch_size"`
 }
 
+const defaultSyncBatchSize = 50
+
 func (this *syncConf) SyncInterval() time.Duration {
 	return time.Second * time.Duration(this.Interval)
 }
 
+// BlockBatchSize is the number of blocks fetched in one batch request when
+// catching up with the chain.
+func (this *syncConf) BlockBatchSize() int {
+	if this.BatchSize <= 0 {
+		return defaultSyncBatchSize
+	}
+
+	return this.BatchSize
+}
+
 func (this *syncConf) URLs() []string {
 	return this.Urls
 }
@@ -125,7 +138,8 @@ var Config config
 
 const configFile = "./config.toml"
 
-func init() {
+// Load reads the config file, it must be called before Config is used.
+func Load() {
 	initFromFile(configFile)
 }
 
@@ -12,7 +12,8 @@ import (
 
 var DefaultDB *DB = newDB()
 
-func init() {
+// Connect connects DefaultDB to the MongoDB in the config and pings it.
+func Connect() {
 	logrus.Infof("db uri:%s", config.Config.DBConf.Uri())
 	optionUri := options.Client().ApplyURI(config.Config.DBConf.Uri())
 	ctx, _ := context.WithTimeout(context.Background(), 10*time.Second)
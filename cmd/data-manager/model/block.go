@@ -24,11 +24,30 @@ func newBlock() *block {
 	return new(block)
 }
 
-func (this *block) InsertBlock(c *dbCtx.Context, b *Block) error {
+func (this *block) CreateIndexes(c *dbCtx.Context) error {
 	collection := c.Collection(collectionNameBlocks)
 	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
 
-	_, err := collection.InsertOne(ctx, b)
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{"height", -1}}},
+		{Keys: bson.D{{"hash", 1}}},
+	}
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if nil != err {
+		logrus.Errorln(err)
+		return err
+	}
+
+	return nil
+}
+
+func (this *block) UpsertBlock(c *dbCtx.Context, b *Block) error {
+	collection := c.Collection(collectionNameBlocks)
+	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+
+	replaceOpts := options.Replace().SetUpsert(true)
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"height": b.Height}, b, replaceOpts)
 	if nil != err {
 		logrus.Errorln(err)
 		return err
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"context"
+	dbCtx "data-manager/db/context"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	collectionNameCursors = "cursors"
+
+	cursorIDBlocks = "blocks"
+)
+
+var DefaultCursor = newCursor()
+
+type cursor struct{}
+
+func newCursor() *cursor {
+	return new(cursor)
+}
+
+// BlockCursor returns the last block written by the syncer, it is nil if the
+// cursor was never saved.
+func (this *cursor) BlockCursor(c *dbCtx.Context) (*Cursor, error) {
+	collection := c.Collection(collectionNameCursors)
+	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+
+	var cur Cursor
+	err := collection.FindOne(ctx, bson.M{"_id": cursorIDBlocks}).Decode(&cur)
+	if mongo.ErrNoDocuments == err {
+		return nil, nil
+	}
+	if nil != err {
+		logrus.Errorln(err)
+		return nil, err
+	}
+
+	return &cur, nil
+}
+
+func (this *cursor) SaveBlockCursor(c *dbCtx.Context, cur *Cursor) error {
+	collection := c.Collection(collectionNameCursors)
+	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+
+	update := bson.M{"$set": cur}
+	updateOpts := options.Update().SetUpsert(true)
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": cursorIDBlocks}, update, updateOpts)
+	if nil != err {
+		logrus.Errorln(err)
+		return err
+	}
+
+	return nil
+}
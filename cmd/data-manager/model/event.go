@@ -0,0 +1,197 @@
+package model
+
+import (
+	"context"
+	dbCtx "data-manager/db/context"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+const (
+	collectionNameEvents = "events"
+)
+
+var DefaultEvent = newEvent()
+
+type event struct{}
+
+func newEvent() *event {
+	return new(event)
+}
+
+// EventQuery holds the conditions of an event search, the empty ones are ignored.
+type EventQuery struct {
+	ContractAddress string
+	Name            string
+	Topic           string
+	TxHash          string
+	ArgName         string
+	ArgValue        string
+	FromHeight      uint64
+	ToHeight        uint64
+}
+
+func (this *EventQuery) filter() bson.M {
+	filter := bson.M{}
+	if this.ContractAddress != "" {
+		filter["contract_address"] = this.ContractAddress
+	}
+	if this.Name != "" {
+		filter["name"] = this.Name
+	}
+	if this.Topic != "" {
+		filter["topics"] = this.Topic
+	}
+	if this.TxHash != "" {
+		filter["tx_hash"] = this.TxHash
+	}
+	if this.ArgName != "" || this.ArgValue != "" {
+		arg := bson.M{}
+		if this.ArgName != "" {
+			arg["name"] = this.ArgName
+		}
+		if this.ArgValue != "" {
+			arg["value"] = this.ArgValue
+		}
+		filter["args"] = bson.M{"$elemMatch": arg}
+	}
+	if this.FromHeight > 0 || this.ToHeight > 0 {
+		height := bson.M{}
+		if this.FromHeight > 0 {
+			height["$gte"] = this.FromHeight
+		}
+		if this.ToHeight > 0 {
+			height["$lte"] = this.ToHeight
+		}
+		filter["block_height"] = height
+	}
+
+	return filter
+}
+
+// Match tells if the event meets the conditions, it agrees with the filter
+// the events are searched with in MongoDB.
+func (this *EventQuery) Match(e *Event) bool {
+	if this.ContractAddress != "" && e.ContractAddress != this.ContractAddress {
+		return false
+	}
+	if this.Name != "" && e.Name != this.Name {
+		return false
+	}
+	if this.TxHash != "" && e.TxHash != this.TxHash {
+		return false
+	}
+	if this.FromHeight > 0 && e.Height < this.FromHeight {
+		return false
+	}
+	if this.ToHeight > 0 && e.Height > this.ToHeight {
+		return false
+	}
+	if this.Topic != "" {
+		found := false
+		for _, topic := range e.Topics {
+			if topic == this.Topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if this.ArgName != "" || this.ArgValue != "" {
+		found := false
+		for _, arg := range e.Args {
+			if (this.ArgName == "" || arg.Name == this.ArgName) && (this.ArgValue == "" || arg.Value == this.ArgValue) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (this *event) CreateIndexes(c *dbCtx.Context) error {
+	collection := c.Collection(collectionNameEvents)
+	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{"contract_address", 1}, {"name", 1}, {"block_height", -1}}},
+		{Keys: bson.D{{"topics", 1}}},
+		{Keys: bson.D{{"tx_hash", 1}, {"log_index", 1}}}, // the key the events are upserted by
+		{Keys: bson.D{{"args.name", 1}, {"args.value", 1}}},
+		{Keys: bson.D{{"block_height", -1}, {"log_index", -1}}},
+	}
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if nil != err {
+		logrus.Errorln(err)
+		return err
+	}
+
+	return nil
+}
+
+func (this *event) UpsertEvents(c *dbCtx.Context, events []*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	collection := c.Collection(collectionNameEvents)
+	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+
+	models := []mongo.WriteModel{}
+	for _, e := range events {
+		filter := bson.M{"tx_hash": e.TxHash, "log_index": e.LogIndex}
+		m := mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(e).SetUpsert(true)
+		models = append(models, m)
+	}
+	_, err := collection.BulkWrite(ctx, models)
+	if nil != err {
+		logrus.Errorln(err)
+		return err
+	}
+
+	return nil
+}
+
+func (this *event) Events(c *dbCtx.Context, pageIndex, pageSize int64, query *EventQuery) ([]*Event, error) {
+	collection := c.Collection(collectionNameEvents)
+	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+
+	findOps := buildOptionsByQuery(pageIndex, pageSize)
+	findOps.SetSort(bson.D{{"block_height", -1}, {"log_index", -1}})
+
+	cur, err := collection.Find(ctx, query.filter(), findOps)
+	if err != nil {
+		logrus.Errorln(err)
+		return nil, err
+	}
+
+	results := []*Event{}
+	err = cur.All(ctx, &results)
+	if err != nil {
+		logrus.Errorln(err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (this *event) TotalEvents(c *dbCtx.Context, query *EventQuery) (int64, error) {
+	collection := c.Collection(collectionNameEvents)
+	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+
+	amount, err := collection.CountDocuments(ctx, query.filter())
+	if err != nil {
+		logrus.Errorln(err)
+		return 0, err
+	}
+
+	return amount, nil
+}
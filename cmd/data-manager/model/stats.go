@@ -1,43 +1,5 @@
 package model
 
-import (
-	dbCtx "data-manager/db/context"
-	"github.com/sirupsen/logrus"
-)
-
-func GetStats(c *dbCtx.Context) (*Stats, error) {
-	var (
-		stats Stats
-		err   error
-	)
-
-	stats.LatestBlock, err = DefaultBlock.LatestHeight(c)
-	if nil != err {
-		logrus.Errorln(err)
-		return nil, err
-	}
-
-	stats.TotalContract, err = DefaultTx.TotalContract(c)
-	if nil != err {
-		logrus.Errorln(err)
-		return nil, err
-	}
-
-	stats.TotalTx, err = DefaultTx.TotalTx(c)
-	if nil != err {
-		logrus.Errorln(err)
-		return nil, err
-	}
-
-	stats.TotalNode, err = DefaultNode.AllNodeCount(c)
-	if nil != err {
-		logrus.Errorln("failed to find amount of nodes,err:", err)
-		return nil, err
-	}
-
-	return &stats, nil
-}
-
 //
 //import (
 //	"context"
@@ -5,6 +5,7 @@ import (
 	dbCtx "data-manager/db/context"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx"
 	"time"
@@ -23,11 +24,38 @@ func newTx() *tx {
 	return new(tx)
 }
 
-func (this *tx) InsertTx(c *dbCtx.Context, tx *Tx) error {
+func (this *tx) CreateIndexes(c *dbCtx.Context) error {
 	collection := c.Collection(collectionNameTxs)
 	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
 
-	_, err := collection.InsertOne(ctx, tx)
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{"tx_hash", 1}}},
+		{Keys: bson.D{{"block_height", 1}}},
+		{Keys: bson.D{{"timestamp", -1}}},
+	}
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if nil != err {
+		logrus.Errorln(err)
+		return err
+	}
+
+	return nil
+}
+
+func (this *tx) UpsertTxs(c *dbCtx.Context, txs []*Tx) error {
+	if len(txs) == 0 {
+		return nil
+	}
+
+	collection := c.Collection(collectionNameTxs)
+	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+
+	models := []mongo.WriteModel{}
+	for _, tx := range txs {
+		m := mongo.NewReplaceOneModel().SetFilter(bson.M{"tx_hash": tx.Hash}).SetReplacement(tx).SetUpsert(true)
+		models = append(models, m)
+	}
+	_, err := collection.BulkWrite(ctx, models)
 	if nil != err {
 		logrus.Errorln(err)
 		return err
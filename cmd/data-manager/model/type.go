@@ -71,3 +71,28 @@ type CNSInfo struct {
 	Version string `json:"version" bson:"version"`
 	Address string `json:"address" bson:"address"`
 }
+
+type Event struct {
+	ContractAddress string      `json:"contract_address" bson:"contract_address"`
+	Name            string      `json:"name" bson:"name"`
+	Topics          []string    `json:"topics" bson:"topics"`
+	Data            string      `json:"data" bson:"data"`
+	Args            []*EventArg `json:"args" bson:"args"`
+	Decoded         bool        `json:"decoded" bson:"decoded"`
+	TxHash          string      `json:"tx_hash" bson:"tx_hash"`
+	Height          uint64      `json:"block_height" bson:"block_height"`
+	LogIndex        uint        `json:"log_index" bson:"log_index"`
+	Timestamp       int64       `json:"timestamp" bson:"timestamp"`
+}
+
+type EventArg struct {
+	Name    string `json:"name" bson:"name"`
+	Type    string `json:"type" bson:"type"`
+	Value   string `json:"value" bson:"value"`
+	Indexed bool   `json:"indexed" bson:"indexed"`
+}
+
+type Cursor struct {
+	Height uint64 `json:"height" bson:"height"`
+	Hash   string `json:"hash" bson:"hash"`
+}
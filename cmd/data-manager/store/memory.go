@@ -0,0 +1,322 @@
+package store
+
+import (
+	"data-manager/model"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// memoryStore keeps the data in memory, it lets the explorer run without
+// MongoDB in tests.
+type memoryStore struct {
+	mu      sync.RWMutex
+	cursor  model.Cursor
+	blocks  map[uint64]*model.Block
+	txs     map[string]*model.Tx
+	events  map[string]*model.Event
+	nodes   []*model.Node
+	cnses   []*model.CNS
+	txStats map[string]*model.TxStats
+}
+
+func NewMemory() Store {
+	return &memoryStore{
+		blocks:  make(map[uint64]*model.Block),
+		txs:     make(map[string]*model.Tx),
+		events:  make(map[string]*model.Event),
+		txStats: make(map[string]*model.TxStats),
+	}
+}
+
+// pageRange returns the range of the page in a result of n items.
+func pageRange(n int, pageIndex, pageSize int64) (int, int) {
+	start := (pageIndex - 1) * pageSize
+	if start < 0 || start > int64(n) {
+		start = int64(n)
+	}
+	end := start + pageSize
+	if pageSize <= 0 || end > int64(n) {
+		end = int64(n)
+	}
+
+	return int(start), int(end)
+}
+
+func eventKey(e *model.Event) string {
+	return fmt.Sprintf("%s:%d", e.TxHash, e.LogIndex)
+}
+
+func (this *memoryStore) WriteBlock(data *BlockData) error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	for _, tx := range data.Txs {
+		this.txs[tx.Hash] = tx
+	}
+	for _, e := range data.Events {
+		this.events[eventKey(e)] = e
+	}
+	this.blocks[data.Block.Height] = data.Block
+	this.cursor = model.Cursor{Height: data.Block.Height, Hash: data.Block.Hash}
+
+	return nil
+}
+
+func (this *memoryStore) Cursor() (*model.Cursor, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	cur := this.cursor
+	return &cur, nil
+}
+
+func (this *memoryStore) sortedBlocks() []*model.Block {
+	blocks := make([]*model.Block, 0, len(this.blocks))
+	for _, b := range this.blocks {
+		blocks = append(blocks, b)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Height > blocks[j].Height
+	})
+
+	return blocks
+}
+
+func (this *memoryStore) LatestBlock() (*model.Block, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	blocks := this.sortedBlocks()
+	if len(blocks) == 0 {
+		return &model.Block{}, nil
+	}
+
+	return blocks[0], nil
+}
+
+func (this *memoryStore) BlockByHeight(height uint64) (*model.Block, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	b, ok := this.blocks[height]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return b, nil
+}
+
+func (this *memoryStore) BlockByHash(hash string) (*model.Block, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	for _, b := range this.blocks {
+		if b.Hash == hash {
+			return b, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+func (this *memoryStore) Blocks(pageIndex, pageSize int64) ([]*model.Block, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	blocks := this.sortedBlocks()
+	start, end := pageRange(len(blocks), pageIndex, pageSize)
+
+	return blocks[start:end], nil
+}
+
+// filterTxs returns the txs matching, the latest first.
+func (this *memoryStore) filterTxs(match func(tx *model.Tx) bool) []*model.Tx {
+	txs := []*model.Tx{}
+	for _, tx := range this.txs {
+		if match(tx) {
+			txs = append(txs, tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].Timestamp != txs[j].Timestamp {
+			return txs[i].Timestamp > txs[j].Timestamp
+		}
+		return txs[i].Height > txs[j].Height
+	})
+
+	return txs
+}
+
+func (this *memoryStore) pageTxs(pageIndex, pageSize int64, match func(tx *model.Tx) bool) ([]*model.Tx, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	txs := this.filterTxs(match)
+	start, end := pageRange(len(txs), pageIndex, pageSize)
+
+	return txs[start:end], nil
+}
+
+func (this *memoryStore) countTxs(match func(tx *model.Tx) bool) (int64, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	return int64(len(this.filterTxs(match))), nil
+}
+
+func isContract(tx *model.Tx) bool {
+	return tx.Receipt != nil && tx.Receipt.ContractAddress != ""
+}
+
+func (this *memoryStore) TxByHash(hash string) (*model.Tx, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	tx, ok := this.txs[hash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return tx, nil
+}
+
+func (this *memoryStore) Txs(pageIndex, pageSize int64) ([]*model.Tx, error) {
+	return this.pageTxs(pageIndex, pageSize, func(tx *model.Tx) bool { return true })
+}
+
+func (this *memoryStore) TxsInHeight(pageIndex, pageSize int64, height uint64) ([]*model.Tx, error) {
+	return this.pageTxs(pageIndex, pageSize, func(tx *model.Tx) bool { return tx.Height == height })
+}
+
+func (this *memoryStore) TxsFromAddress(pageIndex, pageSize int64, address string) ([]*model.Tx, error) {
+	return this.pageTxs(pageIndex, pageSize, func(tx *model.Tx) bool { return tx.From == address })
+}
+
+func (this *memoryStore) TotalTx() (int64, error) {
+	return this.countTxs(func(tx *model.Tx) bool { return true })
+}
+
+func (this *memoryStore) TxAmountByTime(start, end int64) (int64, error) {
+	return this.countTxs(func(tx *model.Tx) bool { return tx.Timestamp >= start && tx.Timestamp <= end })
+}
+
+func (this *memoryStore) Contracts(pageIndex, pageSize int64) ([]*model.Tx, error) {
+	return this.pageTxs(pageIndex, pageSize, isContract)
+}
+
+func (this *memoryStore) ContractByAddress(address string) (*model.Tx, error) {
+	txs, _ := this.pageTxs(1, 1, func(tx *model.Tx) bool {
+		return isContract(tx) && tx.Receipt.ContractAddress == address
+	})
+	if len(txs) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return txs[0], nil
+}
+
+func (this *memoryStore) TotalContract() (int64, error) {
+	return this.countTxs(isContract)
+}
+
+func (this *memoryStore) filterEvents(query *model.EventQuery) []*model.Event {
+	events := []*model.Event{}
+	for _, e := range this.events {
+		if query.Match(e) {
+			events = append(events, e)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Height != events[j].Height {
+			return events[i].Height > events[j].Height
+		}
+		return events[i].LogIndex > events[j].LogIndex
+	})
+
+	return events
+}
+
+func (this *memoryStore) Events(pageIndex, pageSize int64, query *model.EventQuery) ([]*model.Event, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	events := this.filterEvents(query)
+	start, end := pageRange(len(events), pageIndex, pageSize)
+
+	return events[start:end], nil
+}
+
+func (this *memoryStore) TotalEvents(query *model.EventQuery) (int64, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	return int64(len(this.filterEvents(query))), nil
+}
+
+func (this *memoryStore) ReplaceNodes(nodes []*model.Node) error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	this.nodes = nodes
+	return nil
+}
+
+func (this *memoryStore) AllNodes() ([]*model.Node, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	return append([]*model.Node{}, this.nodes...), nil
+}
+
+func (this *memoryStore) ReplaceCNS(cnses []*model.CNS) error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	this.cnses = append([]*model.CNS{}, cnses...)
+	sort.SliceStable(this.cnses, func(i, j int) bool {
+		return strings.Compare(this.cnses[i].Name, this.cnses[j].Name) > 0
+	})
+	return nil
+}
+
+func (this *memoryStore) QueryCNS(pageIndex, pageSize int64) ([]*model.CNS, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	start, end := pageRange(len(this.cnses), pageIndex, pageSize)
+
+	return append([]*model.CNS{}, this.cnses[start:end]...), nil
+}
+
+func (this *memoryStore) TotalCNS() (int64, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	return int64(len(this.cnses)), nil
+}
+
+func (this *memoryStore) UpsertTxAmountOneDay(date string, amount int64) error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	this.txStats[date] = &model.TxStats{Date: date, TxAmount: amount}
+	return nil
+}
+
+func (this *memoryStore) TxStatsHistory(num int64) ([]*model.TxStats, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	history := make([]*model.TxStats, 0, len(this.txStats))
+	for _, stats := range this.txStats {
+		history = append(history, stats)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Date > history[j].Date
+	})
+	_, end := pageRange(len(history), 1, num)
+
+	return history[:end], nil
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	dbCtx "data-manager/db/context"
+	"data-manager/model"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mongoStore keeps the data in MongoDB. MongoDB deployments without replica
+// set have no transactions, so the docs of a block are upserted by their keys
+// before the cursor is moved, writing the block again leaves them unchanged.
+type mongoStore struct {
+	c *dbCtx.Context
+}
+
+func NewMongo(c *dbCtx.Context) Store {
+	for _, create := range []func(*dbCtx.Context) error{
+		model.DefaultBlock.CreateIndexes,
+		model.DefaultTx.CreateIndexes,
+		model.DefaultEvent.CreateIndexes,
+	} {
+		if err := create(c); nil != err {
+			logrus.Errorln("failed to create indexes,err:", err)
+		}
+	}
+
+	return &mongoStore{c: c}
+}
+
+func notFound(err error) error {
+	if mongo.ErrNoDocuments == err {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// WriteBlock isn't atomic: the txs, the events and the block are upserted one
+// collection after the other and the cursor is saved last. A failure leaves the
+// docs written so far behind the cursor, they are visible to the queries until
+// the block is written again from the cursor, which replaces them.
+func (this *mongoStore) WriteBlock(data *BlockData) error {
+	if err := model.DefaultTx.UpsertTxs(this.c, data.Txs); nil != err {
+		return err
+	}
+
+	if err := model.DefaultEvent.UpsertEvents(this.c, data.Events); nil != err {
+		return err
+	}
+
+	if err := model.DefaultBlock.UpsertBlock(this.c, data.Block); nil != err {
+		return err
+	}
+
+	return model.DefaultCursor.SaveBlockCursor(this.c, &model.Cursor{Height: data.Block.Height, Hash: data.Block.Hash})
+}
+
+func (this *mongoStore) Cursor() (*model.Cursor, error) {
+	cur, err := model.DefaultCursor.BlockCursor(this.c)
+	if nil != err || nil != cur {
+		return cur, err
+	}
+
+	// the databases synced before the cursor was saved resume from their latest block
+	block, err := model.DefaultBlock.LatestBlock(this.c)
+	if nil != err {
+		return nil, err
+	}
+
+	return &model.Cursor{Height: block.Height, Hash: block.Hash}, nil
+}
+
+func (this *mongoStore) LatestBlock() (*model.Block, error) {
+	return model.DefaultBlock.LatestBlock(this.c)
+}
+
+func (this *mongoStore) BlockByHeight(height uint64) (*model.Block, error) {
+	b, err := model.DefaultBlock.BlockByHeight(this.c, height)
+	return b, notFound(err)
+}
+
+func (this *mongoStore) BlockByHash(hash string) (*model.Block, error) {
+	b, err := model.DefaultBlock.BlockByHash(this.c, hash)
+	return b, notFound(err)
+}
+
+func (this *mongoStore) Blocks(pageIndex, pageSize int64) ([]*model.Block, error) {
+	return model.DefaultBlock.Blocks(this.c, pageIndex, pageSize)
+}
+
+func (this *mongoStore) TxByHash(hash string) (*model.Tx, error) {
+	tx, err := model.DefaultTx.TxByHash(this.c, hash)
+	return tx, notFound(err)
+}
+
+func (this *mongoStore) Txs(pageIndex, pageSize int64) ([]*model.Tx, error) {
+	return model.DefaultTx.Txs(this.c, pageIndex, pageSize)
+}
+
+func (this *mongoStore) TxsInHeight(pageIndex, pageSize int64, height uint64) ([]*model.Tx, error) {
+	return model.DefaultTx.TxsInHeight(this.c, pageIndex, pageSize, height)
+}
+
+func (this *mongoStore) TxsFromAddress(pageIndex, pageSize int64, address string) ([]*model.Tx, error) {
+	return model.DefaultTx.TxsFromAddress(this.c, pageIndex, pageSize, address)
+}
+
+func (this *mongoStore) TotalTx() (int64, error) {
+	return model.DefaultTx.TotalTx(this.c)
+}
+
+func (this *mongoStore) TxAmountByTime(start, end int64) (int64, error) {
+	return model.DefaultTx.TxAmountByTime(this.c, start, end)
+}
+
+func (this *mongoStore) Contracts(pageIndex, pageSize int64) ([]*model.Tx, error) {
+	return model.DefaultTx.Contracts(this.c, pageIndex, pageSize)
+}
+
+func (this *mongoStore) ContractByAddress(address string) (*model.Tx, error) {
+	tx, err := model.DefaultTx.ContractByAddress(this.c, address)
+	return tx, notFound(err)
+}
+
+func (this *mongoStore) TotalContract() (int64, error) {
+	return model.DefaultTx.TotalContract(this.c)
+}
+
+func (this *mongoStore) Events(pageIndex, pageSize int64, query *model.EventQuery) ([]*model.Event, error) {
+	return model.DefaultEvent.Events(this.c, pageIndex, pageSize, query)
+}
+
+func (this *mongoStore) TotalEvents(query *model.EventQuery) (int64, error) {
+	return model.DefaultEvent.TotalEvents(this.c, query)
+}
+
+func (this *mongoStore) ReplaceNodes(nodes []*model.Node) error {
+	//TODO better idea
+	if err := model.DefaultNode.DeleteAllNodes(this.c); nil != err {
+		return err
+	}
+
+	return model.DefaultNode.InsertNodes(this.c, nodes)
+}
+
+func (this *mongoStore) AllNodes() ([]*model.Node, error) {
+	return model.DefaultNode.AllNodes(this.c)
+}
+
+func (this *mongoStore) ReplaceCNS(cnses []*model.CNS) error {
+	//TODO better idea
+	if err := model.DefaultCNS.DeleteAllCNS(this.c); nil != err {
+		return err
+	}
+
+	return model.DefaultCNS.InsertCNS(this.c, cnses)
+}
+
+func (this *mongoStore) QueryCNS(pageIndex, pageSize int64) ([]*model.CNS, error) {
+	return model.DefaultCNS.QueryCNS(this.c, pageIndex, pageSize)
+}
+
+func (this *mongoStore) TotalCNS() (int64, error) {
+	return model.DefaultCNS.Total(this.c)
+}
+
+func (this *mongoStore) UpsertTxAmountOneDay(date string, amount int64) error {
+	return model.DefaultTxStats.UpsertTxAmountOneDay(this.c, date, amount)
+}
+
+func (this *mongoStore) TxStatsHistory(num int64) ([]*model.TxStats, error) {
+	return model.DefaultTxStats.History(this.c, num)
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"data-manager/model"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+var ErrNotFound = errors.New("store: not found")
+
+// Default is the store the syncer writes to and the web controllers read from,
+// it is set up by main.
+var Default Store
+
+// BlockData is a block synced from the chain along with its txs and the events
+// they emitted.
+type BlockData struct {
+	Block  *model.Block
+	Txs    []*model.Tx
+	Events []*model.Event
+}
+
+// Store is the storage of the explorer.
+type Store interface {
+	// WriteBlock writes the block with its txs and events and moves the cursor
+	// to it. The write isn't atomic on every store, a failure may leave a part
+	// of the block written behind the cursor, writing the block again doesn't
+	// duplicate it.
+	WriteBlock(data *BlockData) error
+	// Cursor returns the last block written, it is zero if none was.
+	Cursor() (*model.Cursor, error)
+
+	LatestBlock() (*model.Block, error)
+	BlockByHeight(height uint64) (*model.Block, error)
+	BlockByHash(hash string) (*model.Block, error)
+	Blocks(pageIndex, pageSize int64) ([]*model.Block, error)
+
+	TxByHash(hash string) (*model.Tx, error)
+	Txs(pageIndex, pageSize int64) ([]*model.Tx, error)
+	TxsInHeight(pageIndex, pageSize int64, height uint64) ([]*model.Tx, error)
+	TxsFromAddress(pageIndex, pageSize int64, address string) ([]*model.Tx, error)
+	TotalTx() (int64, error)
+	TxAmountByTime(start, end int64) (int64, error)
+
+	Contracts(pageIndex, pageSize int64) ([]*model.Tx, error)
+	ContractByAddress(address string) (*model.Tx, error)
+	TotalContract() (int64, error)
+
+	Events(pageIndex, pageSize int64, query *model.EventQuery) ([]*model.Event, error)
+	TotalEvents(query *model.EventQuery) (int64, error)
+
+	// ReplaceNodes replaces all the nodes with the ones given.
+	ReplaceNodes(nodes []*model.Node) error
+	AllNodes() ([]*model.Node, error)
+
+	// ReplaceCNS replaces all the CNS infos with the ones given.
+	ReplaceCNS(cnses []*model.CNS) error
+	QueryCNS(pageIndex, pageSize int64) ([]*model.CNS, error)
+	TotalCNS() (int64, error)
+
+	UpsertTxAmountOneDay(date string, amount int64) error
+	TxStatsHistory(num int64) ([]*model.TxStats, error)
+}
+
+func Stats(s Store) (*model.Stats, error) {
+	var stats model.Stats
+
+	block, err := s.LatestBlock()
+	if nil != err {
+		logrus.Errorln(err)
+		return nil, err
+	}
+	stats.LatestBlock = block.Height
+
+	stats.TotalContract, err = s.TotalContract()
+	if nil != err {
+		logrus.Errorln(err)
+		return nil, err
+	}
+
+	stats.TotalTx, err = s.TotalTx()
+	if nil != err {
+		logrus.Errorln(err)
+		return nil, err
+	}
+
+	nodes, err := s.AllNodes()
+	if nil != err {
+		logrus.Errorln("failed to find amount of nodes,err:", err)
+		return nil, err
+	}
+	stats.TotalNode = len(nodes)
+
+	return &stats, nil
+}
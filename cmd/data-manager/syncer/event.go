@@ -0,0 +1,210 @@
+package syncer
+
+import (
+	"bytes"
+	"context"
+	"data-manager/model"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Venachain/Venachain/accounts/abi"
+	"github.com/Venachain/Venachain/accounts/abi/bind"
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// contractABI is the ABI the logs of a contract are decoded with, only one of
+// evm and wasm is set, none of them if the contract has no valid ABI.
+type contractABI struct {
+	evm  *abi.ABI
+	wasm *bind.WasmABI
+}
+
+// contractABI returns the ABI stored on chain with the contract, the format is
+// told by the contract code since WASM contracts carry it in their code.
+func (this *syncer) contractABI(client chainClient, address common.Address) (*contractABI, error) {
+	if c, ok := this.abis[address]; ok {
+		return c, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	raw, err := client.AbiAt(ctx, address, nil)
+	if nil != err {
+		return nil, err
+	}
+
+	c := new(contractABI)
+	// the miss isn't cached, the node may not have the contract yet when it
+	// lags behind the one the block was read from
+	if len(raw) == 0 {
+		return c, nil
+	}
+
+	code, err := client.CodeAt(ctx, address, nil)
+	if nil != err {
+		return nil, err
+	}
+
+	if ok, _, _, _ := common.IsWasmContractCode(code); ok {
+		c.wasm, err = bind.ParseWasmABI(string(raw))
+	} else {
+		var evmABI abi.ABI
+		evmABI, err = abi.JSON(bytes.NewReader(raw))
+		c.evm = &evmABI
+	}
+	if nil != err {
+		logrus.Warningf("invalid abi of contract %s,err:%v", address.Hex(), err)
+		c = new(contractABI)
+	}
+
+	this.abis[address] = c
+	return c, nil
+}
+
+// decodeEvents turns the logs of a receipt into events, the logs which can't be
+// decoded are kept with their raw topics and data.
+func (this *syncer) decodeEvents(client chainClient, logs []*types.Log, timestamp int64) []*model.Event {
+	events := make([]*model.Event, 0, len(logs))
+	for _, log := range logs {
+		var e model.Event
+
+		e.ContractAddress = log.Address.Hex()
+		e.Topics = make([]string, 0, len(log.Topics))
+		for _, topic := range log.Topics {
+			e.Topics = append(e.Topics, topic.Hex())
+		}
+		e.Data = hex.EncodeToString(log.Data)
+		e.TxHash = log.TxHash.Hex()
+		e.Height = log.BlockNumber
+		e.LogIndex = log.Index
+		e.Timestamp = timestamp
+
+		c, err := this.contractABI(client, log.Address)
+		if nil != err {
+			logrus.Warningf("failed to get abi of contract %s,err:%v", e.ContractAddress, err)
+		} else if len(log.Topics) != 0 {
+			switch {
+			case c.evm != nil:
+				err = decodeEVMEvent(c.evm, log, &e)
+			case c.wasm != nil:
+				err = decodeWasmEvent(c.wasm, log, &e)
+			}
+			if nil != err {
+				logrus.Warningf("failed to decode log %d of tx %s,err:%v", log.Index, e.TxHash, err)
+				e.Name, e.Args = "", nil
+			}
+		}
+
+		events = append(events, &e)
+	}
+
+	return events
+}
+
+func decodeEVMEvent(evmABI *abi.ABI, log *types.Log, e *model.Event) error {
+	for _, ev := range evmABI.Events {
+		if ev.Anonymous || ev.Id() != log.Topics[0] {
+			continue
+		}
+
+		values, err := ev.Inputs.UnpackValues(log.Data)
+		if nil != err {
+			return err
+		}
+
+		topics := log.Topics[1:]
+		for _, input := range ev.Inputs {
+			arg := &model.EventArg{Name: input.Name, Type: input.Type.String(), Indexed: input.Indexed}
+			if input.Indexed {
+				if len(topics) == 0 {
+					return fmt.Errorf("missing topic of indexed argument %s", input.Name)
+				}
+				arg.Value, err = indexedValue(input.Type, topics[0])
+				if nil != err {
+					return err
+				}
+				topics = topics[1:]
+			} else {
+				arg.Value = formatValue(values[0])
+				values = values[1:]
+			}
+			e.Args = append(e.Args, arg)
+		}
+
+		e.Name = ev.Name
+		e.Decoded = true
+		return nil
+	}
+
+	return nil
+}
+
+// indexedValue decodes an indexed argument from its topic, the arguments of
+// dynamic types are only indexed by the hash of their value.
+func indexedValue(typ abi.Type, topic common.Hash) (string, error) {
+	switch typ.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return topic.Hex(), nil
+	}
+
+	values, err := abi.Arguments{{Type: typ}}.UnpackValues(topic.Bytes())
+	if nil != err {
+		return "", err
+	}
+
+	return formatValue(values[0]), nil
+}
+
+func decodeWasmEvent(wasmABI *bind.WasmABI, log *types.Log, e *model.Event) error {
+	for _, ev := range wasmABI.AbiArr {
+		if !strings.EqualFold(ev.Type, "event") || bind.WasmEventID(ev.Name) != log.Topics[0] {
+			continue
+		}
+
+		values, err := wasmABI.UnpackEventValues(ev.Name, log.Data)
+		if nil != err {
+			return err
+		}
+
+		for i, input := range ev.Inputs {
+			e.Args = append(e.Args, &model.EventArg{Name: input.Name, Type: input.Type, Value: formatValue(values[i])})
+		}
+
+		e.Name = ev.Name
+		e.Decoded = true
+		return nil
+	}
+
+	return nil
+}
+
+// formatValue formats a decoded argument as a string so that the arguments of
+// all types can be searched alike.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return "0x" + hex.EncodeToString(b)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -1,49 +1,86 @@
 package syncer
 
 import (
+	"context"
 	"data-manager/config"
-	"data-manager/db"
-	dbCtx "data-manager/db/context"
 	"data-manager/model"
+	"data-manager/store"
 	"data-manager/util"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"sort"
 	"strings"
 	"time"
 
+	ethereum "github.com/Venachain/Venachain"
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/rpc"
 	"github.com/sirupsen/logrus"
 )
 
 var DefaultSyncer = newSyncer()
 
 type syncer struct {
-	stop  chan int
-	dbCtx *dbCtx.Context
+	stop      chan int
+	store     store.Store
+	upstream  *upstream
+	batchSize int
+	cursor    *model.Cursor
+	halted    error // the blocks of the nodes don't follow the cursor
+	heads     chan *types.Header
+	sub       ethereum.Subscription
+	abis      map[common.Address]*contractABI
 }
 
 func newSyncer() *syncer {
 	return &syncer{
 		stop:  make(chan int),
-		dbCtx: dbCtx.New(db.DefaultDB),
+		heads: make(chan *types.Header, 16),
+		abis:  make(map[common.Address]*contractABI),
 	}
 }
 
 func (this *syncer) Run() {
+	this.store = store.Default
+	this.upstream = newUpstream(config.Config.SyncConf.URLs())
+	this.batchSize = config.Config.SyncConf.BlockBatchSize()
+
 	go this.loop()
 	logrus.Info("start to sync.")
 }
 
+// subErr returns the error channel of the subscription, it is nil if there is
+// no subscription so that it is never selected.
+func subErr(sub ethereum.Subscription) <-chan error {
+	if nil == sub {
+		return nil
+	}
+
+	return sub.Err()
+}
+
 func (this *syncer) loop() {
 	tick := time.NewTicker(config.Config.SyncConf.SyncInterval())
 	target := time.Until(config.Config.SyncTxCountConf.GetWhen())
 	syncTxCountTick := time.NewTimer(target)
 
+	this.sync()
+
 	for {
 		select {
+		case head := <-this.heads:
+			err := this.syncBlocks(head.Number.Uint64())
+			if nil != err {
+				logrus.Errorln("failed to sync blocks,err:", err)
+			}
+
+		case err := <-subErr(this.sub):
+			this.sub = nil
+			this.upstream.Fail(err)
+
 		case <-tick.C:
 			this.sync()
 
@@ -63,6 +100,7 @@ func (this *syncer) loop() {
 
 		case <-this.stop:
 			logrus.Info("sync stop")
+			this.unsubscribe()
 			syncTxCountTick.Stop()
 			tick.Stop()
 
@@ -88,85 +126,172 @@ func (this *syncer) sync() {
 		logrus.Debug("sync cns success.")
 	}
 
-	err = this.syncBlocks()
+	// the ticks catch up with the chain on the nodes which can't push new
+	// heads and with the heads missed while the subscription was down
+	err = this.syncLatest()
 	if nil != err {
 		logrus.Errorln("failed to sync blocks,err:", err)
 		return
 	}
 	logrus.Debug("sync blocks success.")
 
-	//err = this.syncStats()
-	//if nil != err {
-	//	logrus.Errorln("failed to sync stats,err:", err)
-	//	return
-	//}
+	this.subscribe()
 }
 
-func (this *syncer) syncBlocks() error {
-	block, err := util.DefaultNode.LatestBlock()
+// subscribe subscribes to the new heads of the current node if it pushes them.
+func (this *syncer) subscribe() {
+	if nil != this.sub {
+		return
+	}
+
+	client, err := this.upstream.Client()
+	if nil != err {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sub, err := client.SubscribeNewHead(ctx, this.heads)
+	if rpc.ErrNotificationsUnsupported == err {
+		return
+	}
+	if nil != err {
+		this.upstream.Fail(err)
+		return
+	}
+
+	logrus.Info("subscribed to new heads.")
+	this.sub = sub
+}
+
+func (this *syncer) unsubscribe() {
+	if nil != this.sub {
+		this.sub.Unsubscribe()
+		this.sub = nil
+	}
+}
+
+// fail drops the current node, along with the subscription to its heads.
+func (this *syncer) fail(err error) {
+	this.unsubscribe()
+	this.upstream.Fail(err)
+}
+
+func (this *syncer) syncLatest() error {
+	client, err := this.upstream.Client()
 	if nil != err {
-		logrus.Errorln(err)
 		return err
 	}
 
-	dbBlock, err := model.DefaultBlock.LatestBlock(this.dbCtx)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	head, err := client.HeaderByNumber(ctx, nil)
 	if nil != err {
-		logrus.Errorln(err)
+		this.fail(err)
 		return err
 	}
 
-	return this.doSyncBlocks(block.NumberU64(), dbBlock.Height)
+	return this.syncBlocks(head.Number.Uint64())
 }
 
-func (this *syncer) doSyncBlocks(heightTarget, heightCur uint64) error {
-	for i := heightCur + 1; i <= heightTarget; i++ {
-		block, err := util.DefaultNode.BlockByHeight(i)
+// syncBlocks syncs the blocks after the cursor up to the target, fetching them
+// in batches. Each block is written along with the cursor, so a restart resumes
+// right after the last block written.
+func (this *syncer) syncBlocks(target uint64) error {
+	if nil != this.halted {
+		return this.halted
+	}
+
+	if nil == this.cursor {
+		cursor, err := this.store.Cursor()
 		if nil != err {
 			logrus.Errorln(err)
 			return err
 		}
+		this.cursor = cursor
+	}
 
-		err = this.doSyncTxs(block)
+	for this.cursor.Height < target {
+		client, err := this.upstream.Client()
 		if nil != err {
-			logrus.Errorln(err)
 			return err
 		}
 
-		var dbBlock model.Block
-
-		dbBlock.Height = i
-		dbBlock.ExtraData = hex.EncodeToString(block.Extra())
-		dbBlock.GasLimit = block.GasLimit()
-		dbBlock.GasUsed = block.GasUsed()
-		dbBlock.Hash = block.Hash().Hex()
-		dbBlock.ParentHash = block.ParentHash().Hex()
-		dbBlock.Proposer = block.Coinbase().Hex()
-		dbBlock.Timestamp = block.Time().Int64()
-		dbBlock.TxAmount = uint64(block.Transactions().Len())
-		dbBlock.Size = block.Size().String()
+		numbers := make([]*big.Int, 0, this.batchSize)
+		for h := this.cursor.Height + 1; h <= target && len(numbers) < this.batchSize; h++ {
+			numbers = append(numbers, new(big.Int).SetUint64(h))
+		}
 
-		err = model.DefaultBlock.InsertBlock(this.dbCtx, &dbBlock)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		blocks, err := client.BlocksByNumber(ctx, numbers)
+		cancel()
 		if nil != err {
-			logrus.Errorln(err)
+			this.fail(err)
 			return err
 		}
+
+		for _, block := range blocks {
+			if this.cursor.Hash != "" && block.ParentHash().Hex() != this.cursor.Hash {
+				// The blocks are final, a node serving another parent is on
+				// another chain than the one stored. Failing over would go
+				// round the nodes forever, the sync stops until the store or
+				// the nodes are fixed and the syncer is restarted.
+				this.halted = fmt.Errorf("block %d of node %s doesn't follow block %d %s, sync stopped",
+					block.NumberU64(), this.upstream.url, this.cursor.Height, this.cursor.Hash)
+				logrus.Errorln(this.halted)
+				this.unsubscribe()
+				return this.halted
+			}
+
+			data, err := this.blockData(client, block)
+			if nil != err {
+				this.fail(err)
+				return err
+			}
+
+			err = this.store.WriteBlock(data)
+			if nil != err {
+				logrus.Errorln(err)
+				return err
+			}
+
+			this.cursor = &model.Cursor{Height: data.Block.Height, Hash: data.Block.Hash}
+		}
 	}
 
 	return nil
 }
 
-func (this *syncer) doSyncTxs(block *types.Block) error {
-	for _, tx := range block.Transactions() {
+func (this *syncer) blockData(client chainClient, block *types.Block) (*store.BlockData, error) {
+	var data store.BlockData
+
+	txs := block.Transactions()
+	hashes := make([]common.Hash, 0, len(txs))
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash())
+	}
+
+	receipts := []*types.Receipt{}
+	if len(hashes) != 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		var err error
+		receipts, err = client.TransactionReceipts(ctx, hashes)
+		cancel()
+		if nil != err {
+			logrus.Errorln("fail to get transaction receipts.err:", err)
+			return nil, err
+		}
+	}
+
+	for i, tx := range txs {
 		var dbTx model.Tx
 
 		dbTx.Timestamp = block.Time().Int64()
 		dbTx.Hash = tx.Hash().Hex()
 		dbTx.GasLimit = tx.Gas()
-		receipt, err := util.DefaultNode.TransactionReceipt(tx.Hash())
-		if nil != err {
-			logrus.Errorln("fail to get transaction receipt.err:", err)
-			return err
-		}
+		receipt := receipts[i]
 		var recpt model.Receipt
 		recpt.GasUsed = receipt.GasUsed
 		recpt.ContractAddress = receipt.ContractAddress.String()
@@ -177,17 +302,19 @@ func (this *syncer) doSyncTxs(block *types.Block) error {
 		bin, err := json.Marshal(receipt.Logs)
 		if nil != err {
 			logrus.Errorln(err)
-			return err
+			return nil, err
 		}
-		recpt.Event = string(bin) //todo parse event
+		recpt.Event = string(bin)
 		recpt.Status = receipt.Status
 
+		data.Events = append(data.Events, this.decodeEvents(client, receipt.Logs, dbTx.Timestamp)...)
+
 		dbTx.Receipt = &recpt
 		from, err := util.Sender(tx)
 		if nil != err {
 			logrus.Errorln("fail to get sender of tx.err:", err)
 
-			return err
+			return nil, err
 		}
 		dbTx.From = from.Hex()
 
@@ -201,14 +328,24 @@ func (this *syncer) doSyncTxs(block *types.Block) error {
 		dbTx.Value = tx.Value().Uint64()
 		dbTx.Height = block.NumberU64()
 
-		err = model.DefaultTx.InsertTx(this.dbCtx, &dbTx)
-		if nil != err {
-			logrus.Errorln(err)
-			return err
-		}
+		data.Txs = append(data.Txs, &dbTx)
 	}
 
-	return nil
+	var dbBlock model.Block
+
+	dbBlock.Height = block.NumberU64()
+	dbBlock.ExtraData = hex.EncodeToString(block.Extra())
+	dbBlock.GasLimit = block.GasLimit()
+	dbBlock.GasUsed = block.GasUsed()
+	dbBlock.Hash = block.Hash().Hex()
+	dbBlock.ParentHash = block.ParentHash().Hex()
+	dbBlock.Proposer = block.Coinbase().Hex()
+	dbBlock.Timestamp = block.Time().Int64()
+	dbBlock.TxAmount = uint64(block.Transactions().Len())
+	dbBlock.Size = block.Size().String()
+
+	data.Block = &dbBlock
+	return &data, nil
 }
 
 func (this *syncer) syncTxStats() error {
@@ -217,12 +354,12 @@ func (this *syncer) syncTxStats() error {
 
 	start := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
 	end := time.Date(y, m, d, 23, 59, 59, 0, time.Local)
-	amount, err := model.DefaultTx.TxAmountByTime(this.dbCtx, start.Unix(), end.Unix())
+	amount, err := this.store.TxAmountByTime(start.Unix(), end.Unix())
 	if nil != err {
 		return err
 	}
 
-	err = model.DefaultTxStats.UpsertTxAmountOneDay(this.dbCtx, fmt.Sprintf("%d:%d:%d", y, m, d), amount)
+	err = this.store.UpsertTxAmountOneDay(fmt.Sprintf("%d:%d:%d", y, m, d), amount)
 	if nil != err {
 		return err
 	}
@@ -230,45 +367,13 @@ func (this *syncer) syncTxStats() error {
 	return nil
 }
 
-//
-//func (this *syncer) syncStats() error {
-//	var stats model.Stats
-//	var err error
-//	stats.LatestBlock, err = model.DefaultBlock.LatestHeight(this.dbCtx)
-//	if nil != err {
-//		logrus.Errorln(err)
-//		return err
-//	}
-//
-//	stats.TotalContract, err = model.DefaultTx.TotalContract(this.dbCtx)
-//	if nil != err {
-//		logrus.Errorln(err)
-//		return err
-//	}
-//
-//	stats.TotalTx, err = model.DefaultTx.TotalTx(this.dbCtx)
-//	if nil != err {
-//		logrus.Errorln(err)
-//		return err
-//	}
-//
-//	stats.TotalNode, err = model.DefaultNode.AllNodeCount(this.dbCtx)
-//	if nil != err {
-//		logrus.Errorln("failed to find amount of nodes,err:", err)
-//		return err
-//	}
-//
-//	err = model.DefaultStats.UpsertStats(this.dbCtx, &stats)
-//	if nil != err {
-//		logrus.Errorln(err)
-//		return err
-//	}
-//
-//	return nil
-//}
-
 func (this *syncer) syncNodes() error {
-	nodeInfos, err := util.GetNodes()
+	endpoint, err := this.upstream.URL()
+	if nil != err {
+		return err
+	}
+
+	nodeInfos, err := util.GetNodes(endpoint)
 	if nil != err {
 		logrus.Errorln(err)
 		return err
@@ -294,18 +399,16 @@ func (this *syncer) syncNodes() error {
 		nodes = append(nodes, &node)
 	}
 
-	//TODO better idea
-	err = model.DefaultNode.DeleteAllNodes(this.dbCtx)
+	return this.store.ReplaceNodes(nodes)
+}
+
+func (this *syncer) syncCNS() error {
+	endpoint, err := this.upstream.URL()
 	if nil != err {
-		logrus.Errorln(err)
 		return err
 	}
 
-	return model.DefaultNode.InsertNodes(this.dbCtx, nodes)
-}
-
-func (this *syncer) syncCNS() error {
-	cnses, err := util.GetAllCNS()
+	cnses, err := util.GetAllCNS(endpoint)
 	if nil != err {
 		logrus.Errorln(err)
 		return err
@@ -313,7 +416,7 @@ func (this *syncer) syncCNS() error {
 
 	mapCns := map[string]*model.CNS{}
 	for _, info := range cnses {
-		latest, err := util.GetLatestCNS(info.Name)
+		latest, err := util.GetLatestCNS(endpoint, info.Name)
 		if nil != err {
 			logrus.Errorln(err)
 			return err
@@ -343,16 +446,9 @@ func (this *syncer) syncCNS() error {
 		modelCnses = append(modelCnses, v)
 	}
 
-	//TODO better idea
-	err = model.DefaultCNS.DeleteAllCNS(this.dbCtx)
-	if nil != err {
-		logrus.Errorln(err)
-		return err
-	}
-
 	sort.SliceStable(modelCnses, func(i, j int) bool {
 		return strings.Compare(modelCnses[i].Name, modelCnses[j].Name) > 0
 	})
 
-	return model.DefaultCNS.InsertCNS(this.dbCtx, modelCnses)
+	return this.store.ReplaceCNS(modelCnses)
 }
@@ -0,0 +1,223 @@
+package syncer
+
+import (
+	"context"
+	"data-manager/model"
+	"data-manager/store"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	ethereum "github.com/Venachain/Venachain"
+	"github.com/Venachain/Venachain/accounts/abi"
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const testEventABI = `[{"type":"event","name":"Transfer","inputs":[{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]}]`
+
+var (
+	testKey, _   = crypto.GenerateKey()
+	testContract = common.HexToAddress("0xc0ffee")
+)
+
+// fakeChain serves a chain of blocks to the syncer in place of a node.
+type fakeChain struct {
+	blocks   []*types.Block
+	receipts map[common.Hash]*types.Receipt
+	fetched  []uint64
+	err      error
+	closed   bool
+	noABI    bool // the node doesn't have the contract yet
+}
+
+// newFakeChain returns a chain of n blocks, the chains made with other times
+// differ.
+func newFakeChain(t *testing.T, n int, time int64) *fakeChain {
+	chain := &fakeChain{receipts: make(map[common.Hash]*types.Receipt)}
+	parent := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0), Time: big.NewInt(0)})
+	chain.blocks = append(chain.blocks, parent)
+
+	evmABI, err := abi.JSON(strings.NewReader(testEventABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= n; i++ {
+		tx, err := types.SignTx(types.NewTransaction(uint64(i), testContract, big.NewInt(0), 100000, big.NewInt(1), nil), types.HomesteadSigner{}, testKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := abi.Arguments{evmABI.Events["Transfer"].Inputs[1]}.Pack(big.NewInt(int64(i)))
+		receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: 21000, Logs: []*types.Log{{
+			Address:     testContract,
+			Topics:      []common.Hash{evmABI.Events["Transfer"].Id(), common.BytesToHash(testContract.Bytes())},
+			Data:        data,
+			BlockNumber: uint64(i),
+			TxHash:      tx.Hash(),
+		}}}
+		chain.receipts[tx.Hash()] = receipt
+
+		header := &types.Header{Number: big.NewInt(int64(i)), ParentHash: parent.Hash(), Time: big.NewInt(time + int64(i))}
+		parent = types.NewBlock(header, []*types.Transaction{tx}, []*types.Receipt{receipt})
+		chain.blocks = append(chain.blocks, parent)
+	}
+
+	return chain
+}
+
+func (this *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return this.blocks[len(this.blocks)-1].Header(), this.err
+}
+
+func (this *fakeChain) BlocksByNumber(ctx context.Context, numbers []*big.Int) ([]*types.Block, error) {
+	if nil != this.err {
+		return nil, this.err
+	}
+
+	blocks := []*types.Block{}
+	for _, number := range numbers {
+		if number.Uint64() >= uint64(len(this.blocks)) {
+			return nil, ethereum.NotFound
+		}
+		this.fetched = append(this.fetched, number.Uint64())
+		blocks = append(blocks, this.blocks[number.Uint64()])
+	}
+
+	return blocks, nil
+}
+
+func (this *fakeChain) TransactionReceipts(ctx context.Context, txHashes []common.Hash) ([]*types.Receipt, error) {
+	receipts := []*types.Receipt{}
+	for _, hash := range txHashes {
+		receipts = append(receipts, this.receipts[hash])
+	}
+
+	return receipts, this.err
+}
+
+func (this *fakeChain) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (this *fakeChain) AbiAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if account == testContract && !this.noABI {
+		return []byte(testEventABI), nil
+	}
+
+	return nil, nil
+}
+
+func (this *fakeChain) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x60, 0x80}, nil
+}
+
+func (this *fakeChain) Close() {
+	this.closed = true
+}
+
+func newTestSyncer(s store.Store, nodes map[string]*fakeChain, urls ...string) *syncer {
+	this := newSyncer()
+	this.store = s
+	this.batchSize = 2
+	this.upstream = newUpstream(urls)
+	this.upstream.dial = func(ctx context.Context, url string) (chainClient, error) {
+		if chain, ok := nodes[url]; ok {
+			return chain, nil
+		}
+		return nil, errors.New("connection refused")
+	}
+
+	return this
+}
+
+func TestSyncBlocks(t *testing.T) {
+	chain := newFakeChain(t, 5, 0)
+	s := store.NewMemory()
+
+	this := newTestSyncer(s, map[string]*fakeChain{"b": chain}, "a", "b")
+	assert.NoError(t, this.syncBlocks(3))
+	assert.Equal(t, []uint64{1, 2, 3}, chain.fetched)
+
+	cursor, _ := s.Cursor()
+	assert.Equal(t, uint64(3), cursor.Height)
+	assert.Equal(t, chain.blocks[3].Hash().Hex(), cursor.Hash)
+
+	total, _ := s.TotalTx()
+	assert.Equal(t, int64(3), total)
+
+	events, _ := s.Events(1, 10, &model.EventQuery{Name: "Transfer", ArgName: "value", ArgValue: "2"})
+	if assert.Len(t, events, 1) {
+		assert.True(t, events[0].Decoded)
+		assert.Equal(t, uint64(2), events[0].Height)
+		assert.Equal(t, testContract.Hex(), events[0].Args[0].Value)
+	}
+
+	// a restarted syncer resumes after the cursor
+	chain.fetched = nil
+	this = newTestSyncer(s, map[string]*fakeChain{"b": chain}, "b")
+	assert.NoError(t, this.syncLatest())
+	assert.Equal(t, []uint64{4, 5}, chain.fetched)
+
+	total, _ = s.TotalTx()
+	assert.Equal(t, int64(5), total)
+	amount, _ := s.TotalEvents(&model.EventQuery{ContractAddress: testContract.Hex()})
+	assert.Equal(t, int64(5), amount)
+}
+
+func TestSyncBlocksFailover(t *testing.T) {
+	chain := newFakeChain(t, 4, 0)
+	broken := newFakeChain(t, 4, 0)
+	broken.err = errors.New("internal error")
+	s := store.NewMemory()
+
+	this := newTestSyncer(s, map[string]*fakeChain{"a": broken, "b": chain}, "a", "b")
+	assert.Error(t, this.syncBlocks(4))
+	assert.True(t, broken.closed)
+
+	assert.NoError(t, this.syncBlocks(4))
+	cursor, _ := s.Cursor()
+	assert.Equal(t, uint64(4), cursor.Height)
+}
+
+func TestSyncBlocksUnlinked(t *testing.T) {
+	chain := newFakeChain(t, 2, 0)
+	other := newFakeChain(t, 3, 100)
+	s := store.NewMemory()
+
+	this := newTestSyncer(s, map[string]*fakeChain{"a": chain}, "a")
+	assert.NoError(t, this.syncBlocks(2))
+
+	// a node of another chain doesn't move the cursor, the sync stops instead
+	// of failing over to the next node
+	chain = newFakeChain(t, 3, 0)
+	this = newTestSyncer(s, map[string]*fakeChain{"a": other, "b": chain}, "a", "b")
+	assert.Error(t, this.syncBlocks(3))
+	assert.False(t, other.closed)
+
+	chain.fetched = nil
+	assert.Error(t, this.syncBlocks(3))
+	assert.Empty(t, chain.fetched)
+
+	cursor, _ := s.Cursor()
+	assert.Equal(t, uint64(2), cursor.Height)
+	assert.Equal(t, chain.blocks[2].Hash().Hex(), cursor.Hash)
+}
+
+func TestContractABIMiss(t *testing.T) {
+	chain := newFakeChain(t, 1, 0)
+	chain.noABI = true
+	this := newTestSyncer(store.NewMemory(), map[string]*fakeChain{"a": chain}, "a")
+
+	c, err := this.contractABI(chain, testContract)
+	assert.NoError(t, err)
+	assert.Nil(t, c.evm)
+
+	// the ABI is read again once the node has it
+	chain.noABI = false
+	c, err = this.contractABI(chain, testContract)
+	assert.NoError(t, err)
+	assert.NotNil(t, c.evm)
+}
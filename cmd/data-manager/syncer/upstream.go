@@ -0,0 +1,99 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	ethereum "github.com/Venachain/Venachain"
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/venaclient"
+	"github.com/sirupsen/logrus"
+)
+
+var errNoUpstream = errors.New("no upstream node available")
+
+// chainClient is the part of venaclient.Client the syncer fetches the chain with.
+type chainClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlocksByNumber(ctx context.Context, numbers []*big.Int) ([]*types.Block, error)
+	TransactionReceipts(ctx context.Context, txHashes []common.Hash) ([]*types.Receipt, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	AbiAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	Close()
+}
+
+func dialNode(ctx context.Context, url string) (chainClient, error) {
+	cli, err := venaclient.DialContext(ctx, url)
+	if nil != err {
+		return nil, err
+	}
+
+	return cli, nil
+}
+
+// upstream is the node the syncer fetches the chain from, it fails over to the
+// next of the configured nodes when the current one fails.
+type upstream struct {
+	urls   []string
+	dial   func(ctx context.Context, url string) (chainClient, error)
+	next   int
+	url    string
+	client chainClient
+}
+
+func newUpstream(urls []string) *upstream {
+	return &upstream{urls: urls, dial: dialNode}
+}
+
+// Client returns the client of the current node, connecting to the nodes in
+// turn if there is none.
+func (this *upstream) Client() (chainClient, error) {
+	if nil != this.client {
+		return this.client, nil
+	}
+
+	for range this.urls {
+		url := this.urls[this.next]
+		this.next = (this.next + 1) % len(this.urls)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		cli, err := this.dial(ctx, url)
+		cancel()
+		if nil != err {
+			logrus.Warningf("failed to dial node %s,err:%v", url, err)
+			continue
+		}
+
+		logrus.Infof("sync from node %s", url)
+		this.url, this.client = url, cli
+		return cli, nil
+	}
+
+	return nil, errNoUpstream
+}
+
+// URL returns the url of the current node, connecting to the nodes in turn if
+// there is none.
+func (this *upstream) URL() (string, error) {
+	if _, err := this.Client(); nil != err {
+		return "", err
+	}
+
+	return this.url, nil
+}
+
+// Fail drops the current node after a failed request, the next node is used
+// from then on.
+func (this *upstream) Fail(err error) {
+	if nil == this.client {
+		return
+	}
+
+	logrus.Warningf("node %s failed,err:%v", this.url, err)
+	this.client.Close()
+	this.url, this.client = "", nil
+}
@@ -33,7 +33,7 @@ type nodeResult struct {
 }
 
 func GetAmountOfNodes() (int, error) {
-	nodes, err := GetNodes()
+	nodes, err := GetNodes(config.Config.ChainConf.NodeRpcAddress)
 	if nil != err {
 		logrus.Errorln("failed to get nodes,err:", err)
 		return 0, err
@@ -42,12 +42,13 @@ func GetAmountOfNodes() (int, error) {
 	return len(nodes), nil
 }
 
-func GetNodes() ([]*nodeInfo, error) {
+// GetNodes returns the nodes registered on the chain read from the endpoint.
+func GetNodes(endpoint string) ([]*nodeInfo, error) {
 	url := fmt.Sprintf(
 		"%s%s?endpoint=%s",
 		config.Config.ChainConf.NodeRestServer,
 		"/node/components",
-		config.Config.ChainConf.NodeRpcAddress,
+		endpoint,
 	)
 
 	return urlNodeComponents(url)
@@ -106,12 +107,13 @@ type cnsResult struct {
 	Data []*cnsInfo `json:"data"`
 }
 
-func GetAllCNS() ([]*cnsInfo, error) {
+// GetAllCNS returns the CNS registrations of the chain read from the endpoint.
+func GetAllCNS(endpoint string) ([]*cnsInfo, error) {
 	url := fmt.Sprintf(
 		"%s%s?endpoint=%s",
 		config.Config.ChainConf.NodeRestServer,
 		"/cns/components",
-		config.Config.ChainConf.NodeRpcAddress,
+		endpoint,
 	)
 
 	ret, err := urlCnsComponents(url)
@@ -122,13 +124,14 @@ func GetAllCNS() ([]*cnsInfo, error) {
 	return ret, nil
 }
 
-func GetLatestCNS(name string) (*cnsInfo, error) {
+// GetLatestCNS returns the latest version of the name read from the endpoint.
+func GetLatestCNS(endpoint, name string) (*cnsInfo, error) {
 	url := fmt.Sprintf(
 		"%s%s/%s?endpoint=%s&version=latest",
 		config.Config.ChainConf.NodeRestServer,
 		"/cns/mappings",
 		name,
-		config.Config.ChainConf.NodeRpcAddress,
+		endpoint,
 	)
 
 	var ret string
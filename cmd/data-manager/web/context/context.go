@@ -1,13 +1,13 @@
 package context
 
 import (
-	dbCtx "data-manager/db/context"
+	"data-manager/store"
 	"github.com/gin-gonic/gin"
 )
 
 type Context struct {
 	*gin.Context
-	DBCtx *dbCtx.Context
+	Store store.Store
 }
 
 func New() *Context {
@@ -18,6 +18,6 @@ func (this *Context) SetContext(ctx *gin.Context) {
 	this.Context = ctx
 }
 
-func (this *Context) SetDBContext(ctx *dbCtx.Context) {
-	this.DBCtx = ctx
+func (this *Context) SetStore(s store.Store) {
+	this.Store = s
 }
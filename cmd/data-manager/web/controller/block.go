@@ -2,7 +2,6 @@ package controller
 
 import (
 	"data-manager/exterror"
-	"data-manager/model"
 	webCtx "data-manager/web/context"
 	webEngine "data-manager/web/engine"
 	"net/http"
@@ -36,7 +35,7 @@ func (this *blockController) Block(ctx *webCtx.Context) {
 	}
 
 	if 0 != br.BlockHeight {
-		ret, err := model.DefaultBlock.BlockByHeight(ctx.DBCtx, br.BlockHeight)
+		ret, err := ctx.Store.BlockByHeight(br.BlockHeight)
 		if nil != err {
 			ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
 			return
@@ -46,7 +45,7 @@ func (this *blockController) Block(ctx *webCtx.Context) {
 		return
 	}
 
-	ret, err := model.DefaultBlock.BlockByHash(ctx.DBCtx, strings.TrimSpace(br.BlockHash))
+	ret, err := ctx.Store.BlockByHash(strings.TrimSpace(br.BlockHash))
 	if nil != err {
 		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
 		return
@@ -63,13 +62,13 @@ func (this *blockController) Blocks(ctx *webCtx.Context) {
 	}
 	setPageDefaultIfEmpty(&p)
 
-	result, err := model.DefaultBlock.Blocks(ctx.DBCtx, p.PageIndex, p.PageSize)
+	result, err := ctx.Store.Blocks(p.PageIndex, p.PageSize)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	block, err := model.DefaultBlock.LatestBlock(ctx.DBCtx)
+	block, err := ctx.Store.LatestBlock()
 	if nil != err {
 		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
 		return
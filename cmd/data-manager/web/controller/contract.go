@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"data-manager/model"
 	"data-manager/util"
 	webCtx "data-manager/web/context"
 	webEngine "data-manager/web/engine"
@@ -30,7 +29,7 @@ func (this *contractController) Contracts(ctx *webCtx.Context) {
 	}
 	setPageDefaultIfEmpty(&p)
 
-	result, err := model.DefaultTx.Contracts(ctx.DBCtx, p.PageIndex, p.PageSize)
+	result, err := ctx.Store.Contracts(p.PageIndex, p.PageSize)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
 		return
@@ -55,7 +54,7 @@ func (this *contractController) Contracts(ctx *webCtx.Context) {
 		cs = append(cs, &c)
 	}
 
-	totalContract, err := model.DefaultTx.TotalContract(ctx.DBCtx)
+	totalContract, err := ctx.Store.TotalContract()
 	if nil != err {
 		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
 		return
@@ -72,13 +71,13 @@ func (this *contractController) CNS(ctx *webCtx.Context) {
 	}
 	setPageDefaultIfEmpty(&p)
 
-	result, err := model.DefaultCNS.QueryCNS(ctx.DBCtx, p.PageIndex, p.PageSize)
+	result, err := ctx.Store.QueryCNS(p.PageIndex, p.PageSize)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	count, err := model.DefaultCNS.Total(ctx.DBCtx)
+	count, err := ctx.Store.TotalCNS()
 	if nil != err {
 		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
 		return
@@ -90,7 +89,7 @@ func (this *contractController) CNS(ctx *webCtx.Context) {
 func (this *contractController) Contract(ctx *webCtx.Context) {
 	contractAddress := ctx.Param("address")
 
-	result, err := model.DefaultTx.ContractByAddress(ctx.DBCtx, contractAddress)
+	result, err := ctx.Store.ContractByAddress(contractAddress)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
 		return
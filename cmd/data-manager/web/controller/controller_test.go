@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"data-manager/model"
+	"data-manager/store"
+	webEngine "data-manager/web/engine"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func get(t *testing.T, url string, v interface{}) int {
+	w := httptest.NewRecorder()
+	webEngine.Default.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+	if w.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), v))
+	}
+
+	return w.Code
+}
+
+func TestControllersWithMemoryStore(t *testing.T) {
+	store.Default = store.NewMemory()
+	for h := uint64(1); h <= 3; h++ {
+		hash := string(rune('a' + h))
+		err := store.Default.WriteBlock(&store.BlockData{
+			Block: &model.Block{Height: h, Hash: hash},
+			Txs:   []*model.Tx{{Hash: "tx" + hash, Height: h, Timestamp: int64(h), Receipt: &model.Receipt{}}},
+			Events: []*model.Event{{
+				ContractAddress: "0x0000000000000000000000000000000000C0FFEE",
+				Name:            "Transfer",
+				TxHash:          "tx" + hash,
+				Height:          h,
+				Args:            []*model.EventArg{{Name: "value", Value: hash}},
+			}},
+		})
+		assert.NoError(t, err)
+	}
+
+	var blocks struct {
+		Total int64          `json:"total"`
+		Items []*model.Block `json:"items"`
+	}
+	assert.Equal(t, http.StatusOK, get(t, "/blocks?page_index=1&page_size=2", &blocks))
+	assert.Equal(t, int64(3), blocks.Total)
+	if assert.Len(t, blocks.Items, 2) {
+		assert.Equal(t, uint64(3), blocks.Items[0].Height)
+	}
+
+	var tx model.Tx
+	assert.Equal(t, http.StatusOK, get(t, "/tx/txc", &tx))
+	assert.Equal(t, uint64(2), tx.Height)
+	assert.Equal(t, http.StatusInternalServerError, get(t, "/tx/none", &tx))
+
+	var events struct {
+		Total int64          `json:"total"`
+		Items []*model.Event `json:"items"`
+	}
+	assert.Equal(t, http.StatusOK, get(t, "/contract/0x0000000000000000000000000000000000c0ffee/events?arg_name=value&arg_value=c", &events))
+	if assert.Equal(t, int64(1), events.Total) {
+		assert.Equal(t, "txc", events.Items[0].TxHash)
+	}
+	assert.Equal(t, http.StatusOK, get(t, "/events?from_height=2", &events))
+	assert.Equal(t, int64(2), events.Total)
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"data-manager/model"
+	webCtx "data-manager/web/context"
+	webEngine "data-manager/web/engine"
+	"net/http"
+
+	"github.com/Venachain/Venachain/common"
+)
+
+func init() {
+	webEngine.Default.GET("/events", webEngine.NewHandler(defaultEventController.Events))
+	webEngine.Default.GET("/contract/:address/events", webEngine.NewHandler(defaultEventController.ContractEvents))
+	webEngine.Default.GET("/tx/:hash/events", webEngine.NewHandler(defaultEventController.TxEvents))
+}
+
+type eventController struct{}
+
+var defaultEventController = &eventController{}
+
+type eventQuery struct {
+	page
+	ContractAddress string `form:"contract_address"`
+	Name            string `form:"name"`
+	Topic           string `form:"topic"`
+	TxHash          string `form:"tx_hash"`
+	ArgName         string `form:"arg_name"`
+	ArgValue        string `form:"arg_value"`
+	FromHeight      uint64 `form:"from_height"`
+	ToHeight        uint64 `form:"to_height"`
+}
+
+func (this *eventController) Events(ctx *webCtx.Context) {
+	var q eventQuery
+	if err := ctx.ShouldBindQuery(&q); err != nil {
+		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	this.events(ctx, &q)
+}
+
+func (this *eventController) ContractEvents(ctx *webCtx.Context) {
+	var q eventQuery
+	if err := ctx.ShouldBindQuery(&q); err != nil {
+		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	q.ContractAddress = ctx.Param("address")
+
+	this.events(ctx, &q)
+}
+
+func (this *eventController) TxEvents(ctx *webCtx.Context) {
+	var q eventQuery
+	if err := ctx.ShouldBindQuery(&q); err != nil {
+		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	q.TxHash = ctx.Param("hash")
+
+	this.events(ctx, &q)
+}
+
+func (this *eventController) events(ctx *webCtx.Context, q *eventQuery) {
+	setPageDefaultIfEmpty(&q.page)
+	if common.IsHexAddress(q.ContractAddress) {
+		q.ContractAddress = common.HexToAddress(q.ContractAddress).Hex()
+	}
+
+	query := &model.EventQuery{
+		ContractAddress: q.ContractAddress,
+		Name:            q.Name,
+		Topic:           q.Topic,
+		TxHash:          q.TxHash,
+		ArgName:         q.ArgName,
+		ArgValue:        q.ArgValue,
+		FromHeight:      q.FromHeight,
+		ToHeight:        q.ToHeight,
+	}
+
+	result, err := ctx.Store.Events(q.PageIndex, q.PageSize, query)
+	if nil != err {
+		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	total, err := ctx.Store.TotalEvents(query)
+	if nil != err {
+		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.IndentedJSON(200, newPageInfo(q.PageIndex, q.PageSize, total, result))
+}
@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"data-manager/model"
 	webCtx "data-manager/web/context"
 	webEngine "data-manager/web/engine"
 	"net/http"
@@ -16,7 +15,7 @@ type nodeController struct{}
 var defaultNodeController = &nodeController{}
 
 func (this *nodeController) Nodes(ctx *webCtx.Context) {
-	ret, err := model.DefaultNode.AllNodes(ctx.DBCtx)
+	ret, err := ctx.Store.AllNodes()
 	if nil != err {
 		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
 		return
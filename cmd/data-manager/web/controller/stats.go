@@ -1,7 +1,7 @@
 package controller
 
 import (
-	"data-manager/model"
+	"data-manager/store"
 	webCtx "data-manager/web/context"
 	webEngine "data-manager/web/engine"
 	"net/http"
@@ -18,7 +18,7 @@ type statsController struct{}
 var defaultStatsController = &statsController{}
 
 func (this *statsController) Stats(ctx *webCtx.Context) {
-	ret, err := model.GetStats(ctx.DBCtx)
+	ret, err := store.Stats(ctx.Store)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
 		return
@@ -35,7 +35,7 @@ func (this *statsController) TxAmountStats(ctx *webCtx.Context) {
 		return
 	}
 
-	ret, err := model.DefaultTxStats.History(ctx.DBCtx, int64(period))
+	ret, err := ctx.Store.TxStatsHistory(int64(period))
 	if nil != err {
 		ctx.IndentedJSON(http.StatusInternalServerError, err)
 		return
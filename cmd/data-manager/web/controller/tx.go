@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"data-manager/model"
 	webCtx "data-manager/web/context"
 	webEngine "data-manager/web/engine"
 	"net/http"
@@ -22,7 +21,7 @@ var defaultTxController = &txController{}
 func (this *txController) Tx(ctx *webCtx.Context) {
 	hash := ctx.Param("hash")
 
-	ret, err := model.DefaultTx.TxByHash(ctx.DBCtx, hash)
+	ret, err := ctx.Store.TxByHash(hash)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
 		return
@@ -39,13 +38,13 @@ func (this *txController) Txs(ctx *webCtx.Context) {
 	}
 	setPageDefaultIfEmpty(&p)
 
-	result, err := model.DefaultTx.Txs(ctx.DBCtx, p.PageIndex, p.PageSize)
+	result, err := ctx.Store.Txs(p.PageIndex, p.PageSize)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	totalTx, err := model.DefaultTx.TotalTx(ctx.DBCtx)
+	totalTx, err := ctx.Store.TotalTx()
 	if nil != err {
 		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
 		return
@@ -68,13 +67,13 @@ func (this *txController) TxsInHeight(ctx *webCtx.Context) {
 		return
 	}
 
-	result, err := model.DefaultTx.TxsInHeight(ctx.DBCtx, p.PageIndex, p.PageSize, height)
+	result, err := ctx.Store.TxsInHeight(p.PageIndex, p.PageSize, height)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	block, err := model.DefaultBlock.BlockByHeight(ctx.DBCtx, height)
+	block, err := ctx.Store.BlockByHeight(height)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
 		return
@@ -92,13 +91,13 @@ func (this *txController) TxsFromAddress(ctx *webCtx.Context) {
 	fromAddr := ctx.Param("from_address")
 	setPageDefaultIfEmpty(&p)
 
-	result, err := model.DefaultTx.TxsFromAddress(ctx.DBCtx, p.PageIndex, p.PageSize, fromAddr)
+	result, err := ctx.Store.TxsFromAddress(p.PageIndex, p.PageSize, fromAddr)
 	if nil != err {
 		ctx.IndentedJSON(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	totalTx, err := model.DefaultTx.TotalTx(ctx.DBCtx)
+	totalTx, err := ctx.Store.TotalTx()
 	if nil != err {
 		ctx.IndentedJSON(http.StatusInternalServerError, err.Error())
 		return
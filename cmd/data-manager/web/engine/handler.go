@@ -1,8 +1,7 @@
 package engine
 
 import (
-	"data-manager/db"
-	dbContext "data-manager/db/context"
+	"data-manager/store"
 	webContext "data-manager/web/context"
 	"github.com/gin-gonic/gin"
 )
@@ -15,8 +14,7 @@ func NewHandler(handlers ...HandlerFunc) func(*gin.Context) {
 
 		webCtx.SetContext(ctx)
 
-		dbCtx := dbContext.New(db.DefaultDB)
-		webCtx.SetDBContext(dbCtx)
+		webCtx.SetStore(store.Default)
 
 		for _, h := range handlers {
 			h(webCtx)
@@ -34,6 +34,7 @@ import (
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/common/fdlimit"
 	"github.com/Venachain/Venachain/core"
+	"github.com/Venachain/Venachain/core/rawdb"
 	"github.com/Venachain/Venachain/core/state"
 	"github.com/Venachain/Venachain/core/vm"
 	"github.com/Venachain/Venachain/crypto"
@@ -148,7 +149,7 @@ var (
 	defaultSyncMode = vena.DefaultConfig.SyncMode
 	SyncModeFlag    = TextMarshalerFlag{
 		Name:  "syncmode",
-		Usage: `Blockchain sync mode ("fast", "full", or "light")`,
+		Usage: `Blockchain sync mode ("fast", "full", "light" or "snap")`,
 		Value: &defaultSyncMode,
 	}
 	ReleaseFlag = cli.StringFlag{
@@ -442,6 +443,15 @@ var (
 		Usage: "Origins from which to accept websockets requests",
 		Value: "",
 	}
+	RPCAuthFlag = cli.BoolFlag{
+		Name:  "rpcauth",
+		Usage: "Require signed account tokens on the HTTP-RPC and WS-RPC servers and authorize the calls by the account roles",
+	}
+	RPCAuditLogFlag = cli.StringFlag{
+		Name:  "rpcauditlog",
+		Usage: "File the RPC calls denied to remote callers are appended to (logged if empty)",
+		Value: "",
+	}
 	ExecFlag = cli.StringFlag{
 		Name:  "exec",
 		Usage: "Execute JavaScript statement",
@@ -576,12 +586,27 @@ var (
 		Usage: "External EVM configuration (default = built-in interpreter)",
 		Value: "",
 	}
+	WasmCompiledFlag = cli.BoolFlag{
+		Name:  "vm.wasmcompiled",
+		Usage: "Execute the WASM contracts with the closure compiled executor (default = interpreter)",
+	}
 
 	ParallelProcessSize = cli.IntFlag{
 		Name:  "process.size",
 		Usage: "parallel process transactions go routine pool size",
 		Value: 0,
 	}
+
+	PreExecuteFlag = cli.BoolFlag{
+		Name:  "process.preexec",
+		Usage: "speculatively execute pending transactions against the chain head for parallel processing",
+	}
+
+	GroupsFlag = cli.StringFlag{
+		Name:  "groups",
+		Usage: "Comma separated group IDs whose ledgers this node hosts",
+		Value: "",
+	}
 )
 
 // MakeDataDir retrieves the currently requested data directory, terminating
@@ -754,6 +779,16 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setRPCAuth applies the RPC authentication flags to the config.
+func setRPCAuth(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalIsSet(RPCAuthFlag.Name) {
+		cfg.RPCAuth = ctx.GlobalBool(RPCAuthFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCAuditLogFlag.Name) {
+		cfg.RPCAuditLog = ctx.GlobalString(RPCAuditLogFlag.Name)
+	}
+}
+
 // setIPC creates an IPC path configuration from the set command line flags,
 // returning an empty string if IPC was explicitly disabled, or the set path.
 func setIPC(ctx *cli.Context, cfg *node.Config) {
@@ -913,6 +948,7 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	setIPC(ctx, cfg)
 	setHTTP(ctx, cfg)
 	setWS(ctx, cfg)
+	setRPCAuth(ctx, cfg)
 	setNodeUserIdent(ctx, cfg)
 
 	switch {
@@ -1114,10 +1150,28 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *vena.Config) {
 		cfg.EVMInterpreter = ctx.GlobalString(EVMInterpreterFlag.Name)
 	}
 
+	if ctx.GlobalIsSet(WasmCompiledFlag.Name) {
+		cfg.WasmCompiled = ctx.GlobalBool(WasmCompiledFlag.Name)
+	}
+
 	if ctx.GlobalIsSet(ParallelProcessSize.Name) {
 		cfg.ParallelSize = ctx.GlobalInt(ParallelProcessSize.Name)
 	}
 
+	if ctx.GlobalIsSet(PreExecuteFlag.Name) {
+		cfg.PreExecute = ctx.GlobalBool(PreExecuteFlag.Name)
+	}
+
+	if ctx.GlobalIsSet(GroupsFlag.Name) {
+		for _, id := range strings.Split(ctx.GlobalString(GroupsFlag.Name), ",") {
+			groupID, err := strconv.ParseUint(strings.TrimSpace(id), 10, 64)
+			if err != nil {
+				Fatalf("Invalid group ID in --groups: %s", id)
+			}
+			cfg.Groups = append(cfg.Groups, groupID)
+		}
+	}
+
 	// TODO(fjl): move trie cache generations into config
 	if gen := ctx.GlobalInt(TrieCacheGenFlag.Name); gen > 0 {
 		state.MaxTrieCacheGen = uint16(gen)
@@ -1216,6 +1270,35 @@ func MakeChainDatabase(ctx *cli.Context, stack *node.Node) dbhandle.Database {
 	return chainDb
 }
 
+// SetupCryptoSuite selects the crypto suite of the chain initialized in the
+// node's data directory, so the node key, the keystore and the signers use the
+// chain's curve and digest. Nodes without an initialized chain keep the
+// default suite.
+func SetupCryptoSuite(ctx *cli.Context, stack *node.Node) {
+	name := "chaindata"
+	if ctx.GlobalString(SyncModeFlag.Name) == "light" {
+		name = "lightchaindata"
+	}
+	if path := stack.ResolvePath(name); path == "" || !common.FileExist(path) {
+		return
+	}
+	chainDb, err := stack.OpenDatabase(name, 0, 0)
+	if err != nil {
+		log.Warn("Failed to read the chain crypto suite", "err", err)
+		return
+	}
+	defer chainDb.Close()
+
+	stored := rawdb.ReadCanonicalHash(chainDb, 0)
+	config := rawdb.ReadChainConfig(chainDb, stored)
+	if config == nil {
+		return
+	}
+	if err := crypto.SetCryptoSuite(config.CryptoSuite); err != nil {
+		Fatalf("%v", err)
+	}
+}
+
 func MakeGenesis(ctx *cli.Context) *core.Genesis {
 	return nil
 }
@@ -201,6 +201,8 @@ type TxParams struct {
 	GasPrice string          `json:"gasPrice"`
 	Value    string          `json:"value"`
 	Data     string          `json:"data"`
+
+	Sponsor *utils.Keyfile `json:"-"` // the account paying the fees of the transaction
 }
 
 // NewTxParams news a TxParams object
@@ -244,6 +246,10 @@ func (tx *TxParams) SendModeV2(keyfile *utils.Keyfile) ([]interface{}, string, e
 	var action string
 	var params = make([]interface{}, 0)
 
+	if tx.Sponsor != nil && keyfile.Json == nil {
+		return nil, "", errors.New("a sponsored transaction must be signed with a local keyfile")
+	}
+
 	if keyfile.Json != nil {
 		signedTx, err := tx.GetSignedTx(keyfile)
 		if err != nil {
@@ -277,6 +283,10 @@ func (tx *TxParams) GetSignedTx(keyfile *utils.Keyfile) (string, error) {
 	} else {
 		txSign = types.NewTransaction(nonce, *tx.To, value, gas, gasPrice, data)
 	}
+	if tx.Sponsor != nil {
+		sponsor := common.HexToAddress(tx.Sponsor.Address)
+		txSign = txSign.WithSponsor(&sponsor)
+	}
 
 	// extract pk from keystore file and sign the transaction
 	// deprecated: move to the outter method
@@ -288,6 +298,12 @@ func (tx *TxParams) GetSignedTx(keyfile *utils.Keyfile) (string, error) {
 
 	// todo: choose the correct signer
 	txSign, _ = types.SignTx(txSign, types.HomesteadSigner{}, keyfile.GetPrivateKey())
+	if tx.Sponsor != nil {
+		var err error
+		if txSign, err = types.SponsorTx(txSign, types.HomesteadSigner{}, tx.Sponsor.GetPrivateKey()); err != nil {
+			return "", err
+		}
+	}
 	/// txSign, _ = types.SignTx(txSign, types.NewEIP155Signer(big.NewInt(300)), priv)
 	/// utl.Logger.Printf("the signed transaction is %v\n", txSign)
 
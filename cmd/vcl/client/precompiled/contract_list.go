@@ -12,6 +12,10 @@ var (
 	ContractDataProcessorAddress = syscontracts.ContractDataProcessorAddress.String() // The Venachain Precompiled contract addr for group management
 	CnsInvokeAddress             = syscontracts.CnsInvokeAddress.String()             // The Venachain Precompiled contract addr for group management
 	PaillierAddress              = syscontracts.PaillierAddress.String()              // The Venachain Precompiled contract addr for group management
+	SponsorManagementAddress     = syscontracts.SponsorManagementAddress.String()     // The Venachain Precompiled contract addr for sponsor management
+	ProposalManagementAddress    = syscontracts.ProposalManagementAddress.String()    // The Venachain Precompiled contract addr for governance proposals
+	EvidenceManagementAddress    = syscontracts.EvidenceManagementAddress.String()    // The Venachain Precompiled contract addr for evidence management
+	BulletProofAddress           = syscontracts.BulletProofAddress.String()           // The Venachain Precompiled contract addr for bullet proof
 
 )
 
@@ -31,6 +35,10 @@ var List = map[string]string{
 	GroupManagementAddress:       "../../release/linux/conf/contracts/groupManager.cpp.abi.json",
 	ContractDataProcessorAddress: "../../release/linux/conf/contracts/contractData.cpp.abi.json",
 	PaillierAddress:              "../../release/linux/conf/contracts/paillier.cpp.abi.json",
+	SponsorManagementAddress:     "../../release/linux/conf/contracts/sponsorManager.cpp.abi.json",
+	ProposalManagementAddress:    "../../release/linux/conf/contracts/proposalManager.cpp.abi.json",
+	EvidenceManagementAddress:    "../../release/linux/conf/contracts/evidenceManager.cpp.abi.json",
+	BulletProofAddress:           "../../release/linux/conf/contracts/RangeProof.cpp.abi.json",
 
 	CnsInitRegEvent: "../../release/linux/conf/contracts/cnsInitRegEvent.json",
 	CnsInvokeEvent:  "../../release/linux/conf/contracts/cnsInvokeEvent.json",
@@ -11,6 +11,7 @@ import (
 	"github.com/Venachain/Venachain/cmd/vcl/client/utils"
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/core/vm"
 	"github.com/Venachain/Venachain/rpc"
 )
 
@@ -297,6 +298,34 @@ func (client *pClient) getReceiptByPolling(txHash string, ch chan interface{}) {
 	}
 }
 
+// ========================== Contract Data =============================
+
+// ExportContract gets the storage, code, abi and firewall status of a
+// contract in the state of a block, with the proofs against the state root.
+func (p *pClient) ExportContract(addr string, block string) (json.RawMessage, error) {
+	var export json.RawMessage
+	err := p.c.Call(&export, "venachain_exportContract", addr, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// ========================== Evidence =============================
+
+// GetEvidenceProof gets the inclusion proof of a document hash in the evidence
+// batch anchoring it, in the state of a block.
+func (p *pClient) GetEvidenceProof(hash string, block string) (*vm.EvidenceProof, error) {
+	var proof *vm.EvidenceProof
+	err := p.c.Call(&proof, "venachain_getEvidenceProof", hash, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
 // ========================== Sol require/ =============================
 
 func (p *pClient) GetRevertMsg(msg *packet.TxParams, blockNum uint64) ([]byte, error) {
@@ -21,6 +21,8 @@ func IsMatch(param, paramName string) bool {
 	switch paramName {
 	case "name":
 		pattern = `^[\w]*$` //english name: Alice_02
+	case "cnsname":
+		pattern = `^\w+(\.\w+)*$` //cns name in a namespace: finance.payroll
 	case "num":
 		pattern = `^[+-]{0,1}\d+$` //1823..., +1, -123
 	case "email":
@@ -31,6 +33,8 @@ func IsMatch(param, paramName string) bool {
 		pattern = `^([\d]+\.){3}[\d]+$` //0.0.0.1
 	case "address":
 		pattern = `^0[x|X][\da-fA-F]{40}$` //0x00...00
+	case "hash":
+		pattern = `^0[x|X][\da-fA-F]{64}$` //0x00...00, 32 bytes
 	default:
 		pattern = `[\s~!@#\$%^&*\(\)\{\}\[\]\|\,\?]` //special char
 	}
@@ -26,3 +26,20 @@ func TestPrintStrArray(t *testing.T) {
 	strArray := []string{"abs", "is a test"}
 	t.Logf("%s", strArray)
 }
+
+func TestIsMatchHash(t *testing.T) {
+	testCases := []struct {
+		hash     string
+		expected bool
+	}{
+		{"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925", true},
+		{"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b9", false},
+		{"8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925", false},
+		{"0x9ccf0b561c9142d3a771ce2131db8bc9fba61f6f", false},
+	}
+
+	for _, data := range testCases {
+		result := IsMatch(data.hash, "hash")
+		assert.Equal(t, data.expected, result, data.hash)
+	}
+}
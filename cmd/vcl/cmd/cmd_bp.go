@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	cmd_common "github.com/Venachain/Venachain/cmd/vcl/common"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	BpCmd = cli.Command{
+		Name:     "bp",
+		Usage:    "Verify bullet range proofs on the chain",
+		Category: "bp",
+		Subcommands: []cli.Command{
+			BpVerifyCmd,
+			BpVerifyRangeCmd,
+			BpGetResultCmd,
+		},
+	}
+
+	BpVerifyCmd = cli.Command{
+		Name:      "verify",
+		Usage:     "Verify an aggregated bullet proof of the default statement",
+		ArgsUsage: "<proof> <pid>",
+		Action:    bpVerify,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl bp verify <proof> <pid>`,
+	}
+
+	BpVerifyRangeCmd = cli.Command{
+		Name:      "verify-range",
+		Usage:     "Verify the bullet proof of a user that a value is in the range",
+		ArgsUsage: "<userid> <proof> <pid> <range>",
+		Action:    bpVerifyRange,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl bp verify-range <userid> <proof> <pid> <range>`,
+	}
+
+	BpGetResultCmd = cli.Command{
+		Name:      "result",
+		Usage:     "Show the range proof verified under the proof id",
+		ArgsUsage: "<pid>",
+		Action:    bpGetResult,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl bp result <pid>`,
+	}
+)
+
+func bpVerify(c *cli.Context) {
+	proof := c.Args().First()
+	pid := c.Args().Get(1)
+
+	funcParams := cmd_common.CombineFuncParams(proof, pid)
+	result := contractCall(c, funcParams, "verifyProof", precompile.BulletProofAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func bpVerifyRange(c *cli.Context) {
+	userid := c.Args().First()
+	proof := c.Args().Get(1)
+	pid := c.Args().Get(2)
+	scope := c.Args().Get(3)
+
+	funcParams := cmd_common.CombineFuncParams(userid, proof, pid, scope)
+	result := contractCall(c, funcParams, "verifyProofByRange", precompile.BulletProofAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func bpGetResult(c *cli.Context) {
+	pid := c.Args().First()
+
+	funcParams := cmd_common.CombineFuncParams(pid)
+	result := contractCall(c, funcParams, "getResult", precompile.BulletProofAddress)
+	strResult := PrintJson([]byte(result.(string)))
+	fmt.Printf("result:\n%s\n", strResult)
+}
@@ -21,9 +21,78 @@ var (
 			CnsRedirectCmd,
 			CnsQueryCmd,
 			CnsStateCmd,
+			CnsTransferCmd,
+			CnsOwnerCmd,
+			CnsReverseCmd,
+			CnsNamespaceCmd,
 		},
 	}
 
+	CnsTransferCmd = cli.Command{
+		Name:      "transfer",
+		Usage:     "Transfer the ownership of a contract name to another account",
+		ArgsUsage: "<name> <owner>",
+		Action:    cnsTransfer,
+		Flags:     globalCmdFlags,
+		Description: `
+Transfer the ownership of a contract name, the name is transferred by its owner,
+the registrar of one of its namespaces or a chain admin, use:
+		vcl cns transfer <name> <owner>`,
+	}
+
+	CnsOwnerCmd = cli.Command{
+		Name:      "owner",
+		Usage:     "Show the owner of a contract name",
+		ArgsUsage: "<name>",
+		Action:    cnsOwner,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl cns owner <name>`,
+	}
+
+	CnsReverseCmd = cli.Command{
+		Name:      "reverse",
+		Usage:     "Show the latest name and version a contract address is registered with",
+		ArgsUsage: "<address>",
+		Action:    cnsReverse,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl cns reverse <address>`,
+	}
+
+	CnsNamespaceCmd = cli.Command{
+		Name:  "namespace",
+		Usage: "Manage the registrars of the namespaces of the contract names",
+		Subcommands: []cli.Command{
+			CnsNamespaceSetCmd,
+			CnsNamespaceQueryCmd,
+		},
+	}
+
+	CnsNamespaceSetCmd = cli.Command{
+		Name:      "set",
+		Usage:     "Delegate the registration of the names of a namespace to a registrar",
+		ArgsUsage: "<namespace> <registrar>",
+		Action:    cnsNamespaceSet,
+		Flags:     globalCmdFlags,
+		Description: `
+Delegate the registration of the names of a namespace, for example the names
+finance.* of the namespace finance, to a registrar. The registrar is set by a
+chain admin or the registrar of the namespace or of an enclosing namespace,
+the zero address releases the namespace, use:
+		vcl cns namespace set <namespace> <registrar>`,
+	}
+
+	CnsNamespaceQueryCmd = cli.Command{
+		Name:      "query",
+		Usage:     "Show the registrar of a namespace",
+		ArgsUsage: "<namespace>",
+		Action:    cnsNamespaceQuery,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl cns namespace query <namespace>`,
+	}
+
 	CnsRegisterCmd = cli.Command{
 		Name:      "register",
 		Usage:     "Register a contract to the CNS",
@@ -95,7 +164,7 @@ func cnsRegister(c *cli.Context) {
 	ver := c.Args().Get(1)
 	address := c.Args().Get(2)
 
-	paramValid(name, "name")
+	paramValid(name, "cnsname")
 	paramValid(ver, "version")
 	paramValid(address, "address")
 
@@ -109,7 +178,7 @@ func cnsRedirect(c *cli.Context) {
 	name := c.Args().First()
 	ver := c.Args().Get(1)
 
-	paramValid(name, "name")
+	paramValid(name, "cnsname")
 	paramValid(ver, "version")
 
 	funcParams := cmd_common.CombineFuncParams(name, ver)
@@ -122,7 +191,7 @@ func cnsResolve(c *cli.Context) {
 	name := c.Args().First()
 	ver := c.String(CnsVersionFlags.Name)
 
-	paramValid(name, "name")
+	paramValid(name, "cnsname")
 	if !strings.EqualFold(ver, "latest") {
 		paramValid(ver, "version")
 	}
@@ -210,3 +279,55 @@ func cnsState(c *cli.Context) {
 	}
 
 }
+
+func cnsTransfer(c *cli.Context) {
+	name := c.Args().First()
+	owner := c.Args().Get(1)
+
+	paramValid(name, "cnsname")
+	paramValid(owner, "address")
+
+	funcParams := cmd_common.CombineFuncParams(name, owner)
+	result := contractCall(c, funcParams, "transferNameOwnership", precompile.CnsManagementAddress)
+	fmt.Printf("%v\n", result)
+}
+
+func cnsOwner(c *cli.Context) {
+	name := c.Args().First()
+	paramValid(name, "cnsname")
+
+	funcParams := cmd_common.CombineFuncParams(name)
+	result := contractCall(c, funcParams, "getNameOwner", precompile.CnsManagementAddress)
+	fmt.Printf("%s\n", result)
+}
+
+func cnsReverse(c *cli.Context) {
+	address := c.Args().First()
+	paramValid(address, "address")
+
+	funcParams := cmd_common.CombineFuncParams(address)
+	result := contractCall(c, funcParams, "getNameByAddress", precompile.CnsManagementAddress)
+	strResult := PrintJson([]byte(result.(string)))
+	fmt.Printf("result:\n%s\n", strResult)
+}
+
+func cnsNamespaceSet(c *cli.Context) {
+	namespace := c.Args().First()
+	registrar := c.Args().Get(1)
+
+	paramValid(namespace, "cnsname")
+	paramValid(registrar, "address")
+
+	funcParams := cmd_common.CombineFuncParams(namespace, registrar)
+	result := contractCall(c, funcParams, "setNamespaceRegistrar", precompile.CnsManagementAddress)
+	fmt.Printf("%v\n", result)
+}
+
+func cnsNamespaceQuery(c *cli.Context) {
+	namespace := c.Args().First()
+	paramValid(namespace, "cnsname")
+
+	funcParams := cmd_common.CombineFuncParams(namespace)
+	result := contractCall(c, funcParams, "getNamespaceRegistrar", precompile.CnsManagementAddress)
+	fmt.Printf("%s\n", result)
+}
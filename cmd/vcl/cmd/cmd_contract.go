@@ -9,10 +9,15 @@ import (
 	"github.com/Venachain/Venachain/cmd/vcl/client"
 	"github.com/Venachain/Venachain/cmd/vcl/client/packet"
 	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	utl "github.com/Venachain/Venachain/cmd/vcl/client/utils"
 	cmd_common "github.com/Venachain/Venachain/cmd/vcl/common"
 	"gopkg.in/urfave/cli.v1"
 )
 
+const (
+	defaultContractDataFilePath = "./contractData.json"
+)
+
 var (
 	// contract
 	ContractCmd = cli.Command{
@@ -24,6 +29,8 @@ var (
 			ExecuteCmd,
 			MethodCmd,
 			MigrateCmd,
+			ExportCmd,
+			ImportCmd,
 			DeployCmd,
 			ReceiptCmd,
 		},
@@ -67,6 +74,31 @@ Wasm: --abi flag is also needed when deploy wasm contract`,
 		vcl contract migrate <address> <to>`,
 	}
 
+	ExportCmd = cli.Command{
+		Name:      "export",
+		Usage:     "Export the storage, code, abi and firewall status of a contract",
+		ArgsUsage: "<address>",
+		Action:    contractExport,
+		Flags:     contractExportCmdFlags,
+		Description: `
+		vcl contract export <address>
+
+The storage entries and the account of the contract are exported with their
+merkle proofs against the state root of the block specified by --block`,
+	}
+
+	ImportCmd = cli.Command{
+		Name:      "import",
+		Usage:     "Import the exported data of a contract to a contract created by the caller",
+		ArgsUsage: "<address>",
+		Action:    contractImport,
+		Flags:     contractImportCmdFlags,
+		Description: `
+		vcl contract import <address>
+
+The proofs of the exported data are verified by the chain before importing`,
+	}
+
 	MethodCmd = cli.Command{
 		Name:   "methods",
 		Usage:  "List all the exported methods of a contract by its abi file or contract address",
@@ -202,6 +234,45 @@ func migrate(c *cli.Context) {
 	}
 }
 
+func contractExport(c *cli.Context) {
+	addr := c.Args().First()
+	filePath := c.String(ContractDataFileFlags.Name)
+	block := c.String(ContractBlockFlags.Name)
+
+	paramValid(addr, "address")
+
+	pc, err := client.SetupClient(getUrl(c))
+	if err != nil {
+		utils.Fatalf("set up client failed: %s\n", err.Error())
+	}
+	export, err := pc.ExportContract(addr, block)
+	if err != nil {
+		utils.Fatalf("export contract failed: %s\n", err.Error())
+	}
+
+	if err := utl.WriteFile(export, filePath); err != nil {
+		utils.Fatalf(err.Error())
+	}
+	fmt.Printf("export to %s success\n", filePath)
+}
+
+func contractImport(c *cli.Context) {
+	funcName := "import"
+	addr := c.Args().First()
+	filePath := c.String(ContractDataFileFlags.Name)
+
+	paramValid(addr, "address")
+
+	fileBytes, err := utl.ParseFileToBytes(filePath)
+	if err != nil {
+		utils.Fatalf(utl.ErrParseFileFormat, "contract data", err.Error())
+	}
+
+	funcParams := cmd_common.CombineFuncParams(addr, string(fileBytes))
+	result := contractCall(c, funcParams, funcName, precompile.ContractDataProcessorAddress)
+	fmt.Printf("%s\n", result)
+}
+
 func contractMethods(c *cli.Context) {
 	var abiPath string
 
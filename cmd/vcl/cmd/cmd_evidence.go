@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Venachain/Venachain/cmd/utils"
+	"github.com/Venachain/Venachain/cmd/vcl/client"
+	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	utl "github.com/Venachain/Venachain/cmd/vcl/client/utils"
+	cmd_common "github.com/Venachain/Venachain/cmd/vcl/common"
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/vm"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	EvidenceCmd = cli.Command{
+		Name:     "evidence",
+		Usage:    "Manage the evidences anchored on the chain",
+		Category: "evidence",
+		Subcommands: []cli.Command{
+			EvidenceSaveCmd,
+			EvidenceGetCmd,
+			EvidenceSaveSignedCmd,
+			EvidenceGetSignedCmd,
+			EvidenceAnchorCmd,
+			EvidenceGetBatchCmd,
+			EvidenceVerifyCmd,
+		},
+	}
+
+	EvidenceSaveCmd = cli.Command{
+		Name:      "save",
+		Usage:     "Save the evidence value under a key",
+		ArgsUsage: "<key> <value>",
+		Action:    evidenceSave,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl evidence save <key> <value>`,
+	}
+
+	EvidenceGetCmd = cli.Command{
+		Name:      "get",
+		Usage:     "Show the evidence saved under a key",
+		ArgsUsage: "<key>",
+		Action:    evidenceGet,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl evidence get <key>`,
+	}
+
+	EvidenceSaveSignedCmd = cli.Command{
+		Name:      "save-signed",
+		Usage:     "Save the document hash signed by a signer under a key",
+		ArgsUsage: "<key> <hash> <signature>",
+		Action:    evidenceSaveSigned,
+		Flags:     globalCmdFlags,
+		Description: `
+The signature is the hex encoded 65 bytes signature of keccak256(key || hash) by
+the signer, who may be another account than the sender, use:
+		vcl evidence save-signed <key> <hash> <signature>`,
+	}
+
+	EvidenceGetSignedCmd = cli.Command{
+		Name:      "get-signed",
+		Usage:     "Show the signed evidence saved under a key",
+		ArgsUsage: "<key>",
+		Action:    evidenceGetSigned,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl evidence get-signed <key>`,
+	}
+
+	EvidenceAnchorCmd = cli.Command{
+		Name:      "anchor",
+		Usage:     "Anchor a batch of document hashes with their Merkle root",
+		ArgsUsage: "[<hash>...]",
+		Action:    evidenceAnchor,
+		Flags:     evidenceAnchorCmdFlags,
+		Description: `
+Anchor the document hashes given as arguments or listed one per line in the
+--file file, the --sig flag is the signature of the Merkle root by the signer of
+the batch, the sender signs the batch if not set, use:
+		vcl evidence anchor --file <hashes file>`,
+	}
+
+	EvidenceGetBatchCmd = cli.Command{
+		Name:      "get-batch",
+		Usage:     "Show the evidence batch anchored with a Merkle root",
+		ArgsUsage: "<root>",
+		Action:    evidenceGetBatch,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl evidence get-batch <root>`,
+	}
+
+	EvidenceVerifyCmd = cli.Command{
+		Name:      "verify",
+		Usage:     "Verify the inclusion of a document hash in an anchored evidence batch",
+		ArgsUsage: "<hash>",
+		Action:    evidenceVerify,
+		Flags:     evidenceVerifyCmdFlags,
+		Description: `
+Get the Merkle proof of the document hash from the node and check it against the
+root and the signature of the evidence batch anchoring the hash. The node isn't
+trusted with the root: it must match the --root flag, or the batch read from
+another node with the --anchor-url flag, use:
+		vcl evidence verify <hash> --root <root>
+		vcl evidence verify <hash> --anchor-url <ip>:<port> --block latest`,
+	}
+)
+
+func evidenceSave(c *cli.Context) {
+	key := c.Args().First()
+	value := c.Args().Get(1)
+
+	funcParams := cmd_common.CombineFuncParams(key, value)
+	result := contractCall(c, funcParams, "saveEvidence", precompile.EvidenceManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func evidenceGet(c *cli.Context) {
+	key := c.Args().First()
+
+	funcParams := cmd_common.CombineFuncParams(key)
+	result := contractCall(c, funcParams, "getEvidence", precompile.EvidenceManagementAddress)
+	strResult := PrintJson([]byte(result.(string)))
+	fmt.Printf("result:\n%s\n", strResult)
+}
+
+func evidenceSaveSigned(c *cli.Context) {
+	key := c.Args().First()
+	hash := c.Args().Get(1)
+	sig := c.Args().Get(2)
+
+	paramValid(hash, "hash")
+
+	funcParams := cmd_common.CombineFuncParams(key, hash, sig)
+	result := contractCall(c, funcParams, "saveSignedEvidence", precompile.EvidenceManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func evidenceGetSigned(c *cli.Context) {
+	key := c.Args().First()
+
+	funcParams := cmd_common.CombineFuncParams(key)
+	result := contractCall(c, funcParams, "getSignedEvidence", precompile.EvidenceManagementAddress)
+	strResult := PrintJson([]byte(result.(string)))
+	fmt.Printf("result:\n%s\n", strResult)
+}
+
+func evidenceAnchor(c *cli.Context) {
+	hashes := append([]string{}, c.Args()...)
+	if filePath := c.String(EvidenceHashFileFlags.Name); filePath != "" {
+		fileBytes, err := utl.ParseFileToBytes(filePath)
+		if err != nil {
+			utils.Fatalf(utl.ErrParseFileFormat, "document hashes", err.Error())
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(fileBytes))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				hashes = append(hashes, line)
+			}
+		}
+	}
+	if len(hashes) == 0 {
+		utils.Fatalf("no document hash to anchor\n")
+	}
+
+	// the contract takes the concatenation of the hashes
+	var leaves strings.Builder
+	leaves.WriteString("0x")
+	for _, hash := range hashes {
+		paramValid(hash, "hash")
+		leaves.WriteString(hash[2:])
+	}
+
+	funcParams := cmd_common.CombineFuncParams(leaves.String(), c.String(EvidenceSigFlags.Name))
+	result := contractCall(c, funcParams, "anchorEvidenceBatch", precompile.EvidenceManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func evidenceGetBatch(c *cli.Context) {
+	root := c.Args().First()
+	paramValid(root, "hash")
+
+	funcParams := cmd_common.CombineFuncParams(root)
+	result := contractCall(c, funcParams, "getEvidenceBatch", precompile.EvidenceManagementAddress)
+	strResult := PrintJson([]byte(result.(string)))
+	fmt.Printf("result:\n%s\n", strResult)
+}
+
+func evidenceVerify(c *cli.Context) {
+	hash := c.Args().First()
+	block := c.String(EvidenceBlockFlags.Name)
+	root := c.String(EvidenceRootFlags.Name)
+	anchorUrl := c.String(EvidenceAnchorUrlFlags.Name)
+
+	paramValid(hash, "hash")
+	if root == "" && anchorUrl == "" {
+		utils.Fatalf("the expected root is not given, set the --root or --anchor-url flag\n")
+	}
+
+	pc, err := client.SetupClient(getUrl(c))
+	if err != nil {
+		utils.Fatalf("set up client failed: %s\n", err.Error())
+	}
+	proof, err := pc.GetEvidenceProof(hash, block)
+	if err != nil {
+		utils.Fatalf("get evidence proof failed: %s\n", err.Error())
+	}
+
+	if root != "" {
+		paramValid(root, "hash")
+		if err := proof.VerifyBatch(&vm.EvidenceBatch{Root: common.HexToHash(root)}); err != nil {
+			utils.Fatalf("verify evidence failed: %s\n", err.Error())
+		}
+	}
+	if anchorUrl != "" {
+		ac, err := client.SetupClient(parseUrl(anchorUrl))
+		if err != nil {
+			utils.Fatalf("set up anchor client failed: %s\n", err.Error())
+		}
+		anchor, err := ac.GetEvidenceProof(hash, block)
+		if err != nil {
+			utils.Fatalf("get evidence anchor failed: %s\n", err.Error())
+		}
+		if err := proof.VerifyBatch(anchor.Batch); err != nil {
+			utils.Fatalf("verify evidence failed: %s\n", err.Error())
+		}
+	}
+
+	data, _ := json.Marshal(proof)
+	strResult := PrintJson(data)
+	fmt.Printf("result:\n%s\n", strResult)
+	fmt.Printf("verified: anchored in block %d at %s by %s\n",
+		proof.Batch.BlockNumber,
+		time.Unix(0, int64(proof.Timestamp)*int64(time.Millisecond)).UTC().Format(time.RFC3339),
+		proof.Batch.Signer.Hex())
+}
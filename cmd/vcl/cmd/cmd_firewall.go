@@ -35,9 +35,57 @@ var (
 			FwDeleteCmd,
 			FwResetCmd,
 			FwClearCmd,
+			FwRuleCmd,
 		},
 	}
 
+	FwRuleCmd = cli.Command{
+		Name:  "rule",
+		Usage: "Manage the conditional fire wall rules of a contract",
+		Subcommands: []cli.Command{
+			FwRuleAddCmd,
+			FwRuleDeleteCmd,
+			FwRuleClearCmd,
+		},
+	}
+
+	FwRuleAddCmd = cli.Command{
+		Name:      "add",
+		Usage:     "Add or replace a conditional fire wall rule",
+		ArgsUsage: "<address> <id> <action> <account> <api>",
+		Action:    fwRuleAdd,
+		Flags:     fwRuleCmdFlags,
+		Description: `
+		vcl fw rule add <address> <id> <action> <account> <api>
+
+Example: ./vcl fw rule add 0xcce493dcb135a19928627a7d5a0df0b1477fbce7 \
+admins accept * transfer --role CONTRACT_ADMIN --priority 1 --rateLimit 10 --rateWindow 100
+
+The conditional rules are checked by priority before the accept and reject lists,
+the first rule matching the caller, the api and all the conditions decides the call.
+A rule with the same id is replaced.`,
+	}
+
+	FwRuleDeleteCmd = cli.Command{
+		Name:      "delete",
+		Usage:     "Delete a conditional fire wall rule",
+		ArgsUsage: "<address> <id>",
+		Action:    fwRuleDelete,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl fw rule delete <address> <id>`,
+	}
+
+	FwRuleClearCmd = cli.Command{
+		Name:      "clear",
+		Usage:     "Clear all the conditional fire wall rules of a contract",
+		ArgsUsage: "<address>",
+		Action:    fwRuleClear,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl fw rule clear <address>`,
+	}
+
 	FwStartCmd = cli.Command{
 		Name:      "start",
 		Usage:     "Start the fire wall of an specific contract",
@@ -168,6 +216,7 @@ type ExportFwStatus struct {
 	// Active       bool
 	AcceptedList []state.FwElem
 	RejectedList []state.FwElem
+	Rules        []state.FwRule `json:",omitempty"`
 }
 
 func fwExport(c *cli.Context) {
@@ -275,3 +324,71 @@ func fwReset(c *cli.Context) {
 	fwCommon(c, funcName)
 	return
 }
+
+func fwRuleAdd(c *cli.Context) {
+	funcName := "__sys_FwAddRule"
+
+	addr := c.Args().First()
+	id := c.Args().Get(1)
+	action := c.Args().Get(2)
+	targetAddr := c.Args().Get(3)
+	api := c.Args().Get(4)
+
+	paramValid(id, "name")
+	paramValid(action, "action")
+	paramValid(targetAddr, "fw")
+	if api != "*" {
+		paramValid(api, "name")
+	}
+
+	rule := state.FwRule{
+		ID:         id,
+		Priority:   uint32(c.Uint64(FwRulePriorityFlags.Name)),
+		Action:     action,
+		Addr:       state.FwWildchardAddr,
+		FuncName:   api,
+		Role:       c.String(FwRuleRoleFlags.Name),
+		FromBlock:  c.Uint64(FwRuleFromBlockFlags.Name),
+		ToBlock:    c.Uint64(FwRuleToBlockFlags.Name),
+		FromTime:   c.Uint64(FwRuleFromTimeFlags.Name),
+		ToTime:     c.Uint64(FwRuleToTimeFlags.Name),
+		RateLimit:  c.Uint64(FwRuleRateLimitFlags.Name),
+		RateWindow: c.Uint64(FwRuleRateWindowFlags.Name),
+	}
+	if targetAddr != "*" {
+		rule.Addr = common.HexToAddress(targetAddr)
+	}
+	if c.IsSet(FwRuleGroupFlags.Name) {
+		groupID := c.Uint64(FwRuleGroupFlags.Name)
+		rule.GroupID = &groupID
+	}
+
+	ruleBytes, err := json.Marshal(rule)
+	if err != nil {
+		utils.Fatalf(err.Error())
+	}
+
+	funcParams := []string{addr, string(ruleBytes)}
+	result := contractCall(c, funcParams, funcName, precompile.FirewallManagementAddress)
+	fmt.Printf("result: %s\n", result)
+}
+
+func fwRuleDelete(c *cli.Context) {
+	funcName := "__sys_FwDelRule"
+	addr := c.Args().First()
+	id := c.Args().Get(1)
+	paramValid(id, "name")
+
+	funcParams := []string{addr, id}
+	result := contractCall(c, funcParams, funcName, precompile.FirewallManagementAddress)
+	fmt.Printf("result: %s\n", result)
+}
+
+func fwRuleClear(c *cli.Context) {
+	funcName := "__sys_FwClearRules"
+	addr := c.Args().First()
+
+	funcParams := []string{addr}
+	result := contractCall(c, funcParams, funcName, precompile.FirewallManagementAddress)
+	fmt.Printf("result: %s\n", result)
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	cmd_common "github.com/Venachain/Venachain/cmd/vcl/common"
+	"github.com/Venachain/Venachain/core/vm"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	GroupCmd = cli.Command{
+		Name:     "group",
+		Usage:    "Manage the groups of the Venachain network",
+		Category: "group",
+		Subcommands: []cli.Command{
+			GroupCreateCmd,
+			GroupQueryCmd,
+			GroupAddBootNodeCmd,
+			GroupDelBootNodeCmd,
+			GroupUpdateBootNodesCmd,
+			GroupAddMemberCmd,
+			GroupDelMemberCmd,
+		},
+	}
+
+	GroupCreateCmd = cli.Command{
+		Name:      "create",
+		Usage:     "Create a group created by the sender account",
+		ArgsUsage: "<groupID> <creatorEnode> [<bootNode>...]",
+		Action:    groupCreate,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl group create <groupID> <creatorEnode> [<bootNode>...]
+
+The sender account must be a CHAIN_ADMIN or a GROUP_ADMIN.`,
+	}
+
+	GroupQueryCmd = cli.Command{
+		Name:      "query",
+		Usage:     "Show a group, or all the groups if no group id is given",
+		ArgsUsage: "[<groupID>]",
+		Action:    groupQuery,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl group query [<groupID>]`,
+	}
+
+	GroupAddBootNodeCmd = cli.Command{
+		Name:      "add-bootnode",
+		Usage:     "Add a boot node to a group created by the sender account",
+		ArgsUsage: "<groupID> <enode>",
+		Action:    groupAddBootNode,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl group add-bootnode <groupID> <enode>`,
+	}
+
+	GroupDelBootNodeCmd = cli.Command{
+		Name:      "del-bootnode",
+		Usage:     "Delete a boot node from a group created by the sender account",
+		ArgsUsage: "<groupID> <enode>",
+		Action:    groupDelBootNode,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl group del-bootnode <groupID> <enode>`,
+	}
+
+	GroupUpdateBootNodesCmd = cli.Command{
+		Name:      "update-bootnodes",
+		Usage:     "Replace the boot nodes of a group created by the sender account",
+		ArgsUsage: "<groupID> [<enode>...]",
+		Action:    groupUpdateBootNodes,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl group update-bootnodes <groupID> [<enode>...]`,
+	}
+
+	GroupAddMemberCmd = cli.Command{
+		Name:      "add-member",
+		Usage:     "Add a member account to a group created by the sender account",
+		ArgsUsage: "<groupID> <address>",
+		Action:    groupAddMember,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl group add-member <groupID> <address>`,
+	}
+
+	GroupDelMemberCmd = cli.Command{
+		Name:      "del-member",
+		Usage:     "Delete a member account from a group created by the sender account",
+		ArgsUsage: "<groupID> <address>",
+		Action:    groupDelMember,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl group del-member <groupID> <address>`,
+	}
+)
+
+func groupCreate(c *cli.Context) {
+	groupID := c.Args().First()
+	paramValid(groupID, "num")
+
+	var group vm.GroupInfo
+	group.GroupID, _ = strconv.ParseUint(groupID, 10, 64)
+	group.CreatorEnode = c.Args().Get(1)
+	if len(c.Args()) > 2 {
+		group.BootNodes = c.Args()[2:]
+	}
+	bytes, _ := json.Marshal(group)
+
+	funcParams := cmd_common.CombineFuncParams(string(bytes))
+	result := contractCall(c, funcParams, "createGroup", precompile.GroupManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func groupQuery(c *cli.Context) {
+	groupID := c.Args().First()
+	if groupID == "" {
+		result := contractCall(c, nil, "getAllGroups", precompile.GroupManagementAddress)
+		strResult := PrintJson([]byte(result.(string)))
+		fmt.Printf("result:\n%s\n", strResult)
+		return
+	}
+	paramValid(groupID, "num")
+
+	funcParams := cmd_common.CombineFuncParams(groupID)
+	result := contractCall(c, funcParams, "getGroupByID", precompile.GroupManagementAddress)
+	strResult := PrintJson([]byte(result.(string)))
+	fmt.Printf("result:\n%s\n", strResult)
+}
+
+func groupAddBootNode(c *cli.Context) {
+	groupBootNode(c, "addBootNode")
+}
+
+func groupDelBootNode(c *cli.Context) {
+	groupBootNode(c, "delBootNode")
+}
+
+func groupBootNode(c *cli.Context, funcName string) {
+	groupID := c.Args().First()
+	enode := c.Args().Get(1)
+	paramValid(groupID, "num")
+
+	funcParams := cmd_common.CombineFuncParams(groupID, enode)
+	result := contractCall(c, funcParams, funcName, precompile.GroupManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func groupUpdateBootNodes(c *cli.Context) {
+	groupID := c.Args().First()
+	paramValid(groupID, "num")
+
+	bytes, _ := json.Marshal(append([]string{}, c.Args().Tail()...))
+
+	funcParams := cmd_common.CombineFuncParams(groupID, string(bytes))
+	result := contractCall(c, funcParams, "updateBootNodes", precompile.GroupManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func groupAddMember(c *cli.Context) {
+	groupMember(c, "addGroupMember")
+}
+
+func groupDelMember(c *cli.Context) {
+	groupMember(c, "delGroupMember")
+}
+
+func groupMember(c *cli.Context, funcName string) {
+	groupID := c.Args().First()
+	member := c.Args().Get(1)
+	paramValid(groupID, "num")
+	paramValid(member, "address")
+
+	funcParams := cmd_common.CombineFuncParams(groupID, member)
+	result := contractCall(c, funcParams, funcName, precompile.GroupManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
@@ -125,7 +125,7 @@ func nodeDelete(c *cli.Context) {
 func nodeUpdate(c *cli.Context) {
 
 	// 可选(必填or必填)
-	var strJson = "{\"type\":\"\",\"delayNum\":\"\",\"desc\":\"\"}"
+	var strJson = "{\"type\":\"\",\"delayNum\":\"\",\"desc\":\"\",\"weight\":\"\"}"
 
 	str := combineJson(c, nil, []byte(strJson))
 
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	cmd_common "github.com/Venachain/Venachain/cmd/vcl/common"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	ProposalCmd = cli.Command{
+		Name:     "proposal",
+		Usage:    "Manage the multi-signature proposals of the system contract operations",
+		Category: "proposal",
+		Subcommands: []cli.Command{
+			ProposalSetRuleCmd,
+			ProposalRemoveRuleCmd,
+			ProposalRulesCmd,
+			ProposalCreateCmd,
+			ProposalApproveCmd,
+			ProposalRejectCmd,
+			ProposalCancelCmd,
+			ProposalQueryCmd,
+		},
+	}
+
+	ProposalSetRuleCmd = cli.Command{
+		Name:      "set-rule",
+		Usage:     "Require the approvals of some holders of a role before a system contract method runs",
+		ArgsUsage: "<contract> <method> <role> <threshold>",
+		Action:    proposalSetRule,
+		Flags:     globalCmdFlags,
+		Description: `
+Require <threshold> approvals of the holders of <role> before the <method> of the
+system contract <contract> runs, e.g. 3 of the CHAIN_ADMINs to add a node:
+		vcl proposal set-rule node add CHAIN_ADMIN 3
+
+The contract is a system contract address or one of: user, node, cns, param,
+firewall, group, sponsor, proposal. A chain admin tightens the rules, relaxing
+or removing a rule requires a proposal approved under the rule itself.`,
+	}
+
+	ProposalRemoveRuleCmd = cli.Command{
+		Name:      "remove-rule",
+		Usage:     "Remove the rule of a system contract method",
+		ArgsUsage: "<contract> <method>",
+		Action:    proposalRemoveRule,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl proposal remove-rule <contract> <method>
+
+The removal of a rule runs from a proposal approved under the rule, see:
+		vcl proposal create proposal removeProposalRule <contract address> <method>`,
+	}
+
+	ProposalRulesCmd = cli.Command{
+		Name:   "rules",
+		Usage:  "List the rules of the system contract methods",
+		Action: proposalRules,
+		Flags:  globalCmdFlags,
+	}
+
+	ProposalCreateCmd = cli.Command{
+		Name:      "create",
+		Usage:     "Propose to call a system contract method",
+		ArgsUsage: "<contract> <method> [<param>...]",
+		Action:    proposalCreate,
+		Flags:     proposalCreateCmdFlags,
+		Description: `
+Propose to call the <method> of the system contract <contract> with the params, the
+proposal counts as the approval of the sender, e.g.
+		vcl proposal create node add '{"name":"node1",...}' --description "add node1"
+
+The method runs once the approvals reach the threshold of its rule.`,
+	}
+
+	ProposalApproveCmd = cli.Command{
+		Name:      "approve",
+		Usage:     "Approve a proposal, the approval reaching its threshold runs the proposed method",
+		ArgsUsage: "<id>",
+		Action:    proposalApprove,
+		Flags:     globalCmdFlags,
+	}
+
+	ProposalRejectCmd = cli.Command{
+		Name:      "reject",
+		Usage:     "Reject a proposal",
+		ArgsUsage: "<id>",
+		Action:    proposalReject,
+		Flags:     globalCmdFlags,
+	}
+
+	ProposalCancelCmd = cli.Command{
+		Name:      "cancel",
+		Usage:     "Cancel a pending proposal of the sender",
+		ArgsUsage: "<id>",
+		Action:    proposalCancel,
+		Flags:     globalCmdFlags,
+	}
+
+	ProposalQueryCmd = cli.Command{
+		Name:      "query",
+		Usage:     "Show a proposal with its votes or list the proposals",
+		ArgsUsage: "[<id>]",
+		Action:    proposalQuery,
+		Flags:     proposalQueryCmdFlags,
+		Description: `
+		vcl proposal query [<id>] [--status pending]
+
+The status of a proposal is pending, executed, failed, rejected, cancelled or expired.`,
+	}
+)
+
+// sysContractAliases names the system contracts in the proposal commands
+var sysContractAliases = map[string]string{
+	"user":     precompile.UserManagementAddress,
+	"node":     precompile.NodeManagementAddress,
+	"cns":      precompile.CnsManagementAddress,
+	"param":    precompile.ParameterManagementAddress,
+	"firewall": precompile.FirewallManagementAddress,
+	"group":    precompile.GroupManagementAddress,
+	"sponsor":  precompile.SponsorManagementAddress,
+	"proposal": precompile.ProposalManagementAddress,
+}
+
+func sysContractAddress(contract string) string {
+	if addr, ok := sysContractAliases[strings.ToLower(contract)]; ok {
+		return addr
+	}
+	paramValid(contract, "address")
+	return contract
+}
+
+func proposalSetRule(c *cli.Context) {
+	contract := sysContractAddress(c.Args().First())
+	method := c.Args().Get(1)
+	role := c.Args().Get(2)
+	threshold := c.Args().Get(3)
+
+	paramValid(role, "role")
+	paramValid(threshold, "num")
+
+	funcParams := cmd_common.CombineFuncParams(contract, method, role, threshold)
+	result := contractCall(c, funcParams, "setProposalRule", precompile.ProposalManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func proposalRemoveRule(c *cli.Context) {
+	contract := sysContractAddress(c.Args().First())
+	method := c.Args().Get(1)
+
+	funcParams := cmd_common.CombineFuncParams(contract, method)
+	result := contractCall(c, funcParams, "removeProposalRule", precompile.ProposalManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func proposalRules(c *cli.Context) {
+	result := contractCall(c, nil, "getProposalRules", precompile.ProposalManagementAddress)
+	strResult := PrintJson([]byte(result.(string)))
+	fmt.Printf("result:\n%s\n", strResult)
+}
+
+func proposalCreate(c *cli.Context) {
+	contract := sysContractAddress(c.Args().First())
+	method := c.Args().Get(1)
+	var params []string
+	if len(c.Args()) > 2 {
+		params = c.Args()[2:]
+	}
+
+	input := contractCallData(c, params, method, contract)
+	description := c.String(ProposalDescFlags.Name)
+	duration := strconv.FormatUint(c.Uint64(ProposalDurationFlags.Name), 10)
+
+	funcParams := cmd_common.CombineFuncParams(contract, input, description, duration)
+	result := contractCall(c, funcParams, "propose", precompile.ProposalManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func proposalApprove(c *cli.Context) {
+	proposalVote(c, "approveProposal")
+}
+
+func proposalReject(c *cli.Context) {
+	proposalVote(c, "rejectProposal")
+}
+
+func proposalCancel(c *cli.Context) {
+	proposalVote(c, "cancelProposal")
+}
+
+func proposalVote(c *cli.Context, funcName string) {
+	id := c.Args().First()
+	paramValid(id, "num")
+
+	funcParams := cmd_common.CombineFuncParams(id)
+	result := contractCall(c, funcParams, funcName, precompile.ProposalManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func proposalQuery(c *cli.Context) {
+	var result interface{}
+
+	if id := c.Args().First(); id != "" {
+		paramValid(id, "num")
+		funcParams := cmd_common.CombineFuncParams(id)
+		result = contractCall(c, funcParams, "getProposal", precompile.ProposalManagementAddress)
+	} else {
+		funcParams := cmd_common.CombineFuncParams(c.String(ProposalStatusFlags.Name))
+		result = contractCall(c, funcParams, "getProposals", precompile.ProposalManagementAddress)
+	}
+	strResult := PrintJson([]byte(result.(string)))
+	fmt.Printf("result:\n%s\n", strResult)
+}
@@ -30,6 +30,15 @@ var (
 			RoleGetAddrListOfRole,
 			RoleHasRole,
 			RoleGetRoles,
+			RoleGrantRole,
+			RoleRevokeRole,
+			RoleGetRoleGrants,
+			RoleGetRoleHistory,
+			RoleCreateCustomRole,
+			RoleRemoveCustomRole,
+			RoleGetCustomRoles,
+			RoleAddCustomRole,
+			RoleDelCustomRole,
 		},
 	}
 	RoleSetSuperAdmin = cli.Command{
@@ -162,7 +171,7 @@ The caller should be a chainAdmin or contractAdmin, call this function to del th
 		Flags:     roleCmdFlags,
 		Description: `
 		vcl role getAddrListOfRole <role>
-role can be "SUPER_ADMIN", "CHAIN_ADMIN", "GROUP_ADMIN", "NODE_ADMIN", "CONTRACT_ADMIN" or "CONTRACT_DEPLOYER"`,
+role can be "SUPER_ADMIN", "CHAIN_ADMIN", "GROUP_ADMIN", "NODE_ADMIN", "CONTRACT_ADMIN", "CONTRACT_DEPLOYER" or a custom role`,
 	}
 	RoleHasRole = cli.Command{
 		Name:      "hasRole",
@@ -172,7 +181,7 @@ role can be "SUPER_ADMIN", "CHAIN_ADMIN", "GROUP_ADMIN", "NODE_ADMIN", "CONTRACT
 		Flags:     roleCmdFlags,
 		Description: `
 		vcl role hasRole <address> <role>
-role can be "SUPER_ADMIN", "CHAIN_ADMIN", "GROUP_ADMIN", "NODE_ADMIN", "CONTRACT_ADMIN" or "CONTRACT_DEPLOYER"`,
+role can be "SUPER_ADMIN", "CHAIN_ADMIN", "GROUP_ADMIN", "NODE_ADMIN", "CONTRACT_ADMIN", "CONTRACT_DEPLOYER" or a custom role`,
 	}
 	RoleGetRoles = cli.Command{
 		Name:      "getRoles",
@@ -184,6 +193,90 @@ role can be "SUPER_ADMIN", "CHAIN_ADMIN", "GROUP_ADMIN", "NODE_ADMIN", "CONTRACT
 		vcl role delContractDeployer <address>
 The caller should be a chainAdmin or contractAdmin, call this function to del the account from ContractDeployer`,
 	}
+	RoleGrantRole = cli.Command{
+		Name:      "grantRole",
+		Usage:     "grant the role to the account for a number of blocks",
+		ArgsUsage: "<address> <role> <blocks>",
+		Action:    grantRole,
+		Flags:     roleGrantCmdFlags,
+		Description: `
+		vcl role grantRole <address> <role> <blocks> --reason <reason>
+The caller should have the permission to add the role, the role expires after <blocks> blocks,
+e.g. a CONTRACT_DEPLOYER for a contractor:
+		vcl role grantRole <address> CONTRACT_DEPLOYER 7200 --reason "deploy the audit contracts"`,
+	}
+	RoleRevokeRole = cli.Command{
+		Name:      "revokeRole",
+		Usage:     "revoke the role of the account with a reason",
+		ArgsUsage: "<address> <role>",
+		Action:    revokeRole,
+		Flags:     roleGrantCmdFlags,
+		Description: `
+		vcl role revokeRole <address> <role> --reason <reason>
+The caller should have the permission to delete the role`,
+	}
+	RoleGetRoleGrants = cli.Command{
+		Name:      "getRoleGrants",
+		Usage:     "get the unexpired time-boxed role grants of the account",
+		ArgsUsage: "<address>",
+		Action:    getRoleGrants,
+		Flags:     roleCmdFlags,
+	}
+	RoleGetRoleHistory = cli.Command{
+		Name:      "getRoleHistory",
+		Usage:     "get the grants, revocations and expiries of the roles of the account",
+		ArgsUsage: "<address>",
+		Action:    getRoleHistory,
+		Flags:     roleCmdFlags,
+	}
+	RoleCreateCustomRole = cli.Command{
+		Name:      "createCustomRole",
+		Usage:     "define a custom role",
+		ArgsUsage: "<role> <description>",
+		Action:    createCustomRole,
+		Flags:     roleCmdFlags,
+		Description: `
+		vcl role createCustomRole <role> <description>
+The caller should be a chainAdmin. The role name is made of 2~32 upper case letters, digits
+or underscores beginning with a letter, e.g. AUDITOR. The contracts check the role with the
+hasRole of the user management contract or the hasRole host function of the wasm contracts`,
+	}
+	RoleRemoveCustomRole = cli.Command{
+		Name:      "removeCustomRole",
+		Usage:     "remove a custom role no longer held by any account",
+		ArgsUsage: "<role>",
+		Action:    removeCustomRole,
+		Flags:     roleCmdFlags,
+		Description: `
+		vcl role removeCustomRole <role>
+The caller should be a chainAdmin`,
+	}
+	RoleGetCustomRoles = cli.Command{
+		Name:   "getCustomRoles",
+		Usage:  "get the custom roles",
+		Action: getCustomRoles,
+		Flags:  roleCmdFlags,
+	}
+	RoleAddCustomRole = cli.Command{
+		Name:      "addCustomRole",
+		Usage:     "add the account to a custom role",
+		ArgsUsage: "<address> <role>",
+		Action:    addCustomRole,
+		Flags:     roleCmdFlags,
+		Description: `
+		vcl role addCustomRole <address> <role>
+The caller should be a chainAdmin`,
+	}
+	RoleDelCustomRole = cli.Command{
+		Name:      "delCustomRole",
+		Usage:     "del the account from a custom role",
+		ArgsUsage: "<address> <role>",
+		Action:    delCustomRole,
+		Flags:     roleCmdFlags,
+		Description: `
+		vcl role delCustomRole <address> <role>
+The caller should be a chainAdmin`,
+	}
 )
 
 func setSuperAdmin(c *cli.Context) {
@@ -328,6 +421,97 @@ func hasRole(c *cli.Context) {
 	callUserManager(c, "hasRole", funcParams)
 }
 
+func grantRole(c *cli.Context) {
+	var addr = c.Args().First()
+	var role = c.Args().Get(1)
+	var blocks = c.Args().Get(2)
+
+	if !common.IsHexAddress(addr) {
+		panic("the first argument should be hex address")
+	}
+	paramValid(role, "role")
+	paramValid(blocks, "num")
+	funcParams := []string{addr, role, blocks, c.String(RoleReasonFlags.Name)}
+
+	callUserManager(c, "grantRoleByAddress", funcParams)
+}
+
+func revokeRole(c *cli.Context) {
+	var addr = c.Args().First()
+	var role = c.Args().Get(1)
+
+	if !common.IsHexAddress(addr) {
+		panic("the first argument should be hex address")
+	}
+	paramValid(role, "role")
+	funcParams := []string{addr, role, c.String(RoleReasonFlags.Name)}
+
+	callUserManager(c, "revokeRoleByAddress", funcParams)
+}
+
+func getRoleGrants(c *cli.Context) {
+	var addr = c.Args().First()
+	if !common.IsHexAddress(addr) {
+		panic("the first argument should be hex address")
+	}
+	funcParams := []string{addr}
+
+	callUserManager(c, "getRoleGrantsByAddress", funcParams)
+}
+
+func getRoleHistory(c *cli.Context) {
+	var addr = c.Args().First()
+	if !common.IsHexAddress(addr) {
+		panic("the first argument should be hex address")
+	}
+	funcParams := []string{addr}
+
+	callUserManager(c, "getRoleHistoryByAddress", funcParams)
+}
+
+func createCustomRole(c *cli.Context) {
+	var role = c.Args().First()
+	var description = c.Args().Get(1)
+	funcParams := []string{role, description}
+
+	callUserManager(c, "createCustomRole", funcParams)
+}
+
+func removeCustomRole(c *cli.Context) {
+	var role = c.Args().First()
+	funcParams := []string{role}
+
+	callUserManager(c, "removeCustomRole", funcParams)
+}
+
+func getCustomRoles(c *cli.Context) {
+	callUserManager(c, "getAllCustomRoles", nil)
+}
+
+func addCustomRole(c *cli.Context) {
+	var addr = c.Args().First()
+	var role = c.Args().Get(1)
+
+	if !common.IsHexAddress(addr) {
+		panic("the first argument should be hex address")
+	}
+	funcParams := []string{addr, role}
+
+	callUserManager(c, "addCustomRoleByAddress", funcParams)
+}
+
+func delCustomRole(c *cli.Context) {
+	var addr = c.Args().First()
+	var role = c.Args().Get(1)
+
+	if !common.IsHexAddress(addr) {
+		panic("the first argument should be hex address")
+	}
+	funcParams := []string{addr, role}
+
+	callUserManager(c, "delCustomRoleByAddress", funcParams)
+}
+
 func callUserManager(c *cli.Context, funcName string, funcParams []string) {
 	result := contractCall(c, funcParams, funcName, precompile.UserManagementAddress)
 	fmt.Printf("%s\n", result)
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+
+	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	cmd_common "github.com/Venachain/Venachain/cmd/vcl/common"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	SponsorCmd = cli.Command{
+		Name:     "sponsor",
+		Usage:    "Manage the limits of the fees paid by a sponsor account",
+		Category: "sponsor",
+		Subcommands: []cli.Command{
+			SponsorSetLimitCmd,
+			SponsorRemoveLimitCmd,
+			SponsorGetLimitCmd,
+		},
+	}
+
+	SponsorSetLimitCmd = cli.Command{
+		Name:      "set-limit",
+		Usage:     "Limit the gas the sender account sponsors for an account or a contract",
+		ArgsUsage: "<target> <limit>",
+		Action:    sponsorSetLimit,
+		Flags:     globalCmdFlags,
+		Description: `
+Limit the gas paid by the sender account for the transactions sent by an account
+or calling a contract, the gas already paid still counts, use:
+		vcl sponsor set-limit <target> <limit>
+
+The transactions are sponsored with the --sponsorKeyfile flag of the sender, the
+transactions exceeding a limit of their sender or of the called contract are rejected.`,
+	}
+
+	SponsorRemoveLimitCmd = cli.Command{
+		Name:      "remove-limit",
+		Usage:     "Remove the limit set by the sender account for an account or a contract",
+		ArgsUsage: "<target>",
+		Action:    sponsorRemoveLimit,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl sponsor remove-limit <target>`,
+	}
+
+	SponsorGetLimitCmd = cli.Command{
+		Name:      "get-limit",
+		Usage:     "Show the limit set by a sponsor for an account or a contract and the gas paid",
+		ArgsUsage: "<sponsor> <target>",
+		Action:    sponsorGetLimit,
+		Flags:     globalCmdFlags,
+		Description: `
+		vcl sponsor get-limit <sponsor> <target>`,
+	}
+)
+
+func sponsorSetLimit(c *cli.Context) {
+	target := c.Args().First()
+	limit := c.Args().Get(1)
+
+	paramValid(target, "address")
+	paramValid(limit, "num")
+
+	funcParams := cmd_common.CombineFuncParams(target, limit)
+	result := contractCall(c, funcParams, "setSponsorLimit", precompile.SponsorManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func sponsorRemoveLimit(c *cli.Context) {
+	target := c.Args().First()
+	paramValid(target, "address")
+
+	funcParams := cmd_common.CombineFuncParams(target)
+	result := contractCall(c, funcParams, "removeSponsorLimit", precompile.SponsorManagementAddress)
+	fmt.Printf("result: %v\n", result)
+}
+
+func sponsorGetLimit(c *cli.Context) {
+	sponsor := c.Args().First()
+	target := c.Args().Get(1)
+
+	paramValid(sponsor, "address")
+	paramValid(target, "address")
+
+	funcParams := cmd_common.CombineFuncParams(sponsor, target)
+	result := contractCall(c, funcParams, "getSponsorLimit", precompile.SponsorManagementAddress)
+	strResult := PrintJson([]byte(result.(string)))
+	fmt.Printf("result:\n%s\n", strResult)
+}
@@ -68,6 +68,7 @@ func setSysConfig(c *cli.Context) {
 	isProduceEmptyBlock := c.String(IsProduceEmptyBlockFlags.Name)
 	gasContractName := c.String(GasContractNameFlags.Name)
 	vrfParams := c.String(VrfParamsFlags.Name)
+	vrfElectionParams := c.String(VrfElectionParamsFlags.Name)
 
 	// temp solution
 	if len(txGasLimit)+len(blockGasLimit)+len(isTxUseGas)+len(isApproveDeployedContract)+
@@ -98,6 +99,9 @@ func setSysConfig(c *cli.Context) {
 	if vrfParams != "" {
 		setConfig(c, vrfParams, vm.VrfParamsKey)
 	}
+	if vrfElectionParams != "" {
+		setConfig(c, vrfElectionParams, vm.VrfElectionParamsKey)
+	}
 }
 
 func setConfig(c *cli.Context, param string, name string) {
@@ -175,6 +179,7 @@ func getSysConfig(c *cli.Context) {
 	isProduceEmptyBlock := c.Bool(IsProduceEmptyBlockFlags.Name)
 	gasContractName := c.Bool(GasContractNameFlags.Name)
 	vrfParams := c.Bool(VrfParamsFlags.Name)
+	vrfElectionParams := c.Bool(VrfElectionParamsFlags.Name)
 
 	getConfig(c, txGasLimit, vm.TxGasLimitKey)
 	getConfig(c, blockGasLimit, vm.BlockGasLimitKey)
@@ -184,6 +189,7 @@ func getSysConfig(c *cli.Context) {
 	getConfig(c, isProduceEmptyBlock, vm.IsProduceEmptyBlockKey)
 	getConfig(c, gasContractName, vm.GasContractNameKey)
 	getConfig(c, vrfParams, vm.VrfParamsKey)
+	getConfig(c, vrfElectionParams, vm.VrfElectionParamsKey)
 }
 
 func getConfig(c *cli.Context, isGet bool, name string) {
@@ -224,7 +230,7 @@ func sysconfigToString(param interface{}) interface{} {
 
 func sysConfigParsing(param interface{}, paramName string) string {
 	if paramName == vm.TxGasLimitKey || paramName == vm.BlockGasLimitKey ||
-		paramName == vm.GasContractNameKey || paramName == vm.VrfParamsKey {
+		paramName == vm.GasContractNameKey || paramName == vm.VrfParamsKey || paramName == vm.VrfElectionParamsKey {
 		return param.(string)
 	}
 
@@ -234,7 +240,7 @@ func sysConfigParsing(param interface{}, paramName string) string {
 
 func sysConfigConvert(param, paramName string) (string, error) {
 
-	if paramName == vm.TxGasLimitKey || paramName == vm.BlockGasLimitKey || paramName == vm.VrfParamsKey || paramName == vm.GasContractNameKey {
+	if paramName == vm.TxGasLimitKey || paramName == vm.BlockGasLimitKey || paramName == vm.VrfParamsKey || paramName == vm.VrfElectionParamsKey || paramName == vm.GasContractNameKey {
 		return param, nil
 	}
 
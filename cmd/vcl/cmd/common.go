@@ -12,6 +12,7 @@ import (
 	"github.com/Venachain/Venachain/cmd/vcl/client/packet"
 	"github.com/Venachain/Venachain/cmd/vcl/client/utils"
 	cmd_common "github.com/Venachain/Venachain/cmd/vcl/common"
+	"github.com/Venachain/Venachain/common"
 	"gopkg.in/urfave/cli.v1"
 )
 
@@ -22,6 +23,22 @@ func contractCall(c *cli.Context, funcParams []string, funcName, contract string
 }
 
 func contractCallWrap(c *cli.Context, funcParams []string, funcName, contract string) []interface{} {
+	dataGenerator, to := newContractDataGen(c, funcParams, funcName, contract)
+	return clientCommonV2(c, dataGenerator, &to)
+}
+
+// contractCallData returns the hex encoded data of the transaction calling the
+// contract method
+func contractCallData(c *cli.Context, funcParams []string, funcName, contract string) string {
+	dataGenerator, _ := newContractDataGen(c, funcParams, funcName, contract)
+	data, err := dataGenerator.CombineData()
+	if err != nil {
+		utl.Fatalf(err.Error())
+	}
+	return data
+}
+
+func newContractDataGen(c *cli.Context, funcParams []string, funcName, contract string) (*packet.ContractDataGen, common.Address) {
 	vm := c.String(ContractVmFlags.Name)
 	paramValid(vm, "vm")
 
@@ -49,7 +66,7 @@ func contractCallWrap(c *cli.Context, funcParams []string, funcName, contract st
 	dataGenerator := packet.NewContractDataGen(data, contractAbi, cns.TxType)
 	dataGenerator.SetInterpreter(vm, cns.Name, cns.TxType)
 
-	return clientCommonV2(c, dataGenerator, &to)
+	return dataGenerator, to
 }
 
 // =====================================================================
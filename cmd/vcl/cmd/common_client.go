@@ -30,6 +30,7 @@ func clientCommonV2(c *cli.Context, dataGen packet.MsgDataGen, to *common.Addres
 		tx.From = common.HexToAddress(keyfile.Address)
 	}
 	tx.To = to
+	tx.Sponsor = getSponsorKeyfile(c)
 	if dataGen == nil {
 		res, err := pc.Send(tx, keyfile)
 		if err != nil {
@@ -105,6 +106,27 @@ func getClientConfig(c *cli.Context) (*utils.Keyfile, bool, bool, string) {
 	return account, isSync, isDefault, url
 }
 
+// getSponsorKeyfile returns the sponsor account of --sponsorKeyfile, or nil if
+// the transaction isn't sponsored
+func getSponsorKeyfile(c *cli.Context) *utils.Keyfile {
+	keyfile := c.String(SponsorKeyfileFlags.Name)
+	if keyfile == "" {
+		return nil
+	}
+
+	sponsor, err := utils.NewKeyfile(keyfile)
+	if err != nil {
+		utl.Fatalf(err.Error())
+	}
+	fmt.Println("sponsor account:")
+	sponsor.Passphrase = utils.PromptPassphrase(false)
+	if err = sponsor.ParsePrivateKey(); err != nil {
+		utl.Fatalf(err.Error())
+	}
+
+	return sponsor
+}
+
 func isTxAccountMatch(address string, keyfile *utils.Keyfile) bool {
 
 	// check if the account address is matched
@@ -124,23 +146,7 @@ func isTxAccountMatch(address string, keyfile *utils.Keyfile) bool {
 func getUrl(c *cli.Context) string {
 	url := c.String(UrlFlags.Name)
 	if url != "" {
-		index := strings.Index(url, "://")
-
-		if index != -1 {
-			// url = scheme://host:port
-			urlNotHttpScheme := !strings.EqualFold(url[:index], "http")
-			if urlNotHttpScheme {
-				utl.Fatalf("invalid url scheme %s, currently only support http", url[:index])
-			}
-
-			paramValid(url[index+3:], "ipAddress")
-		} else {
-			// url = host:port (default scheme: http://)
-			paramValid(url, "ipAddress")
-			url = "http://" + url
-		}
-
-		return url
+		return parseUrl(url)
 	}
 
 	if config.Url == "" {
@@ -150,6 +156,27 @@ func getUrl(c *cli.Context) string {
 	return config.Url
 }
 
+// parseUrl checks the url and adds the default http scheme if missing
+func parseUrl(url string) string {
+	index := strings.Index(url, "://")
+
+	if index != -1 {
+		// url = scheme://host:port
+		urlNotHttpScheme := !strings.EqualFold(url[:index], "http")
+		if urlNotHttpScheme {
+			utl.Fatalf("invalid url scheme %s, currently only support http", url[:index])
+		}
+
+		paramValid(url[index+3:], "ipAddress")
+	} else {
+		// url = host:port (default scheme: http://)
+		paramValid(url, "ipAddress")
+		url = "http://" + url
+	}
+
+	return url
+}
+
 func getTxParams(c *cli.Context) *packet.TxParams {
 
 	//
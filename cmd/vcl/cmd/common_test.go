@@ -33,6 +33,7 @@ func TestParamParse(t *testing.T) {
 	}{
 		{testAccount, "contract", cmd_common.CnsIsAddress},
 		{"Alice_02", "contract", cmd_common.CnsIsName},
+		{"finance.payroll", "contract", cmd_common.CnsIsName},
 		//{"Alice.bob", "contract"},
 		//{"na*&2", "contract"},
 		//{"-1", "p2pPort"},
@@ -39,6 +39,10 @@ var (
 		Name:  "keyfile",
 		Usage: "Use local account to send the message call by specifying the key file",
 	}
+	SponsorKeyfileFlags = cli.StringFlag{
+		Name:  "sponsorKeyfile",
+		Usage: "Specify the key file of the sponsor account paying the fees of the transaction",
+	}
 	SyncFlags = cli.BoolFlag{
 		Name:  "sync",
 		Usage: "Wait for the result of polling the Tx Receipt after executing the commands",
@@ -126,6 +130,10 @@ var (
 		Name:  "delayNum",
 		Usage: "Switch the node type to consensus after <delayNum> numbers of blocks generated",
 	}
+	NodeWeightFlags = cli.StringFlag{
+		Name:  "weight",
+		Usage: "The weight of a consensus node in the weighted VRF election",
+	}
 	NodePublicKeyFlags = cli.StringFlag{
 		Name:  "publicKey",
 		Usage: "Node's public key for secure p2p communication",
@@ -169,6 +177,17 @@ var (
 		Usage: "Specify the fire wall file path to be imported or exported",
 	}
 
+	ContractDataFileFlags = cli.StringFlag{
+		Name:  "file",
+		Value: defaultContractDataFilePath,
+		Usage: "Specify the contract data file path to be imported or exported",
+	}
+	ContractBlockFlags = cli.StringFlag{
+		Name:  "block",
+		Value: "latest",
+		Usage: "Specify the block number of the state to be exported",
+	}
+
 	// cns
 	CnsVersionFlags = cli.StringFlag{
 		Name:  "version",
@@ -194,6 +213,86 @@ var (
 		Usage: "Specify the fire wall rule action, the fire wall action can be either \"accept\" or \"reject\".",
 	}
 
+	FwRulePriorityFlags = cli.Uint64Flag{
+		Name:  "priority",
+		Usage: "The priority of the conditional fire wall rule, the rules with lower values are checked first",
+	}
+	FwRuleRoleFlags = cli.StringFlag{
+		Name:  "role",
+		Usage: "Match the callers having the role, e.g. CONTRACT_ADMIN",
+	}
+	FwRuleGroupFlags = cli.Uint64Flag{
+		Name:  "group",
+		Usage: "Match the callers being members of the group",
+	}
+	FwRuleFromBlockFlags = cli.Uint64Flag{
+		Name:  "fromBlock",
+		Usage: "Match the calls from the block number",
+	}
+	FwRuleToBlockFlags = cli.Uint64Flag{
+		Name:  "toBlock",
+		Usage: "Match the calls until the block number",
+	}
+	FwRuleFromTimeFlags = cli.Uint64Flag{
+		Name:  "fromTime",
+		Usage: "Match the calls from the unix timestamp",
+	}
+	FwRuleToTimeFlags = cli.Uint64Flag{
+		Name:  "toTime",
+		Usage: "Match the calls until the unix timestamp",
+	}
+	FwRuleRateLimitFlags = cli.Uint64Flag{
+		Name:  "rateLimit",
+		Usage: "Accept at most the number of calls of a caller in every --rateWindow blocks",
+	}
+	FwRuleRateWindowFlags = cli.Uint64Flag{
+		Name:  "rateWindow",
+		Usage: "The number of blocks of a --rateLimit window",
+	}
+
+	// role
+	RoleReasonFlags = cli.StringFlag{
+		Name:  "reason",
+		Usage: "The reason of the role grant or revocation",
+	}
+
+	// proposal
+	ProposalDescFlags = cli.StringFlag{
+		Name:  "description",
+		Usage: "The description of the proposal",
+	}
+	ProposalDurationFlags = cli.Uint64Flag{
+		Name:  "duration",
+		Usage: "The number of blocks the proposal can be voted in, 7200 if not set",
+	}
+	ProposalStatusFlags = cli.StringFlag{
+		Name:  "status",
+		Usage: "List the proposals with the status, e.g. pending",
+	}
+
+	// evidence
+	EvidenceBlockFlags = cli.StringFlag{
+		Name:  "block",
+		Value: "latest",
+		Usage: "Specify the block number of the state to get the evidence proof from",
+	}
+	EvidenceRootFlags = cli.StringFlag{
+		Name:  "root",
+		Usage: "The trusted Merkle root of the evidence batch, e.g. given by the anchoring party",
+	}
+	EvidenceAnchorUrlFlags = cli.StringFlag{
+		Name:  "anchor-url",
+		Usage: "Specify another node to read the anchored evidence batch from, url format: <ip>:<port>",
+	}
+	EvidenceHashFileFlags = cli.StringFlag{
+		Name:  "file",
+		Usage: "Path of the file listing the document hashes to be anchored, one per line",
+	}
+	EvidenceSigFlags = cli.StringFlag{
+		Name:  "sig",
+		Usage: "The signature of the Merkle root of the batch by its signer",
+	}
+
 	ShowContractMethodsFlag = cli.BoolFlag{
 		Name:  "methods",
 		Usage: "List all the contract methods",
@@ -255,6 +354,10 @@ var (
 		Name:"vrf-params",
 		Usage:"VRF params",
 	}
+	VrfElectionParamsFlags = cli.StringFlag{
+		Name:  "vrf-election",
+		Usage: "VRF election params, e.g. '{\"weighted\":true,\"guaranteedSeats\":[\"node0\"],\"minRotation\":30}'",
+	}
 
 	GetBlockGasLimitFlags = cli.BoolFlag{
 		Name:  "block-gaslimit",
@@ -300,6 +403,11 @@ var (
 		Usage: "VRF params",
 	}
 
+	GetVrfElectionParamsFlags = cli.BoolFlag{
+		Name:  "vrf-election",
+		Usage: "VRF election params",
+	}
+
 	// rest
 	RestPortFlags = cli.StringFlag{
 		Name:  "port",
@@ -320,6 +428,7 @@ var (
 		GasPriceFlags,
 		LocalFlags,
 		KeyfileFlags,
+		SponsorKeyfileFlags,
 		SyncFlags,
 		DefaultFlags,
 		TransferValueFlag,
@@ -338,6 +447,7 @@ var (
 		IsProduceEmptyBlockFlags,
 		GasContractNameFlags,
 		VrfParamsFlags,
+		VrfElectionParamsFlags,
 	)
 
 	getSysConfigCmdFlags = append(
@@ -350,6 +460,7 @@ var (
 		GetIsProduceEmptyBlockFlags,
 		GetGasContractNameFlags,
 		GetVrfParamsFlags,
+		GetVrfElectionParamsFlags,
 	)
 
 	// user
@@ -358,7 +469,7 @@ var (
 	userQueryCmdFlags  = append(globalCmdFlags, UserIDFlags, ShowAllFlags)
 
 	// node
-	nodeUpdateCmdFlags = append(globalCmdFlags, NodeDescFlags, NodeDelayNumFlags, NodeTypeFlags)
+	nodeUpdateCmdFlags = append(globalCmdFlags, NodeDescFlags, NodeDelayNumFlags, NodeTypeFlags, NodeWeightFlags)
 	nodeStatCmdFlags   = append(globalCmdFlags, NodeStatusFlags, NodeTypeFlags)
 	nodeAddCmdFlags    = append(
 		globalCmdFlags,
@@ -385,6 +496,8 @@ var (
 		//TransferValueFlag,
 		ShowContractMethodsFlag)
 	contractMethodsCmd = append([]cli.Flag{}, ContractAbiFilePathFlag)
+	contractExportCmdFlags = append([]cli.Flag{}, UrlFlags, ContractDataFileFlags, ContractBlockFlags)
+	contractImportCmdFlags = append(globalCmdFlags, ContractDataFileFlags)
 
 	// cns
 	cnsResolveCmdFlags = append(globalCmdFlags, CnsVersionFlags)
@@ -399,7 +512,27 @@ var (
 	//fw
 	fwImportCmdFlags = append(globalCmdFlags, FilePathFlags)
 	fwClearCmdFlags  = append(globalCmdFlags, FwActionFlags, FwClearAllFlags)
+	fwRuleCmdFlags   = append(
+		globalCmdFlags,
+		FwRulePriorityFlags,
+		FwRuleRoleFlags,
+		FwRuleGroupFlags,
+		FwRuleFromBlockFlags,
+		FwRuleToBlockFlags,
+		FwRuleFromTimeFlags,
+		FwRuleToTimeFlags,
+		FwRuleRateLimitFlags,
+		FwRuleRateWindowFlags)
+
+	// evidence
+	evidenceAnchorCmdFlags = append(globalCmdFlags, EvidenceHashFileFlags, EvidenceSigFlags)
+	evidenceVerifyCmdFlags = append([]cli.Flag{}, UrlFlags, EvidenceBlockFlags, EvidenceRootFlags, EvidenceAnchorUrlFlags)
+
+	// proposal
+	proposalCreateCmdFlags = append(globalCmdFlags, ProposalDescFlags, ProposalDurationFlags)
+	proposalQueryCmdFlags  = append(globalCmdFlags, ProposalStatusFlags)
 
 	// role
-	roleCmdFlags = globalCmdFlags
+	roleCmdFlags      = globalCmdFlags
+	roleGrantCmdFlags = append(globalCmdFlags, RoleReasonFlags)
 )
@@ -53,6 +53,7 @@ var AppHelpFlagGroups = []flagGroup{
 			GasPriceFlags,
 			LocalFlags,
 			KeyfileFlags,
+			SponsorKeyfileFlags,
 			SyncFlags,
 			DefaultFlags,
 		},
@@ -80,6 +81,7 @@ var AppHelpFlagGroups = []flagGroup{
 			NodeDescFlags,
 			NodeDelayNumFlags,
 			NodeTypeFlags,
+			NodeWeightFlags,
 			NodeP2pPortFlags,
 			NodeRpcPortFlags,
 			NodePublicKeyFlags,
@@ -98,6 +100,8 @@ var AppHelpFlagGroups = []flagGroup{
 			ContractVmFlags,
 			//TransferValueFlag,
 			ShowContractMethodsFlag,
+			ContractDataFileFlags,
+			ContractBlockFlags,
 		},
 	},
 	{
@@ -111,6 +115,31 @@ var AppHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			FilePathFlags,
 			FwActionFlags,
+			FwRulePriorityFlags,
+			FwRuleRoleFlags,
+			FwRuleGroupFlags,
+			FwRuleFromBlockFlags,
+			FwRuleToBlockFlags,
+			FwRuleFromTimeFlags,
+			FwRuleToTimeFlags,
+			FwRuleRateLimitFlags,
+			FwRuleRateWindowFlags,
+		},
+	},
+	{
+		Name: "EVIDENCE",
+		Flags: []cli.Flag{
+			EvidenceBlockFlags,
+			EvidenceHashFileFlags,
+			EvidenceSigFlags,
+		},
+	},
+	{
+		Name: "PROPOSAL",
+		Flags: []cli.Flag{
+			ProposalDescFlags,
+			ProposalDurationFlags,
+			ProposalStatusFlags,
 		},
 	},
 	{
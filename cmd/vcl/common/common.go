@@ -147,6 +147,8 @@ func ParamParse(param, paramName string) interface{} {
 		} else {
 			err = errors.New("value out of range")
 		}
+	case "weight":
+		i, err = strconv.ParseUint(param, 10, 32)
 	case "operation", "status", "type":
 		i, err = ConvertSelect(param, paramName)
 	case "code", "abi":
@@ -229,7 +231,7 @@ func IsNameOrAddress(str string) int32 {
 	switch {
 	case utils.IsMatch(str, "address"):
 		valid = CnsIsAddress
-	case utils.IsMatch(str, "name") &&
+	case utils.IsMatch(str, "cnsname") &&
 		!strings.HasPrefix(strings.ToLower(str), "0x"):
 		valid = CnsIsName
 	default:
@@ -252,7 +254,7 @@ func ParamValidWrap(param, paramName string) bool {
 	case "to":
 		valid = param == "" || utils.IsMatch(param, "address")
 	case "contract":
-		valid = utils.IsMatch(param, "address") || utils.IsMatch(param, "name")
+		valid = utils.IsMatch(param, "address") || utils.IsMatch(param, "cnsname")
 	case "action":
 		valid = strings.EqualFold(param, "accept") || strings.EqualFold(param, "reject")
 	case "vm":
@@ -267,7 +269,7 @@ func ParamValidWrap(param, paramName string) bool {
 		valid = utils.IsRoleMatch(param)
 	case "roles":
 		valid = utils.IsValidRoles(param)
-	case "email", "mobile", "version", "num":
+	case "email", "mobile", "version", "num", "hash":
 		valid = utils.IsMatch(param, paramName)
 
 	// newly added for restful server
@@ -276,6 +278,8 @@ func ParamValidWrap(param, paramName string) bool {
 		valid = utils.IsMatch(param, "address")
 	case "contractname", "name":
 		valid = utils.IsMatch(param, "name")
+	case "cnsname":
+		valid = utils.IsMatch(param, "cnsname")
 	case "sysparam":
 		valid = strings.EqualFold(param, "0") || strings.EqualFold(param, "1")
 	case "blockgaslimit":
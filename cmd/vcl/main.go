@@ -27,6 +27,11 @@ func init() {
 		cmd.RoleCmd,      // see cmd_role.go
 		cmd.NodeCmd,      // see cmd_node.go
 		cmd.SysConfigCmd, // see cmd_sysconfig.go
+		cmd.SponsorCmd,   // see cmd_sponsor.go
+		cmd.ProposalCmd,  // see cmd_proposal.go
+		cmd.EvidenceCmd,  // see cmd_evidence.go
+		cmd.BpCmd,        // see cmd_bp.go
+		cmd.GroupCmd,     // see cmd_group.go
 
 		StartRest, // see rest
 		cmd.PlCmd,
@@ -0,0 +1,54 @@
+package rest
+
+import (
+	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	"github.com/gin-gonic/gin"
+)
+
+func registerBpRouters(r *gin.Engine) {
+	bp := r.Group("/bp")
+	{
+		bp.POST("/proofs", bpVerifyHandler)              // verifyProof
+		bp.POST("/range-proofs", bpVerifyRangeHandler)   // verifyProofByRange
+		bp.GET("/range-proofs/:pid", bpGetResultHandler) // getResult
+	}
+}
+
+// ===================== Bullet Proof ========================
+func bpVerifyHandler(ctx *gin.Context) {
+	var contractAddr = precompile.BulletProofAddress
+
+	funcParams := &struct {
+		Proof string
+		Pid   string
+	}{}
+
+	data := newContractParams(contractAddr, "verifyProof", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func bpVerifyRangeHandler(ctx *gin.Context) {
+	var contractAddr = precompile.BulletProofAddress
+
+	funcParams := &struct {
+		Userid string
+		Proof  string
+		Pid    string
+		Range  string
+	}{}
+
+	data := newContractParams(contractAddr, "verifyProofByRange", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func bpGetResultHandler(ctx *gin.Context) {
+	var contractAddr = precompile.BulletProofAddress
+
+	endPoint := ctx.Query("endPoint")
+	funcParams := &struct {
+		Pid string
+	}{Pid: ctx.Param("pid")}
+
+	data := newContractParams(contractAddr, "getResult", "wasm", nil, funcParams)
+	queryHandlerCommon(ctx, endPoint, data)
+}
@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================== Bullet Proof =========================
+var (
+	testBpVerifyBody      string
+	testBpVerifyRangeBody string
+)
+
+func initRouterBpTest() {
+	testBpVerifyBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"proof\":\"0x00\", \"pid\":\"pid-001\"}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
+	testBpVerifyRangeBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"userid\":\"alice\", \"proof\":\"0x00\", \"pid\":\"pid-002\", \"range\":\"0-65535\"}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+}
+
+func TestBpHandlers(t *testing.T) {
+	testCase := []struct {
+		method       string
+		path         string
+		body         string
+		expectedCode int
+	}{
+		{"POST", "/bp/proofs", testBpVerifyBody, 200},
+		{"POST", "/bp/range-proofs", testBpVerifyRangeBody, 200},
+		{"GET", "/bp/range-proofs/pid-002", "", 200},
+	}
+
+	router := genRestRouters()
+
+	for _, data := range testCase {
+		w := httptest.NewRecorder()
+		body := bytes.NewBufferString(data.body)
+		req, _ := http.NewRequest(data.method, data.path, body)
+		req.Header.Set("content-type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, data.expectedCode, w.Code)
+		t.Log(w.Body)
+	}
+}
@@ -54,7 +54,10 @@ func TestMain(m *testing.M) {
 	}
 	initRouterCnsTestdata()
 	initRoutContractTest()
+	initRouterEvidenceTest()
+	initRouterBpTest()
 	initRouterFwTest()
+	initRouterGroupTest()
 	initRouterNodeTest()
 	initRouterRoleTest()
 	initRouterSysconfigTest()
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"encoding/json"
+	"strings"
+
+	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	"github.com/Venachain/Venachain/cmd/vcl/client/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func registerEvidenceRouters(r *gin.Engine) {
+	evidence := r.Group("/evidence")
+	{
+		evidence.POST("/values", evidenceSaveHandler)    // saveEvidence
+		evidence.GET("/values/:key", evidenceGetHandler) // getEvidence
+
+		evidence.POST("/signed", evidenceSaveSignedHandler)    // saveSignedEvidence
+		evidence.GET("/signed/:key", evidenceGetSignedHandler) // getSignedEvidence
+
+		evidence.POST("/batches", evidenceAnchorHandler)        // anchorEvidenceBatch
+		evidence.GET("/batches/:root", evidenceGetBatchHandler) // getEvidenceBatch
+		evidence.GET("/proofs/:hash", evidenceGetProofHandler)  // getEvidenceProof
+	}
+}
+
+// ===================== Evidence ========================
+
+// evidenceHashes binds a json array of document hashes to their concatenation
+// taken by anchorEvidenceBatch.
+type evidenceHashes string
+
+func (h *evidenceHashes) UnmarshalJSON(data []byte) error {
+	var hashes []string
+	if err := json.Unmarshal(data, &hashes); err != nil {
+		return err
+	}
+
+	var leaves strings.Builder
+	leaves.WriteString("0x")
+	for _, hash := range hashes {
+		if !utils.IsMatch(hash, "hash") {
+			return errInvalidParam
+		}
+		leaves.WriteString(hash[2:])
+	}
+	*h = evidenceHashes(leaves.String())
+	return nil
+}
+
+func evidenceSaveHandler(ctx *gin.Context) {
+	var contractAddr = precompile.EvidenceManagementAddress
+
+	funcParams := &struct {
+		Key   string
+		Value string
+	}{}
+
+	data := newContractParams(contractAddr, "saveEvidence", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func evidenceGetHandler(ctx *gin.Context) {
+	evidenceQueryHandler(ctx, "getEvidence", ctx.Param("key"))
+}
+
+func evidenceSaveSignedHandler(ctx *gin.Context) {
+	var contractAddr = precompile.EvidenceManagementAddress
+
+	funcParams := &struct {
+		Key  string
+		Hash string
+		Sig  string
+	}{}
+
+	data := newContractParams(contractAddr, "saveSignedEvidence", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func evidenceGetSignedHandler(ctx *gin.Context) {
+	evidenceQueryHandler(ctx, "getSignedEvidence", ctx.Param("key"))
+}
+
+func evidenceAnchorHandler(ctx *gin.Context) {
+	var contractAddr = precompile.EvidenceManagementAddress
+
+	funcParams := &struct {
+		Hashes evidenceHashes
+		Sig    string
+	}{}
+
+	data := newContractParams(contractAddr, "anchorEvidenceBatch", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func evidenceGetBatchHandler(ctx *gin.Context) {
+	evidenceQueryHandler(ctx, "getEvidenceBatch", ctx.Param("root"))
+}
+
+func evidenceGetProofHandler(ctx *gin.Context) {
+	evidenceQueryHandler(ctx, "getEvidenceProof", ctx.Param("hash"))
+}
+
+func evidenceQueryHandler(ctx *gin.Context, funcName, param string) {
+	var contractAddr = precompile.EvidenceManagementAddress
+
+	endPoint := ctx.Query("endPoint")
+	funcParams := &struct {
+		Param string
+	}{Param: param}
+
+	data := newContractParams(contractAddr, funcName, "wasm", nil, funcParams)
+	queryHandlerCommon(ctx, endPoint, data)
+}
@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================== Evidence management =========================
+const testEvidenceHash = "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+
+var (
+	testEvidenceSaveBody      string
+	testEvidenceSignedBody    string
+	testEvidenceSignedErrBody string
+	testEvidenceAnchorBody    string
+	testEvidenceAnchorErrBody string
+)
+
+func initRouterEvidenceTest() {
+	testEvidenceSaveBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"key\":\"contract-001\", \"value\":\"signed on 2020-07-07\"}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
+	testEvidenceSignedBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"key\":\"contract-002\", \"hash\":\"" + testEvidenceHash + "\", \"sig\":\"0x00\"}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
+	testEvidenceSignedErrBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"key\":\"contract-002\", \"hash\":\"0x1234\", \"sig\":\"0x00\"}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
+	testEvidenceAnchorBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"hashes\":[\"" + testEvidenceHash + "\"]}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
+	testEvidenceAnchorErrBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"hashes\":[\"0x1234\"]}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+}
+
+func TestEvidenceHandlers(t *testing.T) {
+	testCase := []struct {
+		method       string
+		path         string
+		body         string
+		expectedCode int
+	}{
+		{"POST", "/evidence/values", testEvidenceSaveBody, 200},
+		{"GET", "/evidence/values/contract-001", "", 200},
+
+		{"POST", "/evidence/signed", testEvidenceSignedBody, 200},
+		{"POST", "/evidence/signed", testEvidenceSignedErrBody, 400},
+		{"GET", "/evidence/signed/contract-002", "", 200},
+
+		{"POST", "/evidence/batches", testEvidenceAnchorBody, 200},
+		{"POST", "/evidence/batches", testEvidenceAnchorErrBody, 400},
+		{"GET", "/evidence/proofs/" + testEvidenceHash, "", 200},
+	}
+
+	router := genRestRouters()
+
+	for _, data := range testCase {
+		w := httptest.NewRecorder()
+		body := bytes.NewBufferString(data.body)
+		req, _ := http.NewRequest(data.method, data.path, body)
+		req.Header.Set("content-type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, data.expectedCode, w.Code)
+		t.Log(w.Body)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	"github.com/Venachain/Venachain/core/state"
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,6 +19,10 @@ func registerFwRouters(r *gin.Engine) {
 		fw.DELETE("/lists", fwClearHandler) // clear
 		fw.PATCH("/lists", fwDeleteHandler) // delete
 
+		fw.POST("/rules", fwRuleAddHandler)          // add or replace a conditional rule
+		fw.DELETE("/rules/:id", fwRuleDeleteHandler) // delete a conditional rule
+		fw.DELETE("/rules", fwRuleClearHandler)      // clear the conditional rules
+
 		fw.GET("", fwGetHandler) // status
 	}
 }
@@ -84,6 +89,45 @@ func fwClearHandler(ctx *gin.Context) {
 	posthandlerCommon(ctx, data)
 }
 
+func fwRuleAddHandler(ctx *gin.Context) {
+	var contractAddr = precompile.FirewallManagementAddress
+
+	funcParams := &struct {
+		Address string
+		Rule    *state.FwRule `json:"rule"`
+	}{}
+	funcParams.Address = ctx.Param("address")
+
+	data := newContractParams(contractAddr, "__sys_FwAddRule", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func fwRuleDeleteHandler(ctx *gin.Context) {
+	var contractAddr = precompile.FirewallManagementAddress
+
+	funcParams := &struct {
+		Address string
+		ID      string
+	}{}
+	funcParams.Address = ctx.Param("address")
+	funcParams.ID = ctx.Param("id")
+
+	data := newContractParams(contractAddr, "__sys_FwDelRule", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func fwRuleClearHandler(ctx *gin.Context) {
+	var contractAddr = precompile.FirewallManagementAddress
+
+	funcParams := &struct {
+		Address string
+	}{}
+	funcParams.Address = ctx.Param("address")
+
+	data := newContractParams(contractAddr, "__sys_FwClearRules", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
 func fwGetHandler(ctx *gin.Context) {
 	var contractAddr = precompile.FirewallManagementAddress
 	endPoint := ctx.Query("endPoint")
@@ -17,6 +17,7 @@ var (
 	testFwStatusBody     string
 	testFwStatusErrBody  string
 	testFwOffBody        string
+	testFwRuleAddBody    string
 )
 
 func initRouterFwTest() {
@@ -39,6 +40,10 @@ func initRouterFwTest() {
 		"\"contract\":{\"data\":{\"address\":\"0x1000000000000000000000000000000000000001\"}}," +
 		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
 
+	testFwRuleAddBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"rule\":{\"id\":\"admins\", \"priority\":1, \"action\":\"ACCEPT\", \"funcName\":\"funcName1\", \"role\":\"CONTRACT_ADMIN\", \"rateLimit\":10, \"rateWindow\":100}}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
 	testFwOffBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
 		"\"contract\":{\"data\":{\"status\":\"false\"}}," +
 		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
@@ -56,6 +61,10 @@ func TestFwHandlers(t *testing.T) {
 		{"PATCH", "/fw/" + testContractAddr + "/lists", testFwDeleteRuleBody, 200},
 		{"GET", "/fw/" + testContractAddr, "", 200},
 
+		{"POST", "/fw/" + testContractAddr + "/rules", testFwRuleAddBody, 200},
+		{"DELETE", "/fw/" + testContractAddr + "/rules/admins", testFwStatusBody, 200},
+		{"DELETE", "/fw/" + testContractAddr + "/rules", testFwStatusBody, 200},
+
 		{"DELETE", "/fw/" + testContractAddr + "/lists", testFwClearRuleBody, 200},
 		{"PUT", "/fw/" + testContractAddr + "/off", testFwStatusBody, 200},
 		{"GET", "/fw/" + testContractAddr, "", 200},
@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"net/http"
+
+	precompile "github.com/Venachain/Venachain/cmd/vcl/client/precompiled"
+	"github.com/Venachain/Venachain/core/vm"
+	"github.com/gin-gonic/gin"
+)
+
+func registerGroupRouters(r *gin.Engine) {
+	group := r.Group("/group")
+	{
+		group.POST("/components", groupCreateHandler) // createGroup
+
+		group.GET("/components", groupGetHandler)          // getAllGroups
+		group.GET("/components/:groupID", groupGetHandler) // getGroupByID
+
+		group.PUT("/components/:groupID/bootnodes", groupUpdateBootNodesHandler) // updateBootNodes
+		group.PATCH("/components/:groupID/bootnodes", groupBootNodeHandler)      // addBootNode
+		group.DELETE("/components/:groupID/bootnodes", groupBootNodeHandler)     // delBootNode
+
+		group.PATCH("/components/:groupID/members", groupMemberHandler)  // addGroupMember
+		group.DELETE("/components/:groupID/members", groupMemberHandler) // delGroupMember
+	}
+}
+
+// ===================== Group ========================
+func groupCreateHandler(ctx *gin.Context) {
+	var contractAddr = precompile.GroupManagementAddress
+
+	funcParams := &struct {
+		Info *vm.GroupInfo
+	}{}
+
+	data := newContractParams(contractAddr, "createGroup", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func groupGetHandler(ctx *gin.Context) {
+	var contractAddr = precompile.GroupManagementAddress
+	var funcName string
+	var funcParams interface{}
+
+	endPoint := ctx.Query("endPoint")
+
+	if groupID := ctx.Param("groupID"); groupID != "" {
+		funcName = "getGroupByID"
+		funcParams = &struct {
+			Num string
+		}{Num: groupID}
+	} else {
+		funcName = "getAllGroups"
+	}
+
+	data := newContractParams(contractAddr, funcName, "wasm", nil, funcParams)
+	queryHandlerCommon(ctx, endPoint, data)
+}
+
+func groupUpdateBootNodesHandler(ctx *gin.Context) {
+	var contractAddr = precompile.GroupManagementAddress
+
+	funcParams := &struct {
+		num       string
+		BootNodes interface{}
+	}{num: ctx.Param("groupID")}
+
+	data := newContractParams(contractAddr, "updateBootNodes", "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func groupBootNodeHandler(ctx *gin.Context) {
+	var contractAddr = precompile.GroupManagementAddress
+
+	funcName := "addBootNode"
+	if ctx.Request.Method == http.MethodDelete {
+		funcName = "delBootNode"
+	}
+
+	funcParams := &struct {
+		num   string
+		Enode string
+	}{num: ctx.Param("groupID")}
+
+	data := newContractParams(contractAddr, funcName, "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
+
+func groupMemberHandler(ctx *gin.Context) {
+	var contractAddr = precompile.GroupManagementAddress
+
+	funcName := "addGroupMember"
+	if ctx.Request.Method == http.MethodDelete {
+		funcName = "delGroupMember"
+	}
+
+	funcParams := &struct {
+		num     string
+		Address string
+	}{num: ctx.Param("groupID")}
+
+	data := newContractParams(contractAddr, funcName, "wasm", nil, funcParams)
+	posthandlerCommon(ctx, data)
+}
@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================== Group management =========================
+const testGroupEnode = "enode://64a684197dbc77b69f418c511e55adb7a4a532a88d25d8e9d34667141d53790b5ff84ed385e35ade60ea9e610b3ac54499119fd1a9bf1344d319aeceadcb5bb7@127.0.0.1:16791"
+
+var (
+	testGroupCreateBody    string
+	testGroupBootNodesBody string
+	testGroupBootNodeBody  string
+	testGroupMemberBody    string
+	testGroupMemberErrBody string
+)
+
+func initRouterGroupTest() {
+	testGroupCreateBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"info\":{\"groupID\":1, \"creatorEnode\":\"" + testGroupEnode + "\"}}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
+	testGroupBootNodesBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"bootNodes\":[\"" + testGroupEnode + "\"]}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
+	testGroupBootNodeBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"enode\":\"" + testGroupEnode + "\"}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
+	testGroupMemberBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"address\":\"" + txSender + "\"}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+
+	testGroupMemberErrBody = "{\"tx\":{\"from\": \"" + txSender + "\", \"gas\":\"0x10\"}," +
+		"\"contract\":{\"data\":{\"address\":\"0x1234\"}}," +
+		"\"rpc\":{\"endPoint\": \"http://127.0.0.1:6791\",\"passphrase\":\"" + testPassphrase + "\"}}"
+}
+
+func TestGroupHandlers(t *testing.T) {
+	testCase := []struct {
+		method       string
+		path         string
+		body         string
+		expectedCode int
+	}{
+		{"POST", "/group/components", testGroupCreateBody, 200},
+		{"GET", "/group/components", "", 200},
+		{"GET", "/group/components/1", "", 200},
+
+		{"PUT", "/group/components/1/bootnodes", testGroupBootNodesBody, 200},
+		{"DELETE", "/group/components/1/bootnodes", testGroupBootNodeBody, 200},
+		{"PATCH", "/group/components/1/bootnodes", testGroupBootNodeBody, 200},
+
+		{"PATCH", "/group/components/1/members", testGroupMemberBody, 200},
+		{"PATCH", "/group/components/1/members", testGroupMemberErrBody, 400},
+		{"PATCH", "/group/components/x/members", testGroupMemberBody, 400},
+		{"DELETE", "/group/components/1/members", testGroupMemberBody, 200},
+	}
+
+	router := genRestRouters()
+
+	for _, data := range testCase {
+		w := httptest.NewRecorder()
+		body := bytes.NewBufferString(data.body)
+		req, _ := http.NewRequest(data.method, data.path, body)
+		req.Header.Set("content-type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, data.expectedCode, w.Code)
+		t.Log(w.Body)
+	}
+}
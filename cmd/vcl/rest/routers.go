@@ -23,9 +23,12 @@ func genRestRouters() *gin.Engine {
 
 func registerRouters(r *gin.Engine) {
 	registerAccountRouters(r)
+	registerBpRouters(r)
 	registerCnsRouters(r)
 	registerContractRouters(r)
+	registerEvidenceRouters(r)
 	registerFwRouters(r)
+	registerGroupRouters(r)
 	registerNodeRouters(r)
 	registerRoleRouters(r)
 	registerSysConfigRouters(r)
@@ -26,6 +26,7 @@ import (
 	"github.com/Venachain/Venachain/console"
 	"github.com/Venachain/Venachain/crypto"
 	"github.com/Venachain/Venachain/log"
+	"github.com/Venachain/Venachain/node"
 	"gopkg.in/urfave/cli.v1"
 )
 
@@ -299,6 +300,9 @@ func accountCreate(ctx *cli.Context) error {
 		}
 	}
 	utils.SetNodeConfig(ctx, &cfg.Node)
+	if stack, err := node.New(&cfg.Node); err == nil {
+		utils.SetupCryptoSuite(ctx, stack)
+	}
 	scryptN, scryptP, keydir, err := cfg.Node.AccountConfig()
 
 	if err != nil {
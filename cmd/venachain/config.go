@@ -128,6 +128,7 @@ func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 	if err != nil {
 		utils.Fatalf("Failed to create the protocol stack: %v", err)
 	}
+	utils.SetupCryptoSuite(ctx, stack)
 
 	utils.SetEthConfig(ctx, stack, &cfg.Eth)
 
@@ -126,8 +126,11 @@ var (
 		utils.GpoBlocksFlag,
 		utils.GpoPercentileFlag,
 		utils.EWASMInterpreterFlag,
+		utils.WasmCompiledFlag,
 		utils.EVMInterpreterFlag,
 		utils.ParallelProcessSize,
+		utils.PreExecuteFlag,
+		utils.GroupsFlag,
 		configFileFlag,
 	}
 
@@ -141,6 +144,8 @@ var (
 		utils.WSPortFlag,
 		utils.WSApiFlag,
 		utils.WSAllowedOriginsFlag,
+		utils.RPCAuthFlag,
+		utils.RPCAuditLogFlag,
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
 	}
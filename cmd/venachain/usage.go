@@ -81,6 +81,8 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.LightPeersFlag,
 			utils.LightKDFFlag,
 			utils.ParallelProcessSize,
+			utils.PreExecuteFlag,
+			utils.GroupsFlag,
 		},
 	},
 	//{
@@ -135,6 +137,8 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.WSPortFlag,
 			utils.WSApiFlag,
 			utils.WSAllowedOriginsFlag,
+			utils.RPCAuthFlag,
+			utils.RPCAuditLogFlag,
 			utils.IPCDisabledFlag,
 			utils.IPCPathFlag,
 			utils.RPCCORSDomainFlag,
@@ -189,6 +193,7 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.VMEnableDebugFlag,
 			utils.EVMInterpreterFlag,
 			utils.EWASMInterpreterFlag,
+			utils.WasmCompiledFlag,
 		},
 	},
 	{
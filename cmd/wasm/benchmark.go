@@ -36,6 +36,11 @@ var (
 		Name:  "prof",
 		Usage: "write cpuprofie to file",
 	}
+
+	compiledFlag = cli.BoolFlag{
+		Name:  "compiled",
+		Usage: "run the closure compiled form of the wasm instead of the interpreter",
+	}
 )
 
 var benchmarkCommand = cli.Command{
@@ -48,6 +53,7 @@ var benchmarkCommand = cli.Command{
 		outDirFlag,
 		loopFlag,
 		profFlag,
+		compiledFlag,
 	},
 	HideHelp: false,
 }
@@ -57,6 +63,7 @@ func benchmarkCmd(ctx *cli.Context) error {
 	outDir := ctx.String(outDirFlag.Name)
 	loop := ctx.Int(loopFlag.Name)
 	profFile := ctx.String(profFlag.Name)
+	compiled := ctx.Bool(compiledFlag.Name)
 
 	if profFile != "" {
 		f, err := os.Create(profFile)
@@ -70,7 +77,7 @@ func benchmarkCmd(ctx *cli.Context) error {
 
 	start := time.Now()
 
-	err := benchmark(wasmFile, outDir, loop)
+	err := benchmark(wasmFile, outDir, loop, compiled)
 
 	if err != nil {
 		return err
@@ -82,7 +89,7 @@ func benchmarkCmd(ctx *cli.Context) error {
 
 	return nil
 }
-func benchmark(wasmFile string, outDir string, loop int) error {
+func benchmark(wasmFile string, outDir string, loop int, compiled bool) error {
 	dbPath := outDir + testDBName
 	logStream := bytes.NewBuffer(make([]byte, 65535))
 	os.RemoveAll(dbPath)
@@ -106,14 +113,18 @@ func benchmark(wasmFile string, outDir string, loop int) error {
 	}
 
 	addr := common.HexToAddress("0x43355c787c50b647c425f594b441d4bd751951c1")
-	lru.WasmCache().Add(addr, &lru.WasmModule{m, functionCode})
+	lru.WasmCache().Add(addr, &lru.WasmModule{Module: m, FunctionCode: functionCode})
 
 	for i := 0; i < loop; i++ {
 		m, ok := lru.WasmCache().Get(addr)
 		if !ok {
 			return errors.New("get wasm cache error")
 		}
-		if err := runModule(m.Module, m.FunctionCode, db, logStream); err != nil {
+		var cm *exec.CompiledModule
+		if compiled {
+			cm = m.Compiled()
+		}
+		if err := runModule(m.Module, m.FunctionCode, cm, db, logStream); err != nil {
 			return err
 		}
 		//lru.WasmCache().Purge()
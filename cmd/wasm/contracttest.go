@@ -0,0 +1,518 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Venachain/Venachain/accounts/abi/bind"
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/core/lru"
+	"github.com/Venachain/Venachain/core/state"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/life/runtime"
+	"github.com/Venachain/Venachain/rlp"
+	"github.com/Venachain/Venachain/venadb/memorydb"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// The contract test runner executes contract test scenarios, JSON files which
+// deploy WASM contracts on an in memory chain and call their methods. The
+// contracts run with the full host API of a node, the state, the block context,
+// the events and the calls between contracts behave as on the chain, and the
+// results are checked against the expectations of the scenario.
+var (
+	reportFlag = cli.StringFlag{
+		Name:  "report",
+		Usage: "report format of the contract tests: junit or json",
+	}
+
+	reportFileFlag = cli.StringFlag{
+		Name:  "reportfile",
+		Usage: "file the report is written to, stdout if not set",
+	}
+
+	// defaultTestSender sends the deployments and the calls without a from address
+	defaultTestSender = common.BytesToAddress([]byte("sender"))
+)
+
+var contractTestCommand = cli.Command{
+	Action:    contractTestCmd,
+	Name:      "contracttest",
+	Usage:     "executes contract test scenarios on an in memory chain",
+	ArgsUsage: "<scenario file or dir>...",
+	Flags: []cli.Flag{
+		reportFlag,
+		reportFileFlag,
+	},
+	Description: `
+A scenario is a JSON file, the code and abi paths are relative to it:
+
+{
+  "name": "token",
+  "block": {"number": 100, "timestamp": 1600000000, "coinbase": "0x..", "gasLimit": 100000000, "hashes": {"99": "0x.."}},
+  "accounts": {"0x..": "1000000"},
+  "contracts": [{"name": "token", "code": "token.wasm", "abi": "token.cpp.abi.json", "from": "0x.."}],
+  "cases": [{
+    "name": "transfer",
+    "from": "0x..", "value": "0", "block": {"number": 101},
+    "contract": "token", "method": "transfer", "args": ["$bank", 10],
+    "expect": {"result": 0, "events": [{"name": "Transfer", "args": ["$bank", 10]}]}
+  }]
+}
+
+The cases run in order on the same state, the block fields of a case override
+the ones of the scenario. A "$name" string stands for the address of the
+deployed contract name. The result and the events are only checked when set,
+the error expectation is a substring of the error of a failing call.`,
+}
+
+type testScenario struct {
+	Name      string             `json:"name"`
+	From      string             `json:"from"`
+	Block     testBlock          `json:"block"`
+	Accounts  map[string]string  `json:"accounts"`
+	Contracts []testContractSpec `json:"contracts"`
+	Cases     []testCase         `json:"cases"`
+}
+
+type testBlock struct {
+	Number    uint64            `json:"number"`
+	Timestamp uint64            `json:"timestamp"`
+	Coinbase  string            `json:"coinbase"`
+	GasLimit  uint64            `json:"gasLimit"`
+	GasPrice  string            `json:"gasPrice"`
+	Hashes    map[string]string `json:"hashes"`
+}
+
+type testContractSpec struct {
+	Name string `json:"name"`
+	Code string `json:"code"`
+	Abi  string `json:"abi"`
+	From string `json:"from"`
+}
+
+type testCase struct {
+	Name     string          `json:"name"`
+	From     string          `json:"from"`
+	Value    string          `json:"value"`
+	Block    json.RawMessage `json:"block"`
+	Contract string          `json:"contract"`
+	Method   string          `json:"method"`
+	Args     []interface{}   `json:"args"`
+	Expect   testExpect      `json:"expect"`
+}
+
+type testExpect struct {
+	Result interface{}  `json:"result"`
+	Error  string       `json:"error"`
+	Events []*testEvent `json:"events"`
+}
+
+type testEvent struct {
+	Contract string        `json:"contract"`
+	Name     string        `json:"name"`
+	Args     []interface{} `json:"args"`
+}
+
+func contractTestCmd(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return errors.New("no contract test scenario given")
+	}
+	var files []string
+	for _, arg := range ctx.Args() {
+		err := filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && (path == arg || filepath.Ext(path) == ".json") && !strings.HasSuffix(path, ".abi.json") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	format, reportFile := ctx.String(reportFlag.Name), ctx.String(reportFileFlag.Name)
+	verbose := format == "" || reportFile != ""
+
+	var (
+		suites          []*suiteReport
+		tests, failures int
+	)
+	for _, file := range files {
+		suite := runScenarioFile(file)
+		for _, c := range suite.Cases {
+			tests++
+			if c.Failure != "" {
+				failures++
+			}
+			if verbose {
+				if c.Failure != "" {
+					fmt.Printf("FAIL %s/%s: %s\n", suite.Name, c.Name, c.Failure)
+				} else {
+					fmt.Printf("PASS %s/%s (gas %d)\n", suite.Name, c.Name, c.GasUsed)
+				}
+			}
+		}
+		suites = append(suites, suite)
+	}
+	if format != "" {
+		if err := writeReport(format, reportFile, suites); err != nil {
+			return err
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d contract tests failed", failures, tests)
+	}
+	if verbose {
+		fmt.Printf("all %d contract tests pass\n", tests)
+	}
+	return nil
+}
+
+// runScenarioFile runs the cases of the scenario, a failure to load it or to
+// deploy its contracts is reported as a failed setup case.
+func runScenarioFile(file string) *suiteReport {
+	suite := &suiteReport{Name: strings.TrimSuffix(filepath.Base(file), ".json"), File: file}
+	start := time.Now()
+	defer func() { suite.Time = time.Since(start).Seconds() }()
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		suite.fail("setup", err)
+		return suite
+	}
+	var scenario testScenario
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&scenario); err != nil {
+		suite.fail("setup", fmt.Errorf("invalid scenario: %v", err))
+		return suite
+	}
+	if scenario.Name != "" {
+		suite.Name = scenario.Name
+	}
+	chain, err := newTestChain(&scenario, filepath.Dir(file))
+	if err != nil {
+		suite.fail("setup", err)
+		return suite
+	}
+	for i := range scenario.Cases {
+		suite.Cases = append(suite.Cases, chain.run(&scenario.Cases[i]))
+	}
+	return suite
+}
+
+// testChain is the in memory chain the contracts of a scenario are deployed on.
+type testChain struct {
+	state     *state.StateDB
+	block     testBlock
+	from      common.Address
+	contracts map[string]*testContract
+	txs       int
+}
+
+type testContract struct {
+	address common.Address
+	abi     *bind.WasmABI
+}
+
+func newTestChain(scenario *testScenario, dir string) (*testChain, error) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(memorydb.NewMemDatabase()))
+	if err != nil {
+		return nil, err
+	}
+	chain := &testChain{
+		state:     statedb,
+		block:     scenario.Block,
+		from:      defaultTestSender,
+		contracts: make(map[string]*testContract),
+	}
+	if scenario.From != "" {
+		chain.from = common.HexToAddress(scenario.From)
+	}
+	for addr, balance := range scenario.Accounts {
+		value, ok := new(big.Int).SetString(balance, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid balance %q of %s", balance, addr)
+		}
+		statedb.AddBalance(common.HexToAddress(addr), value)
+	}
+	// The module cache is keyed by the contract address which the scenarios
+	// reuse, the modules of a previous scenario must not be run.
+	lru.WasmCache().Purge()
+
+	for _, spec := range scenario.Contracts {
+		if err := chain.deploy(spec, dir); err != nil {
+			return nil, fmt.Errorf("deploy %s: %v", spec.Name, err)
+		}
+	}
+	return chain, nil
+}
+
+func (c *testChain) deploy(spec testContractSpec, dir string) error {
+	code, err := ioutil.ReadFile(filepath.Join(dir, spec.Code))
+	if err != nil {
+		return err
+	}
+	abiJSON, err := ioutil.ReadFile(filepath.Join(dir, spec.Abi))
+	if err != nil {
+		return err
+	}
+	abi, err := bind.ParseWasmABI(string(abiJSON))
+	if err != nil {
+		return err
+	}
+	// The deployment is the RLP list [txType][code][abi] sent by vcl
+	input, err := rlp.EncodeToBytes([][]byte{common.Int64ToBytes(0), code, abiJSON})
+	if err != nil {
+		return err
+	}
+	cfg, err := c.config(spec.From, "", nil)
+	if err != nil {
+		return err
+	}
+	c.prepare()
+	_, address, _, err := runtime.Create(input, cfg)
+	if err != nil {
+		return err
+	}
+	c.contracts[spec.Name] = &testContract{address: address, abi: abi}
+	return nil
+}
+
+// config returns the runtime configuration of a transaction from the sender in
+// the block of the scenario overridden by the JSON fields of block.
+func (c *testChain) config(from, value string, block json.RawMessage) (*runtime.Config, error) {
+	b := c.block
+	b.Hashes = make(map[string]string)
+	for n, hash := range c.block.Hashes {
+		b.Hashes[n] = hash
+	}
+	if len(block) > 0 {
+		if err := json.Unmarshal(block, &b); err != nil {
+			return nil, fmt.Errorf("invalid block: %v", err)
+		}
+	}
+	cfg := &runtime.Config{
+		State:       c.state,
+		Origin:      c.from,
+		Coinbase:    common.HexToAddress(b.Coinbase),
+		BlockNumber: new(big.Int).SetUint64(b.Number),
+		Time:        new(big.Int).SetUint64(b.Timestamp),
+		GasLimit:    b.GasLimit,
+		GasPrice:    new(big.Int),
+		Value:       new(big.Int),
+		GetHashFn: func(n uint64) common.Hash {
+			return common.HexToHash(b.Hashes[strconv.FormatUint(n, 10)])
+		},
+	}
+	if from != "" {
+		cfg.Origin = common.HexToAddress(from)
+	}
+	if b.GasPrice != "" {
+		if _, ok := cfg.GasPrice.SetString(b.GasPrice, 0); !ok {
+			return nil, fmt.Errorf("invalid gas price %q", b.GasPrice)
+		}
+	}
+	if value != "" {
+		if _, ok := cfg.Value.SetString(value, 0); !ok {
+			return nil, fmt.Errorf("invalid value %q", value)
+		}
+	}
+	return cfg, nil
+}
+
+// prepare starts a new transaction, the logs it emits are kept apart.
+func (c *testChain) prepare() common.Hash {
+	c.txs++
+	hash := common.BigToHash(big.NewInt(int64(c.txs)))
+	c.state.Prepare(hash, common.Hash{}, c.txs)
+	return hash
+}
+
+// run calls the method of the case and checks the expectations.
+func (c *testChain) run(tc *testCase) *caseReport {
+	report := &caseReport{Name: tc.Name}
+	start := time.Now()
+	defer func() { report.Time = time.Since(start).Seconds() }()
+
+	if report.Name == "" {
+		report.Name = tc.Method
+	}
+	contract, ok := c.contracts[tc.Contract]
+	if !ok {
+		report.Failure = fmt.Sprintf("unknown contract %q", tc.Contract)
+		return report
+	}
+	fn, ok := contract.abi.Method(tc.Method)
+	if !ok {
+		report.Failure = fmt.Sprintf("unknown method %q", tc.Method)
+		return report
+	}
+	if len(tc.Args) != len(fn.Inputs) {
+		report.Failure = fmt.Sprintf("%s takes %d arguments, got %d", tc.Method, len(fn.Inputs), len(tc.Args))
+		return report
+	}
+	args := make([]interface{}, len(tc.Args))
+	for i, input := range fn.Inputs {
+		arg, err := c.convertArg(input.Type, tc.Args[i])
+		if err != nil {
+			report.Failure = fmt.Sprintf("argument %d: %v", i, err)
+			return report
+		}
+		args[i] = arg
+	}
+	input, err := contract.abi.Pack(tc.Method, args...)
+	if err != nil {
+		report.Failure = err.Error()
+		return report
+	}
+	cfg, err := c.config(tc.From, tc.Value, tc.Block)
+	if err != nil {
+		report.Failure = err.Error()
+		return report
+	}
+
+	hash := c.prepare()
+	output, leftOverGas, err := runtime.Call(contract.address, input, cfg)
+	report.GasUsed = cfg.GasLimit - leftOverGas
+
+	switch {
+	case err != nil && tc.Expect.Error == "":
+		report.Failure = fmt.Sprintf("call failed: %v", err)
+		return report
+	case err != nil && !strings.Contains(err.Error(), tc.Expect.Error):
+		report.Failure = fmt.Sprintf("error mismatch: have %q, want %q", err, tc.Expect.Error)
+		return report
+	case err == nil && tc.Expect.Error != "":
+		report.Failure = fmt.Sprintf("call succeeded, want error %q", tc.Expect.Error)
+		return report
+	case err != nil:
+		return report
+	}
+
+	if tc.Expect.Result != nil {
+		result, err := contract.abi.UnpackValue(tc.Method, output)
+		if err != nil {
+			report.Failure = fmt.Sprintf("unpack result: %v", err)
+			return report
+		}
+		if have, want := formatTestValue(result), c.formatExpected(tc.Expect.Result); have != want {
+			report.Failure = fmt.Sprintf("result mismatch: have %s, want %s", have, want)
+			return report
+		}
+	}
+	if tc.Expect.Events != nil {
+		if err := c.checkEvents(tc, c.state.GetLogs(hash)); err != nil {
+			report.Failure = err.Error()
+		}
+	}
+	return report
+}
+
+// checkEvents checks the logs emitted by the case against the expected events,
+// in order.
+func (c *testChain) checkEvents(tc *testCase, logs []*types.Log) error {
+	if len(logs) != len(tc.Expect.Events) {
+		return fmt.Errorf("emitted %d events, want %d", len(logs), len(tc.Expect.Events))
+	}
+	for i, want := range tc.Expect.Events {
+		name := want.Contract
+		if name == "" {
+			name = tc.Contract
+		}
+		contract, ok := c.contracts[name]
+		if !ok {
+			return fmt.Errorf("event %d: unknown contract %q", i, name)
+		}
+		log := logs[i]
+		if log.Address != contract.address || len(log.Topics) == 0 || log.Topics[0] != bind.WasmEventID(want.Name) {
+			return fmt.Errorf("event %d: have topic %x of %s, want %s of %s", i, log.Topics, log.Address.Hex(), want.Name, name)
+		}
+		if want.Args == nil {
+			continue
+		}
+		values, err := contract.abi.UnpackEventValues(want.Name, log.Data)
+		if err != nil {
+			return fmt.Errorf("event %d: %v", i, err)
+		}
+		if len(values) != len(want.Args) {
+			return fmt.Errorf("event %d: have %d arguments, want %d", i, len(values), len(want.Args))
+		}
+		for j, value := range values {
+			if have, want := formatTestValue(value), c.formatExpected(want.Args[j]); have != want {
+				return fmt.Errorf("event %d argument %d mismatch: have %s, want %s", i, j, have, want)
+			}
+		}
+	}
+	return nil
+}
+
+// convertArg converts the JSON value of an argument to the Go value packed by
+// the WASM ABI for the type.
+func (c *testChain) convertArg(t string, v interface{}) (interface{}, error) {
+	if s, ok := v.(string); ok {
+		v = c.resolve(s)
+	}
+	text := fmt.Sprint(v)
+	switch t {
+	case "string", "int128_s", "uint128_s", "int256_s", "uint256_s":
+		return text, nil
+	case "bool":
+		return strconv.ParseBool(text)
+	case "float32", "float64":
+		return strconv.ParseFloat(text, 64)
+	case "int128", "uint128":
+		n, ok := new(big.Int).SetString(text, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s %q", t, text)
+		}
+		return n, nil
+	case "float128":
+		return hexutil.Decode(text)
+	}
+	if strings.HasPrefix(t, "uint") {
+		return strconv.ParseUint(text, 0, 64)
+	}
+	return strconv.ParseInt(text, 0, 64)
+}
+
+// resolve replaces a "$name" reference to a deployed contract by its address.
+func (c *testChain) resolve(s string) string {
+	if contract, ok := c.contracts[strings.TrimPrefix(s, "$")]; ok && strings.HasPrefix(s, "$") {
+		return contract.address.Hex()
+	}
+	return s
+}
+
+func (c *testChain) formatExpected(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return formatTestValue(c.resolve(s))
+	}
+	return formatTestValue(v)
+}
+
+// formatTestValue formats the decoded and the expected values alike, the
+// numbers may be expected as JSON numbers or strings.
+func formatTestValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case []byte:
+		return hexutil.Encode(v)
+	}
+	return fmt.Sprint(v)
+}
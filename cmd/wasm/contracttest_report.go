@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+)
+
+// suiteReport is the result of the cases of a contract test scenario.
+type suiteReport struct {
+	Name  string        `json:"name"`
+	File  string        `json:"file"`
+	Time  float64       `json:"time"` // Seconds spent running the scenario
+	Cases []*caseReport `json:"cases"`
+}
+
+type caseReport struct {
+	Name    string  `json:"name"`
+	Failure string  `json:"failure,omitempty"`
+	GasUsed uint64  `json:"gasUsed"`
+	Time    float64 `json:"time"`
+}
+
+func (s *suiteReport) fail(name string, err error) {
+	s.Cases = append(s.Cases, &caseReport{Name: name, Failure: err.Error()})
+}
+
+func (s *suiteReport) failures() int {
+	failures := 0
+	for _, c := range s.Cases {
+		if c.Failure != "" {
+			failures++
+		}
+	}
+	return failures
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+func junitTime(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
+
+// writeReport writes the report of the suites in the format, junit or json, to
+// the file or to stdout.
+func writeReport(format, file string, suites []*suiteReport) error {
+	var w io.Writer = os.Stdout
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(suites)
+	case "junit":
+		report := junitSuites{}
+		for _, s := range suites {
+			suite := junitSuite{
+				Name:     s.Name,
+				Tests:    len(s.Cases),
+				Failures: s.failures(),
+				Time:     junitTime(s.Time),
+			}
+			for _, c := range s.Cases {
+				jc := junitCase{Name: c.Name, Classname: s.Name, Time: junitTime(c.Time)}
+				if c.Failure != "" {
+					jc.Failure = &junitFailure{Message: c.Failure}
+				}
+				suite.Cases = append(suite.Cases, jc)
+			}
+			report.Suites = append(report.Suites, suite)
+		}
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		enc := xml.NewEncoder(w)
+		enc.Indent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
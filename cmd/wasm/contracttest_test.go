@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+// The counter scenario deploys the contracts of testdata/contracttest, their
+// sources are the .wat files next to them. It covers a call emitting an event,
+// the call of the counter by the caller contract and a trapping call.
+const counterScenario = "testdata/contracttest/counter.json"
+
+// runContractTest runs the contracttest command with the report written to a
+// temporary file and returns the report.
+func runContractTest(t *testing.T, format string, args ...string) ([]byte, error) {
+	dir, err := ioutil.TempDir("", "contracttest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	reportFile := filepath.Join(dir, "report")
+
+	set := flag.NewFlagSet("contracttest", flag.ContinueOnError)
+	reportFlag.Apply(set)
+	reportFileFlag.Apply(set)
+	if err := set.Parse(append([]string{"--report", format, "--reportfile", reportFile}, args...)); err != nil {
+		t.Fatal(err)
+	}
+	cmdErr := contractTestCmd(cli.NewContext(cli.NewApp(), set, nil))
+
+	report, err := ioutil.ReadFile(reportFile)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	return report, cmdErr
+}
+
+func TestContractTestJSON(t *testing.T) {
+	report, err := runContractTest(t, "json", counterScenario)
+	if err != nil {
+		t.Fatalf("contract tests failed: %v\n%s", err, report)
+	}
+	var suites []*suiteReport
+	if err := json.Unmarshal(report, &suites); err != nil {
+		t.Fatalf("invalid json report: %v\n%s", err, report)
+	}
+	if len(suites) != 1 || suites[0].Name != "counter" || suites[0].File != counterScenario {
+		t.Fatalf("unexpected suites: %s", report)
+	}
+	var names []string
+	for _, c := range suites[0].Cases {
+		if c.Failure != "" {
+			t.Errorf("case %s failed: %s", c.Name, c.Failure)
+		}
+		if c.GasUsed == 0 {
+			t.Errorf("case %s used no gas", c.Name)
+		}
+		names = append(names, c.Name)
+	}
+	if have, want := strings.Join(names, ","), "add,call add,fail"; have != want {
+		t.Errorf("cases mismatch: have %s, want %s", have, want)
+	}
+}
+
+func TestContractTestJUnit(t *testing.T) {
+	report, err := runContractTest(t, "junit", counterScenario)
+	if err != nil {
+		t.Fatalf("contract tests failed: %v\n%s", err, report)
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(report, &suites); err != nil {
+		t.Fatalf("invalid junit report: %v\n%s", err, report)
+	}
+	if len(suites.Suites) != 1 {
+		t.Fatalf("unexpected suites: %s", report)
+	}
+	suite := suites.Suites[0]
+	if suite.Name != "counter" || suite.Tests != 3 || suite.Failures != 0 || len(suite.Cases) != 3 {
+		t.Fatalf("unexpected suite: %s", report)
+	}
+	for _, c := range suite.Cases {
+		if c.Classname != "counter" || c.Failure != nil {
+			t.Errorf("unexpected case: %+v", c)
+		}
+	}
+}
+
+func TestContractTestFailures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "contracttest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"counter.wasm", "counter.abi.json", "caller.wasm", "caller.abi.json"} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata/contracttest", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	scenario := `{
+		"name": "failures",
+		"contracts": [
+			{"name": "counter", "code": "counter.wasm", "abi": "counter.abi.json"},
+			{"name": "caller", "code": "caller.wasm", "abi": "caller.abi.json"}
+		],
+		"cases": [
+			{"name": "result", "contract": "counter", "method": "add", "args": [1, 2], "expect": {"result": 4}},
+			{"name": "event", "contract": "caller", "method": "callAdd", "args": ["$counter", 2, 3], "expect": {"events": [{"contract": "counter", "name": "Added", "args": [6]}]}},
+			{"name": "error", "contract": "counter", "method": "fail", "expect": {"error": "out of gas"}},
+			{"name": "success", "contract": "counter", "method": "add", "args": [1, 2], "expect": {"error": "unreachable"}}
+		]
+	}`
+	file := filepath.Join(dir, "failures.json")
+	if err := ioutil.WriteFile(file, []byte(scenario), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := runContractTest(t, "junit", file)
+	if err == nil || err.Error() != "4 of 4 contract tests failed" {
+		t.Fatalf("error mismatch: have %v, want 4 failures", err)
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(report, &suites); err != nil {
+		t.Fatalf("invalid junit report: %v\n%s", err, report)
+	}
+	if len(suites.Suites) != 1 || suites.Suites[0].Failures != 4 {
+		t.Fatalf("unexpected suites: %s", report)
+	}
+	for i, want := range []string{
+		"result mismatch: have 3, want 4",
+		"event 0 argument 0 mismatch: have 5, want 6",
+		"error mismatch",
+		`call succeeded, want error "unreachable"`,
+	} {
+		c := suites.Suites[0].Cases[i]
+		if c.Failure == nil || !strings.Contains(c.Failure.Message, want) {
+			t.Errorf("case %s: have failure %+v, want %q", c.Name, c.Failure, want)
+		}
+	}
+}
@@ -107,6 +107,7 @@ func init() {
 		runCommond,
 		unittestCommand,
 		benchmarkCommand,
+		contractTestCommand,
 	}
 }
 
@@ -0,0 +1,46 @@
+;; caller.wasm, callAdd calls add of the contract at the hex address target.
+(module
+  (import "env" "bcwasmCallInt64" (func $call (param i32 i32 i32) (result i64)))
+  (memory 1)
+  ;; RLP input [9][add][a][b] of the call, a and b are written at 79 and 88
+  (data (i32.const 64) "\df\88\00\00\00\00\00\00\00\09\83add\88\00\00\00\00\00\00\00\00\88\00\00\00\00\00\00\00\00")
+  (func $init)
+  (func $callAdd (param $target i32) (param $a i64) (param $b i64) (result i64)
+    (local $i i32)
+    ;; decode the 20 bytes of the "0x" prefixed target to 0
+    (local.set $i (i32.const 0))
+    (block
+      (loop
+        (i32.store8
+          (local.get $i)
+          (i32.or
+            (i32.shl
+              (call $nibble (i32.load8_u offset=2 (i32.add (local.get $target) (i32.shl (local.get $i) (i32.const 1)))))
+              (i32.const 4))
+            (call $nibble (i32.load8_u offset=3 (i32.add (local.get $target) (i32.shl (local.get $i) (i32.const 1)))))))
+        (br_if 0 (i32.lt_u (local.tee $i (i32.add (local.get $i) (i32.const 1))) (i32.const 20)))))
+    (i64.store8 (i32.const 79) (i64.shr_u (local.get $a) (i64.const 56)))
+    (i64.store8 (i32.const 80) (i64.shr_u (local.get $a) (i64.const 48)))
+    (i64.store8 (i32.const 81) (i64.shr_u (local.get $a) (i64.const 40)))
+    (i64.store8 (i32.const 82) (i64.shr_u (local.get $a) (i64.const 32)))
+    (i64.store8 (i32.const 83) (i64.shr_u (local.get $a) (i64.const 24)))
+    (i64.store8 (i32.const 84) (i64.shr_u (local.get $a) (i64.const 16)))
+    (i64.store8 (i32.const 85) (i64.shr_u (local.get $a) (i64.const 8)))
+    (i64.store8 (i32.const 86) (i64.shr_u (local.get $a) (i64.const 0)))
+    (i64.store8 (i32.const 88) (i64.shr_u (local.get $b) (i64.const 56)))
+    (i64.store8 (i32.const 89) (i64.shr_u (local.get $b) (i64.const 48)))
+    (i64.store8 (i32.const 90) (i64.shr_u (local.get $b) (i64.const 40)))
+    (i64.store8 (i32.const 91) (i64.shr_u (local.get $b) (i64.const 32)))
+    (i64.store8 (i32.const 92) (i64.shr_u (local.get $b) (i64.const 24)))
+    (i64.store8 (i32.const 93) (i64.shr_u (local.get $b) (i64.const 16)))
+    (i64.store8 (i32.const 94) (i64.shr_u (local.get $b) (i64.const 8)))
+    (i64.store8 (i32.const 95) (i64.shr_u (local.get $b) (i64.const 0)))
+    (call $call (i32.const 0) (i32.const 64) (i32.const 32)))
+  ;; nibble returns the value of the hex digit c
+  (func $nibble (param $c i32) (result i32)
+    (select
+      (i32.sub (local.get $c) (i32.const 48))
+      (i32.sub (i32.or (local.get $c) (i32.const 32)) (i32.const 87))
+      (i32.lt_u (local.get $c) (i32.const 58))))
+  (export "init" (func $init))
+  (export "callAdd" (func $callAdd)))
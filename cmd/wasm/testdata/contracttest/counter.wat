@@ -0,0 +1,26 @@
+;; counter.wasm, add emits the sum in an Added event and fail traps.
+(module
+  (import "env" "emitEvent" (func $emitEvent (param i32 i32 i32 i32)))
+  (memory 1)
+  (data (i32.const 16) "Added")
+  ;; RLP list of the 8 bytes big endian sum
+  (data (i32.const 32) "\c9\88")
+  (func $init)
+  (func $add (param $a i64) (param $b i64) (result i64)
+    (local $sum i64)
+    (local.set $sum (i64.add (local.get $a) (local.get $b)))
+    (i64.store8 (i32.const 34) (i64.shr_u (local.get $sum) (i64.const 56)))
+    (i64.store8 (i32.const 35) (i64.shr_u (local.get $sum) (i64.const 48)))
+    (i64.store8 (i32.const 36) (i64.shr_u (local.get $sum) (i64.const 40)))
+    (i64.store8 (i32.const 37) (i64.shr_u (local.get $sum) (i64.const 32)))
+    (i64.store8 (i32.const 38) (i64.shr_u (local.get $sum) (i64.const 24)))
+    (i64.store8 (i32.const 39) (i64.shr_u (local.get $sum) (i64.const 16)))
+    (i64.store8 (i32.const 40) (i64.shr_u (local.get $sum) (i64.const 8)))
+    (i64.store8 (i32.const 41) (i64.shr_u (local.get $sum) (i64.const 0)))
+    (call $emitEvent (i32.const 16) (i32.const 5) (i32.const 32) (i32.const 10))
+    (local.get $sum))
+  (func $fail
+    unreachable)
+  (export "init" (func $init))
+  (export "add" (func $add))
+  (export "fail" (func $fail)))
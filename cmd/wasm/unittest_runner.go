@@ -198,7 +198,7 @@ func runMain(wasm *exec.VirtualMachine) error {
 	return nil
 }
 
-func runModule(m *compiler.Module, functionCode []compiler.InterpreterCode, db *leveldb.DB, logStream *bytes.Buffer) error {
+func runModule(m *compiler.Module, functionCode []compiler.InterpreterCode, compiled *exec.CompiledModule, db *leveldb.DB, logStream *bytes.Buffer) error {
 	context := newContext(logStream)
 
 	wasm, err := exec.NewVirtualMachineWithModule(m, functionCode, context, newUnitTestResolver(db, logStream), nil)
@@ -206,6 +206,7 @@ func runModule(m *compiler.Module, functionCode []compiler.InterpreterCode, db *
 	if err != nil {
 		return err
 	}
+	wasm.Compiled = compiled
 	return runMain(wasm)
 }
 
@@ -479,6 +480,9 @@ func (s *stateDB) Caller() common.Address {
 func (s *stateDB) IsOwner(contractAddress common.Address, accountAddress common.Address) int64 {
 	return 0
 }
+func (s *stateDB) HasRole(account common.Address, role string) bool {
+	return false
+}
 func (s *stateDB) Address() common.Address {
 	return common.HexToAddress(s.state.Address)
 }
@@ -0,0 +1,105 @@
+// Package merkle implements the binary Merkle trees anchoring batches of
+// document hashes in the evidence contract.
+//
+// A leaf of the tree is keccak256(0x00 || hash) and an inner node is
+// keccak256(0x01 || left || right), the last node of a level with an odd
+// number of nodes is carried up to the next level unchanged.
+package merkle
+
+import (
+	"errors"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/crypto"
+)
+
+var (
+	ErrEmptyTree    = errors.New("merkle: no leaves")
+	ErrIndexInvalid = errors.New("merkle: leaf index out of range")
+)
+
+var (
+	leafPrefix = []byte{0}
+	nodePrefix = []byte{1}
+)
+
+// Root returns the root of the tree of the hashes.
+func Root(hashes []common.Hash) (common.Hash, error) {
+	if len(hashes) == 0 {
+		return common.Hash{}, ErrEmptyTree
+	}
+	level := leaves(hashes)
+	for len(level) > 1 {
+		level = nextLevel(level)
+	}
+	return level[0], nil
+}
+
+// Proof returns the siblings of the nodes on the path from the leaf of the hash
+// at index to the root, bottom up.
+func Proof(hashes []common.Hash, index int) ([]common.Hash, error) {
+	if index < 0 || index >= len(hashes) {
+		return nil, ErrIndexInvalid
+	}
+	var proof []common.Hash
+	level := leaves(hashes)
+	for len(level) > 1 {
+		if sibling := index ^ 1; sibling < len(level) {
+			proof = append(proof, level[sibling])
+		}
+		level = nextLevel(level)
+		index /= 2
+	}
+	return proof, nil
+}
+
+// Verify reports whether the hash is the leaf at index of a tree of count
+// leaves with the root.
+func Verify(root common.Hash, hash common.Hash, index, count uint64, proof []common.Hash) bool {
+	if index >= count {
+		return false
+	}
+	node := leafHash(hash)
+	for n := count; n > 1; n = (n + 1) / 2 {
+		if index^1 < n {
+			if len(proof) == 0 {
+				return false
+			}
+			if index%2 == 0 {
+				node = nodeHash(node, proof[0])
+			} else {
+				node = nodeHash(proof[0], node)
+			}
+			proof = proof[1:]
+		}
+		index /= 2
+	}
+	return len(proof) == 0 && node == root
+}
+
+func leaves(hashes []common.Hash) []common.Hash {
+	level := make([]common.Hash, len(hashes))
+	for i, hash := range hashes {
+		level[i] = leafHash(hash)
+	}
+	return level
+}
+
+func nextLevel(level []common.Hash) []common.Hash {
+	next := make([]common.Hash, 0, (len(level)+1)/2)
+	for i := 0; i+1 < len(level); i += 2 {
+		next = append(next, nodeHash(level[i], level[i+1]))
+	}
+	if len(level)%2 == 1 {
+		next = append(next, level[len(level)-1])
+	}
+	return next
+}
+
+func leafHash(hash common.Hash) common.Hash {
+	return crypto.Keccak256Hash(leafPrefix, hash[:])
+}
+
+func nodeHash(left, right common.Hash) common.Hash {
+	return crypto.Keccak256Hash(nodePrefix, left[:], right[:])
+}
@@ -0,0 +1,50 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/crypto"
+)
+
+func testHashes(n int) []common.Hash {
+	hashes := make([]common.Hash, n)
+	for i := range hashes {
+		hashes[i] = crypto.Keccak256Hash([]byte{byte(i), byte(i >> 8)})
+	}
+	return hashes
+}
+
+func TestProof(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 4, 5, 7, 8, 33, 100} {
+		hashes := testHashes(n)
+		root, err := Root(hashes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, hash := range hashes {
+			proof, err := Proof(hashes, i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !Verify(root, hash, uint64(i), uint64(n), proof) {
+				t.Errorf("%d leaves: proof of leaf %d doesn't verify", n, i)
+			}
+			if n > 1 && Verify(root, hash, uint64((i+1)%n), uint64(n), proof) {
+				t.Errorf("%d leaves: proof of leaf %d verifies at index %d", n, i, (i+1)%n)
+			}
+			if n > 1 && Verify(root, hashes[(i+1)%n], uint64(i), uint64(n), proof) {
+				t.Errorf("%d leaves: proof of leaf %d verifies another hash", n, i)
+			}
+		}
+	}
+}
+
+func TestRootEmpty(t *testing.T) {
+	if _, err := Root(nil); err != ErrEmptyTree {
+		t.Errorf("err = %v, want %v", err, ErrEmptyTree)
+	}
+	if _, err := Proof(testHashes(2), 2); err != ErrIndexInvalid {
+		t.Errorf("err = %v, want %v", err, ErrIndexInvalid)
+	}
+}
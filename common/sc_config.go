@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/Venachain/Venachain/common/bcwasmutil"
+	"github.com/Venachain/Venachain/event"
 )
 
 var (
@@ -41,6 +42,19 @@ type VRFParams struct {
 	ValidatorCount    uint64 `json:"validatorCount"`
 }
 
+// VRFElectionParams configures the committee election of the VRF. With all
+// fields zero the committee is made of the nodes of lowest VRF rank.
+type VRFElectionParams struct {
+	Weighted        bool     `json:"weighted"`                  // draw the seats in proportion to the node weights
+	GuaranteedSeats []string `json:"guaranteedSeats,omitempty"` // names of the nodes always on the committee
+	MinRotation     uint64   `json:"minRotation"`               // percentage of the drawn seats won by nodes out of the previous committee
+}
+
+// IsLegacy returns whether the election ranks the nodes by VRF only.
+func (p *VRFElectionParams) IsLegacy() bool {
+	return !p.Weighted && len(p.GuaranteedSeats) == 0 && p.MinRotation == 0
+}
+
 type SystemParameter struct {
 	BlockGasLimit                 int64
 	TxGasLimit                    int64
@@ -70,32 +84,46 @@ type SystemConfig struct {
 	HighsetNumber   *big.Int
 	ContractAddress map[string]Address
 	ReplayParam     *ReplayParam
+	nodeFeed        *event.Feed
+}
+
+// NodeSetChange is posted when the nodes registered in the node management
+// contract are refreshed.
+type NodeSetChange struct {
+	Revoked   []*NodeInfo // nodes deleted, disabled or unregistered by the refresh
+	Consensus []*NodeInfo // nodes becoming consensus nodes by the refresh
 }
 
-var SysCfg = &SystemConfig{
-	SystemConfigMu: &sync.RWMutex{},
-	Nodes:          make([]NodeInfo, 0),
-	nodeMap:        make(map[string]*NodeInfo),
-	ConsensusNodes: make([]*NodeInfo, 0),
-	DeleteNodes:    make([]*NodeInfo, 0),
-	HighsetNumber:  new(big.Int).SetInt64(0),
-	SysParam: &SystemParameter{
-		BlockGasLimit: 0xffffffffffff,
-		TxGasLimit:    100000000000000,
-		VRF: VRFParams{
-			ElectionEpoch:     0,
-			NextElectionBlock: 0,
-			ValidatorCount:    0,
+var SysCfg = NewSystemConfig()
+
+// NewSystemConfig returns a system config holding the default parameters.
+func NewSystemConfig() *SystemConfig {
+	return &SystemConfig{
+		SystemConfigMu: &sync.RWMutex{},
+		Nodes:          make([]NodeInfo, 0),
+		nodeMap:        make(map[string]*NodeInfo),
+		ConsensusNodes: make([]*NodeInfo, 0),
+		DeleteNodes:    make([]*NodeInfo, 0),
+		HighsetNumber:  new(big.Int).SetInt64(0),
+		nodeFeed:       new(event.Feed),
+		SysParam: &SystemParameter{
+			BlockGasLimit: 0xffffffffffff,
+			TxGasLimit:    100000000000000,
+			VRF: VRFParams{
+				ElectionEpoch:     0,
+				NextElectionBlock: 0,
+				ValidatorCount:    0,
+			},
+			IsBlockUseTrieHash: true,
+			IsUseDAG:           false,
+		},
+		ContractAddress: make(map[string]Address),
+		ReplayParam: &ReplayParam{
+			Pivot:           0,
+			OldSysContracts: make(map[Address]string),
+			OldSuperAdmin:   NullAddress,
 		},
-		IsBlockUseTrieHash: true,
-		IsUseDAG:           false,
-	},
-	ContractAddress: make(map[string]Address),
-	ReplayParam: &ReplayParam{
-		Pivot:           0,
-		OldSysContracts: make(map[Address]string),
-		OldSuperAdmin:   NullAddress,
-	},
+	}
 }
 
 func (sc *SystemConfig) IsProduceEmptyBlock() bool {
@@ -231,6 +259,49 @@ func (sc *SystemConfig) GenerateNodeData() {
 	}
 }
 
+// SetNodes replaces the registered nodes and posts the change to the
+// subscribers of SubscribeNodeSetChange.
+func (sc *SystemConfig) SetNodes(nodes []NodeInfo) NodeSetChange {
+	sc.SystemConfigMu.Lock()
+	previous := make(map[string]NodeInfo, len(sc.nodeMap))
+	for key, node := range sc.nodeMap {
+		previous[key] = *node
+	}
+	sc.Nodes = nodes
+	sc.GenerateNodeData()
+
+	var change NodeSetChange
+	for i, node := range sc.Nodes {
+		old, ok := previous[node.PublicKey]
+		delete(previous, node.PublicKey)
+		if node.Status != 1 {
+			if !ok || old.Status == 1 {
+				change.Revoked = append(change.Revoked, &sc.Nodes[i])
+			}
+		} else if node.Types == 1 && (!ok || old.Status != 1 || old.Types != 1) {
+			change.Consensus = append(change.Consensus, &sc.Nodes[i])
+		}
+	}
+	for _, old := range previous {
+		if old.Status == 1 {
+			node := old
+			change.Revoked = append(change.Revoked, &node)
+		}
+	}
+	sc.SystemConfigMu.Unlock()
+
+	if sc.nodeFeed != nil {
+		sc.nodeFeed.Send(change)
+	}
+	return change
+}
+
+// SubscribeNodeSetChange registers a subscription of the refreshes of the
+// registered nodes.
+func (sc *SystemConfig) SubscribeNodeSetChange(ch chan<- NodeSetChange) event.Subscription {
+	return sc.nodeFeed.Subscribe(ch)
+}
+
 func (sc *SystemConfig) GetNodeTypes(publicKey string) int32 {
 	sc.SystemConfigMu.RLock()
 	defer sc.SystemConfigMu.RUnlock()
@@ -241,6 +312,31 @@ func (sc *SystemConfig) GetNodeTypes(publicKey string) int32 {
 	return 0
 }
 
+// GetNodeInfo returns a copy of the registered node of publicKey, nil if the
+// node isn't registered.
+func (sc *SystemConfig) GetNodeInfo(publicKey string) *NodeInfo {
+	sc.SystemConfigMu.RLock()
+	defer sc.SystemConfigMu.RUnlock()
+
+	if node, ok := sc.nodeMap[publicKey]; ok {
+		info := *node
+		return &info
+	}
+	return nil
+}
+
+// IsValidLightNode checks whether publicKey is registered as a normal light
+// node, which may be served by the light servers.
+func (sc *SystemConfig) IsValidLightNode(publicKey string) bool {
+	sc.SystemConfigMu.RLock()
+	defer sc.SystemConfigMu.RUnlock()
+
+	if node, ok := sc.nodeMap[publicKey]; ok {
+		return node.Status == 1 && node.Types == 3
+	}
+	return false
+}
+
 func (sc *SystemConfig) IsBlockUseTrieHash() bool {
 	sc.SystemConfigMu.RLock()
 	defer sc.SystemConfigMu.RUnlock()
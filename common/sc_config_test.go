@@ -0,0 +1,88 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestSystemConfigNodeTypes(t *testing.T) {
+	sc := NewSystemConfig()
+	sc.Nodes = []NodeInfo{
+		{Name: "validator", PublicKey: "01", Types: 1, Status: 1},
+		{Name: "light", PublicKey: "03", Types: 3, Status: 1},
+		{Name: "deleted", PublicKey: "04", Types: 3, Status: 2},
+	}
+	sc.GenerateNodeData()
+
+	tests := []struct {
+		publicKey string
+		typ       int32
+		light     bool
+	}{
+		{"01", 1, false},
+		{"03", 3, true},
+		{"04", 3, false},
+		{"05", 0, false},
+	}
+	for _, test := range tests {
+		if typ := sc.GetNodeTypes(test.publicKey); typ != test.typ {
+			t.Errorf("%s: got type %d, want %d", test.publicKey, typ, test.typ)
+		}
+		if light := sc.IsValidLightNode(test.publicKey); light != test.light {
+			t.Errorf("%s: got light node %v, want %v", test.publicKey, light, test.light)
+		}
+	}
+
+	info := sc.GetNodeInfo("03")
+	if info == nil || info.Name != "light" {
+		t.Fatalf("got node info %+v", info)
+	}
+	info.Types = 1
+	if sc.GetNodeTypes("03") != 3 {
+		t.Error("node info isn't a copy")
+	}
+	if sc.GetNodeInfo("05") != nil {
+		t.Error("got node info of an unregistered node")
+	}
+}
+
+func TestSystemConfigSetNodes(t *testing.T) {
+	sc := NewSystemConfig()
+	changes := make(chan NodeSetChange, 2)
+	sub := sc.SubscribeNodeSetChange(changes)
+	defer sub.Unsubscribe()
+
+	sc.SetNodes([]NodeInfo{
+		{Name: "a", PublicKey: "0a", Types: 1, Status: 1},
+		{Name: "b", PublicKey: "0b", Types: 2, Status: 1},
+		{Name: "c", PublicKey: "0c", Types: 2, Status: 1},
+	})
+	change := <-changes
+	if len(change.Consensus) != 1 || change.Consensus[0].Name != "a" || len(change.Revoked) != 0 {
+		t.Fatalf("first refresh: got %+v", change)
+	}
+
+	// b is deleted, c promoted and a unregistered
+	sc.SetNodes([]NodeInfo{
+		{Name: "b", PublicKey: "0b", Types: 2, Status: 2},
+		{Name: "c", PublicKey: "0c", Types: 1, Status: 1},
+	})
+	change = <-changes
+	if len(change.Consensus) != 1 || change.Consensus[0].Name != "c" {
+		t.Fatalf("got consensus %+v", change.Consensus)
+	}
+	revoked := make(map[string]bool)
+	for _, node := range change.Revoked {
+		revoked[node.Name] = true
+	}
+	if len(revoked) != 2 || !revoked["a"] || !revoked["b"] {
+		t.Fatalf("got revoked %+v", change.Revoked)
+	}
+	if sc.IsValidJoinNode("0b") || !sc.IsValidJoinNode("0c") {
+		t.Error("node map not regenerated")
+	}
+
+	// an unchanged refresh changes nothing
+	if change := sc.SetNodes(sc.Nodes); len(change.Revoked) != 0 || len(change.Consensus) != 0 {
+		t.Errorf("unchanged refresh: got %+v", change)
+	}
+}
@@ -19,6 +19,8 @@ var (
 	EvidenceManagementAddress    = common.HexToAddress("0x0000000000000000000000000000000000000099") // The Venachain Precompiled contract addr for evidence management
 	BulletProofAddress           = common.HexToAddress("0x0000000000000000000000000000000000000100") // The Venachain Precompiled contract addr for Bullet proof
 	PaillierAddress              = common.HexToAddress("0x0000000000000000000000000000000000000101") // The Venachain Precompiled contract addr for Paillier
+	SponsorManagementAddress     = common.HexToAddress("0x1000000000000000000000000000000000000008") // The Venachain Precompiled contract addr for fee sponsor limits
+	ProposalManagementAddress    = common.HexToAddress("0x1000000000000000000000000000000000000009") // The Venachain Precompiled contract addr for governance proposals
 )
 
 type UpdateNode struct {
@@ -28,6 +30,7 @@ type UpdateNode struct {
 	Status *uint32 `json:"status,omitempty,required"`
 	// delay set validatorSet
 	DelayNum *uint64 `json:"delayNum,omitempty"` //共识节点延迟设置的区块高度 (可选, 默认实时设置)
+	Weight   *uint64 `json:"weight,omitempty"`   //共识节点在VRF选举中的权重
 }
 
 func (un *UpdateNode) SetStatus(status uint32) {
@@ -57,6 +60,8 @@ type NodeInfo struct {
 	P2pPort    uint32 `json:"p2pPort,required"`
 	// delay set validatorSet
 	DelayNum uint64 `json:"delayNum,omitempty"` //共识节点延迟设置的区块高度 (可选, 默认实时设置)
+	// 节点在VRF选举中的权重，单独存储，不参与节点信息的rlp编码
+	Weight uint64 `json:"weight,omitempty" rlp:"-"`
 }
 
 func (node *NodeInfo) String() string {
@@ -114,6 +114,19 @@ type Handler interface {
 	SetBroadcaster(Broadcaster)
 }
 
+// SnapVerifier should be implemented if the consensus can verify the headers
+// and the pivot block of a snapshot sync, whose ancestors are not replayed
+type SnapVerifier interface {
+	// VerifySnapHeader checks the seals of a header without the state of its
+	// parent, against the validators trusted from the genesis on. The caller
+	// may pass in a batch of parents (ascending order) not inserted yet.
+	VerifySnapHeader(chain ChainReader, header *types.Header, parents []*types.Header) error
+
+	// VerifySnapPivot checks that the validators recorded in the state of the
+	// parent of a verified header, the pivot block, are those the header carries
+	VerifySnapPivot(chain ChainReader, header *types.Header) error
+}
+
 // Iris is a consensus engine to avoid byzantine failure
 type Iris interface {
 	Engine
@@ -125,4 +138,8 @@ type Iris interface {
 
 	// Stop stops the engine
 	Stop() error
+
+	// SetSystemConfig makes the engine read the system contract config of
+	// its chain from cfg instead of common.SysCfg.
+	SetSystemConfig(cfg *common.SystemConfig)
 }
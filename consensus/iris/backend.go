@@ -79,4 +79,7 @@ type Backend interface {
 
 	//GetConsensusStartTime get consensus start time
 	GetConsensusStartTime() uint64
+
+	// SystemConfig returns the system contract config of the sealed chain
+	SystemConfig() *common.SystemConfig
 }
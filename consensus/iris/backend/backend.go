@@ -52,6 +52,7 @@ const (
 func New(config *params.IstanbulConfig, privateKey *ecdsa.PrivateKey, db dbhandle.Database) consensus.Iris {
 	// Allocate the snapshot caches and create the engine
 	recents, _ := lru.NewARC(inmemorySnapshots)
+	syncRecents, _ := lru.NewARC(inmemorySnapshots)
 	recentMessages, _ := lru.NewARC(inmemoryPeers)
 	knownMessages, _ := lru.NewARC(inmemoryMessages)
 
@@ -71,6 +72,7 @@ func New(config *params.IstanbulConfig, privateKey *ecdsa.PrivateKey, db dbhandl
 		db:               db,
 		commitCh:         make(chan *types.Block, 1),
 		recents:          recents,
+		syncRecents:      syncRecents,
 		candidates:       make(map[common.Address]bool),
 		coreStarted:      false,
 		recentMessages:   recentMessages,
@@ -122,10 +124,14 @@ type backend struct {
 	candidatesLock sync.RWMutex
 	// Snapshots for recent block to speed up reorgs
 	recents *lru.ARCCache
+	// Validators a snapshot sync verified the recent headers with
+	syncRecents *lru.ARCCache
 
 	// event subscription for ChainHeadEvent event
 	broadcaster consensus.Broadcaster
 
+	sysConfig *common.SystemConfig // System contract config of the chain, common.SysCfg if nil
+
 	recentMessages *lru.ARCCache // the cache of peer's messages
 	knownMessages  *lru.ARCCache // the cache of self messages
 
@@ -307,7 +313,7 @@ func (sb *backend) Commit(proposal iris.Proposal, seals [][]byte) error {
 	// update block's header
 	block = block.WithSeal(h)
 	isEmpty := block.Transactions().Len() == 0
-	isProduceEmptyBlock := common.SysCfg.IsProduceEmptyBlock()
+	isProduceEmptyBlock := sb.SystemConfig().IsProduceEmptyBlock()
 
 	if !isEmpty || isProduceEmptyBlock {
 		//post commit event
@@ -403,6 +409,19 @@ func (sb *backend) GetConsensusStartTime() uint64 {
 	return sb.consensusStartTime.Load()
 }
 
+// SetSystemConfig implements consensus.Iris.SetSystemConfig
+func (sb *backend) SetSystemConfig(cfg *common.SystemConfig) {
+	sb.sysConfig = cfg
+}
+
+// SystemConfig implements iris.Backend.SystemConfig
+func (sb *backend) SystemConfig() *common.SystemConfig {
+	if sb.sysConfig == nil {
+		return common.SysCfg
+	}
+	return sb.sysConfig
+}
+
 // makeCurrent creates a new environment for the current cycle.
 func (sb *backend) makeCurrent(parentRoot common.Hash, header *types.Header) error {
 	var (
@@ -544,7 +563,7 @@ func (sb *backend) Verify(proposal iris.Proposal, isProposer bool) (time.Duratio
 
 // Sign implements iris.Backend.Sign
 func (sb *backend) Sign(data []byte) ([]byte, error) {
-	hashData := crypto.Keccak256([]byte(data))
+	hashData := crypto.SignatureHash([]byte(data))
 	return crypto.Sign(hashData, sb.privateKey)
 }
 
@@ -89,7 +89,7 @@ func getCandidateNodesAtNumber(chain consensus.ChainReader, sb *backend, number
 	}
 
 	log.Trace("call system contract", "nodesLength", len(nodes))
-	return common.SysCfg.GetConsensusNodesFilterDelay(number, nodes)
+	return sb.SystemConfig().GetConsensusNodesFilterDelay(number, nodes)
 }
 
 func ParseResultToExtractType(res []byte, v interface{}) interface{} {
@@ -84,6 +84,11 @@ var (
 	errInvalidCommittedSeals = errors.New("invalid committed seals")
 	// errEmptyCommittedSeals is returned if the field of committed seals is zero.
 	errEmptyCommittedSeals = errors.New("zero committed seals")
+	// errEmptyValidators is returned if no validator is recorded in the state of a snapshot sync pivot.
+	errEmptyValidators = errors.New("no validators in the pivot state")
+	// errUntrustedPivotState is returned if the validators recorded in the state of a snapshot sync pivot
+	// aren't those its verified child header carries.
+	errUntrustedPivotState = errors.New("pivot state validators mismatch the verified headers")
 	// errMismatchTxhashes is returned if the TxHash in header is mismatch.
 	errMismatchTxhashes = errors.New("mismatch transcations hashes")
 )
@@ -111,7 +116,7 @@ func (sb *backend) Author(header *types.Header) (common.Address, error) {
 // given engine. Verifying the seal may be done optionally here, or explicitly
 // via the VerifySeal method.
 func (sb *backend) VerifyHeader(chain consensus.ChainReader, header *types.Header, seal bool) error {
-	if header.Number.Uint64() <= common.SysCfg.ReplayParam.Pivot {
+	if header.Number.Uint64() <= sb.SystemConfig().ReplayParam.Pivot {
 		return nil
 	}
 	return sb.verifyHeader(chain, header, nil)
@@ -269,7 +274,13 @@ func (sb *backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 	if err != nil {
 		return err
 	}
+	return sb.checkCommittedSeals(header, snap)
+}
 
+// checkCommittedSeals checks whether a quorum of the validators of the parent
+// snapshot signed the committed seals of the header
+func (sb *backend) checkCommittedSeals(header *types.Header, snap *Snapshot) error {
+	number := header.Number.Uint64()
 	extra, err := types.ExtractIrisExtra(header)
 	if err != nil {
 		return err
@@ -310,6 +321,198 @@ func (sb *backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 	return nil
 }
 
+// VerifySnapHeader implements consensus.SnapVerifier. The states below the
+// pivot of a snapshot sync are unknown, the validators are walked forward from
+// the genesis instead: a header is sealed by the validators it carries, and a
+// change of the validators is sealed by a quorum of the previous ones too.
+func (sb *backend) VerifySnapHeader(chain consensus.ChainReader, header *types.Header, parents []*types.Header) error {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return errUnknownBlock
+	}
+	parent, err := sb.syncSnapshot(chain, number-1, header.ParentHash, parents)
+	if err != nil {
+		return err
+	}
+	snap, err := sb.applySyncHeader(parent, header)
+	if err != nil {
+		return err
+	}
+	sb.syncRecents.Add(snap.Hash, snap)
+	if number%checkpointInterval == 0 {
+		return snap.storeSync(sb.db)
+	}
+	return nil
+}
+
+// VerifySnapPivot implements consensus.SnapVerifier. The validators recorded
+// in the downloaded state of the pivot block, the parent of the header, must
+// be those the verified header carries. They are cached as the snapshot of the
+// pivot so the later blocks are verified without replaying the chain below it.
+func (sb *backend) VerifySnapPivot(chain consensus.ChainReader, header *types.Header) error {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return errUnknownBlock
+	}
+	trusted, err := sb.syncSnapshot(chain, number, header.Hash(), nil)
+	if err != nil {
+		return err
+	}
+	nodes, err := getConsensusNodesList(chain, sb, number-1)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return errEmptyValidators
+	}
+	addrs, err := nodeAddresses(nodes)
+	if err != nil {
+		return err
+	}
+	snap := newSnapshot(number-1, header.ParentHash, validator.NewSet(addrs, sb.config.ProposerPolicy))
+	if !sameValidators(snap.validators(), trusted.validators()) {
+		return errUntrustedPivotState
+	}
+	if err := sb.checkCommittedSeals(header, snap); err != nil {
+		return err
+	}
+	sb.recents.Add(snap.Hash, snap)
+	return snap.store(sb.db)
+}
+
+// syncSnapshot retrieves the validators a snapshot sync verified the header
+// of the number and hash with, verifying the missing headers from the last
+// known ones, or the genesis, on.
+func (sb *backend) syncSnapshot(chain consensus.ChainReader, number uint64, hash common.Hash, parents []*types.Header) (*Snapshot, error) {
+	var (
+		headers []*types.Header
+		snap    *Snapshot
+	)
+	for snap == nil {
+		if s, ok := sb.syncRecents.Get(hash); ok {
+			snap = s.(*Snapshot)
+			break
+		}
+		if number%checkpointInterval == 0 {
+			if s, err := loadSyncSnapshot(sb.db, hash); err == nil {
+				snap = s
+				break
+			}
+		}
+		// The genesis validators are configured locally, the trust anchor
+		if number == 0 {
+			s, err := sb.snapshot(chain, 0, hash, nil)
+			if err != nil {
+				return nil, err
+			}
+			snap = s
+			break
+		}
+		var header *types.Header
+		if len(parents) > 0 {
+			header = parents[len(parents)-1]
+			if header.Hash() != hash || header.Number.Uint64() != number {
+				return nil, consensus.ErrUnknownAncestor
+			}
+			parents = parents[:len(parents)-1]
+		} else {
+			header = chain.GetHeader(hash, number)
+			if header == nil {
+				return nil, consensus.ErrUnknownAncestor
+			}
+		}
+		headers = append(headers, header)
+		number, hash = number-1, header.ParentHash
+	}
+	for i := len(headers) - 1; i >= 0; i-- {
+		var err error
+		if snap, err = sb.applySyncHeader(snap, headers[i]); err != nil {
+			return nil, err
+		}
+		sb.syncRecents.Add(snap.Hash, snap)
+	}
+	return snap, nil
+}
+
+// applySyncHeader checks the seals of the header against the validators it
+// carries, and against the validators of its parent if they changed, and
+// returns the snapshot of the validators that sealed it.
+func (sb *backend) applySyncHeader(parent *Snapshot, header *types.Header) (*Snapshot, error) {
+	number := header.Number.Uint64()
+	if number != parent.Number+1 || header.ParentHash != parent.Hash {
+		return nil, errInvalidVotingChain
+	}
+	// The replayed blocks aren't sealed, see VerifyHeader
+	if number <= sb.SystemConfig().ReplayParam.Pivot {
+		return newSnapshot(number, header.Hash(), parent.ValSet.Copy()), nil
+	}
+	extra, err := types.ExtractIrisExtra(header)
+	if err != nil {
+		return nil, err
+	}
+	snap := newSnapshot(number, header.Hash(), parent.ValSet.Copy())
+	if len(extra.Validators) > 0 {
+		snap.ValSet = validator.NewSet(extra.Validators, parent.ValSet.Policy())
+	}
+
+	signer, err := ecrecover(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, v := snap.ValSet.GetByAddress(signer); v == nil {
+		return nil, errUnauthorized
+	}
+	if err := sb.checkCommittedSeals(header, snap); err != nil {
+		return nil, err
+	}
+	if !sameValidators(snap.validators(), parent.validators()) {
+		sealed, err := countCommittedSeals(header, parent.ValSet)
+		if err != nil {
+			return nil, err
+		}
+		if sealed < parent.ValSet.Size()-parent.ValSet.F() {
+			log.Error("Validators changed without a quorum of the previous ones", "number", number, "hash", header.Hash(), "sealed", sealed)
+			return nil, errInvalidCommittedSeals
+		}
+	}
+	return snap, nil
+}
+
+// countCommittedSeals returns the number of committed seals of the header
+// signed by the validators of the set.
+func countCommittedSeals(header *types.Header, valSet iris.ValidatorSet) (int, error) {
+	extra, err := types.ExtractIrisExtra(header)
+	if err != nil {
+		return 0, err
+	}
+	proposalSeal := irisCore.PrepareCommittedSeal(header.Hash())
+	validators := valSet.Copy()
+	sealed := 0
+	for _, seal := range extra.CommittedSeal {
+		addr, err := iris.GetSignatureAddress(proposalSeal, seal)
+		if err != nil {
+			return 0, errInvalidSignature
+		}
+		if validators.RemoveValidator(addr) {
+			sealed++
+		}
+	}
+	return sealed, nil
+}
+
+// sameValidators checks whether the sorted validator lists are equal
+func sameValidators(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // VerifySeal checks whether the crypto seal on a header is valid according to
 // the consensus rules of the given engine.
 func (sb *backend) VerifySeal(chain consensus.ChainReader, header *types.Header) error {
@@ -0,0 +1,98 @@
+package backend
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/consensus/iris"
+	irisCore "github.com/Venachain/Venachain/consensus/iris/core"
+	"github.com/Venachain/Venachain/consensus/iris/validator"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/crypto"
+)
+
+// makeSnapHeader creates a child of the parent carrying the validators, signed
+// by the proposer and committed by the sealers.
+func makeSnapHeader(t *testing.T, parent *types.Header, vals []common.Address, proposer *ecdsa.PrivateKey, sealers []*ecdsa.PrivateKey) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       new(big.Int).Add(parent.Time, common.Big1),
+		MixDigest:  types.IrisDigest,
+	}
+	extra, err := prepareExtra(header, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.Extra = extra
+
+	sig, err := crypto.Sign(crypto.SignatureHash(sigHash(header).Bytes()), proposer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeSeal(header, sig); err != nil {
+		t.Fatal(err)
+	}
+	seals := make([][]byte, len(sealers))
+	for i, key := range sealers {
+		if seals[i], err = crypto.Sign(crypto.SignatureHash(irisCore.PrepareCommittedSeal(header.Hash())), key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writeCommittedSeals(header, seals); err != nil {
+		t.Fatal(err)
+	}
+	return header
+}
+
+func TestApplySyncHeader(t *testing.T) {
+	keys := make([]*ecdsa.PrivateKey, 8)
+	addrs := make([]common.Address, len(keys))
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	sysConfig := common.NewSystemConfig()
+	sysConfig.ReplayParam = &common.ReplayParam{}
+	sb := &backend{sysConfig: sysConfig}
+
+	genesis := &types.Header{Number: big.NewInt(0), Time: big.NewInt(0), MixDigest: types.IrisDigest}
+	trusted := newSnapshot(0, genesis.Hash(), validator.NewSet(addrs[:4], iris.RoundRobin))
+
+	// the validators are unchanged, a quorum of them sealed the header
+	header := makeSnapHeader(t, genesis, addrs[:4], keys[0], keys[:3])
+	snap, err := sb.applySyncHeader(trusted, header)
+	if err != nil {
+		t.Fatalf("unchanged validators: %v", err)
+	}
+	if snap.Number != 1 || snap.Hash != header.Hash() || !sameValidators(snap.validators(), trusted.validators()) {
+		t.Fatalf("unexpected snapshot: %d %x %v", snap.Number, snap.Hash, snap.validators())
+	}
+	if _, err := sb.applySyncHeader(trusted, makeSnapHeader(t, genesis, addrs[:4], keys[0], keys[:2])); err != errInvalidCommittedSeals {
+		t.Fatalf("no quorum: have %v, want %v", err, errInvalidCommittedSeals)
+	}
+	if _, err := sb.applySyncHeader(trusted, makeSnapHeader(t, genesis, addrs[:4], keys[4], keys[:3])); err != errUnauthorized {
+		t.Fatalf("foreign proposer: have %v, want %v", err, errUnauthorized)
+	}
+	if _, err := sb.applySyncHeader(snap, makeSnapHeader(t, genesis, addrs[:4], keys[0], keys[:3])); err != errInvalidVotingChain {
+		t.Fatalf("unlinked header: have %v, want %v", err, errInvalidVotingChain)
+	}
+
+	// a validator replaced with the seals of a quorum of the previous ones
+	changed := append(append([]common.Address{}, addrs[:3]...), addrs[4])
+	next, err := sb.applySyncHeader(snap, makeSnapHeader(t, header, changed, keys[4], []*ecdsa.PrivateKey{keys[0], keys[1], keys[2], keys[4]}))
+	if err != nil {
+		t.Fatalf("validator replaced: %v", err)
+	}
+	if _, v := next.ValSet.GetByAddress(addrs[4]); v == nil {
+		t.Fatalf("replacing validator missing: %v", next.validators())
+	}
+
+	// the validators forged by a set of unknown nodes
+	forged := makeSnapHeader(t, header, addrs[4:], keys[4], keys[4:])
+	if _, err := sb.applySyncHeader(snap, forged); err != errInvalidCommittedSeals {
+		t.Fatalf("forged validators: have %v, want %v", err, errInvalidCommittedSeals)
+	}
+}
@@ -26,12 +26,14 @@ import (
 	"github.com/Venachain/Venachain/consensus/iris/validator"
 	"github.com/Venachain/Venachain/core/types"
 	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/p2p/discover"
 	"github.com/Venachain/Venachain/params"
 	"github.com/Venachain/Venachain/venadb/dbhandle"
 )
 
 const (
-	dbKeySnapshotPrefix = "iris-snapshot"
+	dbKeySnapshotPrefix     = "iris-snapshot"
+	dbKeySyncSnapshotPrefix = "iris-sync-snapshot"
 )
 
 // Vote represents a single vote that an authorized validator made to modify the
@@ -77,7 +79,17 @@ func newSnapshot(number uint64, hash common.Hash, valSet iris.ValidatorSet) *Sna
 
 // loadSnapshot loads an existing snapshot from the database.
 func loadSnapshot(db dbhandle.Database, hash common.Hash) (*Snapshot, error) {
-	blob, err := db.Get(append([]byte(dbKeySnapshotPrefix), hash[:]...))
+	return loadSnapshotWithPrefix(db, dbKeySnapshotPrefix, hash)
+}
+
+// loadSyncSnapshot loads the validators a snapshot sync verified the header of
+// the hash with from the database.
+func loadSyncSnapshot(db dbhandle.Database, hash common.Hash) (*Snapshot, error) {
+	return loadSnapshotWithPrefix(db, dbKeySyncSnapshotPrefix, hash)
+}
+
+func loadSnapshotWithPrefix(db dbhandle.Database, prefix string, hash common.Hash) (*Snapshot, error) {
+	blob, err := db.Get(append([]byte(prefix), hash[:]...))
 	if err != nil {
 		return nil, err
 	}
@@ -92,11 +104,21 @@ func loadSnapshot(db dbhandle.Database, hash common.Hash) (*Snapshot, error) {
 
 // store inserts the snapshot into the database.
 func (s *Snapshot) store(db dbhandle.Database) error {
+	return s.storeWithPrefix(db, dbKeySnapshotPrefix)
+}
+
+// storeSync inserts the validators a snapshot sync verified the header with
+// into the database.
+func (s *Snapshot) storeSync(db dbhandle.Database) error {
+	return s.storeWithPrefix(db, dbKeySyncSnapshotPrefix)
+}
+
+func (s *Snapshot) storeWithPrefix(db dbhandle.Database, prefix string) error {
 	blob, err := json.Marshal(s)
 	if err != nil {
 		return err
 	}
-	return db.Put(append([]byte(dbKeySnapshotPrefix), s.Hash[:]...), blob)
+	return db.Put(append([]byte(prefix), s.Hash[:]...), blob)
 }
 
 // copy creates a deep copy of the snapshot, though not the individual votes.
@@ -187,15 +209,9 @@ func (s *Snapshot) apply(chain consensus.ChainReader, sb *backend, headers []*ty
 		return snap, nil
 	}
 
-	addrs := make([]common.Address, len(validatorNodesList))
-	for index, valNode := range validatorNodesList {
-		pub, err := valNode.Pubkey()
-		if err != nil {
-			return nil, err
-		}
-		addr := crypto.PubkeyToAddress(*pub)
-		addrs[index] = addr
-
+	addrs, err := nodeAddresses(validatorNodesList)
+	if err != nil {
+		return nil, err
 	}
 	newValSet := validator.NewSet(addrs, snap.ValSet.Policy())
 	snap.ValSet = newValSet
@@ -206,6 +222,19 @@ func (s *Snapshot) apply(chain consensus.ChainReader, sb *backend, headers []*ty
 	return snap, nil
 }
 
+// nodeAddresses returns the addresses of the validator nodes.
+func nodeAddresses(nodes []discover.NodeID) ([]common.Address, error) {
+	addrs := make([]common.Address, len(nodes))
+	for index, valNode := range nodes {
+		pub, err := valNode.Pubkey()
+		if err != nil {
+			return nil, err
+		}
+		addrs[index] = crypto.PubkeyToAddress(*pub)
+	}
+	return addrs, nil
+}
+
 // validators retrieves the list of authorized validators in ascending order.
 func (s *Snapshot) validators() []common.Address {
 	validators := make([]common.Address, 0, s.ValSet.Size())
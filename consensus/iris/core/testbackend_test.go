@@ -149,6 +149,10 @@ func (self *testSystemBackend) HasPropsal(hash common.Hash, number *big.Int) boo
 	return number.Cmp(big.NewInt(5)) == 0
 }
 
+func (self *testSystemBackend) SystemConfig() *common.SystemConfig {
+	return common.SysCfg
+}
+
 func (self *testSystemBackend) GetProposer(number uint64) common.Address {
 	return common.Address{}
 }
@@ -34,8 +34,8 @@ func RLPHash(v interface{}) (h common.Hash) {
 }
 
 func GetSignaturePubkey(data []byte, sig []byte) (*ecdsa.PublicKey, error) {
-	// 1. Keccak data
-	hashData := crypto.Keccak256([]byte(data))
+	// 1. Hash data with the digest of the crypto suite
+	hashData := crypto.SignatureHash([]byte(data))
 	// 2. Recover public key
 	pubkey, err := crypto.SigToPub(hashData, sig)
 	if err != nil {
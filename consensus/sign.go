@@ -65,8 +65,7 @@ func Ecrecover(header *types.Header, sigcache *lru.ARCCache) (common.Address, er
 	if err != nil {
 		return common.Address{}, err
 	}
-	var signer common.Address
-	copy(signer[:], crypto.Keccak256(pubkey[1:])[12:])
+	signer := crypto.PubkeyBytesToAddress(pubkey[1:])
 
 	sigcache.Add(hash, signer)
 	return signer, nil
@@ -136,12 +136,23 @@ type BlockChain struct {
 
 	badBlocks      *lru.Cache              // Bad block cache
 	shouldPreserve func(*types.Block) bool // Function used to determine whether should preserve the given block.
+
+	sysConfig *common.SystemConfig // System contract config loaded on block insertion, common.SysCfg if nil
+	recentTxs *txReplayCache       // Transactions included in the most recent blocks
 }
 
 // NewBlockChain returns a fully initialised block chain using information
 // available in the database. It initialises the default Ethereum Validator and
 // Processor.
 func NewBlockChain(db dbhandle.Database, extdb dbhandle.Database, cacheConfig *CacheConfig, chainConfig *params.ChainConfig, engine consensus.Engine, vmConfig vm.Config, shouldPreserve func(block *types.Block) bool) (*BlockChain, types.Blocks, error) {
+	return NewBlockChainWithSystemConfig(db, extdb, cacheConfig, chainConfig, engine, vmConfig, shouldPreserve, nil)
+}
+
+// NewBlockChainWithSystemConfig is NewBlockChain loading the system contract
+// config into sysConfig instead of common.SysCfg. The config is set before the
+// genesis and head blocks are loaded so that they never reach the global one,
+// it's used by the group ledgers hosted next to the main chain.
+func NewBlockChainWithSystemConfig(db dbhandle.Database, extdb dbhandle.Database, cacheConfig *CacheConfig, chainConfig *params.ChainConfig, engine consensus.Engine, vmConfig vm.Config, shouldPreserve func(block *types.Block) bool, sysConfig *common.SystemConfig) (*BlockChain, types.Blocks, error) {
 	if cacheConfig == nil {
 		cacheConfig = &CacheConfig{
 			TrieNodeLimit: 256 * 1024 * 1024,
@@ -171,6 +182,8 @@ func NewBlockChain(db dbhandle.Database, extdb dbhandle.Database, cacheConfig *C
 		engine:         engine,
 		vmConfig:       vmConfig,
 		badBlocks:      badBlocks,
+		recentTxs:      newTxReplayCache(params.TxValidityMaxBlocks),
+		sysConfig:      sysConfig,
 	}
 	bc.SetValidator(NewBlockValidator(chainConfig, bc, engine))
 	bc.SetProcessor(NewStateProcessor(chainConfig, bc, engine))
@@ -202,6 +215,8 @@ func NewBlockChain(db dbhandle.Database, extdb dbhandle.Database, cacheConfig *C
 			}
 		}
 	}
+	bc.loadRecentTxs()
+
 	// Take ownership of this particular state
 	go bc.update()
 	go bc.receiptsLoop()
@@ -337,10 +352,26 @@ func (bc *BlockChain) FastSyncCommitHead(hash common.Hash) error {
 	bc.currentBlock.Store(block)
 	bc.mu.Unlock()
 
+	// The blocks below the head were not replayed, load the system contract
+	// configure from the synced state
+	UpdateSysContractConfig(bc, bc.SystemConfig())
+	bc.SystemConfig().HighsetNumber = block.Number()
+
 	log.Info("Committed new head block", "number", block.Number(), "hash", hash)
 	return nil
 }
 
+// VerifySnapPivot verifies the parent of the header, the pivot block of a
+// snapshot sync: the validators recorded in its downloaded state must be those
+// the verified header carries.
+func (bc *BlockChain) VerifySnapPivot(header *types.Header) error {
+	verifier, ok := bc.engine.(consensus.SnapVerifier)
+	if !ok {
+		return ErrSnapSyncUnsupported
+	}
+	return verifier.VerifySnapPivot(bc, header)
+}
+
 // GasLimit returns the gas limit of the current HEAD block.
 func (bc *BlockChain) GasLimit() uint64 {
 	return bc.CurrentBlock().GasLimit()
@@ -485,32 +516,64 @@ func (bc *BlockChain) insert(block *types.Block) {
 		bc.currentFastBlock.Store(block)
 	}
 
+	bc.recentTxs.add(block.NumberU64(), block.Transactions())
+
 	//load system contract configure
 	bc.UpdateSystemConfig(block)
 }
 
+// loadRecentTxs fills the replay cache with the transactions of the blocks
+// below the current head.
+func (bc *BlockChain) loadRecentTxs() {
+	head := bc.CurrentBlock().NumberU64()
+	from := uint64(0)
+	if head >= params.TxValidityMaxBlocks {
+		from = head - params.TxValidityMaxBlocks + 1
+	}
+	for number := from; number <= head; number++ {
+		if block := bc.GetBlockByNumber(number); block != nil {
+			bc.recentTxs.add(number, block.Transactions())
+		}
+	}
+}
+
+// HasRecentTransaction reports whether the transaction was included in one of
+// the last params.TxValidityMaxBlocks blocks.
+func (bc *BlockChain) HasRecentTransaction(hash common.Hash) bool {
+	return bc.recentTxs.contains(hash)
+}
+
 func (bc *BlockChain) UpdateSystemConfig(block *types.Block) {
-	common.SysCfg.HighsetNumber = block.Number()
+	sysCfg := bc.SystemConfig()
+	sysCfg.HighsetNumber = block.Number()
 	for _, tx := range block.Body().Transactions {
 		//not deploy tx
 		if nil == tx.To() {
 			continue
 		}
 		// in block replay,old NodeManager contract address maybe different
-		if common.SysCfg.HighsetNumber.Uint64() <= common.SysCfg.ReplayParam.Pivot {
-			UpdateNodeSysContractConfig(bc, common.SysCfg)
-			UpdateParamSysContractConfig(bc, common.SysCfg)
+		if sysCfg.HighsetNumber.Uint64() <= sysCfg.ReplayParam.Pivot {
+			UpdateNodeSysContractConfig(bc, sysCfg)
+			UpdateParamSysContractConfig(bc, sysCfg)
 		} else {
 			switch *tx.To() {
 			case syscontracts.NodeManagementAddress:
-				UpdateNodeSysContractConfig(bc, common.SysCfg)
+				UpdateNodeSysContractConfig(bc, sysCfg)
 			case syscontracts.ParameterManagementAddress:
-				UpdateParamSysContractConfig(bc, common.SysCfg)
+				UpdateParamSysContractConfig(bc, sysCfg)
 			}
 		}
 	}
 }
 
+// SystemConfig returns the system contract config the chain loads into.
+func (bc *BlockChain) SystemConfig() *common.SystemConfig {
+	if bc.sysConfig == nil {
+		return common.SysCfg
+	}
+	return bc.sysConfig
+}
+
 // Genesis retrieves the chain's genesis block.
 func (bc *BlockChain) Genesis() *types.Block {
 	return bc.genesisBlock
@@ -1438,6 +1501,33 @@ func (bc *BlockChain) InsertHeaderChain(chain []*types.Header, checkFreq int) (i
 	return bc.hc.InsertHeaderChain(chain, whFunc, start)
 }
 
+// InsertSnapHeaderChain is InsertHeaderChain for a snapshot sync, every seal
+// is verified against the validators trusted from the genesis on since the
+// states of the headers are unknown.
+func (bc *BlockChain) InsertSnapHeaderChain(chain []*types.Header) (int, error) {
+	start := time.Now()
+	if i, err := bc.hc.ValidateSnapHeaderChain(chain); err != nil {
+		return i, err
+	}
+
+	// Make sure only one thread manipulates the chain at once
+	bc.chainmu.Lock()
+	defer bc.chainmu.Unlock()
+
+	bc.wg.Add(1)
+	defer bc.wg.Done()
+
+	whFunc := func(header *types.Header) error {
+		bc.mu.Lock()
+		defer bc.mu.Unlock()
+
+		_, err := bc.hc.WriteHeader(header)
+		return err
+	}
+
+	return bc.hc.InsertHeaderChain(chain, whFunc, start)
+}
+
 // writeHeader writes a header into the local chain, given that its parent is
 // already known. If the total difficulty of the newly inserted header becomes
 // greater than the current known TD, the canonical chain is re-routed.
@@ -34,4 +34,20 @@ var (
 	ErrNonceTooHigh = errors.New("nonce too high")
 
 	ErrParamaManagerContractAddressNotFound = errors.New("paramManager contract address not found")
+
+	// ErrTxExpired is returned if the validity window of a transaction ends
+	// before the block it's meant to be included in.
+	ErrTxExpired = errors.New("transaction expired")
+
+	// ErrTxValidityTooLong is returned if the validity window of a transaction
+	// spans more than params.TxValidityMaxBlocks blocks.
+	ErrTxValidityTooLong = errors.New("transaction validity window too long")
+
+	// ErrUnknownRefBlock is returned if the reference block of a transaction
+	// validity window is not part of the chain.
+	ErrUnknownRefBlock = errors.New("unknown transaction reference block")
+
+	// ErrSnapSyncUnsupported is returned if the consensus engine can't verify
+	// the pivot block of a snapshot sync.
+	ErrSnapSyncUnsupported = errors.New("snapshot sync not supported by the consensus engine")
 )
@@ -31,6 +31,7 @@ import (
 	"github.com/Venachain/Venachain/core/state"
 	"github.com/Venachain/Venachain/core/types"
 	"github.com/Venachain/Venachain/core/vm"
+	"github.com/Venachain/Venachain/crypto"
 	"github.com/Venachain/Venachain/log"
 	"github.com/Venachain/Venachain/params"
 	"github.com/Venachain/Venachain/venadb/dbhandle"
@@ -154,6 +155,9 @@ func SetupGenesisBlock(db dbhandle.Database, genesis *Genesis) (*params.ChainCon
 	)
 
 	if nil != genesis {
+		if err := checkCryptoSuite(genesis.Config); err != nil {
+			return genesis.Config, common.Hash{}, err
+		}
 		if (stored == common.Hash{}) {
 			block, err := genesis.Commit(db)
 			return genesis.Config, block.Hash(), err
@@ -178,7 +182,23 @@ func SetupGenesisBlock(db dbhandle.Database, genesis *Genesis) (*params.ChainCon
 	} else {
 		log.Error("no genesis config found,maybe you should run \"venachain init \" with proper params first")
 	}
-	return storedcfg, stored, nil
+	return storedcfg, stored, checkCryptoSuite(storedcfg)
+}
+
+// checkCryptoSuite makes sure the node runs with the crypto suite the chain
+// was created with, keys and signatures of different suites don't mix.
+func checkCryptoSuite(config *params.ChainConfig) error {
+	if config == nil {
+		return nil
+	}
+	suite := config.CryptoSuite
+	if suite == "" {
+		suite = crypto.SuiteSecp256k1
+	}
+	if suite != crypto.CryptoSuite() {
+		return fmt.Errorf("chain crypto suite %q doesn't match the node's crypto suite %q", suite, crypto.CryptoSuite())
+	}
+	return nil
 }
 
 // ToBlock creates the genesis block and writes state of a genesis specification
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/core/vm"
+	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/p2p/discover"
+	"github.com/Venachain/Venachain/params"
+)
+
+var errGroupNoIstanbul = errors.New("group ledger requires an istanbul chain config")
+
+// GroupChainID derives the chain id of a group ledger from the main chain id
+// and the group id, so transactions signed for one ledger can't be replayed
+// on another.
+func GroupChainID(parent *big.Int, groupID uint64) *big.Int {
+	var id [8]byte
+	binary.BigEndian.PutUint64(id[:], groupID)
+
+	var parentID []byte
+	if parent != nil {
+		parentID = parent.Bytes()
+	}
+	hash := crypto.Keccak256(parentID, id[:])
+	// keep the id in the positive int64 range, it's used as a signer chain id
+	return new(big.Int).SetUint64(binary.BigEndian.Uint64(hash[:8]) >> 1)
+}
+
+// DeriveGroupGenesis derives the genesis of a group ledger from the group
+// record stored in the GroupManagement contract and the main chain genesis.
+// Every node hosting the group derives the same genesis block, the group
+// creator's node becomes the first validator of the group ledger.
+func DeriveGroupGenesis(parent *params.ChainConfig, parentGenesis *types.Header, group *vm.GroupInfo) (*Genesis, error) {
+	if parent == nil || parent.Istanbul == nil {
+		return nil, errGroupNoIstanbul
+	}
+	firstNode, err := discover.ParseNode(group.CreatorEnode)
+	if err != nil {
+		return nil, err
+	}
+
+	istanbul := *parent.Istanbul
+	istanbul.FirstValidatorNode = *firstNode
+
+	config := &params.ChainConfig{
+		ChainID:       GroupChainID(parent.ChainID, group.GroupID),
+		Istanbul:      &istanbul,
+		VMInterpreter: parent.VMInterpreter,
+		LicenseCheck:  parent.LicenseCheck,
+		CryptoSuite:   parent.CryptoSuite,
+	}
+
+	return &Genesis{
+		Config:    config,
+		Nonce:     group.GroupID,
+		Timestamp: parentGenesis.Time.Uint64(),
+		ExtraData: parentGenesis.Extra,
+		GasLimit:  parentGenesis.GasLimit,
+		Alloc:     GenesisAlloc{},
+	}, nil
+}
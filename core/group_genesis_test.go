@@ -0,0 +1,47 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/core/vm"
+	"github.com/Venachain/Venachain/params"
+)
+
+const testGroupEnode = "enode://1dd9d65c4552b5eb43d5ad55a2ee3f56c6cbc1c64a5c8d659f51fcd51bace24351232b8d7821617d2b29b54b81cdefb9b3e9c37d7fd5f63270bcc9e1a6f6a439@127.0.0.1:16789"
+
+func TestDeriveGroupGenesis(t *testing.T) {
+	parent := &params.ChainConfig{
+		ChainID:  big.NewInt(300),
+		Istanbul: &params.IstanbulConfig{RequestTimeout: 10000, BlockPeriod: 1},
+	}
+	parentGenesis := &types.Header{Time: big.NewInt(1600000000000), GasLimit: params.GenesisGasLimit}
+
+	g1, err := DeriveGroupGenesis(parent, parentGenesis, &vm.GroupInfo{GroupID: 1, CreatorEnode: testGroupEnode})
+	if err != nil {
+		t.Fatalf("failed to derive group genesis: %v", err)
+	}
+	again, _ := DeriveGroupGenesis(parent, parentGenesis, &vm.GroupInfo{GroupID: 1, CreatorEnode: testGroupEnode})
+	if g1.ToBlock(nil).Hash() != again.ToBlock(nil).Hash() {
+		t.Errorf("group genesis is not deterministic")
+	}
+	if g1.Config.Istanbul.FirstValidatorNode.String() != testGroupEnode {
+		t.Errorf("first validator mismatch: have %s, want %s", g1.Config.Istanbul.FirstValidatorNode.String(), testGroupEnode)
+	}
+	if parent.Istanbul.FirstValidatorNode.String() == testGroupEnode {
+		t.Errorf("parent chain config modified")
+	}
+
+	g2, _ := DeriveGroupGenesis(parent, parentGenesis, &vm.GroupInfo{GroupID: 2, CreatorEnode: testGroupEnode})
+	if g1.ToBlock(nil).Hash() == g2.ToBlock(nil).Hash() {
+		t.Errorf("groups share the same genesis")
+	}
+	if g1.Config.ChainID.Cmp(g2.Config.ChainID) == 0 || g1.Config.ChainID.Cmp(parent.ChainID) == 0 {
+		t.Errorf("group chain ids are not distinct: %v %v", g1.Config.ChainID, g2.Config.ChainID)
+	}
+
+	if _, err := DeriveGroupGenesis(parent, parentGenesis, &vm.GroupInfo{GroupID: 3}); err == nil {
+		t.Errorf("expected error for group without creator enode")
+	}
+}
@@ -215,16 +215,8 @@ func (hc *HeaderChain) WriteHeader(header *types.Header) (status WriteStatus, er
 type WhCallback func(*types.Header) error
 
 func (hc *HeaderChain) ValidateHeaderChain(chain []*types.Header, checkFreq int) (int, error) {
-	// Do a sanity check that the provided chain is actually ordered and linked
-	for i := 1; i < len(chain); i++ {
-		if chain[i].Number.Uint64() != chain[i-1].Number.Uint64()+1 || chain[i].ParentHash != chain[i-1].Hash() {
-			// Chain broke ancestry, log a message (programming error) and skip insertion
-			log.Error("Non contiguous header insert", "number", chain[i].Number, "hash", chain[i].Hash(),
-				"parent", chain[i].ParentHash, "prevnumber", chain[i-1].Number, "prevhash", chain[i-1].Hash())
-
-			return 0, fmt.Errorf("non contiguous insert: item %d is #%d [%x…], item %d is #%d [%x…] (parent [%x…])", i-1, chain[i-1].Number,
-				chain[i-1].Hash().Bytes()[:4], i, chain[i].Number, chain[i].Hash().Bytes()[:4], chain[i].ParentHash[:4])
-		}
+	if err := checkContiguous(chain); err != nil {
+		return 0, err
 	}
 
 	// Generate the list of seal verification requests, and start the parallel verifier
@@ -261,6 +253,47 @@ func (hc *HeaderChain) ValidateHeaderChain(chain []*types.Header, checkFreq int)
 	return 0, nil
 }
 
+// ValidateSnapHeaderChain verifies the seals of every header of a snapshot
+// sync, whose parent states are unknown, with the consensus.SnapVerifier.
+func (hc *HeaderChain) ValidateSnapHeaderChain(chain []*types.Header) (int, error) {
+	verifier, ok := hc.engine.(consensus.SnapVerifier)
+	if !ok {
+		return 0, ErrSnapSyncUnsupported
+	}
+	if err := checkContiguous(chain); err != nil {
+		return 0, err
+	}
+	for i, header := range chain {
+		if hc.procInterrupt() {
+			log.Debug("Premature abort during headers verification")
+			return 0, errors.New("aborted")
+		}
+		if BadHashes[header.Hash()] {
+			return i, ErrBlacklistedHash
+		}
+		if err := verifier.VerifySnapHeader(hc, header, chain[:i]); err != nil {
+			return i, err
+		}
+	}
+	return 0, nil
+}
+
+// checkContiguous does a sanity check that the provided chain is actually
+// ordered and linked.
+func checkContiguous(chain []*types.Header) error {
+	for i := 1; i < len(chain); i++ {
+		if chain[i].Number.Uint64() != chain[i-1].Number.Uint64()+1 || chain[i].ParentHash != chain[i-1].Hash() {
+			// Chain broke ancestry, log a message (programming error) and skip insertion
+			log.Error("Non contiguous header insert", "number", chain[i].Number, "hash", chain[i].Hash(),
+				"parent", chain[i].ParentHash, "prevnumber", chain[i-1].Number, "prevhash", chain[i-1].Hash())
+
+			return fmt.Errorf("non contiguous insert: item %d is #%d [%x…], item %d is #%d [%x…] (parent [%x…])", i-1, chain[i-1].Number,
+				chain[i-1].Hash().Bytes()[:4], i, chain[i].Number, chain[i].Hash().Bytes()[:4], chain[i].ParentHash[:4])
+		}
+	}
+	return nil
+}
+
 // InsertHeaderChain attempts to insert the given header chain in to the local
 // chain, possibly creating a reorg. If an error is returned, it will return the
 // index number of the failing header as well an error describing what went wrong.
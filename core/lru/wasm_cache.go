@@ -8,6 +8,7 @@ import (
 
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/life/compiler"
+	"github.com/Venachain/Venachain/life/exec"
 	"github.com/Venachain/Venachain/log"
 	"github.com/hashicorp/golang-lru/simplelru"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -28,6 +29,26 @@ type WasmLDBCache struct {
 type WasmModule struct {
 	Module       *compiler.Module
 	FunctionCode []compiler.InterpreterCode
+
+	// compiled is the closure compiled form of FunctionCode, it is not
+	// stored in the database and built on the first use.
+	compiled    *exec.CompiledModule
+	compileOnce sync.Once
+}
+
+// Compiled returns the closure compiled form of the module functions, nil if
+// they can only run in the interpreter. The functions are compiled on the
+// first call.
+func (m *WasmModule) Compiled() *exec.CompiledModule {
+	m.compileOnce.Do(func() {
+		compiled, err := exec.Compile(m.FunctionCode)
+		if err != nil {
+			log.Warn("compile wasm module", "err", err)
+			return
+		}
+		m.compiled = compiled
+	})
+	return m.compiled
 }
 
 func WasmCache() *WasmLDBCache {
@@ -161,7 +182,7 @@ func (w *WasmLDBCache) Peek(key common.Address) (*WasmModule, bool) {
 				var module WasmModule
 				buffer := bytes.NewReader(value)
 				dec := gob.NewDecoder(buffer)
-				dec.Decode(module)
+				dec.Decode(&module)
 				return &module, true
 			}
 		}
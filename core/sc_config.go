@@ -8,7 +8,6 @@ import (
 	"github.com/Venachain/Venachain/core/vm"
 	"github.com/Venachain/Venachain/life/utils"
 	"github.com/Venachain/Venachain/log"
-	"github.com/Venachain/Venachain/p2p"
 	"github.com/Venachain/Venachain/rlp"
 )
 
@@ -124,11 +123,7 @@ func UpdateNodeSysContractConfig(bc *BlockChain, sysContractConf *common.SystemC
 	} else if tmp.RetCode != 0 {
 		log.Debug("contract inner error", "code", tmp.RetCode, "msg", tmp.RetMsg)
 	} else {
-		sysContractConf.SystemConfigMu.Lock()
-		defer sysContractConf.SystemConfigMu.Unlock()
-		sysContractConf.Nodes = tmp.Data
-		sysContractConf.GenerateNodeData()
-		p2p.UpdatePeer()
+		sysContractConf.SetNodes(tmp.Data)
 	}
 }
 
@@ -0,0 +1,188 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/rlp"
+	"github.com/Venachain/Venachain/trie"
+	"github.com/Venachain/Venachain/venadb/memorydb"
+)
+
+var (
+	ErrContractNotFound    = errors.New("contract not found")
+	ErrInvalidExportProof  = errors.New("invalid contract export proof")
+	ErrExportStateNotFinal = errors.New("contract export needs a committed state")
+)
+
+// ContractExport is the content of a contract in the state of a block: its
+// storage, code, ABI and firewall status. The account and every storage
+// entry come with their Merkle proofs against the state root of the block
+// header, so the export can be verified without trusting the node it was
+// taken from. The ABI of a WASM contract is part of its code, it's decoded for
+// reading convenience.
+type ContractExport struct {
+	Address      common.Address  `json:"address"`
+	Header       *types.Header   `json:"header"`
+	StateRoot    common.Hash     `json:"stateRoot"`
+	Creator      common.Address  `json:"creator"`
+	Code         hexutil.Bytes   `json:"code"`
+	Abi          hexutil.Bytes   `json:"abi"`
+	FwStatus     FwStatus        `json:"fwStatus"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Storage      []StorageExport `json:"storage"`
+}
+
+// StorageExport is a storage entry of an exported contract, the key is the
+// one passed to GetState.
+type StorageExport struct {
+	Key   hexutil.Bytes   `json:"key"`
+	Value hexutil.Bytes   `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// proofList collects the trie nodes of a proof.
+type proofList []hexutil.Bytes
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, common.CopyBytes(value))
+	return nil
+}
+
+// proofReader serves the nodes of a proof to trie.VerifyProof.
+type proofReader map[string][]byte
+
+func newProofReader(proof []hexutil.Bytes) proofReader {
+	r := make(proofReader, len(proof))
+	for _, node := range proof {
+		r[string(crypto.Keccak256(node))] = node
+	}
+	return r
+}
+
+func (r proofReader) Get(key []byte) ([]byte, error) {
+	if node, ok := r[string(key)]; ok {
+		return node, nil
+	}
+	return nil, errors.New("proof node not found")
+}
+
+func (r proofReader) Has(key []byte) (bool, error) {
+	_, ok := r[string(key)]
+	return ok, nil
+}
+
+// ExportContract exports the contract with the proofs of its account and
+// storage. The state must be committed, e.g. the state of a block.
+func (self *StateDB) ExportContract(addr common.Address) (*ContractExport, error) {
+	stateObject := self.getStateObject(addr)
+	if stateObject == nil {
+		return nil, ErrContractNotFound
+	}
+	if len(self.journal.dirties) != 0 {
+		return nil, ErrExportStateNotFinal
+	}
+
+	export := &ContractExport{
+		Address:   addr,
+		StateRoot: self.trie.Hash(),
+		Creator:   stateObject.ContractCreator(),
+		Code:      stateObject.Code(self.db),
+		FwStatus:  self.GetFwStatus(addr),
+	}
+	export.Abi = contractAbi(export.Code)
+	var accountProof proofList
+	if err := self.trie.Prove(crypto.Keccak256(addr.Bytes()), 0, &accountProof); err != nil {
+		return nil, err
+	}
+	export.AccountProof = accountProof
+
+	prefix := addr.String()
+	storageTrie := stateObject.getTrie(self.db)
+	it := trie.NewIterator(storageTrie.NodeIterator(nil))
+	for it.Next() {
+		keyTrie := self.trie.GetKey(it.Key)
+		if len(keyTrie) <= len(prefix) {
+			return nil, fmt.Errorf("missing preimage of storage key %x", it.Key)
+		}
+		_, content, _, err := rlp.Split(it.Value)
+		if err != nil {
+			return nil, err
+		}
+		value := self.trie.GetKey(common.BytesToHash(content).Bytes())
+
+		var proof proofList
+		if err := storageTrie.Prove(it.Key, 0, &proof); err != nil {
+			return nil, err
+		}
+		export.Storage = append(export.Storage, StorageExport{
+			Key:   common.CopyBytes(keyTrie[len(prefix):]),
+			Value: common.CopyBytes(value),
+			Proof: proof,
+		})
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+	sort.Slice(export.Storage, func(i, j int) bool {
+		return bytes.Compare(export.Storage[i].Key, export.Storage[j].Key) < 0
+	})
+	return export, nil
+}
+
+// VerifyContractExport checks the export was taken from the block of the
+// given hash, the account against the state root of the block, and the code,
+// ABI and storage against the account, it returns the verified account. The
+// storage must be complete: the storage trie rebuilt from the entries must
+// have the root of the account.
+func VerifyContractExport(export *ContractExport, blockHash common.Hash) (*Account, error) {
+	if export.Header == nil || export.Header.Hash() != blockHash || export.Header.Root != export.StateRoot {
+		return nil, ErrInvalidExportProof
+	}
+	enc, _, err := trie.VerifyProof(export.StateRoot, crypto.Keccak256(export.Address.Bytes()), newProofReader(export.AccountProof))
+	if err != nil || len(enc) == 0 {
+		return nil, ErrInvalidExportProof
+	}
+	var account Account
+	if err := rlp.DecodeBytes(enc, &account); err != nil {
+		return nil, ErrInvalidExportProof
+	}
+	if !bytes.Equal(account.CodeHash, crypto.Keccak256(export.Code)) ||
+		!bytes.Equal(contractAbi(export.Code), export.Abi) ||
+		account.Creator != export.Creator ||
+		(account.FwActive != 0) != export.FwStatus.Active {
+		return nil, ErrInvalidExportProof
+	}
+
+	// the proofs of the entries don't prove no entry is missing, the whole
+	// storage trie is rebuilt instead
+	storage, err := trie.NewSecure(common.Hash{}, trie.NewDatabase(memorydb.NewMemDatabase()), 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range export.Storage {
+		keyTrie, valueKey, _ := getKeyValue(export.Address, entry.Key, entry.Value)
+		v, _ := rlp.EncodeToBytes(bytes.TrimLeft(valueKey[:], "\x00"))
+		if err := storage.TryUpdate([]byte(keyTrie), v); err != nil {
+			return nil, err
+		}
+	}
+	if storage.Hash() != account.Root {
+		return nil, ErrInvalidExportProof
+	}
+	return &account, nil
+}
+
+// contractAbi returns the ABI embedded in the code of a WASM contract.
+func contractAbi(code []byte) []byte {
+	if _, abi, _, err := common.ParseWasmCodeRlpData(code); err == nil {
+		return abi
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/rlp"
+	"github.com/Venachain/Venachain/venadb/memorydb"
+)
+
+func TestExportContract(t *testing.T) {
+	var (
+		db      = NewDatabase(memorydb.NewMemDatabase())
+		addr    = common.HexToAddress("0x1000000000000000000000000000000000000aaa")
+		creator = common.HexToAddress("0x1000000000000000000000000000000000000bbb")
+	)
+	code, _ := rlp.EncodeToBytes([]interface{}{common.Int64ToBytes(2), []byte{0x00, 0x61, 0x73, 0x6d}, []byte(`[{"name":"transfer"}]`)})
+	statedb, _ := New(common.Hash{}, db)
+	statedb.SetCode(addr, code)
+	statedb.SetContractCreator(addr, creator)
+	statedb.SetState(addr, []byte("balance"), []byte{0x01, 0x02})
+	statedb.SetState(addr, []byte("owner"), creator.Bytes())
+	statedb.OpenFirewall(addr)
+	statedb.FwAdd(addr, accept, []FwElem{{Addr: creator, FuncName: "transfer"}})
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statedb, _ = New(root, db)
+	export, err := statedb.ExportContract(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export.StateRoot != root || export.Creator != creator || !export.FwStatus.Active || string(export.Abi) != `[{"name":"transfer"}]` {
+		t.Fatalf("export mismatch: %+v", export)
+	}
+	// the storage holds the firewall data besides the two entries
+	if len(export.Storage) != 3 {
+		t.Fatalf("expected 3 storage entries, got %d", len(export.Storage))
+	}
+	header := &types.Header{Number: big.NewInt(1), Time: big.NewInt(1000), Root: root}
+	export.Header = header
+	account, err := VerifyContractExport(export, header.Hash())
+	if err != nil {
+		t.Fatalf("export not verified: %v", err)
+	}
+	if account.Creator != creator {
+		t.Fatalf("verified account mismatch: %+v", account)
+	}
+	if _, err := VerifyContractExport(export, common.Hash{0x01}); err != ErrInvalidExportProof {
+		t.Fatalf("expected an export of another block to fail, got %v", err)
+	}
+
+	full := export.Storage
+	export.Storage = full[1:]
+	if _, err := VerifyContractExport(export, header.Hash()); err != ErrInvalidExportProof {
+		t.Fatalf("expected an incomplete storage to fail, got %v", err)
+	}
+	export.Storage = full
+
+	for _, entry := range export.Storage {
+		if string(entry.Key) == "balance" {
+			entry.Value[0] = 0x02
+		}
+	}
+	if _, err := VerifyContractExport(export, header.Hash()); err != ErrInvalidExportProof {
+		t.Fatalf("expected a tampered storage value to fail, got %v", err)
+	}
+
+	if _, err := statedb.ExportContract(common.HexToAddress("0x01")); err != ErrContractNotFound {
+		t.Fatalf("expected ErrContractNotFound, got %v", err)
+	}
+}
+
+func TestHasStorage(t *testing.T) {
+	var (
+		db   = NewDatabase(memorydb.NewMemDatabase())
+		addr = common.HexToAddress("0x1000000000000000000000000000000000000aaa")
+	)
+	statedb, _ := New(common.Hash{}, db)
+	statedb.SetCode(addr, []byte{0x00, 0x61, 0x73, 0x6d})
+	statedb.OpenFirewall(addr)
+	statedb.FwAdd(addr, accept, []FwElem{{Addr: addr, FuncName: "transfer"}})
+	if statedb.HasStorage(addr) {
+		t.Fatal("expected the firewall data not to count as storage")
+	}
+	statedb.SetState(addr, []byte("balance"), []byte{0x01})
+	if !statedb.HasStorage(addr) {
+		t.Fatal("expected a dirty entry to count as storage")
+	}
+	statedb.SetState(addr, []byte("balance"), nil)
+	if statedb.HasStorage(addr) {
+		t.Fatal("expected a deleted entry not to count as storage")
+	}
+	statedb.SetState(addr, []byte("balance"), []byte{0x01})
+	root, _ := statedb.Commit(false)
+	statedb, _ = New(root, db)
+	if !statedb.HasStorage(addr) {
+		t.Fatal("expected a committed entry to count as storage")
+	}
+}
@@ -18,6 +18,8 @@ type ObjectChange interface {
 	setVersion(version int)
 
 	getVersion() int
+
+	rebind(*StateDB) ObjectChange //复制变更，使其作用于另一个stateDB中的stateObject
 }
 
 //CreateAccount 创建账户的变更操作
@@ -42,6 +44,11 @@ func (ca *CreateAccount) change(s *StateDB) {
 	}
 }
 
+func (ca *CreateAccount) rebind(s *StateDB) ObjectChange {
+	no, po := s.createObjectSafe(ca.account)
+	return &CreateAccount{account: ca.account, prev: po, newObj: no, version: ca.version}
+}
+
 func (ca *CreateAccount) getAddr() common.Address {
 	return ca.account
 }
@@ -81,6 +88,12 @@ func (ca *SetCode) change(s *StateDB) {
 	ca.obj.setCode(ca.hash, ca.code)
 }
 
+func (ca *SetCode) rebind(s *StateDB) ObjectChange {
+	cp := *ca
+	cp.obj = s.getSimObject(ca.address)
+	return &cp
+}
+
 func (ca *SetCode) getAddr() common.Address {
 	return ca.address
 }
@@ -119,6 +132,12 @@ func (ca *SetAbi) change(s *StateDB) {
 	ca.obj.setAbi(ca.hash, ca.code)
 }
 
+func (ca *SetAbi) rebind(s *StateDB) ObjectChange {
+	cp := *ca
+	cp.obj = s.getSimObject(ca.address)
+	return &cp
+}
+
 func (ca *SetAbi) getAddr() common.Address {
 	return ca.address
 }
@@ -153,6 +172,12 @@ func (ca *Suicide) change(s *StateDB) {
 	s.deleteStateObject(ca.obj)
 }
 
+func (ca *Suicide) rebind(s *StateDB) ObjectChange {
+	cp := *ca
+	cp.obj = s.getSimObject(ca.address)
+	return &cp
+}
+
 func (ca *Suicide) getAddr() common.Address {
 	return ca.address
 }
@@ -188,6 +213,12 @@ func (ca *SetFwData) change(s *StateDB) {
 	ca.obj.setFwData(ca.data)
 }
 
+func (ca *SetFwData) rebind(s *StateDB) ObjectChange {
+	cp := *ca
+	cp.obj = s.getSimObject(ca.address)
+	return &cp
+}
+
 func (ca *SetFwData) getAddr() common.Address {
 	return ca.address
 }
@@ -227,6 +258,12 @@ func (ca *SetFwActive) change(s *StateDB) {
 	}
 }
 
+func (ca *SetFwActive) rebind(s *StateDB) ObjectChange {
+	cp := *ca
+	cp.obj = s.getSimObject(ca.address)
+	return &cp
+}
+
 func (ca *SetFwActive) getAddr() common.Address {
 	return ca.address
 }
@@ -265,6 +302,12 @@ func (ca *SetCreator) change(s *StateDB) {
 	ca.obj.setContractCreator(ca.creator)
 }
 
+func (ca *SetCreator) rebind(s *StateDB) ObjectChange {
+	cp := *ca
+	cp.obj = s.getSimObject(ca.address)
+	return &cp
+}
+
 func (ca *SetCreator) getAddr() common.Address {
 	return ca.address
 }
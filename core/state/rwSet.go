@@ -35,3 +35,36 @@ type BalanceOp struct {
 }
 
 type BalanceSet []*BalanceOp
+
+//NonceOp 缓存nonce的变更，Versions记录修改过该nonce的全部交易版本
+type NonceOp struct {
+	ContractAddress common.Address
+	Nonce           uint64
+	Versions        []int
+}
+
+//AccountOp 缓存账户的创建以及code、abi、创建人的变更
+type AccountOp struct {
+	ContractAddress common.Address
+	Created         bool
+	Code            []byte
+	CodeHash        common.Hash
+	Abi             []byte
+	AbiHash         common.Hash
+	Creator         common.Address
+	Version         int //txSimulator中用到的版本号
+}
+
+//merge 将object变更中涉及账户的内容合并到AccountOp
+func (op *AccountOp) merge(change ObjectChange) {
+	switch c := change.(type) {
+	case *CreateAccount:
+		op.Created = true
+	case *SetCode:
+		op.Code, op.CodeHash = c.code, c.hash
+	case *SetAbi:
+		op.Abi, op.AbiHash = c.code, c.hash
+	case *SetCreator:
+		op.Creator = c.creator
+	}
+}
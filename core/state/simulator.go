@@ -25,8 +25,9 @@ type TxSimulator struct {
 	balanceMap map[common.Address]*BalanceOp
 	logs       []*types.Log
 	nonce      []common.Address
-	oc         []ObjectChange //用于记录除balance变化之外的object变更
-	version    int            //stateDB中已经已处理的交易数
+	account    map[common.Address]struct{} //读取过nonce、code、abi、创建人等账户信息的地址
+	oc         []ObjectChange              //用于记录除balance变化之外的object变更
+	version    int                         //stateDB中已经已处理的交易数
 	receipt    *types.Receipt
 	err        error
 	dirty      map[common.Address]*stateObject
@@ -34,6 +35,7 @@ type TxSimulator struct {
 	index      int
 	txRlp      []byte
 	isRevert   bool
+	headerRead bool //执行过程中是否读取了区块头的coinbase、时间戳、gas上限
 }
 
 func NewTxSimulator(sdb *StateDB, transaction *types.Transaction) *TxSimulator {
@@ -47,6 +49,7 @@ func NewTxSimulator(sdb *StateDB, transaction *types.Transaction) *TxSimulator {
 		writeMap:   make(map[string]*WriteOp),
 		balanceMap: make(map[common.Address]*BalanceOp),
 		dirty:      make(map[common.Address]*stateObject),
+		account:    make(map[common.Address]struct{}),
 		txRlp:      enc,
 	}
 }
@@ -91,12 +94,76 @@ func (txSim *TxSimulator) GetIndex() int {
 }
 
 func (txSim *TxSimulator) getStateObject(addr common.Address) *stateObject {
-	obj := txSim.stateDb.GetOrNewStateObjectSafe(addr)
-	obj.CreateTrie(txSim.stateDb.db)
+	obj := txSim.stateDb.getSimObject(addr)
 	txSim.dirty[addr] = obj
 	return obj
 }
 
+//ReadHeader 记录交易读取了区块头，其模拟结果不能用于区块头不同的区块
+func (txSim *TxSimulator) ReadHeader() {
+	txSim.headerRead = true
+}
+
+//HeaderRead 判断交易是否读取了区块头
+func (txSim *TxSimulator) HeaderRead() bool {
+	return txSim.headerRead
+}
+
+//Rebind 复制在同一父区块的另一个stateDB上模拟的交易，复制的模拟交易的变更作用于sdb中的stateObject
+func (txSim *TxSimulator) Rebind(sdb *StateDB) *TxSimulator {
+	cp := &TxSimulator{
+		stateDb:    sdb,
+		tx:         txSim.tx,
+		hash:       txSim.hash,
+		readMap:    make(map[string]*ReadOp, len(txSim.readMap)),
+		writeMap:   make(map[string]*WriteOp, len(txSim.writeMap)),
+		balanceMap: make(map[common.Address]*BalanceOp, len(txSim.balanceMap)),
+		nonce:      append([]common.Address{}, txSim.nonce...),
+		account:    make(map[common.Address]struct{}, len(txSim.account)),
+		dirty:      make(map[common.Address]*stateObject, len(txSim.dirty)),
+		txRlp:      txSim.txRlp,
+		isRevert:   txSim.isRevert,
+		headerRead: txSim.headerRead,
+	}
+	for _, change := range txSim.oc {
+		change = change.rebind(sdb)
+		cp.dirty[change.getAddr()] = change.getObject()
+		cp.oc = append(cp.oc, change)
+	}
+	for addr := range txSim.dirty {
+		if _, ok := cp.dirty[addr]; !ok {
+			cp.getStateObject(addr)
+		}
+	}
+	for keyTrie, op := range txSim.readMap {
+		read := *op
+		cp.readMap[keyTrie] = &read
+	}
+	for keyTrie, op := range txSim.writeMap {
+		write := *op
+		write.object = cp.dirty[op.ContractAddress]
+		cp.writeMap[keyTrie] = &write
+	}
+	for addr, op := range txSim.balanceMap {
+		balance := *op
+		balance.object = cp.dirty[addr]
+		cp.balanceMap[addr] = &balance
+	}
+	for addr := range txSim.account {
+		cp.account[addr] = struct{}{}
+	}
+	for _, l := range txSim.logs {
+		cpy := *l
+		cp.logs = append(cp.logs, &cpy)
+	}
+	if txSim.receipt != nil {
+		receipt := *txSim.receipt
+		receipt.Logs = cp.logs
+		cp.receipt = &receipt
+	}
+	return cp
+}
+
 //SetState 将设置的操作存于writeSet内
 func (txSim *TxSimulator) SetState(addr common.Address, key, value []byte) {
 	keyTrie, vk, _ := getKeyValue(addr, key, value)
@@ -264,7 +331,25 @@ func (txSim *TxSimulator) GetBalance(addr common.Address) *big.Int {
 	}
 }
 
+//getAccount 记录账户的读操作，并返回已加入db的交易以及本交易对该账户的变更
+func (txSim *TxSimulator) getAccount(addr common.Address) *AccountOp {
+	txSim.account[addr] = struct{}{}
+	op, ok := txSim.stateDb.GetAccountByCache(addr)
+	if !ok {
+		op = &AccountOp{ContractAddress: addr}
+	}
+	for _, change := range txSim.oc {
+		if change.getAddr() == addr {
+			op.merge(change)
+		}
+	}
+	return op
+}
+
 func (txSim *TxSimulator) GetCode(addr common.Address) []byte {
+	if op := txSim.getAccount(addr); op.CodeHash != (common.Hash{}) {
+		return op.Code
+	}
 	return txSim.stateDb.GetCode(addr)
 }
 
@@ -276,8 +361,19 @@ func (txSim *TxSimulator) CreateAccount(address common.Address) {
 	txSim.oc = append(txSim.oc, NewCreateAccount(po, no))
 }
 
+//GetNonce 获取nonce，包含已加入db的交易以及本交易对nonce的增加，合约地址由nonce推导，因此记录为账户的读操作
 func (txSim *TxSimulator) GetNonce(address common.Address) uint64 {
-	return txSim.stateDb.GetNonce(address)
+	txSim.account[address] = struct{}{}
+	nonce, ok := txSim.stateDb.GetNonceByCache(address)
+	if !ok {
+		nonce = txSim.stateDb.GetNonce(address)
+	}
+	for _, addr := range txSim.nonce {
+		if addr == address {
+			nonce++
+		}
+	}
+	return nonce
 }
 
 //SetNonce 记录nonce有变更的地址,鉴于nonce的变更都是对原有的nonce+1,这里只记录需要变更的地址
@@ -293,6 +389,9 @@ func (txSim *TxSimulator) AddNonce(address common.Address) {
 }
 
 func (txSim *TxSimulator) GetCodeHash(address common.Address) common.Hash {
+	if op := txSim.getAccount(address); op.CodeHash != (common.Hash{}) {
+		return op.CodeHash
+	}
 	return txSim.stateDb.GetCodeHash(address)
 }
 
@@ -304,14 +403,23 @@ func (txSim *TxSimulator) SetCode(address common.Address, bytes []byte) {
 }
 
 func (txSim *TxSimulator) GetCodeSize(address common.Address) int {
+	if op := txSim.getAccount(address); op.CodeHash != (common.Hash{}) {
+		return len(op.Code)
+	}
 	return txSim.stateDb.GetCodeSize(address)
 }
 
 func (txSim *TxSimulator) GetAbiHash(address common.Address) common.Hash {
+	if op := txSim.getAccount(address); op.AbiHash != (common.Hash{}) {
+		return op.AbiHash
+	}
 	return txSim.stateDb.GetAbiHash(address)
 }
 
 func (txSim *TxSimulator) GetAbi(address common.Address) []byte {
+	if op := txSim.getAccount(address); op.AbiHash != (common.Hash{}) {
+		return op.Abi
+	}
 	return txSim.stateDb.GetAbi(address)
 }
 
@@ -367,11 +475,16 @@ func (txSim *TxSimulator) HasSuicided(address common.Address) bool {
 }
 
 func (txSim *TxSimulator) Exist(address common.Address) bool {
+	if op := txSim.getAccount(address); op.Created || op.CodeHash != (common.Hash{}) {
+		return true
+	}
 	return txSim.stateDb.Exist(address)
 }
 
 func (txSim *TxSimulator) Empty(address common.Address) bool {
-	return txSim.stateDb.Empty(address)
+	codeHash := txSim.GetCodeHash(address)
+	return txSim.GetNonce(address) == 0 && txSim.GetBalance(address).Sign() == 0 &&
+		(codeHash == (common.Hash{}) || bytes.Equal(codeHash.Bytes(), emptyCodeHash))
 }
 
 //RevertToSnapshot 用于回退模拟交易
@@ -466,6 +579,15 @@ func (txSim *TxSimulator) FwSet(contractAddr common.Address, action Action, list
 		}
 		fwData.DeniedList = stateObject.FwData().DeniedList
 	}
+	fwData.Rules = stateObject.FwData().Rules
+	txSim.oc = append(txSim.oc, NewSetFwData(stateObject, contractAddr, fwData))
+}
+
+//FwSetRules 处理条件规则并记录防火墙的设置操作
+func (txSim *TxSimulator) FwSetRules(contractAddr common.Address, rules []FwRule) {
+	stateObject := txSim.getStateObject(contractAddr)
+	fwData := stateObject.FwData()
+	fwData.Rules = SortedFwRules(rules)
 	txSim.oc = append(txSim.oc, NewSetFwData(stateObject, contractAddr, fwData))
 }
 
@@ -479,6 +601,7 @@ func (txSim *TxSimulator) SetFwStatus(contractAddr common.Address, status FwStat
 	for _, addr := range status.AcceptedList {
 		fwData.AcceptedList[addr.FuncName+":"+addr.Addr.String()] = true
 	}
+	fwData.Rules = SortedFwRules(status.Rules)
 	txSim.oc = append(txSim.oc, NewSetFwData(stateObject, contractAddr, fwData))
 	active := uint64(0)
 	if status.Active {
@@ -502,6 +625,9 @@ func (txSim *TxSimulator) SetContractCreator(contractAddr common.Address, creato
 
 func (txSim *TxSimulator) GetContractCreator(contractAddr common.Address) common.Address {
 	log.Debug("GetContractCreator", "addr", contractAddr.Hex())
+	if op := txSim.getAccount(contractAddr); op.Creator != (common.Address{}) {
+		return op.Creator
+	}
 	return txSim.stateDb.GetContractCreator(contractAddr)
 }
 
@@ -537,6 +663,7 @@ func (txSim *TxSimulator) FwImport(contractAddr common.Address, data []byte) err
 	for _, addr := range status.AcceptedList {
 		fwData.AcceptedList[addr.FuncName+":"+addr.Addr.String()] = true
 	}
+	fwData.Rules = MergeFwRules(stateObject.FwData().Rules, status.Rules...)
 	txSim.oc = append(txSim.oc, NewSetFwData(stateObject, contractAddr, fwData))
 	return nil
 }
@@ -546,6 +673,17 @@ func (txSim *TxSimulator) CloneAccount(src common.Address, dest common.Address)
 	return txSim.stateDb.CloneAccount(src, dest)
 }
 
+//HasStorage 检查合约存储是否有防火墙数据以外的数据，记录为账户的读操作
+func (txSim *TxSimulator) HasStorage(addr common.Address) bool {
+	txSim.getAccount(addr)
+	for _, op := range txSim.writeMap {
+		if op.ContractAddress == addr && len(op.Value) != 0 {
+			return true
+		}
+	}
+	return txSim.stateDb.HasStorage(addr)
+}
+
 type CallSimulator struct {
 	stateDb    *StateDB
 	readMap    map[string]*ReadOp
@@ -810,6 +948,10 @@ func (txSim *CallSimulator) FwDel(contractAddr common.Address, action Action, li
 func (txSim *CallSimulator) FwSet(contractAddr common.Address, action Action, list []FwElem) {
 }
 
+//FwSetRules 处理条件规则并记录防火墙的设置操作
+func (txSim *CallSimulator) FwSetRules(contractAddr common.Address, rules []FwRule) {
+}
+
 //SetFwStatus 拆分成防火墙的数据设置操作和防火墙的活跃操作，并记录
 func (txSim *CallSimulator) SetFwStatus(contractAddr common.Address, status FwStatus) {
 }
@@ -850,6 +992,10 @@ func (txSim *CallSimulator) CloneAccount(src common.Address, dest common.Address
 	return nil
 }
 
+func (txSim *CallSimulator) HasStorage(addr common.Address) bool {
+	return txSim.stateDb.HasStorage(addr)
+}
+
 //StartProcess 开始并行计算
 func (self *StateDB) StartProcess() {
 	self.rwLock.Lock()
@@ -917,6 +1063,50 @@ func (self *StateDB) GetBalanceByCache(addr common.Address) (*big.Int, bool) {
 	return nil, false
 }
 
+//getSimObject 获取模拟交易使用的stateObject，不存在时创建
+func (self *StateDB) getSimObject(addr common.Address) *stateObject {
+	obj := self.GetOrNewStateObjectSafe(addr)
+	obj.CreateTrie(self.db)
+	return obj
+}
+
+//IsTxSimStale 判断模拟交易读取的状态是否已被加入db的交易变更
+func (self *StateDB) IsTxSimStale(txSim *TxSimulator) bool {
+	self.rwLock.RLock()
+	defer self.rwLock.RUnlock()
+	if txSim.version == len(self.txs) {
+		return false
+	}
+	return self.checkConflict(txSim)
+}
+
+//GetNonceByCache 从模拟交易的缓存中获取nonce变动
+func (self *StateDB) GetNonceByCache(addr common.Address) (uint64, bool) {
+	if self.nonceMap == nil {
+		return 0, false
+	}
+	self.rwLock.RLock()
+	defer self.rwLock.RUnlock()
+	if op, ok := self.nonceMap[addr]; ok {
+		return op.Nonce, ok
+	}
+	return 0, false
+}
+
+//GetAccountByCache 从模拟交易的缓存中获取账户的创建以及code、abi、创建人的变动
+func (self *StateDB) GetAccountByCache(addr common.Address) (*AccountOp, bool) {
+	if self.accountMap == nil {
+		return nil, false
+	}
+	self.rwLock.RLock()
+	defer self.rwLock.RUnlock()
+	if op, ok := self.accountMap[addr]; ok {
+		cp := *op
+		return &cp, ok
+	}
+	return nil, false
+}
+
 //GetTxsLen 获取db加入的模拟交易数
 func (self *StateDB) GetTxsLen() int {
 	self.rwLock.RLock()
@@ -993,6 +1183,16 @@ func (self *StateDB) checkConflict(txSim *TxSimulator) bool {
 		}
 	}
 
+	//判断模拟交易读取的账户信息(nonce、code、abi、创建人)在模拟过程中是否被其他交易变更，合约的创建依赖这些信息推导地址
+	for addr := range txSim.account {
+		if c, ok := self.oc[addr]; ok && c.getVersion() >= txSim.version {
+			return true
+		}
+		if op, ok := self.nonceMap[addr]; ok && op.Versions[len(op.Versions)-1] >= txSim.version {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -1065,6 +1265,19 @@ func (self *StateDB) addTxSim(txSim *TxSimulator) {
 		}
 	}
 
+	for addr := range txSim.account {
+		//模拟交易读取的账户信息依赖于变更过该账户的交易，nonce依赖于全部增加过nonce的交易
+		if c, ok := self.oc[addr]; ok && c.getVersion() != version {
+			depend = depend.Add(c.getVersion())
+		}
+		if op, ok := self.nonceMap[addr]; ok {
+			for _, v := range op.Versions {
+				depend = depend.Add(v)
+			}
+		}
+	}
+	self.cacheAccount(txSim, version)
+
 	self.dag = append(self.dag, depend)
 
 	self.txs = append(self.txs, txSim.tx)
@@ -1103,10 +1316,40 @@ func (self *StateDB) addTxSimWithoutDependency(txSim *TxSimulator) {
 			self.oc[op.getAddr()] = op
 		}
 	}
+	self.cacheAccount(txSim, version)
 
 	self.txs = append(self.txs, txSim.tx)
 }
 
+//cacheAccount 缓存模拟交易对nonce以及账户的变更，使后续模拟的交易能读取到尚未应用的变更
+func (self *StateDB) cacheAccount(txSim *TxSimulator, version int) {
+	//nonce的增加不论交易是否回退都会应用
+	for _, addr := range txSim.nonce {
+		op, ok := self.nonceMap[addr]
+		if !ok {
+			op = &NonceOp{ContractAddress: addr, Nonce: self.GetNonce(addr)}
+			self.nonceMap[addr] = op
+		}
+		op.Nonce++
+		if len(op.Versions) == 0 || op.Versions[len(op.Versions)-1] != version {
+			op.Versions = append(op.Versions, version)
+		}
+	}
+	if txSim.isRevert {
+		return
+	}
+	for _, change := range txSim.oc {
+		addr := change.getAddr()
+		op, ok := self.accountMap[addr]
+		if !ok {
+			op = &AccountOp{ContractAddress: addr}
+			self.accountMap[addr] = op
+		}
+		op.merge(change)
+		op.Version = version
+	}
+}
+
 //ApplyTxSim 将模拟交易的变更应用到stateObject的MPT树中，可异步进行
 func (self *StateDB) ApplyTxSim(txSim *TxSimulator, isProposer bool) {
 	if len(txSim.writeMap) != 0 {
@@ -0,0 +1,160 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/venadb/memorydb"
+)
+
+// simulateCreate records the operations of the EVM creating a contract.
+func simulateCreate(txSim *TxSimulator, sender common.Address, code []byte) common.Address {
+	addr := crypto.CreateAddress(sender, txSim.GetNonce(sender))
+	txSim.AddNonce(sender)
+	txSim.CreateAccount(addr)
+	txSim.AddNonce(addr)
+	txSim.SetCode(addr, code)
+	txSim.SetContractCreator(addr, sender)
+	return addr
+}
+
+func TestTxSimulatorContractCreation(t *testing.T) {
+	var (
+		sender = common.HexToAddress("0x1000000000000000000000000000000000000aaa")
+		code   = []byte{0x00, 0x61, 0x73, 0x6d}
+		txs    = []*types.Transaction{
+			types.NewTransaction(1, common.HexToAddress("0x01"), new(big.Int), 0, new(big.Int), []byte{0x01}),
+			types.NewContractCreation(2, new(big.Int), 0, new(big.Int), code),
+			types.NewContractCreation(3, new(big.Int), 0, new(big.Int), code),
+		}
+		applyCh = make(chan *TxSimulator, 4)
+	)
+	statedb, _ := New(common.Hash{}, NewDatabase(memorydb.NewMemDatabase()))
+	statedb.SetNonce(sender, 3)
+	statedb.StartProcess()
+
+	call := NewTxSimulator(statedb, txs[0])
+	call.AddNonce(sender)
+	call.SetReceipt(&types.Receipt{})
+	create := NewTxSimulator(statedb, txs[1])
+	simulateCreate(create, sender, code)
+	create.SetReceipt(&types.Receipt{})
+
+	if ok, _ := statedb.AddTxSim(call, applyCh, false); !ok {
+		t.Fatal("call not added")
+	}
+	// the creation derived its address from a nonce changed meanwhile
+	if ok, _ := statedb.AddTxSim(create, applyCh, false); ok {
+		t.Fatal("expected the creation to conflict")
+	}
+
+	create = NewTxSimulator(statedb, txs[1])
+	contract := simulateCreate(create, sender, code)
+	create.SetReceipt(&types.Receipt{})
+	if contract != crypto.CreateAddress(sender, 4) {
+		t.Fatalf("creation address mismatch: %x", contract)
+	}
+	if ok, _ := statedb.AddTxSim(create, applyCh, false); !ok {
+		t.Fatal("creation not added")
+	}
+
+	// a following creation sees the pending nonce and code
+	next := NewTxSimulator(statedb, txs[2])
+	if !bytes.Equal(next.GetCode(contract), code) || next.GetContractCreator(contract) != sender || !next.Exist(contract) {
+		t.Fatal("pending contract not visible to the next transaction")
+	}
+	if addr := simulateCreate(next, sender, code); addr != crypto.CreateAddress(sender, 5) {
+		t.Fatalf("creation address mismatch: %x", addr)
+	}
+	next.SetReceipt(&types.Receipt{})
+	if ok, _ := statedb.AddTxSim(next, applyCh, false); !ok {
+		t.Fatal("next creation not added")
+	}
+
+	dag := statedb.GetDag()
+	if len(dag) != 3 || len(dag[0]) != 0 {
+		t.Fatalf("unexpected dag: %v", dag)
+	}
+	if !dependsOn(dag[1], 0) || !dependsOn(dag[2], 0) || !dependsOn(dag[2], 1) {
+		t.Fatalf("creations should depend on the previous nonce and code writers: %v", dag)
+	}
+
+	for i := 0; i < 3; i++ {
+		statedb.ApplyTxSim(<-applyCh, true)
+	}
+	statedb.StopProcess()
+	if statedb.GetNonce(sender) != 6 || !bytes.Equal(statedb.GetCode(contract), code) || statedb.GetContractCreator(contract) != sender {
+		t.Fatal("creation not applied")
+	}
+}
+
+func dependsOn(d types.Dependency, index uint) bool {
+	for _, v := range d {
+		if v == index {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTxSimulatorRebind(t *testing.T) {
+	var (
+		contract = common.HexToAddress("0x1000000000000000000000000000000000000aaa")
+		txs      = []*types.Transaction{
+			types.NewTransaction(1, contract, new(big.Int), 0, new(big.Int), []byte{0x01}),
+			types.NewTransaction(2, contract, new(big.Int), 0, new(big.Int), []byte{0x02}),
+		}
+		applyCh = make(chan *TxSimulator, 2)
+		db      = NewDatabase(memorydb.NewMemDatabase())
+	)
+	statedb, _ := New(common.Hash{}, db)
+	statedb.SetState(contract, []byte("a"), []byte{0x01})
+	root, _ := statedb.Commit(false)
+
+	// simulate against the head before the block is processed
+	head, _ := New(root, db)
+	read := NewTxSimulator(head, txs[1])
+	read.GetState(contract, []byte("a"))
+	read.SetState(contract, []byte("b"), []byte{0x02})
+	read.SetReceipt(&types.Receipt{})
+
+	statedb, _ = New(root, db)
+	statedb.StartProcess()
+	if statedb.IsTxSimStale(read) {
+		t.Fatal("simulation stale on an unchanged state")
+	}
+	write := NewTxSimulator(statedb, txs[0])
+	write.SetState(contract, []byte("a"), []byte{0x03})
+	write.SetReceipt(&types.Receipt{})
+	if ok, _ := statedb.AddTxSim(write, applyCh, false); !ok {
+		t.Fatal("write not added")
+	}
+	if !statedb.IsTxSimStale(read) {
+		t.Fatal("expected the simulation to be stale after the write of its read set")
+	}
+
+	statedb, _ = New(root, db)
+	statedb.StartProcess()
+	applyCh = make(chan *TxSimulator, 1)
+	rebound := read.Rebind(statedb)
+	if statedb.IsTxSimStale(rebound) {
+		t.Fatal("rebound simulation stale on an unchanged state")
+	}
+	if ok, _ := statedb.AddTxSim(rebound, applyCh, false); !ok {
+		t.Fatal("rebound simulation not added")
+	}
+	statedb.ApplyTxSim(<-applyCh, true)
+	statedb.StopProcess()
+	root, _ = statedb.Commit(false)
+	statedb, _ = New(root, db)
+	if !bytes.Equal(statedb.GetState(contract, []byte("b")), []byte{0x02}) {
+		t.Fatal("rebound simulation not applied")
+	}
+	if len(head.GetState(contract, []byte("b"))) != 0 {
+		t.Fatal("head state modified by the simulation")
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"sort"
 	"strings"
 	"sync"
 
@@ -64,6 +65,86 @@ type FwStatus struct {
 	Active       bool
 	AcceptedList []FwElem
 	RejectedList []FwElem
+	Rules        []FwRule `json:",omitempty"`
+}
+
+// FwRule is a conditional firewall rule. A rule matches a call when the
+// caller and the function match and all the configured conditions hold. The
+// rules are evaluated by ascending priority before the accepted and rejected
+// lists, the first matching rule decides whether the call passes.
+type FwRule struct {
+	ID       string         `json:"id"`
+	Priority uint32         `json:"priority"`
+	Action   string         `json:"action"` // ACCEPT or REJECT
+	Addr     common.Address `json:"addr"`   // FwWildchardAddr matches any caller
+	FuncName string         `json:"funcName"`
+
+	Role    string  `json:"role,omitempty"`    // the caller has the role in UserManagement
+	GroupID *uint64 `json:"groupID,omitempty"` // the caller is a member of the group
+
+	// block number and timestamp window, zero bounds are open
+	FromBlock uint64 `json:"fromBlock,omitempty"`
+	ToBlock   uint64 `json:"toBlock,omitempty"`
+	FromTime  uint64 `json:"fromTime,omitempty"`
+	ToTime    uint64 `json:"toTime,omitempty"`
+
+	// an accepting rule with a rate limit matches at most RateLimit calls of
+	// a caller in every window of RateWindow blocks
+	RateLimit  uint64 `json:"rateLimit,omitempty"`
+	RateWindow uint64 `json:"rateWindow,omitempty"`
+}
+
+// IsReject reports whether the rule rejects the calls it matches.
+func (r *FwRule) IsReject() bool {
+	act, err := NewAction(r.Action)
+	return err != nil || act == reject
+}
+
+type FwRules []FwRule
+
+func (l FwRules) Len() int {
+	return len(l)
+}
+
+func (l FwRules) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func (l FwRules) Less(i, j int) bool {
+	if l[i].Priority != l[j].Priority {
+		return l[i].Priority < l[j].Priority
+	}
+	return l[i].ID < l[j].ID
+}
+
+// SortedFwRules returns a copy of the rules in evaluation order.
+func SortedFwRules(rules []FwRule) []FwRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	sorted := make([]FwRule, len(rules))
+	copy(sorted, rules)
+	sort.Sort(FwRules(sorted))
+	return sorted
+}
+
+// MergeFwRules adds the rules to the list, replacing the rules with the
+// same id.
+func MergeFwRules(list []FwRule, rules ...FwRule) []FwRule {
+	merged := make([]FwRule, 0, len(list)+len(rules))
+	for _, r := range list {
+		replaced := false
+		for _, n := range rules {
+			if n.ID == r.ID {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, r)
+		}
+	}
+	return SortedFwRules(append(merged, rules...))
 }
 
 func (fw *FwStatus) canFindInList(funcName string, caller common.Address, act Action) bool {
@@ -92,6 +173,7 @@ func (fw *FwStatus) IsAccepted(funcName string, caller common.Address) bool {
 type FwData struct {
 	AcceptedList map[string]bool
 	DeniedList   map[string]bool
+	Rules        []FwRule `json:",omitempty"`
 }
 
 func NewAction(action string) (Action, error) {
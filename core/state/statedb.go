@@ -104,8 +104,10 @@ type StateDB struct {
 	writeMap map[string]*WriteOp
 	// 缓存余额变动 键 addr
 	balanceMap map[common.Address]*BalanceOp
-	// 缓存nonce的增加数
-	nonce map[common.Address]int
+	// 缓存nonce的变动 键 addr
+	nonceMap map[common.Address]*NonceOp
+	// 缓存账户的创建以及code、abi、创建人的变动 键 addr
+	accountMap map[common.Address]*AccountOp
 	// 缓存state_object的变动
 	oc map[common.Address]ObjectChange
 	// 缓存已经处理的交易
@@ -138,7 +140,8 @@ func New(root common.Hash, db Database) (*StateDB, error) {
 		readMap:           make(map[string]*ReadOp),
 		writeMap:          make(map[string]*WriteOp),
 		balanceMap:        make(map[common.Address]*BalanceOp),
-		nonce:             make(map[common.Address]int),
+		nonceMap:          make(map[common.Address]*NonceOp),
+		accountMap:        make(map[common.Address]*AccountOp),
 		oc:                make(map[common.Address]ObjectChange),
 		journal:           newJournal(),
 	}, nil
@@ -677,6 +680,33 @@ func (self *StateDB) ForEachStorage(addr common.Address, cb func(key, value comm
 	}
 }
 
+// HasStorage reports whether the storage of the account holds an entry besides
+// its firewall data.
+func (self *StateDB) HasStorage(addr common.Address) bool {
+	so := self.getStateObject(addr)
+	if so == nil {
+		return false
+	}
+	fwKey := addr.String() + string(so.FwDataHash())
+	for key, valueKey := range so.dirtyStorage {
+		if key != fwKey && valueKey != emptyStorage {
+			return true
+		}
+	}
+	it := trie.NewIterator(so.getTrie(self.db).NodeIterator(nil))
+	for it.Next() {
+		key := string(self.trie.GetKey(it.Key))
+		if key == fwKey {
+			continue
+		}
+		if valueKey, dirty := so.dirtyStorage[key]; dirty && valueKey == emptyStorage {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
 // Copy creates a deep, independent copy of the state.
 // Snapshots of the copied state cannot be applied to the copy.
 func (self *StateDB) Copy() *StateDB {
@@ -697,7 +727,8 @@ func (self *StateDB) Copy() *StateDB {
 		readMap:           make(map[string]*ReadOp),
 		writeMap:          make(map[string]*WriteOp),
 		balanceMap:        make(map[common.Address]*BalanceOp),
-		nonce:             make(map[common.Address]int),
+		nonceMap:          make(map[common.Address]*NonceOp),
+		accountMap:        make(map[common.Address]*AccountOp),
 		oc:                make(map[common.Address]ObjectChange),
 	}
 	// Copy the dirty states, logs, and preimages
@@ -994,6 +1025,16 @@ func (self *StateDB) FwSet(addr common.Address, action Action, list []FwElem) {
 		}
 		fwData.DeniedList = stateObject.FwData().DeniedList
 	}
+	fwData.Rules = stateObject.FwData().Rules
+	stateObject.SetFwData(fwData)
+}
+
+// FwSetRules replaces the conditional firewall rules of a contract.
+func (self *StateDB) FwSetRules(addr common.Address, rules []FwRule) {
+	stateObject := self.GetOrNewStateObject(addr)
+
+	fwData := stateObject.FwData()
+	fwData.Rules = SortedFwRules(rules)
 	stateObject.SetFwData(fwData)
 }
 func (self *StateDB) SetFwStatus(addr common.Address, status FwStatus) {
@@ -1006,6 +1047,8 @@ func (self *StateDB) SetFwStatus(addr common.Address, status FwStatus) {
 
 	denied := status.RejectedList
 	self.FwSet(addr, reject, denied)
+
+	self.FwSetRules(addr, status.Rules)
 }
 
 func (self *StateDB) GetFwStatus(addr common.Address) FwStatus {
@@ -1064,6 +1107,7 @@ func (self *StateDB) GetFwStatus(addr common.Address) FwStatus {
 		Active:       fwActive,
 		RejectedList: deniedList,
 		AcceptedList: acceptedList,
+		Rules:        SortedFwRules(fwData.Rules),
 	}
 }
 
@@ -1076,6 +1120,9 @@ func (self *StateDB) FwImport(addr common.Address, data []byte) error {
 	if checkFuncNameValid(status) {
 		self.FwAdd(addr, reject, status.RejectedList)
 		self.FwAdd(addr, accept, status.AcceptedList)
+		if len(status.Rules) != 0 {
+			self.FwSetRules(addr, MergeFwRules(self.GetFwStatus(addr).Rules, status.Rules...))
+		}
 	} else {
 		return errors.New("funcName parameter error")
 	}
@@ -48,6 +48,8 @@ type StateProcessor struct {
 	engine   consensus.Engine    // Consensus engine used for block rewards
 	timeout  time.Duration
 	poolSize int
+
+	preExecutor atomic.Value // *TxPreExecutor reused by the parallel processing
 }
 
 // NewStateProcessor initialises a new StateProcessor.
@@ -57,7 +59,7 @@ func NewStateProcessor(config *params.ChainConfig, bc *BlockChain, engine consen
 		size = runtime.NumCPU() * 2
 	}
 	return &StateProcessor{
-		signer:   types.NewEIP155Signer(config.ChainID),
+		signer:   types.MakeSigner(config),
 		config:   config,
 		bc:       bc,
 		engine:   engine,
@@ -155,7 +157,7 @@ func (p *StateProcessor) CheckAndProcess(block *types.Block, statedb *state.Stat
 		snap := statedb.Snapshot()
 
 		// 防止交易重放
-		ok, err := p.bc.HasTransaction(tx.Hash())
+		ok, err := p.isIncluded(tx)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -183,6 +185,19 @@ func (p *StateProcessor) CheckAndProcess(block *types.Block, statedb *state.Stat
 	return cblock, receipts, nil
 }
 
+// isIncluded reports whether the transaction is already part of the chain,
+// transactions with a bounded validity window are only looked up in the
+// in-memory cache of the recent blocks.
+func (p *StateProcessor) isIncluded(tx *types.Transaction) (bool, error) {
+	if p.bc.HasRecentTransaction(tx.Hash()) {
+		return true, nil
+	}
+	if hasBoundedValidity(tx) {
+		return false, nil
+	}
+	return p.bc.HasTransaction(tx.Hash())
+}
+
 func (p *StateProcessor) ParallelProcessTxs(stateDb *state.StateDB, header *types.Header, txs types.Transactions) error {
 	log.Debug("Parallel Process Txs start")
 
@@ -196,6 +211,7 @@ func (p *StateProcessor) ParallelProcessTxs(stateDb *state.StateDB, header *type
 	gp := new(GasPool).AddGas(header.GasLimit)
 	stateDb.StartProcess()
 	var errCnt int32 = 0
+	var preExecCnt int32 = 0
 
 	goRoutinePool, err := ants.NewPool(p.poolSize, ants.WithOptions(ants.Options{
 		PreAlloc: true,
@@ -217,13 +233,19 @@ func (p *StateProcessor) ParallelProcessTxs(stateDb *state.StateDB, header *type
 					if !stateDb.IsProcess() {
 						return
 					}
-					//执行模拟交易
-					txSim, err := p.SimulateTx(stateDb, tx, header, gp)
-					if err != nil {
-						log.Warn("Transaction failed, skipped", "blockNumber", header.Number,
-							"blockParentHash", header.ParentHash, "hash", tx.Hash(), "err", err)
-						atomic.AddInt32(&errCnt, 1)
-						return
+					//优先使用预执行的模拟交易，否则执行模拟交易
+					txSim := p.preExecuted(stateDb, tx, header, gp)
+					if txSim != nil {
+						atomic.AddInt32(&preExecCnt, 1)
+					} else {
+						var err error
+						txSim, err = p.SimulateTx(stateDb, tx, header, gp)
+						if err != nil {
+							log.Warn("Transaction failed, skipped", "blockNumber", header.Number,
+								"blockParentHash", header.ParentHash, "hash", tx.Hash(), "err", err)
+							atomic.AddInt32(&errCnt, 1)
+							return
+						}
 					}
 
 					if txSim.ReTry() {
@@ -296,10 +318,34 @@ func (p *StateProcessor) ParallelProcessTxs(stateDb *state.StateDB, header *type
 	stateDb.StopProcess()
 	stateDb.UpdateDirtyObject()
 	header.GasUsed = stateDb.GetGasUsed()
-	log.Info("Parallel Process Txs stop", "txCount", stateDb.GetTxsLen(), "gasUsed", header.GasUsed)
+	log.Info("Parallel Process Txs stop", "txCount", stateDb.GetTxsLen(), "preExecuted", atomic.LoadInt32(&preExecCnt), "gasUsed", header.GasUsed)
 	return nil
 }
 
+// SetPreExecutor sets the pre-executor whose simulations are reused by the
+// parallel processing, nil disables the reuse.
+func (p *StateProcessor) SetPreExecutor(e *TxPreExecutor) {
+	p.preExecutor.Store(e)
+}
+
+// preExecuted returns the pre-execution of tx for the block of header rebound
+// to stateDb, or nil if there's none or the state it read has been written by
+// the transactions already added to stateDb.
+func (p *StateProcessor) preExecuted(stateDb *state.StateDB, tx *types.Transaction, header *types.Header, gp *GasPool) *state.TxSimulator {
+	e, _ := p.preExecutor.Load().(*TxPreExecutor)
+	if e == nil {
+		return nil
+	}
+	txSim := e.Result(header.ParentHash, tx.Hash())
+	if txSim == nil || stateDb.IsTxSimStale(txSim) {
+		return nil
+	}
+	if err := gp.SubGas(txSim.GetReceipt().GasUsed); err != nil {
+		return nil
+	}
+	return txSim.Rebind(stateDb)
+}
+
 func (p *StateProcessor) SimulateTx(stateDb *state.StateDB, tx *types.Transaction, header *types.Header, pool *GasPool) (*state.TxSimulator, error) {
 	txSim := state.NewTxSimulator(stateDb, tx)
 	receipt, _, err := ApplyTransactionForSimulator(p.config, p.bc, pool, txSim, header, tx, p.bc.vmConfig)
@@ -323,6 +369,19 @@ func (p *StateProcessor) ParallelProcessTxsWithDag(block *types.Block, statedb *
 		return nil, nil, fmt.Errorf("cycle dependency error")
 	}
 
+	// 防止交易重放, the transactions are checked before any of them runs
+	if check {
+		for _, tx := range block.Transactions() {
+			ok, err := p.isIncluded(tx)
+			if err != nil {
+				return nil, nil, err
+			}
+			if ok {
+				return nil, nil, errors.New("already executed tx")
+			}
+		}
+	}
+
 	header := block.Header()
 	gp := new(GasPool).AddGas(header.GasLimit)
 	runCh := make(chan int, count)                  //用于传递待运行的交易
@@ -358,24 +417,22 @@ func (p *StateProcessor) ParallelProcessTxsWithDag(block *types.Block, statedb *
 			case txIndex := <-runCh:
 				tx := txs[txIndex]
 				err := goRoutinePool.Submit(func() {
-					if check {
-						if r := p.bc.GetReceiptsByHash(tx.Hash()); r != nil {
-							errCh <- errors.New("already executed transaction")
-							return
-						}
-					}
 					if !statedb.IsProcess() {
 						return
 					}
-					//执行模拟交易
-					txSim, err := p.SimulateTx(statedb, tx, header, gp)
-					txSim.SetIndex(txIndex)
-					if err != nil {
-						log.Warn("Transaction failed, skipped", "blockNumber", header.Number,
-							"blockParentHash", header.ParentHash, "hash", tx.Hash(), "err", err)
-						errCh <- err
-						return
+					//优先使用预执行的模拟交易，否则执行模拟交易
+					txSim := p.preExecuted(statedb, tx, header, gp)
+					if txSim == nil {
+						var err error
+						txSim, err = p.SimulateTx(statedb, tx, header, gp)
+						if err != nil {
+							log.Warn("Transaction failed, skipped", "blockNumber", header.Number,
+								"blockParentHash", header.ParentHash, "hash", tx.Hash(), "err", err)
+							errCh <- err
+							return
+						}
 					}
+					txSim.SetIndex(txIndex)
 
 					if txSim.ReTry() {
 						//需要retry的交易等待一段时间，保证前序交易已经处理完成，再重新执行
@@ -533,6 +590,10 @@ func getNoneDependency(index int, left map[int]txNeed, all map[int]txNeed) []int
 // for the transaction, gas used and an error if the transaction failed,
 // indicating the block was invalid.
 func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *common.Address, gp *GasPool, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, cfg vm.Config) (*types.Receipt, uint64, error) {
+	chain, _ := bc.(canonicalReader)
+	if err := ValidateTxValidity(tx, header.Number.Uint64(), chain); err != nil {
+		return nil, 0, err
+	}
 	var from common.Address
 	var gas uint64
 	var gasPrice int64
@@ -654,6 +715,10 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 // for the transaction, gas used and an error if the transaction failed,
 // indicating the block was invalid.
 func ApplyTransactionForSimulator(config *params.ChainConfig, bc ChainContext, gp *GasPool, txSim *state.TxSimulator, header *types.Header, tx *types.Transaction, cfg vm.Config) (*types.Receipt, uint64, error) {
+	chain, _ := bc.(canonicalReader)
+	if err := ValidateTxValidity(tx, header.Number.Uint64(), chain); err != nil {
+		return nil, 0, err
+	}
 	var from common.Address
 	var gas uint64
 	var gasPrice int64
@@ -755,7 +820,7 @@ func ApplyTransactionForSimulator(config *params.ChainConfig, bc ChainContext, g
 	receipt.GasUsed = gas
 	// if the transaction created a contract, store the creation address in the receipt.
 	if tx.To() == nil && err == nil {
-		receipt.ContractAddress = crypto.CreateAddress(from, txSim.GetNonce(from))
+		receipt.ContractAddress = crypto.CreateAddress(from, txSim.GetNonce(from)-1)
 	}
 	// Set the receipt logs and create a bloom for filtering
 
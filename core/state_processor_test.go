@@ -1,13 +1,17 @@
 package core
 
 import (
+	"math/big"
 	"runtime"
 	"testing"
 	"time"
 
 	"github.com/panjf2000/ants/v2"
 
+	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/core/vm"
+	"github.com/Venachain/Venachain/params"
 )
 
 func TestDependency(t *testing.T) {
@@ -88,3 +92,19 @@ func TestPool(t *testing.T) {
 	time.Sleep(1 * time.Second)
 	println("complete")
 }
+
+func TestCheckAndProcessReplayedDagTx(t *testing.T) {
+	validity := &types.TxValidity{ExpiryBlock: 10}
+	fresh := types.NewTransaction(0, common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil).WithValidity(validity)
+	replayed := types.NewTransaction(1, common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil).WithValidity(validity)
+
+	bc := &BlockChain{recentTxs: newTxReplayCache(params.TxValidityMaxBlocks)}
+	bc.recentTxs.add(1, types.Transactions{replayed})
+	processor := NewStateProcessor(params.TestChainConfig, bc, nil)
+
+	header := &types.Header{Number: big.NewInt(2), GasLimit: 1000000}
+	block := types.NewBlockWithDag(header, types.Transactions{fresh, replayed}, nil, make(types.DAG, 2))
+	if _, _, err := processor.CheckAndProcess(block, nil, vm.Config{}); err == nil || err.Error() != "already executed tx" {
+		t.Fatalf("error mismatch: have %v, want already executed tx", err)
+	}
+}
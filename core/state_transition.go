@@ -79,6 +79,10 @@ type Message interface {
 	Nonce() uint64
 	CheckNonce() bool
 	Data() []byte
+
+	// Sponsor returns the account paying the fees in place of the sender,
+	// nil if the sender pays them.
+	Sponsor() *common.Address
 }
 
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
@@ -167,8 +171,24 @@ func (st *StateTransition) buyGas() error {
 	return nil
 }
 
+// feePayer returns the account paying the fees of the message, its sponsor if
+// it has one.
+func (st *StateTransition) feePayer() common.Address {
+	if sponsor := st.msg.Sponsor(); sponsor != nil {
+		return *sponsor
+	}
+	return st.msg.From()
+}
+
 func (st *StateTransition) buyContractGas(contractAddr common.Address) error {
-	addr := st.msg.From().String()
+	if sponsor := st.msg.Sponsor(); sponsor != nil {
+		if err := vm.CheckSponsorLimits(st.state, *sponsor, st.msg.From(), st.msg.To(), st.msg.Gas()); err != nil {
+			log.Error("sponsor limits error", "sponsor", sponsor, "err", err)
+			return err
+		}
+	}
+
+	addr := st.feePayer().String()
 	addr = strings.ToLower(addr)
 	params := []interface{}{addr, st.msg.Gas()}
 
@@ -294,7 +314,7 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, gasPrice
 	} else {
 		st.state.AddNonce(msg.From())
 		var pass bool
-		if ret, pass = vm.FwCheck(evm.StateDB, st.to(), msg.From(), msg.Data()); !pass {
+		if ret, pass = vm.FwCheck(evm, st.to(), msg.From(), msg.Data()); !pass {
 			err = vm.PermissionErr
 			vmerr = vm.PermissionErr
 			log.Debug("Calling contract was refused by firewall", "err", vmerr)
@@ -353,7 +373,7 @@ func (st *StateTransition) refundContractGas(contractAddr common.Address) error
 	// Return ETH for remaining gas, exchanged at the original rate.
 	//remaining := new(big.Int).Mul(new(big.Int).SetUint64(st.gas), st.gasPrice)
 	//st.state.AddBalance(st.msg.From(), remaining)
-	addr := st.msg.From().String()
+	addr := st.feePayer().String()
 	addr = strings.ToLower(addr)
 	params := []interface{}{addr, st.gas}
 	_, _, err := st.doCallContract(contractAddr, "refundFee", params)
@@ -361,6 +381,12 @@ func (st *StateTransition) refundContractGas(contractAddr common.Address) error
 		log.Warn("refundContractGas error", "err", err.Error()) //TODO format
 		return err
 	}
+	if sponsor := st.msg.Sponsor(); sponsor != nil {
+		if err := vm.ChargeSponsorLimits(st.state, *sponsor, st.msg.From(), st.msg.To(), st.gasUsed()); err != nil {
+			log.Warn("charge sponsor limits error", "err", err)
+			return err
+		}
+	}
 
 	// Also return remaining gas to the block gas counter so it is
 	// available for the next transaction.
@@ -390,7 +416,7 @@ func checkContractDeployPermission(sender common.Address, evm *vm.EVM) bool {
 		return true
 	}
 
-	if vm.HasContractDeployPermission(evm.StateDB, sender) {
+	if vm.HasContractDeployPermission(evm.StateDB, sender, evm.BlockNumber) {
 		return true
 	}
 
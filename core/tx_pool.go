@@ -33,6 +33,7 @@ import (
 	"github.com/Venachain/Venachain/core/rawdb"
 	"github.com/Venachain/Venachain/core/state"
 	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/core/vm"
 	"github.com/Venachain/Venachain/event"
 	"github.com/Venachain/Venachain/log"
 	"github.com/Venachain/Venachain/metrics"
@@ -66,6 +67,10 @@ var (
 	// ErrInvalidSender is returned if the transaction contains an invalid signature.
 	ErrInvalidSender = errors.New("invalid sender")
 
+	// ErrInvalidSponsor is returned if the sponsor of a transaction didn't sign
+	// it properly.
+	ErrInvalidSponsor = errors.New("invalid sponsor")
+
 	// ErrNonceTooLow is returned if the nonce of a transaction is lower than the
 	// one present in the local chain.
 	ErrNonceTooLow = errors.New("nonce too low")
@@ -145,7 +150,9 @@ const (
 type txPoolBlockChain interface {
 	CurrentBlock() *types.Block
 	GetBlock(hash common.Hash, number uint64) *types.Block
+	GetHeaderByNumber(number uint64) *types.Header
 	GetState(header *types.Header) (*state.StateDB, error)
+	HasRecentTransaction(hash common.Hash) bool
 	SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) event.Subscription
 	SubscribeBlockConsensusFinishEvent(ch chan<- BlockConsensusFinishEvent) event.Subscription
 }
@@ -274,7 +281,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain txPoo
 		config:      config,
 		chainconfig: chainconfig,
 		chain:       chain,
-		signer:      types.NewEIP155Signer(chainconfig.ChainID),
+		signer:      types.MakeSigner(chainconfig),
 		pending:     make(map[common.Address]*txQueuedMap),
 		all:         newTxLookup(),
 		db:          db,
@@ -478,6 +485,7 @@ func (pool *TxPool) reset(oldBlock, newBlock *types.Block) {
 	// have been invalidated because of another transaction (e.g.
 	// higher gas price)
 	pool.demoteUnexecutables(newBlock.Transactions(), newBlock.Hash())
+	pool.removeExpiredTxs(newHead.Number.Uint64() + 1)
 }
 
 // Stop terminates the transaction pool.
@@ -650,10 +658,27 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	}
 
 	// Make sure the transaction is signed properly
-	_, err := types.Sender(pool.signer, tx)
+	from, err := types.Sender(pool.signer, tx)
 	if err != nil {
 		return ErrInvalidSender
 	}
+	// Make sure the sponsor, if any, signed it too and still pays for it
+	if tx.Sponsorship() != nil {
+		sponsor, err := types.Sponsor(pool.signer, tx)
+		if err != nil {
+			return ErrInvalidSponsor
+		}
+		if common.SysCfg.GetIsTxUseGas() {
+			if err := vm.CheckSponsorLimits(pool.currentState, sponsor, from, tx.To(), tx.Gas()); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Make sure the transaction can still be included in the next block
+	if err := ValidateTxValidity(tx, pool.chain.CurrentBlock().NumberU64()+1, pool.chain); err != nil {
+		return err
+	}
 
 	if !isCallParamManager(tx.To()) && common.SysCfg.GetIsTxUseGas() && common.SysCfg.GetGasContractName() != "" {
 		contractCreation := tx.To() == nil
@@ -683,7 +708,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 	// If the transaction is already known, discard it
 	hash := tx.Hash()
 
-	if ok, _ := rawdb.HasTransaction(pool.db, tx.Hash()); ok {
+	if pool.isIncluded(tx) {
 		log.Error("Transaction Repeat", "hash", tx.Hash().String())
 		return false, ErrTransactionRepeat
 	}
@@ -709,6 +734,20 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 	return false, nil
 }
 
+// isIncluded reports whether the transaction is already part of the chain.
+// Transactions with a bounded validity window can only be included in recent
+// blocks, so they are looked up in the in-memory replay cache only.
+func (pool *TxPool) isIncluded(tx *types.Transaction) bool {
+	if pool.chain.HasRecentTransaction(tx.Hash()) {
+		return true
+	}
+	if hasBoundedValidity(tx) {
+		return false
+	}
+	ok, _ := rawdb.HasTransaction(pool.db, tx.Hash())
+	return ok
+}
+
 // journalTx adds the specified transaction to the local disk journal if it is
 // deemed to have been sent from a local account.
 func (pool *TxPool) journalTx(from common.Address, tx *types.Transaction) {
@@ -1001,6 +1040,25 @@ func (pool *TxPool) removeTxsForBalanceSufficient() {
 	}
 }
 
+// removeExpiredTxs drops the transactions whose validity window doesn't
+// allow them to be included in the block with the given number.
+func (pool *TxPool) removeExpiredTxs(number uint64) {
+	for addr, list := range pool.pending {
+		for _, tx := range list.Get() {
+			if err := ValidateTxValidity(tx, number, pool.chain); err != nil {
+				hash := tx.Hash()
+				log.Trace("Removed invalid transaction", "hash", hash, "err", err)
+				list.Remove(hash)
+				pool.all.Remove(hash)
+			}
+		}
+
+		if list.Len() == 0 {
+			delete(pool.pending, addr)
+		}
+	}
+}
+
 func (pool *TxPool) GetResetNumber() *big.Int {
 	pool.mu.RLock()
 	defer pool.mu.RUnlock()
@@ -81,10 +81,18 @@ func (bc *testBlockChain) SubscribeBlockConsensusFinishEvent(ch chan<- BlockCons
 	return bc.blockConsensusFinishEvent.Subscribe(ch)
 }
 
+func (bc *testBlockChain) GetHeaderByNumber(number uint64) *types.Header {
+	return bc.CurrentBlock().Header()
+}
+
 func (bc *testBlockChain) GetState(header *types.Header) (*state.StateDB, error) {
 	return bc.statedb, nil
 }
 
+func (bc *testBlockChain) HasRecentTransaction(hash common.Hash) bool {
+	return false
+}
+
 func transaction(nonce uint64, gaslimit uint64, key *ecdsa.PrivateKey) *types.Transaction {
 	return pricedTransaction(nonce, gaslimit, big.NewInt(1), key)
 }
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/state"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/event"
+	"github.com/Venachain/Venachain/log"
+)
+
+const (
+	// preExecChanSize is the size of the channels listening to the new
+	// transactions and the chain head.
+	preExecChanSize = 4096
+)
+
+var errUnsupportedProcessor = errors.New("pre-execution needs the state processor of the chain")
+
+// TxPreExecutor speculatively simulates the pending transactions of the pool
+// against the state of the chain head while the next block is agreed on. The
+// proposer and the validators of the next block reuse the simulations whose
+// read set hasn't been written by the transactions before them, instead of
+// running the transactions again.
+//
+// A simulation reading the coinbase, timestamp or gas limit of its block is
+// dropped, the header of the next block isn't known yet.
+type TxPreExecutor struct {
+	bc        *BlockChain
+	pool      *TxPool
+	processor *StateProcessor
+	workers   *ants.Pool
+
+	mu      sync.RWMutex
+	parent  common.Hash                        // head the simulations run on
+	statedb *state.StateDB                     // state of the head shared by the simulations
+	header  *types.Header                      // provisional header of the next block
+	results map[common.Hash]*state.TxSimulator // tx hash -> simulation
+
+	headCh  chan ChainHeadEvent
+	headSub event.Subscription
+	txsCh   chan NewTxsEvent
+	txsSub  event.Subscription
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewTxPreExecutor creates a pre-executor of the pending transactions of pool
+// and registers it to the state processor of the chain.
+func NewTxPreExecutor(bc *BlockChain, pool *TxPool) (*TxPreExecutor, error) {
+	processor, ok := bc.Processor().(*StateProcessor)
+	if !ok {
+		return nil, errUnsupportedProcessor
+	}
+	workers, err := ants.NewPool(processor.poolSize)
+	if err != nil {
+		return nil, err
+	}
+	e := &TxPreExecutor{
+		bc:        bc,
+		pool:      pool,
+		processor: processor,
+		workers:   workers,
+		results:   make(map[common.Hash]*state.TxSimulator),
+		headCh:    make(chan ChainHeadEvent, preExecChanSize),
+		txsCh:     make(chan NewTxsEvent, preExecChanSize),
+		quit:      make(chan struct{}),
+	}
+	e.headSub = bc.SubscribeChainHeadEvent(e.headCh)
+	e.txsSub = pool.SubscribeNewTxsEvent(e.txsCh)
+	processor.SetPreExecutor(e)
+
+	e.reset(bc.CurrentBlock())
+	e.wg.Add(1)
+	go e.loop()
+	return e, nil
+}
+
+// Stop stops the pre-execution and unregisters it from the state processor.
+func (e *TxPreExecutor) Stop() {
+	e.processor.SetPreExecutor(nil)
+	e.headSub.Unsubscribe()
+	e.txsSub.Unsubscribe()
+	close(e.quit)
+	e.wg.Wait()
+	e.workers.Release()
+}
+
+func (e *TxPreExecutor) loop() {
+	defer e.wg.Done()
+
+	for {
+		select {
+		case ev := <-e.headCh:
+			e.reset(ev.Block)
+		case ev := <-e.txsCh:
+			e.execute(ev.Txs)
+		case <-e.headSub.Err():
+			return
+		case <-e.txsSub.Err():
+			return
+		case <-e.quit:
+			return
+		}
+	}
+}
+
+// reset drops the simulations on the previous head and simulates the pending
+// transactions against the new one.
+func (e *TxPreExecutor) reset(head *types.Block) {
+	if head == nil {
+		return
+	}
+	statedb, err := e.bc.StateAt(head.Root())
+	if err != nil {
+		log.Debug("Failed to open the state for pre-execution", "number", head.Number(), "err", err)
+		return
+	}
+	header := &types.Header{
+		ParentHash: head.Hash(),
+		Number:     new(big.Int).Add(head.Number(), common.Big1),
+		GasLimit:   head.GasLimit(),
+		Time:       big.NewInt(time.Now().Unix()),
+	}
+
+	e.mu.Lock()
+	e.parent = head.Hash()
+	e.statedb = statedb
+	e.header = header
+	e.results = make(map[common.Hash]*state.TxSimulator)
+	e.mu.Unlock()
+
+	pending, err := e.pool.Pending()
+	if err != nil {
+		return
+	}
+	for _, txs := range pending {
+		e.execute(txs)
+	}
+}
+
+// execute simulates the transactions against the current head.
+func (e *TxPreExecutor) execute(txs types.Transactions) {
+	if !common.SysCfg.IsUseDAG() {
+		return
+	}
+	e.mu.RLock()
+	parent, statedb, header := e.parent, e.statedb, e.header
+	e.mu.RUnlock()
+	if statedb == nil {
+		return
+	}
+
+	for _, tx := range txs {
+		tx := tx
+		if err := e.workers.Submit(func() { e.simulate(parent, statedb, header, tx) }); err != nil {
+			log.Debug("Failed to submit pre-execution", "hash", tx.Hash(), "err", err)
+			return
+		}
+	}
+}
+
+func (e *TxPreExecutor) simulate(parent common.Hash, statedb *state.StateDB, header *types.Header, tx *types.Transaction) {
+	if e.Result(parent, tx.Hash()) != nil {
+		return
+	}
+	// the simulation runs on a copy, a revert mustn't use up the retries
+	// of the transaction in the block
+	cpy := new(types.Transaction)
+	*cpy = *tx
+	txSim, err := e.processor.SimulateTx(statedb, cpy, header, new(GasPool).AddGas(header.GasLimit))
+	if err != nil || txSim.ReTry() || txSim.HeaderRead() {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.parent == parent {
+		e.results[tx.Hash()] = txSim
+	}
+}
+
+// Result returns the simulation of a transaction against the block parent.
+func (e *TxPreExecutor) Result(parent common.Hash, hash common.Hash) *state.TxSimulator {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.parent != parent {
+		return nil
+	}
+	return e.results[hash]
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"sync"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/params"
+)
+
+// txReplayCache remembers the hashes of the transactions included in the most
+// recent blocks. A transaction carrying a validity window can only be included
+// within params.TxValidityMaxBlocks blocks, so its replays are rejected by
+// this cache without a lookup in the transaction index.
+type txReplayCache struct {
+	mu     sync.RWMutex
+	limit  uint64
+	blocks map[uint64][]common.Hash // block number -> included tx hashes
+	hashes map[common.Hash]uint64   // tx hash -> including block number
+}
+
+func newTxReplayCache(limit uint64) *txReplayCache {
+	return &txReplayCache{
+		limit:  limit,
+		blocks: make(map[uint64][]common.Hash),
+		hashes: make(map[common.Hash]uint64),
+	}
+}
+
+// add records the transactions included in the block with the given number,
+// replacing the ones of a previous block at the same height, and evicts the
+// blocks falling out of the window.
+func (c *txReplayCache) add(number uint64, txs types.Transactions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeBlock(number)
+	hashes := make([]common.Hash, 0, len(txs))
+	for _, tx := range txs {
+		hash := tx.Hash()
+		hashes = append(hashes, hash)
+		c.hashes[hash] = number
+	}
+	c.blocks[number] = hashes
+
+	if number < c.limit {
+		return
+	}
+	for n := range c.blocks {
+		if n <= number-c.limit {
+			c.removeBlock(n)
+		}
+	}
+}
+
+// removeBlock drops the transactions of the given block, the lock must be held.
+func (c *txReplayCache) removeBlock(number uint64) {
+	for _, hash := range c.blocks[number] {
+		if n, ok := c.hashes[hash]; ok && n == number {
+			delete(c.hashes, hash)
+		}
+	}
+	delete(c.blocks, number)
+}
+
+// contains reports whether the transaction was included in a recent block.
+func (c *txReplayCache) contains(hash common.Hash) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.hashes[hash]
+	return ok
+}
+
+// canonicalReader retrieves the headers of the canonical chain.
+type canonicalReader interface {
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// ValidateTxValidity checks the validity window of tx against the block with
+// the given number. Transactions without a window are always valid and never
+// touch the chain. The window can't span more than params.TxValidityMaxBlocks
+// blocks, and the reference block, if any, must be on the canonical chain.
+func ValidateTxValidity(tx *types.Transaction, number uint64, chain canonicalReader) error {
+	v := tx.Validity()
+	if v == nil {
+		return nil
+	}
+	last, bounded := v.LastBlock(params.TxValidityMaxBlocks)
+	if !bounded {
+		return nil
+	}
+	if v.HasRefBlock() && v.RefBlockNumber >= number {
+		return ErrUnknownRefBlock
+	}
+	if number > last {
+		return ErrTxExpired
+	}
+	if last >= number+params.TxValidityMaxBlocks {
+		return ErrTxValidityTooLong
+	}
+	if v.HasRefBlock() && chain != nil {
+		if header := chain.GetHeaderByNumber(v.RefBlockNumber); header == nil || header.Hash() != v.RefBlockHash {
+			return ErrUnknownRefBlock
+		}
+	}
+	return nil
+}
+
+// hasBoundedValidity reports whether tx carries a validity window bounding the
+// blocks it can be included in.
+func hasBoundedValidity(tx *types.Transaction) bool {
+	v := tx.Validity()
+	if v == nil {
+		return false
+	}
+	_, bounded := v.LastBlock(params.TxValidityMaxBlocks)
+	return bounded
+}
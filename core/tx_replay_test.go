@@ -0,0 +1,101 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/types"
+)
+
+func TestTxReplayCache(t *testing.T) {
+	cache := newTxReplayCache(2)
+	txs := make(types.Transactions, 3)
+	for i := range txs {
+		txs[i] = types.NewTransaction(uint64(i), common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	}
+
+	cache.add(1, txs[:1])
+	cache.add(2, txs[1:2])
+	if !cache.contains(txs[0].Hash()) || !cache.contains(txs[1].Hash()) {
+		t.Fatalf("recent transactions missing from the cache")
+	}
+	cache.add(3, txs[2:])
+	if cache.contains(txs[0].Hash()) {
+		t.Errorf("transaction of evicted block still cached")
+	}
+	if !cache.contains(txs[1].Hash()) || !cache.contains(txs[2].Hash()) {
+		t.Errorf("recent transactions missing from the cache")
+	}
+
+	// re-adding a height replaces its transactions
+	cache.add(3, nil)
+	if cache.contains(txs[2].Hash()) {
+		t.Errorf("transaction of replaced block still cached")
+	}
+}
+
+func TestValidateTxValidity(t *testing.T) {
+	tx := types.NewTransaction(0, common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	if err := ValidateTxValidity(tx, 1000000, nil); err != nil {
+		t.Errorf("legacy transaction rejected: %v", err)
+	}
+
+	expiring := tx.WithValidity(&types.TxValidity{ExpiryBlock: 10})
+	if err := ValidateTxValidity(expiring, 10, nil); err != nil {
+		t.Errorf("transaction rejected within its window: %v", err)
+	}
+	if err := ValidateTxValidity(expiring, 11, nil); err != ErrTxExpired {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrTxExpired)
+	}
+
+	tooLong := tx.WithValidity(&types.TxValidity{ExpiryBlock: 5000})
+	if err := ValidateTxValidity(tooLong, 10, nil); err != ErrTxValidityTooLong {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrTxValidityTooLong)
+	}
+
+	referencing := tx.WithValidity(&types.TxValidity{RefBlockNumber: 20, RefBlockHash: common.Hash{1}})
+	if err := ValidateTxValidity(referencing, 20, nil); err != ErrUnknownRefBlock {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrUnknownRefBlock)
+	}
+	if err := ValidateTxValidity(referencing, 21, nil); err != nil {
+		t.Errorf("transaction rejected within its window: %v", err)
+	}
+}
+
+// canonicalChain serves the canonical headers and counts the lookups.
+type canonicalChain struct {
+	headers map[uint64]*types.Header
+	lookups int
+}
+
+func (c *canonicalChain) GetHeaderByNumber(number uint64) *types.Header {
+	c.lookups++
+	return c.headers[number]
+}
+
+func TestValidateTxValidityRefBlock(t *testing.T) {
+	canonical := &types.Header{Number: big.NewInt(20), Extra: []byte("canonical")}
+	side := &types.Header{Number: big.NewInt(20), Extra: []byte("side")}
+	chain := &canonicalChain{headers: map[uint64]*types.Header{20: canonical}}
+
+	tx := types.NewTransaction(0, common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	if err := ValidateTxValidity(tx, 21, chain); err != nil {
+		t.Errorf("legacy transaction rejected: %v", err)
+	}
+	if err := ValidateTxValidity(tx.WithValidity(&types.TxValidity{}), 21, chain); err != nil {
+		t.Errorf("unbounded transaction rejected: %v", err)
+	}
+	if chain.lookups != 0 {
+		t.Errorf("transactions without a bound looked up %d headers", chain.lookups)
+	}
+
+	onCanonical := tx.WithValidity(&types.TxValidity{RefBlockNumber: 20, RefBlockHash: canonical.Hash()})
+	if err := ValidateTxValidity(onCanonical, 21, chain); err != nil {
+		t.Errorf("transaction referencing the canonical chain rejected: %v", err)
+	}
+	onSide := tx.WithValidity(&types.TxValidity{RefBlockNumber: 20, RefBlockHash: side.Hash()})
+	if err := ValidateTxValidity(onSide, 21, chain); err != ErrUnknownRefBlock {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrUnknownRefBlock)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/rlp"
 )
 
 var _ = (*txdataMarshaling)(nil)
@@ -26,6 +27,10 @@ func (t txdata) MarshalJSON() ([]byte, error) {
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
+		Validity     *TxValidity     `json:"validity,omitempty" rlp:"-"`
+		Sponsorship  *TxSponsorship  `json:"sponsorship,omitempty" rlp:"-"`
+		SignerKey    hexutil.Bytes   `json:"signerKey,omitempty" rlp:"-"`
+		Extensions   []rlp.RawValue  `json:"-" rlp:"tail"`
 	}
 	var enc txdata
 	enc.AccountNonce = hexutil.Uint64(t.AccountNonce)
@@ -38,6 +43,10 @@ func (t txdata) MarshalJSON() ([]byte, error) {
 	enc.R = (*hexutil.Big)(t.R)
 	enc.S = (*hexutil.Big)(t.S)
 	enc.Hash = t.Hash
+	enc.Validity = t.Validity
+	enc.Sponsorship = t.Sponsorship
+	enc.SignerKey = t.SignerKey
+	enc.Extensions = t.Extensions
 	return json.Marshal(&enc)
 }
 
@@ -54,6 +63,10 @@ func (t *txdata) UnmarshalJSON(input []byte) error {
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
+		Validity     *TxValidity     `json:"validity,omitempty" rlp:"-"`
+		Sponsorship  *TxSponsorship  `json:"sponsorship,omitempty" rlp:"-"`
+		SignerKey    *hexutil.Bytes  `json:"signerKey,omitempty" rlp:"-"`
+		Extensions   []rlp.RawValue  `json:"-" rlp:"tail"`
 	}
 	var dec txdata
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -97,5 +110,17 @@ func (t *txdata) UnmarshalJSON(input []byte) error {
 	if dec.Hash != nil {
 		t.Hash = dec.Hash
 	}
+	if dec.Validity != nil {
+		t.Validity = dec.Validity
+	}
+	if dec.Sponsorship != nil {
+		t.Sponsorship = dec.Sponsorship
+	}
+	if dec.SignerKey != nil {
+		t.SignerKey = *dec.SignerKey
+	}
+	if dec.Extensions != nil {
+		t.Extensions = dec.Extensions
+	}
 	return nil
 }
@@ -35,16 +35,27 @@ import (
 //go:generate gencodec -type txdata -field-override txdataMarshaling -out gen_tx_json.go
 
 var (
-	ErrInvalidSig    = errors.New("invalid transaction v, r, s values")
-	ErrInvalidOldTrx = errors.New("invalid old transaction payload")
+	ErrInvalidSig      = errors.New("invalid transaction v, r, s values")
+	ErrInvalidOldTrx   = errors.New("invalid old transaction payload")
+	ErrInvalidValidity = errors.New("invalid transaction validity window")
+
+	ErrInvalidSponsorship = errors.New("invalid transaction sponsorship")
+	ErrInvalidSponsorSig  = errors.New("invalid sponsor signature")
+	ErrInvalidExtensions  = errors.New("too many transaction extensions")
+	ErrInvalidSignerKey   = errors.New("invalid transaction signer key")
 )
 
+// maxTxExtensions is the number of optional trailing fields of a transaction:
+// the validity window, the sponsorship and the signer key.
+const maxTxExtensions = 3
+
 type Transaction struct {
 	data txdata
 	// caches
 	hash       atomic.Value
 	size       atomic.Value
 	from       atomic.Value
+	sponsor    atomic.Value
 	rlp        atomic.Value
 	router     int32
 	processCnt int32
@@ -66,6 +77,18 @@ type txdata struct {
 
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
+
+	// Optional validity window, fee sponsorship and compressed SM2 public key
+	// of the sender, decoded from Extensions.
+	Validity    *TxValidity    `json:"validity,omitempty" rlp:"-"`
+	Sponsorship *TxSponsorship `json:"sponsorship,omitempty" rlp:"-"`
+	SignerKey   []byte         `json:"signerKey,omitempty" rlp:"-"`
+
+	// Optional trailing fields, legacy transactions don't carry them and keep
+	// their encoding: the validity window, the sponsorship and the signer key.
+	// A transaction carrying one of the later fields without the former ones
+	// carries an unbounded window and an empty sponsorship in their place.
+	Extensions []rlp.RawValue `json:"-" rlp:"tail"`
 }
 
 type txdataMarshaling struct {
@@ -74,6 +97,7 @@ type txdataMarshaling struct {
 	GasLimit     hexutil.Uint64
 	Amount       *hexutil.Big
 	Payload      hexutil.Bytes
+	SignerKey    hexutil.Bytes
 	//CnsData	     hexutil.Bytes
 	V *hexutil.Big
 	R *hexutil.Big
@@ -158,6 +182,9 @@ func (tx *Transaction) EncodeRLP(w io.Writer) error {
 func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
 	_, size, _ := s.Kind()
 	err := s.Decode(&tx.data)
+	if err == nil {
+		err = tx.data.decodeExtensions()
+	}
 	if err == nil {
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 	}
@@ -189,10 +216,84 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	if !crypto.ValidateSignatureValues(V, dec.R, dec.S, false) {
 		return ErrInvalidSig
 	}
+	if err := dec.setExtensions(); err != nil {
+		return err
+	}
 	*tx = Transaction{data: dec}
 	return nil
 }
 
+// setExtensions encodes the validity window, the sponsorship and the signer
+// key of d into its trailing fields.
+func (d *txdata) setExtensions() error {
+	d.Extensions = nil
+	if d.Validity == nil && d.Sponsorship == nil && d.SignerKey == nil {
+		return nil
+	}
+	validity := d.Validity
+	if validity == nil {
+		validity = new(TxValidity)
+	}
+	enc, err := rlp.EncodeToBytes(validity)
+	if err != nil {
+		return err
+	}
+	d.Extensions = append(d.Extensions, enc)
+	if d.Sponsorship == nil && d.SignerKey == nil {
+		return nil
+	}
+	sponsorship := d.Sponsorship
+	if sponsorship == nil {
+		sponsorship = newTxSponsorship(common.Address{})
+	}
+	if enc, err = rlp.EncodeToBytes(sponsorship); err != nil {
+		return err
+	}
+	d.Extensions = append(d.Extensions, enc)
+	if d.SignerKey != nil {
+		if enc, err = rlp.EncodeToBytes(d.SignerKey); err != nil {
+			return err
+		}
+		d.Extensions = append(d.Extensions, enc)
+	}
+	return nil
+}
+
+// decodeExtensions decodes the validity window, the sponsorship and the signer
+// key from the trailing fields of d.
+func (d *txdata) decodeExtensions() error {
+	d.Validity, d.Sponsorship, d.SignerKey = nil, nil, nil
+	if len(d.Extensions) > maxTxExtensions {
+		return ErrInvalidExtensions
+	}
+	if len(d.Extensions) > 0 {
+		d.Validity = new(TxValidity)
+		if err := rlp.DecodeBytes(d.Extensions[0], d.Validity); err != nil {
+			return ErrInvalidValidity
+		}
+	}
+	if len(d.Extensions) > 1 {
+		d.Sponsorship = new(TxSponsorship)
+		if err := rlp.DecodeBytes(d.Extensions[1], d.Sponsorship); err != nil {
+			return ErrInvalidSponsorship
+		}
+		// the later fields are preceded by a zero window if there is none
+		if *d.Validity == (TxValidity{}) {
+			d.Validity = nil
+		}
+	}
+	if len(d.Extensions) > 2 {
+		if err := rlp.DecodeBytes(d.Extensions[2], &d.SignerKey); err != nil || len(d.SignerKey) != 33 {
+			return ErrInvalidSignerKey
+		}
+		// and by an empty sponsorship if there is none
+		if d.Sponsorship.Sponsor == (common.Address{}) && !d.Sponsorship.Signed() {
+			d.Sponsorship = nil
+		}
+	}
+	return nil
+}
+
 //func (tx *Transaction) Cns() []byte    { return common.CopyBytes(tx.data.CnsData) }
 func (tx *Transaction) Data() []byte       { return common.CopyBytes(tx.data.Payload) }
 func (tx *Transaction) Gas() uint64        { return tx.data.GasLimit }
@@ -201,12 +302,78 @@ func (tx *Transaction) Value() *big.Int    { return new(big.Int).Set(tx.data.Amo
 func (tx *Transaction) Nonce() uint64      { return tx.data.AccountNonce }
 func (tx *Transaction) CheckNonce() bool   { return true }
 
-func (tx *Transaction) From() *common.Address {
-	var signer Signer = FrontierSigner{}
-	if tx.Protected() {
-		signer = NewEIP155Signer(tx.ChainId())
+// Validity returns the validity window of the transaction, nil if it has none.
+func (tx *Transaction) Validity() *TxValidity {
+	if tx.data.Validity == nil {
+		return nil
 	}
-	from, _ := Sender(signer, tx)
+	cpy := *tx.data.Validity
+	return &cpy
+}
+
+// WithValidity returns a new unsigned transaction carrying the given validity
+// window. The sponsor of the transaction, if any, is kept but has to sign it
+// again.
+func (tx *Transaction) WithValidity(validity *TxValidity) *Transaction {
+	cpy := &Transaction{data: tx.data}
+	cpy.data.V, cpy.data.R, cpy.data.S = new(big.Int), new(big.Int), new(big.Int)
+	cpy.data.SignerKey = nil
+	cpy.data.Validity = nil
+	if validity != nil {
+		v := *validity
+		cpy.data.Validity = &v
+	}
+	if tx.data.Sponsorship != nil {
+		cpy.data.Sponsorship = newTxSponsorship(tx.data.Sponsorship.Sponsor)
+	}
+	cpy.data.setExtensions()
+	return cpy
+}
+
+// Sponsorship returns the sponsorship of the transaction, nil if its sender
+// pays its fees.
+func (tx *Transaction) Sponsorship() *TxSponsorship {
+	if tx.data.Sponsorship == nil {
+		return nil
+	}
+	return tx.data.Sponsorship.copy()
+}
+
+// WithSponsor returns a new unsigned transaction whose fees are paid by
+// sponsor, nil for the sender. The transaction has to be signed by the sender,
+// then by the sponsor with SponsorTx.
+func (tx *Transaction) WithSponsor(sponsor *common.Address) *Transaction {
+	cpy := &Transaction{data: tx.data}
+	cpy.data.V, cpy.data.R, cpy.data.S = new(big.Int), new(big.Int), new(big.Int)
+	cpy.data.SignerKey = nil
+	cpy.data.Sponsorship = nil
+	if sponsor != nil {
+		cpy.data.Sponsorship = newTxSponsorship(*sponsor)
+	}
+	cpy.data.setExtensions()
+	return cpy
+}
+
+// WithSponsorSignature returns a new transaction with the given signature of
+// its sponsor, in the [R || S || V] format where V is 0 or 1.
+func (tx *Transaction) WithSponsorSignature(sig []byte) (*Transaction, error) {
+	if tx.data.Sponsorship == nil {
+		return nil, ErrInvalidSponsorship
+	}
+	r, s, v, err := FrontierSigner{}.SignatureValues(tx, sig)
+	if err != nil {
+		return nil, err
+	}
+	cpy := &Transaction{data: tx.data}
+	cpy.data.Sponsorship = &TxSponsorship{Sponsor: tx.data.Sponsorship.Sponsor, V: v, R: r, S: s}
+	if err := cpy.data.setExtensions(); err != nil {
+		return nil, err
+	}
+	return cpy, nil
+}
+
+func (tx *Transaction) From() *common.Address {
+	from, _ := Sender(TxSigner(tx), tx)
 	return &from
 }
 
@@ -261,6 +428,11 @@ func (tx *Transaction) AsMessage(s Signer) (*Message, error) {
 
 	var err error
 	msg.from, err = Sender(s, tx)
+	if err == nil && tx.data.Sponsorship != nil {
+		var sponsor common.Address
+		sponsor, err = Sponsor(s, tx)
+		msg.sponsor = &sponsor
+	}
 	return &msg, err
 }
 
@@ -294,9 +466,33 @@ func (tx *Transaction) WithSignature(signer Signer, sig []byte) (*Transaction, e
 	}
 	cpy := &Transaction{data: tx.data}
 	cpy.data.R, cpy.data.S, cpy.data.V = r, s, v
+	// the key of a previous SM2 signer doesn't belong to the new signature
+	if cpy.data.SignerKey != nil {
+		cpy.data.SignerKey = nil
+		if err := cpy.data.setExtensions(); err != nil {
+			return nil, err
+		}
+	}
 	return cpy, nil
 }
 
+// withSignerKey returns a copy of the transaction carrying the compressed SM2
+// public key of its sender.
+func (tx *Transaction) withSignerKey(key []byte) (*Transaction, error) {
+	cpy := &Transaction{data: tx.data}
+	cpy.data.SignerKey = common.CopyBytes(key)
+	if err := cpy.data.setExtensions(); err != nil {
+		return nil, err
+	}
+	return cpy, nil
+}
+
+// SignerKey returns the compressed SM2 public key of the sender, only the
+// transactions of the SM crypto suite carry it.
+func (tx *Transaction) SignerKey() []byte {
+	return common.CopyBytes(tx.data.SignerKey)
+}
+
 // Cost returns amount + gasprice * gaslimit.
 func (tx *Transaction) Cost() *big.Int {
 	total := new(big.Int).Mul(tx.data.Price, new(big.Int).SetUint64(tx.data.GasLimit))
@@ -470,6 +666,7 @@ type Message struct {
 	gasPrice   *big.Int
 	data       []byte
 	checkNonce bool
+	sponsor    *common.Address
 }
 
 func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, checkNonce bool) *Message {
@@ -496,6 +693,10 @@ func (m *Message) Nonce() uint64        { return m.nonce }
 func (m *Message) Data() []byte         { return m.data }
 func (m *Message) CheckNonce() bool     { return m.checkNonce }
 
+// Sponsor returns the account paying the fees of the message, nil for the
+// sender.
+func (m *Message) Sponsor() *common.Address { return m.sponsor }
+
 func (m *Message) SetTo(to common.Address) { m.to = &to }
 func (m *Message) SetData(b []byte)        { m.data = b }
 func (m *Message) SetNonce(n uint64)       { m.nonce = n }
@@ -18,18 +18,20 @@ package types
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/crypto/sm2"
+	"github.com/Venachain/Venachain/crypto/sm3"
 	"github.com/Venachain/Venachain/params"
+	"github.com/Venachain/Venachain/rlp"
 )
 
-var (
-	ErrInvalidChainId = errors.New("invalid chain id for signer")
-)
+var ErrInvalidChainId = errors.New("invalid chain id for signer")
 
 var (
 	OldTxPrefix    = []byte("old_Tx_Prefix")
@@ -45,13 +47,41 @@ type sigCache struct {
 
 // MakeSigner returns a Signer based on the given chain config and block number.
 func MakeSigner(config *params.ChainConfig) Signer {
+	if config.CryptoSuite == crypto.SuiteSM {
+		return NewSMSigner(config.ChainID)
+	}
 	signer := NewEIP155Signer(config.ChainID)
 
 	return signer
 }
 
+// TxSigner returns the Signer a transaction was signed with, for callers that
+// don't have the chain config: transactions carrying a signer key are SM2
+// signed.
+func TxSigner(tx *Transaction) Signer {
+	switch {
+	case tx.data.SignerKey != nil:
+		return NewSMSigner(tx.ChainId())
+	case tx.Protected():
+		return NewEIP155Signer(tx.ChainId())
+	}
+	return FrontierSigner{}
+}
+
+// SignerForKey returns the Signer of the crypto suite of the key, for callers
+// signing with a key without the chain config.
+func SignerForKey(chainId *big.Int, pub *ecdsa.PublicKey) Signer {
+	if pub.Curve == sm2.P256Sm2() {
+		return NewSMSigner(chainId)
+	}
+	return NewEIP155Signer(chainId)
+}
+
 // SignTx signs the transaction using the given signer and private key
 func SignTx(tx *Transaction, s Signer, prv *ecdsa.PrivateKey) (*Transaction, error) {
+	if sm, ok := s.(SMSigner); ok {
+		return sm.signTx(tx, prv)
+	}
 	h := s.Hash(tx)
 	sig, err := crypto.Sign(h[:], prv)
 	if err != nil {
@@ -60,6 +90,53 @@ func SignTx(tx *Transaction, s Signer, prv *ecdsa.PrivateKey) (*Transaction, err
 	return tx.WithSignature(s, sig)
 }
 
+// SponsorTx signs the transaction as its sponsor using the given signer and
+// private key. The transaction must name the sponsor and be signed by its
+// sender.
+func SponsorTx(tx *Transaction, s Signer, prv *ecdsa.PrivateKey) (*Transaction, error) {
+	h := s.SponsorHash(tx)
+	sign := crypto.Sign
+	if _, ok := s.(SMSigner); ok {
+		sign = sm2.Sign
+	}
+	sig, err := sign(h[:], prv)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSponsorSignature(sig)
+}
+
+// Sponsor returns the account paying the fees of a sponsored transaction, once
+// checked against the signature of the sponsor. The result is cached like the
+// sender.
+func Sponsor(signer Signer, tx *Transaction) (common.Address, error) {
+	sp := tx.data.Sponsorship
+	if sp == nil {
+		return common.Address{}, ErrInvalidSponsorship
+	}
+	if sc := tx.sponsor.Load(); sc != nil {
+		sigCache := sc.(sigCache)
+		if sigCache.signer.Equal(signer) {
+			return sigCache.from, nil
+		}
+	}
+
+	var (
+		addr common.Address
+		err  error
+	)
+	if _, ok := signer.(SMSigner); ok {
+		addr, err = recoverSM2(signer.SponsorHash(tx), sp.R, sp.S, sp.V)
+	} else {
+		addr, err = RecoverPlain(signer.SponsorHash(tx), sp.R, sp.S, sp.V, true)
+	}
+	if err != nil || addr != sp.Sponsor {
+		return common.Address{}, ErrInvalidSponsorSig
+	}
+	tx.sponsor.Store(sigCache{signer: signer, from: addr})
+	return addr, nil
+}
+
 // Sender returns the address derived from the signature (V, R, S) using secp256k1
 // elliptic curve and an error if it failed deriving or upon an incorrect
 // signature.
@@ -100,6 +177,9 @@ type Signer interface {
 	Equal(Signer) bool
 	// Signature return the sig info of the transaction
 	SignatureAndSender(tx *Transaction) (common.Address, []byte, error)
+	// SponsorHash returns the hash to be signed by the sponsor of a
+	// sponsored transaction, once signed by its sender.
+	SponsorHash(tx *Transaction) common.Hash
 }
 
 // EIP155Transaction implements Signer using the EIP155 rules.
@@ -153,7 +233,7 @@ func (s EIP155Signer) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (s EIP155Signer) Hash(tx *Transaction) common.Hash {
-	return rlpHash([]interface{}{
+	return rlpHash(withExtensions([]interface{}{
 		tx.data.AccountNonce,
 		tx.data.Price,
 		tx.data.GasLimit,
@@ -161,7 +241,12 @@ func (s EIP155Signer) Hash(tx *Transaction) common.Hash {
 		tx.data.Amount,
 		tx.data.Payload,
 		s.chainId, uint(0), uint(0),
-	})
+	}, tx))
+}
+
+// SponsorHash returns the hash to be signed by the sponsor of tx.
+func (s EIP155Signer) SponsorHash(tx *Transaction) common.Hash {
+	return rlpHash(sponsorFields(s, tx))
 }
 
 func (s EIP155Signer) SignatureAndSender(tx *Transaction) (common.Address, []byte, error) {
@@ -176,6 +261,133 @@ func (s EIP155Signer) SignatureAndSender(tx *Transaction) (common.Address, []byt
 	return recoverPubKeyAndSender(s.Hash(tx), tx.data.R, tx.data.S, V, true)
 }
 
+// SMSigner implements Signer for chains running the SM crypto suite. It
+// follows the EIP155 rules, but the signed hash is an SM3 digest and the
+// sender signs it with a standard GB/T 32918 SM2 signature, over the ZA digest
+// of its public key and the default user identity. As such a signature can't
+// be recovered, the transaction carries the compressed public key of the
+// sender, and V always holds the recovery id 0. Unprotected transactions are
+// not accepted.
+//
+// Sponsors sign with the recoverable SM2 variant, see sm2.Sign.
+type SMSigner struct {
+	EIP155Signer
+}
+
+func NewSMSigner(chainId *big.Int) SMSigner {
+	return SMSigner{NewEIP155Signer(chainId)}
+}
+
+func (s SMSigner) Equal(s2 Signer) bool {
+	sm, ok := s2.(SMSigner)
+	return ok && sm.chainId.Cmp(s.chainId) == 0
+}
+
+func (s SMSigner) Sender(tx *Transaction) (common.Address, error) {
+	addr, _, err := s.SignatureAndSender(tx)
+	return addr, err
+}
+
+// Hash returns the SM3 hash to be signed by the sender.
+// It does not uniquely identify the transaction.
+func (s SMSigner) Hash(tx *Transaction) common.Hash {
+	return sm3RlpHash(withExtensions([]interface{}{
+		tx.data.AccountNonce,
+		tx.data.Price,
+		tx.data.GasLimit,
+		tx.data.Recipient,
+		tx.data.Amount,
+		tx.data.Payload,
+		s.chainId, uint(0), uint(0),
+	}, tx))
+}
+
+// SponsorHash returns the SM3 hash to be signed by the sponsor of tx.
+func (s SMSigner) SponsorHash(tx *Transaction) common.Hash {
+	return sm3RlpHash(sponsorFields(s, tx))
+}
+
+func (s SMSigner) SignatureAndSender(tx *Transaction) (common.Address, []byte, error) {
+	if !tx.Protected() {
+		return common.Address{}, []byte{}, ErrInvalidSig
+	}
+	if tx.ChainId().Cmp(s.chainId) != 0 {
+		return common.Address{}, []byte{}, ErrInvalidChainId
+	}
+	V := new(big.Int).Sub(tx.data.V, s.chainIdMul)
+	if V.Cmp(big.NewInt(35)) != 0 {
+		return common.Address{}, []byte{}, ErrInvalidSig
+	}
+	x, y := sm2.DecompressPubkey(tx.data.SignerKey)
+	if x == nil {
+		return common.Address{}, []byte{}, ErrInvalidSignerKey
+	}
+	pub := &ecdsa.PublicKey{Curve: sm2.P256Sm2(), X: x, Y: y}
+	h := s.Hash(tx)
+	if !sm2.VerifyWithID(pub, h[:], sigBytes(tx.data.R, tx.data.S), sm2.DefaultUID) {
+		return common.Address{}, []byte{}, ErrInvalidSig
+	}
+	key := elliptic.Marshal(pub.Curve, x, y)[1:]
+	return sm3Address(key), key, nil
+}
+
+// signTx signs tx with a standard SM2 signature and attaches the public key
+// of prv.
+func (s SMSigner) signTx(tx *Transaction, prv *ecdsa.PrivateKey) (*Transaction, error) {
+	h := s.Hash(tx)
+	sig, err := sm2.SignWithID(prv, h[:], sm2.DefaultUID)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := tx.WithSignature(s, append(sig, 0))
+	if err != nil {
+		return nil, err
+	}
+	return signed.withSignerKey(sm2.CompressPubkey(prv.X, prv.Y))
+}
+
+// recoverSM2 returns the address of the signer of the recoverable SM2
+// signature (V, R, S) of sighash, V is 27 or 28.
+func recoverSM2(sighash common.Hash, R, S, Vb *big.Int) (common.Address, error) {
+	if Vb.BitLen() > 8 || (Vb.Uint64() != 27 && Vb.Uint64() != 28) {
+		return common.Address{}, ErrInvalidSig
+	}
+	sig := append(sigBytes(R, S), byte(Vb.Uint64()-27))
+	pub, err := sm2.RecoverPubkey(sighash[:], sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return sm3Address(pub[1:]), nil
+}
+
+// sigBytes encodes R and S in the 64 byte [R || S] format.
+func sigBytes(R, S *big.Int) []byte {
+	sig := make([]byte, 64)
+	if R.BitLen() > 256 || S.BitLen() > 256 {
+		return sig
+	}
+	r, s := R.Bytes(), S.Bytes()
+	copy(sig[32-len(r):32], r)
+	copy(sig[64-len(s):64], s)
+	return sig
+}
+
+// sm3Address derives the address of an SM2 public key without its 0x04
+// prefix.
+func sm3Address(pub []byte) common.Address {
+	h := sm3.New()
+	h.Write(pub)
+	return common.BytesToAddress(h.Sum(nil)[12:])
+}
+
+// sm3RlpHash returns the SM3 digest of the rlp encoding of x.
+func sm3RlpHash(x interface{}) (h common.Hash) {
+	hw := sm3.New()
+	rlp.Encode(hw, x)
+	hw.Sum(h[:0])
+	return h
+}
+
 // HomesteadTransaction implements TransactionInterface using the
 // homestead rules.
 type HomesteadSigner struct{ FrontierSigner }
@@ -221,14 +433,43 @@ func (fs FrontierSigner) SignatureValues(tx *Transaction, sig []byte) (r, s, v *
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (fs FrontierSigner) Hash(tx *Transaction) common.Hash {
-	return rlpHash([]interface{}{
+	return rlpHash(withExtensions([]interface{}{
 		tx.data.AccountNonce,
 		tx.data.Price,
 		tx.data.GasLimit,
 		tx.data.Recipient,
 		tx.data.Amount,
 		tx.data.Payload,
-	})
+	}, tx))
+}
+
+// SponsorHash returns the hash to be signed by the sponsor of tx.
+func (fs FrontierSigner) SponsorHash(tx *Transaction) common.Hash {
+	return rlpHash(sponsorFields(fs, tx))
+}
+
+// withExtensions appends the validity window and the sponsor of tx to the
+// signed fields, so transactions without them keep their legacy signing hash.
+// The signature of the sponsor isn't covered, the sponsor signs last.
+func withExtensions(fields []interface{}, tx *Transaction) []interface{} {
+	if tx.data.Validity == nil && tx.data.Sponsorship == nil {
+		return fields
+	}
+	validity := tx.data.Validity
+	if validity == nil {
+		validity = new(TxValidity)
+	}
+	fields = append(fields, validity)
+	if tx.data.Sponsorship != nil {
+		fields = append(fields, tx.data.Sponsorship.Sponsor)
+	}
+	return fields
+}
+
+// sponsorFields returns the fields signed by the sponsor of tx: the hash
+// signed by the sender and the signature of the sender.
+func sponsorFields(s Signer, tx *Transaction) []interface{} {
+	return []interface{}{s.Hash(tx), tx.data.V, tx.data.R, tx.data.S}
 }
 
 func (fs FrontierSigner) Sender(tx *Transaction) (common.Address, error) {
@@ -261,9 +502,7 @@ func RecoverPlain(sighash common.Hash, R, S, Vb *big.Int, homestead bool) (commo
 	if len(pub) == 0 || pub[0] != 4 {
 		return common.Address{}, errors.New("invalid public key")
 	}
-	var addr common.Address
-	copy(addr[:], crypto.Keccak256(pub[1:])[12:])
-	return addr, nil
+	return crypto.PubkeyBytesToAddress(pub[1:]), nil
 }
 
 func recoverPubKeyAndSender(sighash common.Hash, R, S, Vb *big.Int, homestead bool) (common.Address, []byte, error) {
@@ -288,10 +527,7 @@ func recoverPubKeyAndSender(sighash common.Hash, R, S, Vb *big.Int, homestead bo
 	if len(pub) == 0 || pub[0] != 4 {
 		return common.Address{}, []byte{}, errors.New("invalid public key")
 	}
-	pubValid := crypto.Keccak256(pub[1:])
-	var addr common.Address
-	copy(addr[:], pubValid[12:])
-	return addr, pub[1:], nil
+	return crypto.PubkeyBytesToAddress(pub[1:]), pub[1:], nil
 }
 
 // deriveChainId derives the chain id from the given v parameter
@@ -17,11 +17,16 @@
 package types
 
 import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
 	"math/big"
 	"testing"
 
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/crypto/sm2"
+	"github.com/Venachain/Venachain/params"
 	"github.com/Venachain/Venachain/rlp"
 )
 
@@ -44,6 +49,88 @@ func TestEIP155Signing(t *testing.T) {
 	}
 }
 
+func TestSMSigning(t *testing.T) {
+	key, _ := sm2.GenerateKey(rand.Reader)
+	addr := sm3Address(elliptic.Marshal(key.Curve, key.X, key.Y)[1:])
+
+	signer := MakeSigner(&params.ChainConfig{ChainID: big.NewInt(18), CryptoSuite: crypto.SuiteSM})
+	if !signer.Equal(SignerForKey(big.NewInt(18), &key.PublicKey)) {
+		t.Fatal("expected the signer of an SM2 key to be the SM signer")
+	}
+	tx, err := SignTx(NewTransaction(0, addr, new(big.Int), 0, new(big.Int), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from, err := Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+	if addr == common.BytesToAddress(crypto.Keccak256(elliptic.Marshal(key.Curve, key.X, key.Y)[1:])[12:]) {
+		t.Errorf("expected the address to be derived with SM3")
+	}
+	if from, err := NewEIP155Signer(big.NewInt(18)).Sender(tx); err == nil && from == addr {
+		t.Errorf("expected the EIP155 signer to reject an SM2 signed transaction")
+	}
+
+	// the signature is a standard one, over the ZA digest of the signer
+	h := signer.Hash(tx)
+	v, r, s := tx.RawSignatureValues()
+	if !sm2.VerifyWithID(&key.PublicKey, h[:], sigBytes(r, s), sm2.DefaultUID) {
+		t.Error("expected a standard SM2 signature")
+	}
+	if v.Uint64() != 18*2+35 {
+		t.Errorf("expected recovery id 0, got v %d", v)
+	}
+
+	// the signer key survives the encoding, and selects the SM signer
+	enc, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec *Transaction
+	if err := rlp.DecodeBytes(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dec.SignerKey(), tx.SignerKey()) || dec.Hash() != tx.Hash() {
+		t.Fatal("signer key lost in the encoding")
+	}
+	if from := dec.From(); *from != addr {
+		t.Errorf("expected From to use the SM signer. Got %x want %x", *from, addr)
+	}
+}
+
+func TestSMSignerRejectsForeignKey(t *testing.T) {
+	key, _ := sm2.GenerateKey(rand.Reader)
+	other, _ := sm2.GenerateKey(rand.Reader)
+	signer := NewSMSigner(big.NewInt(18))
+
+	tx, err := SignTx(NewTransaction(0, common.Address{}, new(big.Int), 0, new(big.Int), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, err := tx.withSignerKey(sm2.CompressPubkey(other.X, other.Y))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Sender(signer, forged); err != ErrInvalidSig {
+		t.Errorf("expected ErrInvalidSig for a foreign signer key, got %v", err)
+	}
+	if _, err := Sender(signer, NewTransaction(0, common.Address{}, new(big.Int), 0, new(big.Int), nil)); err == nil {
+		t.Error("expected an unsigned transaction to be rejected")
+	}
+
+	// V must hold the recovery id 0, or the transaction hash is malleable
+	malleable := &Transaction{data: tx.data}
+	malleable.data.V = new(big.Int).Add(tx.data.V, big.NewInt(1))
+	if _, err := Sender(signer, malleable); err != ErrInvalidSig {
+		t.Errorf("expected ErrInvalidSig for recovery id 1, got %v", err)
+	}
+}
+
 func TestEIP155ChainId(t *testing.T) {
 	key, _ := crypto.GenerateKey()
 	addr := crypto.PubkeyToAddress(key.PublicKey)
@@ -226,3 +226,150 @@ func TestReceipt_DecodeRLP(t *testing.T) {
 	println(bytes)
 
 }
+
+func TestTransactionValidity(t *testing.T) {
+	key, _ := defaultTestKey()
+	signer := NewEIP155Signer(common.Big1)
+	legacy := NewTransaction(1, common.Address{1}, common.Big0, 21000, common.Big1, nil)
+	validity := &TxValidity{ExpiryBlock: 100, RefBlockNumber: 90, RefBlockHash: common.Hash{9}}
+	windowed := legacy.WithValidity(validity)
+
+	if signer.Hash(legacy) == signer.Hash(windowed) {
+		t.Fatalf("validity window not covered by the signing hash")
+	}
+	if legacy.WithValidity(nil).Hash() != legacy.Hash() {
+		t.Errorf("transaction without validity window changed its encoding")
+	}
+
+	signed, err := SignTx(windowed, signer, key)
+	if err != nil {
+		t.Fatalf("could not sign transaction: %v", err)
+	}
+	enc, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+	decoded, err := decodeTx(enc)
+	if err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if decoded.Hash() != signed.Hash() {
+		t.Errorf("hash mismatch after decoding: have %x, want %x", decoded.Hash(), signed.Hash())
+	}
+	if v := decoded.Validity(); v == nil || *v != *validity {
+		t.Errorf("validity mismatch after decoding: have %v, want %v", v, validity)
+	}
+	if _, err := Sender(signer, decoded); err != nil {
+		t.Errorf("could not recover sender: %v", err)
+	}
+
+	data, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	var parsed *Transaction
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if parsed.Hash() != signed.Hash() {
+		t.Errorf("parsed tx differs from original tx")
+	}
+
+	if last, bounded := validity.LastBlock(50); !bounded || last != 100 {
+		t.Errorf("last block mismatch: have %d, want 100", last)
+	}
+	if last, bounded := validity.LastBlock(5); !bounded || last != 95 {
+		t.Errorf("last block mismatch: have %d, want 95", last)
+	}
+	if _, bounded := (&TxValidity{}).LastBlock(5); bounded {
+		t.Errorf("empty validity window is bounded")
+	}
+}
+
+func TestTransactionSponsorship(t *testing.T) {
+	key, _ := defaultTestKey()
+	sponsorKey, _ := crypto.GenerateKey()
+	otherKey, _ := crypto.GenerateKey()
+	sponsor := crypto.PubkeyToAddress(sponsorKey.PublicKey)
+	signer := NewEIP155Signer(common.Big1)
+	legacy := NewTransaction(1, common.Address{1}, common.Big0, 21000, common.Big1, nil)
+
+	if legacy.WithSponsor(nil).Hash() != legacy.Hash() {
+		t.Errorf("transaction without sponsor changed its encoding")
+	}
+	if _, err := Sponsor(signer, legacy); err != ErrInvalidSponsorship {
+		t.Errorf("sponsor of an unsponsored transaction: have %v, want %v", err, ErrInvalidSponsorship)
+	}
+
+	sponsored := legacy.WithSponsor(&sponsor)
+	if signer.Hash(legacy) == signer.Hash(sponsored) {
+		t.Fatalf("sponsor not covered by the signing hash")
+	}
+	signed, err := SignTx(sponsored, signer, key)
+	if err != nil {
+		t.Fatalf("could not sign transaction: %v", err)
+	}
+	if _, err := Sponsor(signer, signed); err != ErrInvalidSponsorSig {
+		t.Errorf("sponsor of a transaction without sponsor signature: have %v, want %v", err, ErrInvalidSponsorSig)
+	}
+	forged, err := SponsorTx(signed, signer, otherKey)
+	if err != nil {
+		t.Fatalf("could not sponsor transaction: %v", err)
+	}
+	if _, err := Sponsor(signer, forged); err != ErrInvalidSponsorSig {
+		t.Errorf("sponsor signed with another key: have %v, want %v", err, ErrInvalidSponsorSig)
+	}
+	signed, err = SponsorTx(signed, signer, sponsorKey)
+	if err != nil {
+		t.Fatalf("could not sponsor transaction: %v", err)
+	}
+
+	enc, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+	decoded, err := decodeTx(enc)
+	if err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if decoded.Hash() != signed.Hash() {
+		t.Errorf("hash mismatch after decoding: have %x, want %x", decoded.Hash(), signed.Hash())
+	}
+	if decoded.Validity() != nil {
+		t.Errorf("sponsored transaction decoded with a validity window")
+	}
+	if from, err := Sender(signer, decoded); err != nil || from != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("sender mismatch: have %x, %v", from, err)
+	}
+	if addr, err := Sponsor(signer, decoded); err != nil || addr != sponsor {
+		t.Errorf("sponsor mismatch: have %x, %v, want %x", addr, err, sponsor)
+	}
+	msg, err := decoded.AsMessage(signer)
+	if err != nil {
+		t.Fatalf("could not convert to message: %v", err)
+	}
+	if msg.Sponsor() == nil || *msg.Sponsor() != sponsor {
+		t.Errorf("message sponsor mismatch: have %v, want %x", msg.Sponsor(), sponsor)
+	}
+
+	data, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	var parsed *Transaction
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if parsed.Hash() != signed.Hash() {
+		t.Errorf("parsed tx differs from original tx")
+	}
+	if addr, err := Sponsor(signer, parsed); err != nil || addr != sponsor {
+		t.Errorf("sponsor mismatch after json: have %x, %v, want %x", addr, err, sponsor)
+	}
+
+	// a validity window resets both signatures but keeps the sponsor
+	windowed := signed.WithValidity(&TxValidity{ExpiryBlock: 100})
+	if sp := windowed.Sponsorship(); sp == nil || sp.Sponsor != sponsor || sp.Signed() {
+		t.Errorf("sponsorship after setting the validity window: %v", sp)
+	}
+}
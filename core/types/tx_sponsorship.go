@@ -0,0 +1,70 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+)
+
+// TxSponsorship designates the account paying the fees of a transaction in
+// place of its sender. The sponsor signs the transaction signed by the sender,
+// so it only pays for that transaction of that sender.
+type TxSponsorship struct {
+	Sponsor common.Address // Account paying the fees
+
+	// Signature values of the sponsor, V is 27 or 28
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+func newTxSponsorship(sponsor common.Address) *TxSponsorship {
+	return &TxSponsorship{Sponsor: sponsor, V: new(big.Int), R: new(big.Int), S: new(big.Int)}
+}
+
+func (s *TxSponsorship) copy() *TxSponsorship {
+	return &TxSponsorship{
+		Sponsor: s.Sponsor,
+		V:       new(big.Int).Set(s.V),
+		R:       new(big.Int).Set(s.R),
+		S:       new(big.Int).Set(s.S),
+	}
+}
+
+// Signed reports whether the sponsor signed the transaction.
+func (s *TxSponsorship) Signed() bool {
+	return s.V.Sign() != 0 || s.R.Sign() != 0 || s.S.Sign() != 0
+}
+
+type txSponsorshipJSON struct {
+	Sponsor common.Address `json:"sponsor"`
+	V       *hexutil.Big   `json:"v"`
+	R       *hexutil.Big   `json:"r"`
+	S       *hexutil.Big   `json:"s"`
+}
+
+// MarshalJSON encodes the web3 RPC sponsorship format.
+func (s TxSponsorship) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&txSponsorshipJSON{
+		Sponsor: s.Sponsor,
+		V:       (*hexutil.Big)(s.V),
+		R:       (*hexutil.Big)(s.R),
+		S:       (*hexutil.Big)(s.S),
+	})
+}
+
+// UnmarshalJSON decodes the web3 RPC sponsorship format.
+func (s *TxSponsorship) UnmarshalJSON(input []byte) error {
+	var dec txSponsorshipJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.V == nil || dec.R == nil || dec.S == nil {
+		return ErrInvalidSponsorship
+	}
+	s.Sponsor = dec.Sponsor
+	s.V, s.R, s.S = (*big.Int)(dec.V), (*big.Int)(dec.R), (*big.Int)(dec.S)
+	return nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+)
+
+// TxValidity restricts the blocks a transaction can be included in. A
+// transaction carrying a validity window can only be included in a block
+// whose number is not higher than ExpiryBlock, and if RefBlockHash is set,
+// only on a chain containing that block.
+type TxValidity struct {
+	ExpiryBlock    uint64      // Last block number the transaction can be included in, 0 means unbounded
+	RefBlockNumber uint64      // Number of the reference block
+	RefBlockHash   common.Hash // Hash of the reference block, zero means no reference block
+}
+
+// HasRefBlock reports whether the window references a block.
+func (v *TxValidity) HasRefBlock() bool {
+	return v.RefBlockHash != (common.Hash{})
+}
+
+// LastBlock returns the last block number the transaction can be included in
+// and whether the window is bounded at all.
+func (v *TxValidity) LastBlock(maxBlocks uint64) (uint64, bool) {
+	last, bounded := v.ExpiryBlock, v.ExpiryBlock != 0
+	if v.HasRefBlock() {
+		if refLast := v.RefBlockNumber + maxBlocks; !bounded || refLast < last {
+			last, bounded = refLast, true
+		}
+	}
+	return last, bounded
+}
+
+type txValidityJSON struct {
+	ExpiryBlock    hexutil.Uint64 `json:"expiryBlock"`
+	RefBlockNumber hexutil.Uint64 `json:"refBlockNumber"`
+	RefBlockHash   common.Hash    `json:"refBlockHash"`
+}
+
+// MarshalJSON encodes the web3 RPC validity window format.
+func (v TxValidity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&txValidityJSON{
+		ExpiryBlock:    hexutil.Uint64(v.ExpiryBlock),
+		RefBlockNumber: hexutil.Uint64(v.RefBlockNumber),
+		RefBlockHash:   v.RefBlockHash,
+	})
+}
+
+// UnmarshalJSON decodes the web3 RPC validity window format.
+func (v *TxValidity) UnmarshalJSON(input []byte) error {
+	var dec txValidityJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	v.ExpiryBlock = uint64(dec.ExpiryBlock)
+	v.RefBlockNumber = uint64(dec.RefBlockNumber)
+	v.RefBlockHash = dec.RefBlockHash
+	return nil
+}
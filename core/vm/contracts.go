@@ -82,11 +82,14 @@ func (c *ecrecover) Run(input []byte) ([]byte, error) {
 	v := input[63] - 27
 
 	// tighter sig s values input homestead only apply to tx sigs
-	if !allZero(input[32:63]) || !crypto.ValidateSignatureValues(v, r, s, false) {
+	if !allZero(input[32:63]) || !crypto.ValidateSecp256k1SignatureValues(v, r, s, false) {
 		return nil, nil
 	}
+	// The precompile keeps the EVM semantics on the chains of the SM crypto
+	// suite too: it recovers secp256k1 signers and their Keccak256 addresses,
+	// so it doesn't recover the SM2 accounts of such chains.
 	// v needs to be at the end for libsecp256k1
-	pubKey, err := crypto.Ecrecover(input[:32], append(input[64:128], v))
+	pubKey, err := crypto.EcrecoverSecp256k1(input[:32], append(input[64:128], v))
 	// make sure the public key is a valid one
 	if err != nil {
 		return nil, nil
@@ -166,6 +166,20 @@ func (evm *EVM) Cancel() {
 	atomic.StoreInt32(&evm.abort, 1)
 }
 
+// headerReader is implemented by the states of speculative executions, an
+// execution reading the coinbase, timestamp or gas limit of its block can't
+// be reused in a block with a different header.
+type headerReader interface {
+	ReadHeader()
+}
+
+// readHeader notifies the state that the block header is read.
+func (evm *EVM) readHeader() {
+	if r, ok := evm.StateDB.(headerReader); ok {
+		r.ReadHeader()
+	}
+}
+
 // Interpreter returns the current interpreter
 func (evm *EVM) Interpreters() []Interpreter {
 	return evm.interpreters
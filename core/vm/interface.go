@@ -79,6 +79,7 @@ type StateDB interface {
 	FwClear(contractAddr common.Address, action state.Action)
 	FwDel(contractAddr common.Address, action state.Action, list []state.FwElem)
 	FwSet(contractAddr common.Address, action state.Action, list []state.FwElem)
+	FwSetRules(contractAddr common.Address, rules []state.FwRule)
 
 	SetFwStatus(contractAddr common.Address, status state.FwStatus)
 	GetFwStatus(contractAddr common.Address) state.FwStatus
@@ -92,6 +93,8 @@ type StateDB interface {
 	FwImport(contractAddr common.Address, data []byte) error
 	//clone storage data from the `src` to `dest`
 	CloneAccount(src common.Address, dest common.Address) error
+	// HasStorage reports whether the account stores data besides its firewall
+	HasStorage(addr common.Address) bool
 }
 
 // CallContext provides a basic interface for the EVM calling conventions. The EVM
@@ -114,6 +114,16 @@ func (in *WASMInterpreter) preCheckFunction(contract *Contract, input []byte, ab
 // considered a revert-and-consume-all-gas operations except for
 // errExecutionReverted which means revert-and-keep-gas-left.
 func (in *WASMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (ret []byte, err error) {
+	// the trace is closed after a panic was turned into an error
+	var (
+		tracer WasmTracer
+		lvm    *exec.VirtualMachine
+	)
+	defer func() {
+		if tracer != nil {
+			tracer.CaptureWasmEnd(lvm, ret, err)
+		}
+	}()
 	defer func() {
 		if er := recover(); er != nil {
 			ret, err = nil, fmt.Errorf("VM execute fail：%v", er)
@@ -164,7 +174,6 @@ func (in *WASMInterpreter) Run(contract *Contract, input []byte, readOnly bool)
 		Log:      in.WasmLogger,
 	}
 
-	var lvm *exec.VirtualMachine
 	var module *lru.WasmModule
 	module, ok := lru.WasmCache().Get(contract.Address())
 
@@ -181,6 +190,9 @@ func (in *WASMInterpreter) Run(contract *Contract, input []byte, readOnly bool)
 	if err != nil {
 		return nil, err
 	}
+	if in.evm.vmConfig.WasmCompiled {
+		lvm.Compiled = module.Compiled()
+	}
 	defer func() {
 		lvm.Stop()
 	}()
@@ -220,6 +232,11 @@ func (in *WASMInterpreter) Run(contract *Contract, input []byte, readOnly bool)
 	}
 	lvm.InitEntryID = in.evm.InitEntryID
 
+	if t, ok := in.cfg.Tracer.(WasmTracer); ok && in.cfg.Debug {
+		tracer = t
+		context.Tracer = t
+		tracer.CaptureWasmStart(in.evm, contract, funcName, in.evm.depth)
+	}
 	res, err := lvm.RunWithGasLimit(entryID, int(context.GasLimit), params...)
 	if err != nil {
 		log.Error("RunWithGasLimit error", "err", err.Error())
@@ -12,6 +12,7 @@ import (
 
 	"github.com/Venachain/Venachain/common"
 	math2 "github.com/Venachain/Venachain/common/math"
+	"github.com/Venachain/Venachain/core/lru"
 	"github.com/Venachain/Venachain/core/state"
 	"github.com/Venachain/Venachain/core/types"
 	"github.com/Venachain/Venachain/crypto"
@@ -444,3 +445,95 @@ func TestInt128(t *testing.T) {
 		t.Fatal("result is not correct")
 	}
 }
+
+// runWasmContract runs a call of the contract in a fresh interpreter and
+// returns the result and the gas used.
+func runWasmContract(t *testing.T, codePath, abiPath string, input [][]byte, compiled bool) ([]byte, uint64, error) {
+	codeBytes, err := ioutil.ReadFile(codePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	abiBytes, err := ioutil.ReadFile(abiPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := rlp.EncodeToBytes([3][]byte{Int64ToBytes(2), codeBytes, abiBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{WasmCompiled: compiled}
+	evm := &EVM{
+		StateDB: stateDB{},
+		Context: Context{
+			GasLimit:    1000000,
+			BlockNumber: big.NewInt(10),
+		},
+		vmConfig: cfg,
+	}
+	wasmInterpreter := NewWASMInterpreter(evm, cfg)
+
+	contract := &Contract{
+		CallerAddress: common.BigToAddress(big.NewInt(88888)),
+		caller:        ContractRefCaller{},
+		self:          ContractRefSelf{},
+		Code:          code,
+		Gas:           100000000,
+	}
+	// the contracts share the address, the module of the previous one is
+	// dropped from the cache
+	lru.WasmCache().Remove(contract.Address())
+
+	var callInput []byte
+	if input != nil {
+		if callInput, err = rlp.EncodeToBytes(input); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ret, err := wasmInterpreter.Run(contract, callInput, true)
+	return ret, 100000000 - contract.Gas, err
+}
+
+// TestWasmCompiledContracts runs the calls of the contracts in life/contract in
+// the interpreter and in the compiled executor, both have to return the same
+// result and use the same gas.
+func TestWasmCompiledContracts(t *testing.T) {
+	int128, _ := common.BigToByte128(big.NewInt(153265412365478951))
+	float128, _, _ := big.ParseFloat("123456789.123456789", 10, 113, big.ToNearestEven)
+	f128, _ := math2.NewFromBig(float128)
+	long := append(common.Uint64ToBytes(f128.High()), common.Uint64ToBytes(f128.Low())...)
+
+	const (
+		getset  = "../../life/contract/getsettest"
+		numbers = "../../life/contract/numberstest"
+	)
+	tests := []struct {
+		contract string
+		abi      string
+		input    [][]byte
+	}{
+		{getset, ".cpp.abi.json", nil},
+		{getset, ".cpp.abi.json", [][]byte{Int64ToBytes(1), []byte("Set"), []byte("venachain"), common.Int32ToBytes(11)}},
+		{getset, ".cpp.abi.json", [][]byte{Int64ToBytes(1), []byte("Get"), []byte("venachain")}},
+		{numbers, ".abi.json", [][]byte{Int64ToBytes(1), []byte("addLong"), int128, int128}},
+		{numbers, ".abi.json", [][]byte{Int64ToBytes(1), []byte("addUlong"), int128, int128}},
+		{numbers, ".abi.json", [][]byte{Int64ToBytes(1), []byte("addFloat"), common.Float32ToBytes(1.5), common.Float32ToBytes(2.25)}},
+		{numbers, ".abi.json", [][]byte{Int64ToBytes(1), []byte("addDouble"), common.Float64ToBytes(1.5), common.Float64ToBytes(2.25)}},
+		{numbers, ".abi.json", [][]byte{Int64ToBytes(1), []byte("addLongDouble"), long, long}},
+	}
+	for i, test := range tests {
+		ret, gasUsed, err := runWasmContract(t, test.contract+".wasm", test.contract+test.abi, test.input, false)
+		if err != nil {
+			t.Fatalf("test %d: interpreted call failed: %v", i, err)
+		}
+		compiledRet, compiledGasUsed, err := runWasmContract(t, test.contract+".wasm", test.contract+test.abi, test.input, true)
+		if err != nil {
+			t.Fatalf("test %d: compiled call failed: %v", i, err)
+		}
+		if !bytes.Equal(compiledRet, ret) {
+			t.Errorf("test %d: result mismatch: compiled %x, interpreted %x", i, compiledRet, ret)
+		}
+		if compiledGasUsed != gasUsed {
+			t.Errorf("test %d: gas mismatch: compiled %d, interpreted %d", i, compiledGasUsed, gasUsed)
+		}
+	}
+}
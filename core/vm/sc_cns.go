@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/common/syscontracts"
@@ -31,12 +32,17 @@ const (
 
 const (
 	/// namePattern    = `^[a-zA-Z]\w{2,15}$`  // alice
-	versionRegPattern = `^([\d]{1,3}\.){3}[\d]{1,3}$` // 0.0.0.1
+	versionRegPattern = `^([\d]{1,3}\.){3}[\d]{1,3}$`                     // 0.0.0.1
+	cnsNameRegPattern = `^[a-zA-Z0-9_\p{Han}]+(\.[a-zA-Z0-9_\p{Han}]+)*$` // finance.payroll
+	cnsNameMaxLength  = 128
+
+	namespaceSeparator = "."
 )
 
 var (
 	/// regName = regexp.MustCompile(namePattern)
-	regVer = regexp.MustCompile(versionRegPattern)
+	regVer     = regexp.MustCompile(versionRegPattern)
+	regCnsName = regexp.MustCompile(cnsNameRegPattern)
 )
 
 //
@@ -68,6 +74,26 @@ type ContractInfo struct {
 	TimeStamp uint64         `json:"create_time"`
 }
 
+// checkCnsNameFormat checks a cns name or namespace, the dotted names are
+// registered in the namespaces they are prefixed by.
+func checkCnsNameFormat(name string) (bool, error) {
+	if utf8.RuneCountInString(name) > cnsNameMaxLength || !regCnsName.MatchString(name) {
+		return false, errNameInvalid
+	}
+	return true, nil
+}
+
+// namespacesOf returns the namespaces enclosing a cns name, the closest first:
+// "a.b.c" is in the namespaces "a.b" and "a".
+func namespacesOf(name string) []string {
+	var namespaces []string
+	for i := strings.LastIndex(name, namespaceSeparator); i > 0; i = strings.LastIndex(name, namespaceSeparator) {
+		name = name[:i]
+		namespaces = append(namespaces, name)
+	}
+	return namespaces
+}
+
 func newContractInfo(name, version string, address, origin common.Address, blockNumber uint64) *ContractInfo {
 	return &ContractInfo{
 		Name:      name,
@@ -106,6 +132,27 @@ func (cns *CnsManager) isOwner(contractAddr common.Address) bool {
 	}
 }
 
+// isCnsAdmin checks if the origin administrates the cns names
+func (cns *CnsManager) isCnsAdmin() bool {
+	return checkPermission(cns.cMap.StateDB, cns.origin, cnsOpPermission, cns.blockNumber)
+}
+
+// namespaceAuthority checks if the origin is the registrar of one of the
+// namespaces, and if any of them has a registrar.
+func (cns *CnsManager) namespaceAuthority(namespaces []string) (isRegistrar, claimed bool) {
+	for _, namespace := range namespaces {
+		registrar := cns.cMap.getRegistrar(namespace)
+		if registrar == (common.Address{}) {
+			continue
+		}
+		claimed = true
+		if registrar == cns.origin {
+			return true, true
+		}
+	}
+	return false, claimed
+}
+
 // isFromInit checks if the method is called from init()
 func (cns *CnsManager) isFromInit() bool {
 	if cns.isInit != -1 {
@@ -121,6 +168,7 @@ func (cns *CnsManager) importOldCnsManagerData(cnsInfos []*ContractInfo) {
 	for _, data := range cnsInfos {
 		key := getSearchKey(data.Name, data.Version)
 		cns.cMap.insert(key, data)
+		cns.cMap.setReverse(data.Address, key)
 
 		// set the largest version to CURRENT version
 		curVersion := cns.cMap.getCurrentVer(data.Name)
@@ -160,7 +208,7 @@ func (cns *CnsManager) cnsRegister(name, version string, contractAddr common.Add
 
 func (cns *CnsManager) doCnsRegister(name, version string, address common.Address) error {
 
-	if ok, _ := checkNameFormat(name); !ok {
+	if ok, _ := checkCnsNameFormat(name); !ok {
 		cns.emitNotifyEvent(cnsInvalidArgument, errNameInvalid.Error())
 		return errNameInvalid
 	}
@@ -180,11 +228,21 @@ func (cns *CnsManager) doCnsRegister(name, version string, address common.Addres
 
 	// check is name unique
 	ori := cns.origin
-	if cns.cMap.isNameRegByOthers(name, ori) {
+	owner := cns.cMap.getNameOwner(name)
+	if owner != (common.Address{}) && owner != ori {
 		cns.emitNotifyEvent(cnsRegErr, errNameReg.Error())
 		return errNameReg
 	}
 
+	// the new names of a namespace with a registrar are registered by it
+	if owner == (common.Address{}) {
+		isRegistrar, claimed := cns.namespaceAuthority(namespacesOf(name))
+		if claimed && !isRegistrar && !cns.isCnsAdmin() {
+			cns.emitNotifyEvent(cnsNoPermission, errNotRegistrar.Error())
+			return errNotRegistrar
+		}
+	}
+
 	// check is version valid
 	largestVersion := cns.cMap.getLargestVersion(name)
 	if verCompare(version, largestVersion) != 1 {
@@ -197,6 +255,10 @@ func (cns *CnsManager) doCnsRegister(name, version string, address common.Addres
 
 	// record the info to stateDB
 	cns.cMap.insert(key, cnsInfo)
+	cns.cMap.setReverse(address, key)
+	if owner == (common.Address{}) {
+		cns.cMap.setNameOwner(name, ori)
+	}
 
 	// update the current version of the cns name
 	cns.cMap.setCurrentVer(name, version)
@@ -205,7 +267,8 @@ func (cns *CnsManager) doCnsRegister(name, version string, address common.Addres
 	return nil
 }
 
-// verCompare compares the versions
+// verCompare compares the versions segment by segment as numbers, the
+// missing segments count as 0
 // 1: ver1 > ver2
 // -1: ver1 < ver2
 // 0: ver1 = ver2
@@ -213,13 +276,26 @@ func verCompare(ver1, ver2 string) int {
 	ver1Arr := strings.Split(ver1, ".")
 	ver2Arr := strings.Split(ver2, ".")
 
-	for i := 0; i < len(ver1Arr); i++ {
-		if ver1Arr[i] > ver2Arr[i] {
+	for i := 0; i < len(ver1Arr) || i < len(ver2Arr); i++ {
+		var seg1, seg2 string
+		if i < len(ver1Arr) {
+			seg1 = strings.TrimLeft(ver1Arr[i], "0")
+		}
+		if i < len(ver2Arr) {
+			seg2 = strings.TrimLeft(ver2Arr[i], "0")
+		}
+
+		// the segments are digits, the longer number is the larger
+		switch {
+		case len(seg1) != len(seg2):
+			if len(seg1) > len(seg2) {
+				return 1
+			}
+			return -1
+		case seg1 > seg2:
 			return 1
-		} else if ver1Arr[i] < ver2Arr[i] {
+		case seg1 < seg2:
 			return -1
-		} else {
-			continue
 		}
 	}
 
@@ -230,7 +306,7 @@ func verCompare(ver1, ver2 string) int {
 // cnsRedirect selects a specific version of a cns name and set it to current version
 func (cns *CnsManager) cnsRedirect(name, version string) error {
 
-	if ok, _ := checkNameFormat(name); !ok {
+	if ok, _ := checkCnsNameFormat(name); !ok {
 		cns.emitNotifyEvent(cnsInvalidArgument, errNameInvalid.Error())
 		return errNameInvalid
 	}
@@ -249,9 +325,8 @@ func (cns *CnsManager) cnsRedirect(name, version string) error {
 		return errNameAndVerUnReg
 	}
 
-	// check is Owner
-	contractAddr := cnsInfo.Address
-	if !cns.isOwner(contractAddr) {
+	// check is Owner of the name
+	if cns.cMap.getNameOwner(name) != cns.origin {
 		cns.emitNotifyEvent(cnsNoPermission, errNotOwner.Error())
 		return errNotOwner
 	}
@@ -262,6 +337,95 @@ func (cns *CnsManager) cnsRedirect(name, version string) error {
 	return nil
 }
 
+// transferNameOwnership transfers a cns name to a new owner, by its owner, a
+// registrar of its namespaces or a chain admin
+func (cns *CnsManager) transferNameOwnership(name string, newOwner common.Address) error {
+	if ok, _ := checkCnsNameFormat(name); !ok {
+		cns.emitNotifyEvent(cnsInvalidArgument, errNameInvalid.Error())
+		return errNameInvalid
+	}
+
+	if newOwner == (common.Address{}) {
+		cns.emitNotifyEvent(cnsInvalidArgument, errAddressInvalid.Error())
+		return errAddressInvalid
+	}
+
+	owner := cns.cMap.getNameOwner(name)
+	if owner == (common.Address{}) {
+		cns.emitNotifyEvent(cnsRegErr, errNameUnReg.Error())
+		return errNameUnReg
+	}
+
+	if owner != cns.origin {
+		isRegistrar, _ := cns.namespaceAuthority(namespacesOf(name))
+		if !isRegistrar && !cns.isCnsAdmin() {
+			cns.emitNotifyEvent(cnsNoPermission, errNotNameOwner.Error())
+			return errNotNameOwner
+		}
+	}
+
+	cns.cMap.setNameOwner(name, newOwner)
+
+	cns.emitNotifyEvent(cnsSuccess, "[CNS] cns name ownership transferred")
+	return nil
+}
+
+// setNamespaceRegistrar delegates the registration of the new names of a
+// namespace to a registrar, the zero address releases the namespace. It is
+// called by a chain admin or a registrar of the namespace or of the namespaces
+// enclosing it.
+func (cns *CnsManager) setNamespaceRegistrar(namespace string, registrar common.Address) error {
+	if ok, _ := checkCnsNameFormat(namespace); !ok {
+		cns.emitNotifyEvent(cnsInvalidArgument, errNameInvalid.Error())
+		return errNameInvalid
+	}
+
+	isRegistrar, _ := cns.namespaceAuthority(append([]string{namespace}, namespacesOf(namespace)...))
+	if !isRegistrar && !cns.isCnsAdmin() {
+		cns.emitNotifyEvent(cnsNoPermission, errNotRegistrar.Error())
+		return errNotRegistrar
+	}
+
+	cns.cMap.setRegistrar(namespace, registrar)
+
+	cns.emitNotifyEvent(cnsSuccess, "[CNS] cns namespace registrar set")
+	return nil
+}
+
+// getNameOwner returns the owner of a cns name
+func (cns *CnsManager) getNameOwner(name string) (common.Address, error) {
+	if ok, _ := checkCnsNameFormat(name); !ok {
+		return common.Address{}, errNameInvalid
+	}
+
+	owner := cns.cMap.getNameOwner(name)
+	if owner == (common.Address{}) {
+		return common.Address{}, errNameUnReg
+	}
+	return owner, nil
+}
+
+// getNamespaceRegistrar returns the registrar of a namespace, the zero address
+// if it has none
+func (cns *CnsManager) getNamespaceRegistrar(namespace string) (common.Address, error) {
+	if ok, _ := checkCnsNameFormat(namespace); !ok {
+		return common.Address{}, errNameInvalid
+	}
+
+	return cns.cMap.getRegistrar(namespace), nil
+}
+
+// getNameByAddress resolves a contract address to the latest cns name and
+// version it is registered with
+func (cns *CnsManager) getNameByAddress(address common.Address) (*ContractInfo, error) {
+	key := cns.cMap.getReverse(address)
+	if key == "" {
+		return nil, errAddressUnReg
+	}
+
+	return cns.cMap.find(key), nil
+}
+
 // getContractAddress returns the address of a cns name at specific version
 func (cns *CnsManager) getContractAddress(name, version string) (common.Address, error) {
 	//if sysCon, ok := cnsSysContractsMap[name]; ok {
@@ -272,7 +436,7 @@ func (cns *CnsManager) getContractAddress(name, version string) (common.Address,
 		version = cns.cMap.getCurrentVer(name)
 	}
 
-	if ok, _ := checkNameFormat(name); !ok {
+	if ok, _ := checkCnsNameFormat(name); !ok {
 		return common.Address{}, errNameInvalid
 	}
 
@@ -294,7 +458,7 @@ func (cns *CnsManager) getContractAddress(name, version string) (common.Address,
 func (cns *CnsManager) ifRegisteredByName(name string) (bool, error) {
 	var index uint64
 
-	if ok, _ := checkNameFormat(name); !ok {
+	if ok, _ := checkCnsNameFormat(name); !ok {
 		return false, errNameInvalid
 	}
 
@@ -355,7 +519,7 @@ func (cns *CnsManager) getRegisteredContractsByName(name string) ([]*ContractInf
 	var cnsInfoArray = make([]*ContractInfo, 0)
 	var index uint64
 
-	if ok, _ := checkNameFormat(name); !ok {
+	if ok, _ := checkCnsNameFormat(name); !ok {
 		return nil, errNameInvalid
 	}
 
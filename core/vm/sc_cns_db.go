@@ -11,9 +11,12 @@ import (
 )
 
 const (
-	cnsName    = "cnsManager"
-	cnsTotal   = "total"
-	cnsCurrent = "current"
+	cnsName      = "cnsManager"
+	cnsTotal     = "total"
+	cnsCurrent   = "current"
+	cnsOwner     = "owner"
+	cnsRegistrar = "registrar"
+	cnsReverse   = "reverse"
 )
 
 const seperateChar = ":"
@@ -111,10 +114,79 @@ func (c *cnsMap) setCurrentVer(name, ver string) {
 	c.setState(currentVerWrapper(name), []byte(ver))
 }
 
+// getNameOwner returns the owner of a cns name, the zero address if the name
+// isn't registered. The names registered before the ownership records are
+// owned by the origin of their first registration.
+func (c *cnsMap) getNameOwner(name string) common.Address {
+	var owner common.Address
+	c.getState(ownerWrapper(name), &owner)
+	if owner != (common.Address{}) {
+		return owner
+	}
+
+	for index := uint64(0); index < c.total(); index++ {
+		key := c.getKeyByIndex(index)
+		if strings.Split(key, seperateChar)[0] == name {
+			return c.find(key).Origin
+		}
+	}
+	return common.Address{}
+}
+
+func (c *cnsMap) setNameOwner(name string, owner common.Address) {
+	c.setState(ownerWrapper(name), owner)
+}
+
+// getRegistrar returns the registrar of a namespace, the zero address if the
+// namespace has none.
+func (c *cnsMap) getRegistrar(namespace string) common.Address {
+	var registrar common.Address
+	c.getState(registrarWrapper(namespace), &registrar)
+	return registrar
+}
+
+func (c *cnsMap) setRegistrar(namespace string, registrar common.Address) {
+	c.setState(registrarWrapper(namespace), registrar)
+}
+
+// getReverse returns the search key of the latest registration of a contract
+// address, "" if the address isn't registered.
+func (c *cnsMap) getReverse(address common.Address) string {
+	var key string
+	c.getState(reverseWrapper(address), &key)
+	if key != "" {
+		return key
+	}
+
+	for index := c.total(); index > 0; index-- {
+		key := c.getKeyByIndex(index - 1)
+		if c.find(key).Address == address {
+			return key
+		}
+	}
+	return ""
+}
+
+func (c *cnsMap) setReverse(address common.Address, key string) {
+	c.setState(reverseWrapper(address), key)
+}
+
 func currentVerWrapper(name string) []byte {
 	return []byte(cnsName + cnsCurrent + name)
 }
 
+func ownerWrapper(name string) string {
+	return cnsName + cnsOwner + name
+}
+
+func registrarWrapper(namespace string) string {
+	return cnsName + cnsRegistrar + namespace
+}
+
+func reverseWrapper(address common.Address) string {
+	return cnsName + cnsReverse + address.Hex()
+}
+
 func indexWrapper(index uint64) string {
 	return cnsName + strconv.FormatUint(index, 10)
 }
@@ -141,25 +213,6 @@ func (c *cnsMap) isNameRegByOthers_Old(name string, origin common.Address) bool
 	return false
 }*/
 
-func (c *cnsMap) isNameRegByOthers(name string, origin common.Address) bool {
-	var index uint64
-
-	for index = 0; index < c.total(); index++ {
-		key := c.getKeyByIndex(index)
-		existedName := strings.Split(key, seperateChar)[0]
-		if existedName == name {
-			cnsInfo := c.find(key)
-			if cnsInfo.Origin != origin {
-				return true
-			} else {
-				return false
-			}
-		}
-	}
-
-	return false
-}
-
 /*
 func (c *cnsMap) getLargestVersion_Old(name string) string {
 	tempVersion := "0.0.0.0"
@@ -72,7 +72,7 @@ func (c *CnsInvoke) Run(input []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	if res, pass := fwCheck(c.evm.StateDB, *addr, c.caller, cnsRawData); !pass {
+	if res, pass := fwCheck(c.evm, *addr, c.caller, cnsRawData); !pass {
 		return res, PermissionErr
 	}
 
@@ -1,9 +1,11 @@
 package vm
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/syscontracts"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -49,6 +51,10 @@ func TestLatestVersion(t *testing.T) {
 		{"1.0.0.0", "0.0.0.1", 1},
 		{"1.0.0.03011", "1.0.0.0301", 1},
 		{"1.0.0.07141108", "1.0.0.07141130", -1},
+		{"0.0.0.10", "0.0.0.9", 1},
+		{"0.0.2.0", "0.0.10.0", -1},
+		{"0.0.0.010", "0.0.0.10", 0},
+		{"1.0", "1.0.0.0", 0},
 	}
 
 	for _, data := range testCase {
@@ -185,3 +191,85 @@ func TestCnsManager_getRegisteredContractsByRange(t *testing.T) {
 	}
 	t.Logf("the registered contracts result is %s\n", result)
 }
+
+func TestNamespacesOf(t *testing.T) {
+	assert.Equal(t, []string{"a.b", "a"}, namespacesOf("a.b.c"))
+	assert.Nil(t, namespacesOf("a"))
+
+	for _, name := range []string{"finance.payroll", "a_1.b2", "名字"} {
+		ok, _ := checkCnsNameFormat(name)
+		assert.True(t, ok, name)
+	}
+	for _, name := range []string{"", "a..b", ".a", "a.", "a:b"} {
+		ok, _ := checkCnsNameFormat(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestCnsManager_ownership(t *testing.T) {
+	db := newMockStateDB()
+	admin := common.HexToAddress("0x62fb664c49cfa4fa35931760c704f9b3ab664666")
+	um := UserManagement{stateDB: db, caller: admin, contractAddr: syscontracts.UserManagementAddress, blockNumber: big.NewInt(100)}
+	um.setSuperAdmin()
+	um.addChainAdminByAddress(admin)
+
+	cm := &CnsManager{
+		cMap:        NewCnsMap(db, syscontracts.CnsManagementAddress),
+		origin:      testOrigin,
+		isInit:      -1,
+		blockNumber: big1,
+	}
+
+	assert.NoError(t, cm.cnsRegister("payroll", "0.0.0.9", testAddr1))
+	assert.NoError(t, cm.cnsRegister("payroll", "0.0.0.10", testAddr2))
+	assert.Equal(t, errLowRegVersion, cm.cnsRegister("payroll", "0.0.0.2", testAddr3))
+	owner, err := cm.getNameOwner("payroll")
+	assert.NoError(t, err)
+	assert.Equal(t, testOrigin, owner)
+
+	// reverse resolution
+	info, err := cm.getNameByAddress(testAddr2)
+	assert.NoError(t, err)
+	assert.Equal(t, "payroll", info.Name)
+	assert.Equal(t, "0.0.0.10", info.Version)
+	_, err = cm.getNameByAddress(testNoneExist)
+	assert.Equal(t, errAddressUnReg, err)
+
+	// ownership transfer
+	cm.origin = testCaller
+	assert.Equal(t, errNotNameOwner, cm.transferNameOwnership("payroll", testCaller))
+	cm.origin = testOrigin
+	assert.Equal(t, errNameUnReg, cm.transferNameOwnership("unknown", testCaller))
+	assert.NoError(t, cm.transferNameOwnership("payroll", testCaller))
+	assert.Equal(t, errNameReg, cm.doCnsRegister("payroll", "0.0.1.0", testAddr3))
+	assert.Equal(t, errNotOwner, cm.cnsRedirect("payroll", "0.0.0.9"))
+	cm.origin = testCaller
+	assert.NoError(t, cm.doCnsRegister("payroll", "0.0.1.0", testAddr3))
+	assert.NoError(t, cm.cnsRedirect("payroll", "0.0.0.9"))
+
+	// namespaces delegated by a chain admin
+	assert.Equal(t, errNotRegistrar, cm.setNamespaceRegistrar("finance", testCaller))
+	cm.origin = admin
+	assert.NoError(t, cm.setNamespaceRegistrar("finance", testCaller))
+	cm.origin = testOrigin
+	assert.Equal(t, errNotRegistrar, cm.doCnsRegister("finance.payroll", "0.0.0.1", testAddr1))
+	assert.NoError(t, cm.doCnsRegister("hr.payroll", "0.0.0.1", testAddr1))
+
+	cm.origin = testCaller
+	assert.NoError(t, cm.doCnsRegister("finance.payroll", "0.0.0.1", testAddr1))
+	assert.NoError(t, cm.setNamespaceRegistrar("finance.audit", testOrigin))
+	cm.origin = testOrigin
+	assert.NoError(t, cm.doCnsRegister("finance.audit.report", "0.0.0.1", testAddr4))
+	assert.Equal(t, errNotRegistrar, cm.setNamespaceRegistrar("finance", testOrigin))
+
+	// the registrar of an enclosing namespace takes over the names of its members
+	cm.origin = testCaller
+	assert.NoError(t, cm.transferNameOwnership("finance.audit.report", testAddr2))
+	owner, err = cm.getNameOwner("finance.audit.report")
+	assert.NoError(t, err)
+	assert.Equal(t, testAddr2, owner)
+
+	registrar, err := cm.getNamespaceRegistrar("finance.audit")
+	assert.NoError(t, err)
+	assert.Equal(t, testOrigin, registrar)
+}
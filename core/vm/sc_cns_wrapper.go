@@ -18,6 +18,10 @@ var (
 	errNameAndVerReg          = errors.New("[CNS] name and version is already registered and activated in CNS")
 	errNameReg                = errors.New("[CNS] Name is already registered")
 	errNameAndVerUnReg        = errors.New("[CNS] Name or version didn't register before")
+	errNameUnReg              = errors.New("[CNS] Name didn't register before")
+	errAddressUnReg           = errors.New("[CNS] Address didn't register before")
+	errNotNameOwner           = errors.New("[CNS] not owner of the name or registrar of its namespace")
+	errNotRegistrar           = errors.New("[CNS] not registrar of the namespace")
 )
 
 var (
@@ -79,6 +83,11 @@ func (cns *CnsWrapper) AllExportFns() SCExportFns {
 		"getRegisteredContractsByOrigin":  cns.getRegisteredContractsByOrigin, // getContractInfoByAddress -> getRegisteredContractsByOrigin
 		"importOldCnsManagerData":         cns.importOldCnsManagerData,
 		"getContractInfoByAddress":        cns.getRegisteredContractsByAddress,
+		"transferNameOwnership":           cns.transferNameOwnership,
+		"setNamespaceRegistrar":           cns.setNamespaceRegistrar,
+		"getNameOwner":                    cns.getNameOwner,
+		"getNamespaceRegistrar":           cns.getNamespaceRegistrar,
+		"getNameByAddress":                cns.getNameByAddress,
 	}
 }
 
@@ -127,7 +136,7 @@ func cnsRegisterErrHandle(err error) (int32, error) {
 	switch err {
 	case errInvalidCallFromInit, errInvalidCallNotFromInit:
 		return int32(cnsInvalidCall), err
-	case errNotOwner:
+	case errNotOwner, errNotRegistrar:
 		return int32(cnsNoPermission), err
 	case errNameInvalid, errVersionInvalid, errLowRegVersion:
 		return int32(cnsInvalidArgument), err
@@ -153,6 +162,57 @@ func (cns *CnsWrapper) cnsRedirect(name, version string) (int32, error) {
 	return int32(cnsSuccess), nil
 }
 
+func (cns *CnsWrapper) transferNameOwnership(name string, newOwner common.Address) (int32, error) {
+	err := cns.base.transferNameOwnership(name, newOwner)
+	return cnsOwnershipErrHandle(err)
+}
+
+func (cns *CnsWrapper) setNamespaceRegistrar(namespace string, registrar common.Address) (int32, error) {
+	err := cns.base.setNamespaceRegistrar(namespace, registrar)
+	return cnsOwnershipErrHandle(err)
+}
+
+func cnsOwnershipErrHandle(err error) (int32, error) {
+
+	switch err {
+	case errNotNameOwner, errNotRegistrar:
+		return int32(cnsNoPermission), err
+	case errNameInvalid, errAddressInvalid:
+		return int32(cnsInvalidArgument), err
+	case errNameUnReg:
+		return int32(cnsRegErr), err
+	}
+
+	return int32(cnsSuccess), nil
+}
+
+func (cns *CnsWrapper) getNameOwner(name string) (string, error) {
+	owner, err := cns.base.getNameOwner(name)
+	if err != nil {
+		return "", nil
+	}
+
+	return owner.String(), nil
+}
+
+func (cns *CnsWrapper) getNamespaceRegistrar(namespace string) (string, error) {
+	registrar, err := cns.base.getNamespaceRegistrar(namespace)
+	if err != nil {
+		return "", nil
+	}
+
+	return registrar.String(), nil
+}
+
+func (cns *CnsWrapper) getNameByAddress(address common.Address) (string, error) {
+	cnsInfo, err := cns.base.getNameByAddress(address)
+	if err != nil {
+		return newInternalErrorResult(err).String(), nil
+	}
+
+	return newSuccessResult(cnsInfo).String(), nil
+}
+
 func (cns *CnsWrapper) getContractAddress(name, version string) (string, error) {
 	addr, err := cns.base.getContractAddress(name, version)
 	if err != nil {
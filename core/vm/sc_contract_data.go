@@ -1,15 +1,21 @@
 package vm
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"math/big"
 
 	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/state"
 	"github.com/Venachain/Venachain/params"
 )
 
 var (
-	errNotCreator = errors.New("not creator of the contract")
+	errNotCreator         = errors.New("not creator of the contract")
+	errInvalidExport      = errors.New("invalid contract export data")
+	errExportBlockUnknown = errors.New("contract export block is not one of the last 256 blocks")
+	errImportNotEmpty     = errors.New("contract to import into already has storage")
 )
 
 type ContractDataProcessor struct {
@@ -17,12 +23,23 @@ type ContractDataProcessor struct {
 	caller       common.Address
 	contractAddr common.Address
 	blockNumber  *big.Int
+	getHash      GetHashFunc
 }
 
 func (d *ContractDataProcessor) RequiredGas(input []byte) uint64 {
 	if common.IsBytesEmpty(input) {
 		return 0
 	}
+	// an import is priced by the storage entries and the code it writes
+	_, fnName, _, fnParams, err := retrieveFnAndParams(input, d.AllExportFns())
+	if err == nil && fnName == "import" {
+		var export state.ContractExport
+		if err := json.Unmarshal([]byte(fnParams[1].String()), &export); err == nil {
+			return params.UserManagementGas +
+				uint64(len(export.Storage))*params.SCImportEntryGas +
+				uint64(len(export.Code))*params.CreateDataGas
+		}
+	}
 	return params.UserManagementGas
 }
 
@@ -59,6 +76,7 @@ func (d *ContractDataProcessor) emitEvent(topic string, code CodeType, msg strin
 func (d *ContractDataProcessor) AllExportFns() SCExportFns {
 	return SCExportFns{
 		"migrate": d.dataMigrate,
+		"import":  d.dataImport,
 	}
 }
 
@@ -75,18 +93,43 @@ func (d *ContractDataProcessor) dataMigrate(src common.Address, dest common.Addr
 	return 0, nil
 }
 
-// TODO: export all storage k-v data of the contract
-func (d *ContractDataProcessor) dataExport(addr common.Address) (int32, error) {
+// dataImport imports an export of a contract, as served by the
+// exportContract RPC, into a contract of the caller with an empty storage.
+// The export must be taken from one of the last 256 blocks of the chain, it
+// is checked against the state root of the block before anything is written.
+func (d *ContractDataProcessor) dataImport(addr common.Address, data string) (int32, error) {
 	if d.stateDB.GetContractCreator(addr) != d.Caller() {
 		return -1, errNotCreator
 	}
-	return 0, nil
-}
+	if d.stateDB.HasStorage(addr) {
+		return -1, errImportNotEmpty
+	}
+	var export state.ContractExport
+	if err := json.Unmarshal([]byte(data), &export); err != nil || export.Header == nil || export.Header.Number == nil {
+		return -1, errInvalidExport
+	}
+	number := export.Header.Number
+	if d.getHash == nil || number.Cmp(d.blockNumber) >= 0 || new(big.Int).Sub(d.blockNumber, number).Cmp(big.NewInt(256)) > 0 {
+		return -1, errExportBlockUnknown
+	}
+	account, err := state.VerifyContractExport(&export, d.getHash(number.Uint64()))
+	if err != nil {
+		return -1, err
+	}
 
-// TODO: import all storage k-v data to the contract
-func (d *ContractDataProcessor) dataImport(addr common.Address) (int32, error) {
-	if d.stateDB.GetContractCreator(addr) != d.Caller() {
-		return -1, errNotCreator
+	for _, entry := range export.Storage {
+		// the firewall data of the source is imported with the status below
+		if bytes.Equal(entry.Key, account.FwDataHash) {
+			continue
+		}
+		d.stateDB.SetState(addr, entry.Key, entry.Value)
 	}
+	d.stateDB.SetCode(addr, export.Code)
+
+	status := export.FwStatus
+	status.ContractAddr = addr
+	d.stateDB.SetFwStatus(addr, status)
+
+	d.emitEvent("import", operateSuccess, "import contract data success.")
 	return 0, nil
 }
@@ -0,0 +1,91 @@
+package vm
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/core/state"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/params"
+	"github.com/Venachain/Venachain/rlp"
+	"github.com/Venachain/Venachain/venadb/memorydb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractDataImport(t *testing.T) {
+	var (
+		db      = state.NewDatabase(memorydb.NewMemDatabase())
+		src     = common.HexToAddress("0x1000000000000000000000000000000000000aaa")
+		dest    = common.HexToAddress("0x1000000000000000000000000000000000000ccc")
+		creator = common.HexToAddress("0x1000000000000000000000000000000000000bbb")
+	)
+	code, _ := rlp.EncodeToBytes([]interface{}{common.Int64ToBytes(2), []byte{0x00, 0x61, 0x73, 0x6d}, []byte(`[]`)})
+	statedb, _ := state.New(common.Hash{}, db)
+	statedb.SetCode(src, code)
+	statedb.SetContractCreator(src, creator)
+	statedb.SetState(src, []byte("balance"), []byte{0x01, 0x02})
+	statedb.OpenFirewall(src)
+	accept, _ := state.NewAction("ACCEPT")
+	statedb.FwAdd(src, accept, []state.FwElem{{Addr: creator, FuncName: "transfer"}})
+	statedb.SetCode(dest, []byte{0x00, 0x61, 0x73, 0x6d})
+	statedb.SetContractCreator(dest, creator)
+	root, err := statedb.Commit(false)
+	assert.NoError(t, err)
+
+	statedb, _ = state.New(root, db)
+	export, err := statedb.ExportContract(src)
+	assert.NoError(t, err)
+	header := &types.Header{Number: big.NewInt(1), Time: big.NewInt(1000), Root: root}
+	export.Header = header
+	data, err := json.Marshal(export)
+	assert.NoError(t, err)
+
+	getHash := func(n uint64) common.Hash {
+		if n == 1 {
+			return header.Hash()
+		}
+		return common.Hash{}
+	}
+	d := &ContractDataProcessor{stateDB: statedb, caller: creator, blockNumber: big.NewInt(1), getHash: getHash}
+	_, err = d.dataImport(dest, string(data))
+	assert.Equal(t, errExportBlockUnknown, err, "the export block must precede the import")
+
+	d.blockNumber = big.NewInt(2)
+	ret, err := d.dataImport(dest, string(data))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), ret)
+	assert.Equal(t, []byte{0x01, 0x02}, statedb.GetState(dest, []byte("balance")))
+	assert.Equal(t, code, statedb.GetCode(dest))
+	status := statedb.GetFwStatus(dest)
+	assert.True(t, status.Active)
+	assert.Equal(t, dest, status.ContractAddr)
+	assert.Len(t, status.AcceptedList, 1)
+
+	d.caller = common.HexToAddress("0x01")
+	_, err = d.dataImport(dest, string(data))
+	assert.Equal(t, errNotCreator, err)
+
+	// the storage of the destination isn't overwritten
+	d.caller = creator
+	_, err = d.dataImport(dest, string(data))
+	assert.Equal(t, errImportNotEmpty, err)
+
+	other := common.HexToAddress("0x1000000000000000000000000000000000000ddd")
+	statedb.SetCode(other, []byte{0x00, 0x61, 0x73, 0x6d})
+	statedb.SetContractCreator(other, creator)
+	export.Storage[0].Value = []byte{0xff}
+	data, _ = json.Marshal(export)
+	_, err = d.dataImport(other, string(data))
+	assert.Equal(t, state.ErrInvalidExportProof, err)
+}
+
+func TestContractDataImportGas(t *testing.T) {
+	export := state.ContractExport{Code: make([]byte, 100), Storage: make([]state.StorageExport, 3)}
+	data, _ := json.Marshal(export)
+	input, _ := rlp.EncodeToBytes([]interface{}{common.Int64ToBytes(2), []byte("import"), []byte("0x1000000000000000000000000000000000000ccc"), data})
+
+	gas := (&ContractDataProcessor{}).RequiredGas(input)
+	assert.Equal(t, params.UserManagementGas+3*params.SCImportEntryGas+100*params.CreateDataGas, gas)
+}
@@ -1,13 +1,33 @@
 package vm
 
 import (
+	"errors"
 	"math/big"
 
 	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/common/merkle"
 	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/Venachain/Venachain/crypto"
 	"github.com/Venachain/Venachain/rlp"
 )
 
+const (
+	prefixSignedEvidence = "signedEvidence_"
+	prefixEvidenceBatch  = "evidenceBatch_"
+	prefixEvidenceLeaves = "evidenceLeaves_"
+	prefixEvidenceLeaf   = "evidenceLeaf_"
+
+	// maxEvidenceBatchSize bounds the document hashes anchored by a batch
+	maxEvidenceBatchSize = 4096
+)
+
+var (
+	errEvidenceSigInvalid   = errors.New("evidence signature is invalid")
+	errEvidenceBatchInvalid = errors.New("evidence batch is empty or too large")
+	errEvidenceRootMismatch = errors.New("evidence batch doesn't match the trusted batch")
+)
+
 type SCEvidence struct {
 	stateDB      StateDB
 	contractAddr common.Address
@@ -15,19 +35,77 @@ type SCEvidence struct {
 	blockNumber  *big.Int
 }
 
-//type Evidence struct {
-//	Owner        common.Address
-//	EvidenceHash common.Hash
-//	EvidenceSig  string
-//	Timestamp    *big.Int
-//}
-
 type Evidence struct {
 	Owner         common.Address
 	EvidenceValue string
 	Timestamp     *big.Int
 }
 
+// SignedEvidence is the hash of a document signed by the signer, who may be
+// another account than the owner sending the transaction.
+type SignedEvidence struct {
+	Owner       common.Address `json:"owner"`
+	Signer      common.Address `json:"signer"`
+	Hash        common.Hash    `json:"hash"`
+	Sig         hexutil.Bytes  `json:"sig"`
+	BlockNumber uint64         `json:"blockNumber"`
+}
+
+// EvidenceBatch anchors a batch of document hashes with their Merkle root,
+// the signer signs the root.
+type EvidenceBatch struct {
+	Root        common.Hash    `json:"root"`
+	Count       uint64         `json:"count"`
+	Owner       common.Address `json:"owner"`
+	Signer      common.Address `json:"signer"`
+	Sig         hexutil.Bytes  `json:"sig"`
+	BlockNumber uint64         `json:"blockNumber"`
+}
+
+// EvidenceProof proves the inclusion of a document hash in an anchored batch,
+// see merkle.Verify. The timestamp is the time of the anchoring block.
+type EvidenceProof struct {
+	Hash      common.Hash    `json:"hash"`
+	Index     uint64         `json:"index"`
+	Proof     []common.Hash  `json:"proof"`
+	Batch     *EvidenceBatch `json:"batch"`
+	Timestamp uint64         `json:"timestamp"`
+}
+
+// Verify checks the inclusion proof and the signature of the batch root.
+func (p *EvidenceProof) Verify() error {
+	if p.Batch == nil || !merkle.Verify(p.Batch.Root, p.Hash, p.Index, p.Batch.Count, p.Proof) {
+		return errors.New("inclusion proof is invalid")
+	}
+	if len(p.Batch.Sig) != 0 {
+		signer, err := recoverEvidenceSigner(p.Batch.Root, p.Batch.Sig)
+		if err != nil || signer != p.Batch.Signer {
+			return errEvidenceSigInvalid
+		}
+	}
+	return nil
+}
+
+// VerifyBatch checks the proof against the batch obtained from a trusted
+// source, the root must match and so must the count, signer and block if set.
+func (p *EvidenceProof) VerifyBatch(trusted *EvidenceBatch) error {
+	if p.Batch == nil || trusted == nil || p.Batch.Root != trusted.Root {
+		return errEvidenceRootMismatch
+	}
+	if (trusted.Count != 0 && p.Batch.Count != trusted.Count) ||
+		(trusted.Signer != (common.Address{}) && p.Batch.Signer != trusted.Signer) ||
+		(trusted.BlockNumber != 0 && p.Batch.BlockNumber != trusted.BlockNumber) {
+		return errEvidenceRootMismatch
+	}
+	return p.Verify()
+}
+
+// SignedEvidenceDigest returns the digest the signer of the evidence of key
+// signs.
+func SignedEvidenceDigest(key string, hash common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte(key), hash[:])
+}
+
 func NewSCEvidence(db StateDB) *SCEvidence {
 	return &SCEvidence{
 		stateDB:      db,
@@ -64,18 +142,152 @@ func (e *SCEvidence) setEvidence(key string, value string, isUpdateEvidence bool
 	return nil
 }
 
-//func (e *SCEvidence) checkSignature(hash string, sig string) bool {
-//	pub, err := crypto.SigToPub(crypto.Keccak256([]byte(hash)), []byte(sig))
-//	if err != nil {
-//		log.Error("Ecrecover Fail", "hash =", hash, "signature =", sig)
-//		return false
-//	}
-//	if e.caller != crypto.PubkeyToAddress(*pub) {
-//		log.Error("Authentication failed！！！")
-//		return false
-//	}
-//	return true
-//}
+// setSignedEvidence saves the hash signed by the signer recovered from sig
+// under key.
+func (e *SCEvidence) setSignedEvidence(key string, hash common.Hash, sig []byte) error {
+	signer, err := recoverEvidenceSigner(SignedEvidenceDigest(key, hash), sig)
+	if err != nil {
+		return err
+	}
+	if e.getState(prefixSignedEvidence+key) != nil {
+		return errAlreadySetEvidence
+	}
+	evidence := &SignedEvidence{
+		Owner:       e.caller,
+		Signer:      signer,
+		Hash:        hash,
+		Sig:         sig,
+		BlockNumber: e.blockNumber.Uint64(),
+	}
+	value, err := rlp.EncodeToBytes(evidence)
+	if err != nil {
+		return err
+	}
+	e.setState(prefixSignedEvidence+key, value)
+	return nil
+}
+
+func (e *SCEvidence) getSignedEvidence(key string) (*SignedEvidence, error) {
+	value := e.getState(prefixSignedEvidence + key)
+	if len(value) == 0 {
+		return nil, errEvidenceNotFound
+	}
+	var evidence SignedEvidence
+	if err := rlp.DecodeBytes(value, &evidence); err != nil {
+		return nil, err
+	}
+	return &evidence, nil
+}
+
+// anchorBatch anchors the hashes with their Merkle root. The root is signed by
+// the signer recovered from sig, or by the sender if sig is empty. A hash
+// anchored again keeps the proof of its first batch.
+func (e *SCEvidence) anchorBatch(hashes []common.Hash, sig []byte) (*EvidenceBatch, error) {
+	if len(hashes) == 0 || len(hashes) > maxEvidenceBatchSize {
+		return nil, errEvidenceBatchInvalid
+	}
+	root, err := merkle.Root(hashes)
+	if err != nil {
+		return nil, err
+	}
+	if e.getState(prefixEvidenceBatch+root.Hex()) != nil {
+		return nil, errAlreadySetEvidence
+	}
+
+	signer := e.caller
+	if len(sig) != 0 {
+		if signer, err = recoverEvidenceSigner(root, sig); err != nil {
+			return nil, err
+		}
+	}
+	batch := &EvidenceBatch{
+		Root:        root,
+		Count:       uint64(len(hashes)),
+		Owner:       e.caller,
+		Signer:      signer,
+		Sig:         sig,
+		BlockNumber: e.blockNumber.Uint64(),
+	}
+	value, err := rlp.EncodeToBytes(batch)
+	if err != nil {
+		return nil, err
+	}
+	e.setState(prefixEvidenceBatch+root.Hex(), value)
+
+	leaves := make([]byte, 0, len(hashes)*common.HashLength)
+	for _, hash := range hashes {
+		leaves = append(leaves, hash[:]...)
+		if e.getState(prefixEvidenceLeaf+hash.Hex()) == nil {
+			e.setState(prefixEvidenceLeaf+hash.Hex(), root[:])
+		}
+	}
+	e.setState(prefixEvidenceLeaves+root.Hex(), leaves)
+	return batch, nil
+}
+
+func (e *SCEvidence) getEvidenceBatch(root common.Hash) (*EvidenceBatch, error) {
+	value := e.getState(prefixEvidenceBatch + root.Hex())
+	if len(value) == 0 {
+		return nil, errEvidenceNotFound
+	}
+	var batch EvidenceBatch
+	if err := rlp.DecodeBytes(value, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// getEvidenceProof returns the inclusion proof of the hash in the batch first
+// anchoring it, without the timestamp.
+func (e *SCEvidence) getEvidenceProof(hash common.Hash) (*EvidenceProof, error) {
+	value := e.getState(prefixEvidenceLeaf + hash.Hex())
+	if len(value) == 0 {
+		return nil, errEvidenceNotFound
+	}
+	batch, err := e.getEvidenceBatch(common.BytesToHash(value))
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := e.getState(prefixEvidenceLeaves + batch.Root.Hex())
+	hashes := make([]common.Hash, 0, batch.Count)
+	index := -1
+	for i := 0; i+common.HashLength <= len(leaves); i += common.HashLength {
+		leaf := common.BytesToHash(leaves[i : i+common.HashLength])
+		if leaf == hash && index < 0 {
+			index = len(hashes)
+		}
+		hashes = append(hashes, leaf)
+	}
+	proof, err := merkle.Proof(hashes, index)
+	if err != nil {
+		return nil, err
+	}
+	return &EvidenceProof{Hash: hash, Index: uint64(index), Proof: proof, Batch: batch}, nil
+}
+
+// GetEvidenceProof returns the inclusion proof of the document hash in the
+// evidence batch anchoring it, in the state.
+func GetEvidenceProof(state StateDB, hash common.Hash) (*EvidenceProof, error) {
+	return NewSCEvidence(state).getEvidenceProof(hash)
+}
+
+// recoverEvidenceSigner recovers the signer of the digest from a 65 bytes
+// [R || S || V] signature, V is 0/1 or 27/28.
+func recoverEvidenceSigner(digest common.Hash, sig []byte) (common.Address, error) {
+	if len(sig) != 65 {
+		return common.Address{}, errEvidenceSigInvalid
+	}
+	rsv := common.CopyBytes(sig)
+	if rsv[64] >= 27 {
+		rsv[64] -= 27
+	}
+	pub, err := crypto.SigToPub(digest[:], rsv)
+	if err != nil {
+		return common.Address{}, errEvidenceSigInvalid
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
 
 func (e *SCEvidence) getEvidenceById(key string) (*Evidence, error) {
 	value := e.getState(key)
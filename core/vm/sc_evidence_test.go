@@ -1,9 +1,12 @@
 package vm
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/merkle"
+	"github.com/Venachain/Venachain/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,3 +28,70 @@ func TestSetEvidence(t *testing.T) {
 	rr := res.EvidenceValue
 	assert.True(t, rr == "cxhwxblockchain")
 }
+
+func TestSetSignedEvidence(t *testing.T) {
+	db := newMockStateDB()
+	e := NewSCEvidence(db)
+	e.caller = common.HexToAddress("0x80989fb9a8eb623dad541c1525828484e5fab75a")
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	hash := crypto.Keccak256Hash([]byte("contract.pdf"))
+	sig, err := crypto.Sign(SignedEvidenceDigest("contract", hash).Bytes(), key)
+	assert.NoError(t, err)
+	sig[64] += 27
+	assert.Equal(t, errEvidenceSigInvalid, e.setSignedEvidence("contract", hash, sig[:64]))
+	assert.NoError(t, e.setSignedEvidence("contract", hash, sig))
+	assert.Equal(t, errAlreadySetEvidence, e.setSignedEvidence("contract", hash, sig))
+
+	evidence, err := e.getSignedEvidence("contract")
+	assert.NoError(t, err)
+	assert.Equal(t, e.caller, evidence.Owner)
+	assert.Equal(t, signer, evidence.Signer)
+	assert.Equal(t, hash, evidence.Hash)
+}
+
+func TestAnchorEvidenceBatch(t *testing.T) {
+	db := newMockStateDB()
+	e := NewSCEvidence(db)
+	e.caller = common.HexToAddress("0x80989fb9a8eb623dad541c1525828484e5fab75a")
+	e.blockNumber = big.NewInt(7)
+	key, _ := crypto.GenerateKey()
+
+	hashes := make([]common.Hash, 5)
+	for i := range hashes {
+		hashes[i] = crypto.Keccak256Hash([]byte{byte(i)})
+	}
+	root, err := merkle.Root(hashes)
+	assert.NoError(t, err)
+	sig, err := crypto.Sign(root.Bytes(), key)
+	assert.NoError(t, err)
+
+	_, err = e.anchorBatch(nil, nil)
+	assert.Equal(t, errEvidenceBatchInvalid, err)
+	batch, err := e.anchorBatch(hashes, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, root, batch.Root)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), batch.Signer)
+	_, err = e.anchorBatch(hashes, nil)
+	assert.Equal(t, errAlreadySetEvidence, err)
+
+	for i, hash := range hashes {
+		proof, err := GetEvidenceProof(db, hash)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(i), proof.Index)
+		assert.Equal(t, uint64(7), proof.Batch.BlockNumber)
+		assert.NoError(t, proof.Verify())
+	}
+	_, err = GetEvidenceProof(db, common.Hash{})
+	assert.Equal(t, errEvidenceNotFound, err)
+
+	// the root served along the proof must match the trusted one
+	proof, err := GetEvidenceProof(db, hashes[0])
+	assert.NoError(t, err)
+	assert.NoError(t, proof.VerifyBatch(&EvidenceBatch{Root: root}))
+	assert.NoError(t, proof.VerifyBatch(batch))
+	assert.Equal(t, errEvidenceRootMismatch, proof.VerifyBatch(&EvidenceBatch{Root: common.Hash{0x01}}))
+	assert.Equal(t, errEvidenceRootMismatch, proof.VerifyBatch(&EvidenceBatch{Root: root, BlockNumber: 8}))
+	assert.Equal(t, errEvidenceRootMismatch, proof.VerifyBatch(nil))
+}
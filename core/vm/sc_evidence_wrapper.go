@@ -1,9 +1,11 @@
 package vm
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
 	"github.com/Venachain/Venachain/params"
 )
 
@@ -21,6 +23,12 @@ func (e *SCEvidenceWrapper) RequiredGas(input []byte) uint64 {
 	if common.IsBytesEmpty(input) {
 		return 0
 	}
+	// anchoring a batch is priced by the number of hashes
+	_, fnName, _, fnParams, err := retrieveFnAndParams(input, e.allExportFns())
+	if err == nil && fnName == "anchorEvidenceBatch" {
+		count := uint64(len(strings.TrimPrefix(fnParams[0].String(), "0x")) / (2 * common.HashLength))
+		return params.SCEvidenceGas + count*params.SCEvidenceLeafGas
+	}
 	return params.SCEvidenceGas
 }
 
@@ -88,11 +96,99 @@ func (e *SCEvidenceWrapper) getJsonData(key string) (string, error) {
 	return evidence.EvidenceValue, nil
 }
 
+// saveSignedEvidence saves the document hash under key, sig is the hex
+// encoded signature of SignedEvidenceDigest(key, hash) by the signer.
+func (e *SCEvidenceWrapper) saveSignedEvidence(key string, hash string, sig string) (int, error) {
+	h, err := hexutil.Decode(hash)
+	if err != nil || len(h) != common.HashLength {
+		return int(setEvidenceFailed), errParamInvalid
+	}
+	s, err := hexutil.Decode(sig)
+	if err != nil {
+		return int(setEvidenceFailed), errParamInvalid
+	}
+	if err := e.base.setSignedEvidence(key, common.BytesToHash(h), s); err != nil {
+		if err == errAlreadySetEvidence {
+			return int(setEvidenceAlreadyExist), err
+		}
+		return int(setEvidenceFailed), err
+	}
+	e.base.emitNotifyEvent(setEvidenceSuccess, "save signed evidence success")
+	return int(setEvidenceSuccess), nil
+}
+
+func (e *SCEvidenceWrapper) getSignedEvidence(key string) (string, error) {
+	evidence, err := e.base.getSignedEvidence(key)
+	if err != nil {
+		return "", err
+	}
+	return newSuccessResult(evidence).String(), nil
+}
+
+// anchorEvidenceBatch anchors the concatenated hex encoded document hashes
+// with their Merkle root, sig is the optional hex encoded signature of the
+// root.
+func (e *SCEvidenceWrapper) anchorEvidenceBatch(hashes string, sig string) (int, error) {
+	data, err := hexutil.Decode(hashes)
+	if err != nil || len(data)%common.HashLength != 0 {
+		return int(setEvidenceFailed), errParamInvalid
+	}
+	leaves := make([]common.Hash, 0, len(data)/common.HashLength)
+	for i := 0; i < len(data); i += common.HashLength {
+		leaves = append(leaves, common.BytesToHash(data[i:i+common.HashLength]))
+	}
+	var s []byte
+	if sig != "" {
+		if s, err = hexutil.Decode(sig); err != nil {
+			return int(setEvidenceFailed), errParamInvalid
+		}
+	}
+
+	batch, err := e.base.anchorBatch(leaves, s)
+	if err != nil {
+		if err == errAlreadySetEvidence {
+			return int(setEvidenceAlreadyExist), err
+		}
+		return int(setEvidenceFailed), err
+	}
+	e.base.emitNotifyEvent(setEvidenceSuccess, fmt.Sprintf("anchor evidence batch %s", batch.Root.Hex()))
+	return int(setEvidenceSuccess), nil
+}
+
+func (e *SCEvidenceWrapper) getEvidenceBatch(root string) (string, error) {
+	r, err := hexutil.Decode(root)
+	if err != nil || len(r) != common.HashLength {
+		return "", errParamInvalid
+	}
+	batch, err := e.base.getEvidenceBatch(common.BytesToHash(r))
+	if err != nil {
+		return "", err
+	}
+	return newSuccessResult(batch).String(), nil
+}
+
+func (e *SCEvidenceWrapper) getEvidenceProof(hash string) (string, error) {
+	h, err := hexutil.Decode(hash)
+	if err != nil || len(h) != common.HashLength {
+		return "", errParamInvalid
+	}
+	proof, err := e.base.getEvidenceProof(common.BytesToHash(h))
+	if err != nil {
+		return "", err
+	}
+	return newSuccessResult(proof).String(), nil
+}
+
 func (e *SCEvidenceWrapper) allExportFns() SCExportFns {
 	return SCExportFns{
-		"saveEvidence": e.saveEvidence,
-		"getEvidence":  e.getEvidence,
-		"setJsonData":  e.setJsonData,
-		"getJsonData":  e.getJsonData,
+		"saveEvidence":        e.saveEvidence,
+		"getEvidence":         e.getEvidence,
+		"setJsonData":         e.setJsonData,
+		"getJsonData":         e.getJsonData,
+		"saveSignedEvidence":  e.saveSignedEvidence,
+		"getSignedEvidence":   e.getSignedEvidence,
+		"anchorEvidenceBatch": e.anchorEvidenceBatch,
+		"getEvidenceBatch":    e.getEvidenceBatch,
+		"getEvidenceProof":    e.getEvidenceProof,
 	}
 }
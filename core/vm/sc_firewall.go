@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"encoding/json"
 	"math/big"
 	"strings"
 
@@ -154,13 +155,69 @@ func (u *FireWall) fwSet(contractAddr common.Address, act, lst string) error {
 	return nil
 }
 
+func (u *FireWall) fwAddRule(contractAddr common.Address, data string) error {
+	if !u.isOwner(contractAddr) {
+		u.emitNotifyEvent(fwNoPermission, fwErrNotOwner.Error())
+		return fwErrNotOwner
+	}
+
+	rule, err := convertToFwRule(data)
+	if err != nil {
+		u.emitNotifyEvent(fwInvalidArgument, err.Error())
+		return err
+	}
+
+	rules := u.stateDB.GetFwStatus(contractAddr).Rules
+	u.stateDB.FwSetRules(contractAddr, state.MergeFwRules(rules, rule))
+
+	u.emitNotifyEvent(fwOpSuccess, "fw add rule success")
+	return nil
+}
+
+func (u *FireWall) fwDelRule(contractAddr common.Address, id string) error {
+	if !u.isOwner(contractAddr) {
+		u.emitNotifyEvent(fwNoPermission, fwErrNotOwner.Error())
+		return fwErrNotOwner
+	}
+
+	rules := u.stateDB.GetFwStatus(contractAddr).Rules
+	for i, r := range rules {
+		if r.ID == id {
+			u.stateDB.FwSetRules(contractAddr, append(rules[:i], rules[i+1:]...))
+			u.emitNotifyEvent(fwOpSuccess, "fw delete rule success")
+			return nil
+		}
+	}
+
+	u.emitNotifyEvent(fwInvalidArgument, ErrFwRuleNotFound.Error())
+	return ErrFwRuleNotFound
+}
+
+func (u *FireWall) fwClearRules(contractAddr common.Address) error {
+	if !u.isOwner(contractAddr) {
+		u.emitNotifyEvent(fwNoPermission, fwErrNotOwner.Error())
+		return fwErrNotOwner
+	}
+
+	u.stateDB.FwSetRules(contractAddr, nil)
+
+	u.emitNotifyEvent(fwOpSuccess, "fw clear rules success")
+	return nil
+}
+
 func (u *FireWall) fwImport(contractAddr common.Address, data []byte) error {
 	if !u.isOwner(contractAddr) {
 		u.emitNotifyEvent(fwNoPermission, fwErrNotOwner.Error())
 		return fwErrNotOwner
 	}
 
-	err := u.stateDB.FwImport(contractAddr, data)
+	data, err := checkFwImportRules(data)
+	if err != nil {
+		u.emitNotifyEvent(fwInvalidArgument, err.Error())
+		return err
+	}
+
+	err = u.stateDB.FwImport(contractAddr, data)
 	if err != nil {
 		u.emitNotifyEvent(fwInvalidArgument, err.Error())
 		return err
@@ -225,3 +282,21 @@ func convertToFwElem(l string) ([]state.FwElem, error) {
 
 	return list, nil
 }
+
+// checkFwImportRules checks the conditional rules of the imported firewall
+// data and returns the data with the rule defaults filled.
+func checkFwImportRules(data []byte) ([]byte, error) {
+	var status state.FwStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, ErrFwRule
+	}
+	if len(status.Rules) == 0 {
+		return data, nil
+	}
+	for i := range status.Rules {
+		if err := checkFwRule(&status.Rules[i]); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(status)
+}
@@ -0,0 +1,156 @@
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/Venachain/Venachain/core/state"
+	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/rlp"
+)
+
+var (
+	ErrFwRuleID        = errors.New("FW : error, incorrect firewall rule id")
+	ErrFwRuleRole      = errors.New("FW : error, unsupported role in firewall rule")
+	ErrFwRuleWindow    = errors.New("FW : error, incorrect block or time window in firewall rule")
+	ErrFwRuleRateLimit = errors.New("FW : error, incorrect rate limit in firewall rule")
+	ErrFwRuleNotFound  = errors.New("FW : error, firewall rule not found")
+)
+
+// fwRateKeyPrefix prefixes the storage keys of the rate limit counters. The
+// counters are kept in the storage of the firewall system contract, out of
+// reach of the protected contract.
+const fwRateKeyPrefix = "fwRateLimit"
+
+// fwRateCounter counts the calls of a caller accepted by a rate limited rule
+// in a window of blocks.
+type fwRateCounter struct {
+	Window uint64
+	Count  uint64
+}
+
+// convertToFwRule parses and checks a conditional firewall rule in json.
+func convertToFwRule(data string) (state.FwRule, error) {
+	var rule state.FwRule
+	if err := json.Unmarshal([]byte(data), &rule); err != nil {
+		return rule, ErrFwRule
+	}
+	if err := checkFwRule(&rule); err != nil {
+		return rule, err
+	}
+	return rule, nil
+}
+
+// checkFwRule checks a rule and fills the defaults of the caller and the
+// function, both match anything when omitted.
+func checkFwRule(rule *state.FwRule) error {
+	if ok, _ := checkNameFormat(rule.ID); !ok {
+		return ErrFwRuleID
+	}
+	if _, err := state.NewAction(rule.Action); err != nil {
+		return err
+	}
+	if rule.Addr == (common.Address{}) {
+		rule.Addr = state.FwWildchardAddr
+	}
+	if rule.FuncName == "" {
+		rule.FuncName = "*"
+	}
+	if ok, _ := checkNameFormat(rule.FuncName); rule.FuncName != "*" && !ok {
+		return ErrFwRuleName
+	}
+	if _, ok := rolesMap[rule.Role]; rule.Role != "" && !ok {
+		return ErrFwRuleRole
+	}
+	if (rule.ToBlock != 0 && rule.ToBlock < rule.FromBlock) ||
+		(rule.ToTime != 0 && rule.ToTime < rule.FromTime) {
+		return ErrFwRuleWindow
+	}
+	if (rule.RateLimit == 0) != (rule.RateWindow == 0) {
+		return ErrFwRuleRateLimit
+	}
+	// a rate limit only bounds the calls a rule lets through
+	if rule.RateLimit != 0 && rule.IsReject() {
+		return ErrFwRuleRateLimit
+	}
+	return nil
+}
+
+// fwRuleCheck evaluates the conditional rules of a contract in priority
+// order. It returns whether a rule matched the call and whether the matching
+// rule accepts it.
+func fwRuleCheck(evm *EVM, rules []state.FwRule, contractAddr, caller common.Address, funcName string) (matched bool, accepted bool) {
+	for i := range rules {
+		rule := &rules[i]
+		if !matchFwRule(evm, rule, caller, funcName) {
+			continue
+		}
+		if rule.IsReject() {
+			return true, false
+		}
+		if rule.RateLimit != 0 && !consumeFwRate(evm, rule, contractAddr, caller) {
+			// the caller used up the calls allowed by the rule in the
+			// current window, the rule doesn't apply anymore
+			continue
+		}
+		return true, true
+	}
+	return false, false
+}
+
+func matchFwRule(evm *EVM, rule *state.FwRule, caller common.Address, funcName string) bool {
+	if rule.Addr != state.FwWildchardAddr && rule.Addr != caller {
+		return false
+	}
+	if rule.FuncName != "*" && rule.FuncName != funcName {
+		return false
+	}
+
+	number := evm.BlockNumber.Uint64()
+	if number < rule.FromBlock || (rule.ToBlock != 0 && number > rule.ToBlock) {
+		return false
+	}
+	if rule.FromTime != 0 || rule.ToTime != 0 {
+		evm.readHeader()
+		now := evm.Time.Uint64()
+		if now < rule.FromTime || (rule.ToTime != 0 && now > rule.ToTime) {
+			return false
+		}
+	}
+
+	if rule.Role != "" && !hasUserRole(evm.StateDB, caller, rule.Role, evm.BlockNumber) {
+		return false
+	}
+	if rule.GroupID != nil && !isGroupMember(evm.StateDB, *rule.GroupID, caller) {
+		return false
+	}
+	return true
+}
+
+// consumeFwRate counts a call of the caller against the rate limit of the
+// rule, it returns false if the limit of the current window is reached.
+func consumeFwRate(evm *EVM, rule *state.FwRule, contractAddr, caller common.Address) bool {
+	key := crypto.Keccak256([]byte(fwRateKeyPrefix), contractAddr.Bytes(), []byte(rule.ID), caller.Bytes())
+	window := evm.BlockNumber.Uint64() / rule.RateWindow
+
+	counter := fwRateCounter{Window: window}
+	if data := evm.StateDB.GetState(syscontracts.FirewallManagementAddress, key); len(data) != 0 {
+		var stored fwRateCounter
+		if err := rlp.DecodeBytes(data, &stored); err == nil && stored.Window == window {
+			counter = stored
+		}
+	}
+	if counter.Count >= rule.RateLimit {
+		return false
+	}
+	counter.Count++
+
+	data, err := rlp.EncodeToBytes(counter)
+	if err != nil {
+		return false
+	}
+	evm.StateDB.SetState(syscontracts.FirewallManagementAddress, key, data)
+	return true
+}
@@ -1,8 +1,13 @@
 package vm
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/Venachain/Venachain/core/state"
+	"github.com/Venachain/Venachain/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,3 +35,84 @@ func TestConvertToFwElem(t *testing.T) {
 	}
 
 }
+
+func TestConvertToFwRule(t *testing.T) {
+	testCases := []struct {
+		rule     string
+		expected error
+	}{
+		{`{"id":"r1","action":"accept","funcName":"transfer","role":"CONTRACT_ADMIN"}`, nil},
+		{`{"id":"r2","action":"accept","rateLimit":2,"rateWindow":10}`, nil},
+		{`{"id":"r3","action":"drop"}`, state.ErrInvalidFwAction},
+		{`{"action":"accept"}`, ErrFwRuleID},
+		{`{"id":"r4","action":"accept","role":"NO_ROLE"}`, ErrFwRuleRole},
+		{`{"id":"r5","action":"accept","fromBlock":10,"toBlock":5}`, ErrFwRuleWindow},
+		{`{"id":"r6","action":"accept","rateLimit":2}`, ErrFwRuleRateLimit},
+		{`{"id":"r7","action":"reject","rateLimit":2,"rateWindow":10}`, ErrFwRuleRateLimit},
+		{`not json`, ErrFwRule},
+	}
+
+	for _, data := range testCases {
+		_, err := convertToFwRule(data.rule)
+		assert.Equal(t, data.expected, err, data.rule)
+	}
+
+	rule, err := convertToFwRule(`{"id":"r1","action":"accept"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, state.FwWildchardAddr, rule.Addr)
+	assert.Equal(t, "*", rule.FuncName)
+}
+
+func TestFwRuleCheck(t *testing.T) {
+	var (
+		db      = newMockStateDB()
+		admin   = common.HexToAddress(fwTestAddr1)
+		member  = common.HexToAddress(fwTestAddr2)
+		other   = common.HexToAddress("0x0000000000000000000000000000000000000789")
+		fwAddr  = common.HexToAddress("0x0000000000000000000000000000000000000abc")
+		groupID = uint64(7)
+		evm     = &EVM{StateDB: db, Context: Context{BlockNumber: big.NewInt(100), Time: big.NewInt(1000)}}
+	)
+	um := &UserManagement{stateDB: db, contractAddr: syscontracts.UserManagementAddress}
+	assert.NoError(t, um.setRole(admin, UserRoles(1<<contractAdmin)))
+	gm := &GroupManagement{stateDB: db, contractAddr: syscontracts.GroupManagementAddress}
+	assert.NoError(t, gm.storeGroupInfo(&GroupInfo{GroupID: groupID, Creator: admin.String(), Members: []string{member.String()}}))
+
+	rules := state.SortedFwRules([]state.FwRule{
+		{ID: "window", Priority: 3, Action: "accept", Addr: state.FwWildchardAddr, FuncName: "*", FromBlock: 200},
+		{ID: "group", Priority: 2, Action: "accept", Addr: state.FwWildchardAddr, FuncName: "read", GroupID: &groupID},
+		{ID: "admin", Priority: 1, Action: "reject", Addr: state.FwWildchardAddr, FuncName: "read", Role: "CONTRACT_ADMIN"},
+		{ID: "rate", Priority: 4, Action: "accept", Addr: other, FuncName: "write", RateLimit: 2, RateWindow: 10},
+	})
+
+	testCases := []struct {
+		caller   common.Address
+		funcName string
+		matched  bool
+		accepted bool
+	}{
+		{admin, "read", true, false},  // the role rule has precedence over the group rule
+		{member, "read", true, true},  // group member
+		{other, "read", false, false}, // neither in the group nor in the block window
+		{other, "write", true, true},
+		{other, "write", true, true},
+		{other, "write", false, false}, // rate limit reached in the window
+	}
+	for i, data := range testCases {
+		matched, accepted := fwRuleCheck(evm, rules, fwAddr, data.caller, data.funcName)
+		assert.Equal(t, data.matched, matched, "case %d", i)
+		assert.Equal(t, data.accepted, accepted, "case %d", i)
+	}
+
+	// the rate limit starts over in the next window, the block window opens
+	evm.BlockNumber = big.NewInt(200)
+	matched, accepted := fwRuleCheck(evm, rules, fwAddr, other, "write")
+	assert.True(t, matched && accepted)
+	matched, accepted = fwRuleCheck(evm, rules, fwAddr, other, "read")
+	assert.True(t, matched && accepted)
+
+	// the counters are out of reach of the protected contract
+	key := crypto.Keccak256([]byte(fwRateKeyPrefix), fwAddr.Bytes(), []byte("rate"), other.Bytes())
+	assert.Empty(t, db.GetState(fwAddr, key))
+	assert.NotEmpty(t, db.GetState(syscontracts.FirewallManagementAddress, key))
+}
@@ -48,6 +48,11 @@ func (u *FwWrapper) AllExportFns() SCExportFns {
 		"__sys_FwDel":    u.fwDel,
 		"__sys_FwSet":    u.fwSet,
 		"__sys_FwImport": u.fwImport,
+
+		"__sys_FwAddRule":    u.fwAddRule,
+		"__sys_FwDelRule":    u.fwDelRule,
+		"__sys_FwClearRules": u.fwClearRules,
+
 		"__sys_FwStatus": u.getFwStatus,
 		"__sys_FwExport": u.getFwStatus,
 	}
@@ -127,6 +132,43 @@ func (u *FwWrapper) fwSet(contractAddr common.Address, act, lst string) (int32,
 	return int32(fwOpSuccess), nil
 }
 
+func (u *FwWrapper) fwAddRule(contractAddr common.Address, rule string) (int32, error) {
+	err := u.base.fwAddRule(contractAddr, rule)
+
+	switch err {
+	case fwErrNotOwner:
+		return int32(fwNoPermission), err
+	case state.ErrInvalidFwAction, ErrFwRule, ErrFwRuleName, ErrFwRuleID, ErrFwRuleRole, ErrFwRuleWindow, ErrFwRuleRateLimit:
+		return int32(fwInvalidArgument), err
+	}
+
+	return int32(fwOpSuccess), nil
+}
+
+func (u *FwWrapper) fwDelRule(contractAddr common.Address, id string) (int32, error) {
+	err := u.base.fwDelRule(contractAddr, id)
+
+	switch err {
+	case fwErrNotOwner:
+		return int32(fwNoPermission), err
+	case ErrFwRuleNotFound:
+		return int32(fwInvalidArgument), err
+	}
+
+	return int32(fwOpSuccess), nil
+}
+
+func (u *FwWrapper) fwClearRules(contractAddr common.Address) (int32, error) {
+	err := u.base.fwClearRules(contractAddr)
+
+	switch err {
+	case fwErrNotOwner:
+		return int32(fwNoPermission), err
+	}
+
+	return int32(fwOpSuccess), nil
+}
+
 func (u *FwWrapper) fwImport(contractAddr common.Address, data string) (int32, error) {
 	err := u.base.fwImport(contractAddr, []byte(data))
 
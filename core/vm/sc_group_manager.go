@@ -9,11 +9,15 @@ import (
 	"github.com/Venachain/Venachain/common/syscontracts"
 
 	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/p2p/discover"
 	"github.com/Venachain/Venachain/params"
 )
 
 var (
-	ErrRepeatedGroupID = errors.New("Repeated GroupID ")
+	ErrRepeatedGroupID   = errors.New("Repeated GroupID ")
+	ErrGroupNotFound     = errors.New("Group not found ")
+	ErrBootNodeExists    = errors.New("Boot node already exists ")
+	ErrGroupMemberExists = errors.New("Group member already exists ")
 )
 
 const (
@@ -33,6 +37,21 @@ type GroupInfo struct {
 	GroupID      uint64   `json:"groupID"`
 	CreatorEnode string   `json:"creatorEnode"`
 	BootNodes    []string `json:"bootNodes"`
+	CreateBlock  uint64   `json:"createBlock"` // main chain block number the group was created at
+	Members      []string `json:"members,omitempty"`
+}
+
+// IsMember reports whether the account is the creator or a member of the group.
+func (g *GroupInfo) IsMember(addr common.Address) bool {
+	if common.HexToAddress(g.Creator) == addr {
+		return true
+	}
+	for _, m := range g.Members {
+		if common.HexToAddress(m) == addr {
+			return true
+		}
+	}
+	return false
 }
 
 func (g GroupInfo) String() string {
@@ -81,12 +100,14 @@ func (g *GroupManagement) AllExportFns() SCExportFns {
 		"updateBootNodes":      g.updateBootNodes,
 		"addBootNode":          g.addBootNode,
 		"delBootNode":          g.delBootNode,
+		"addGroupMember":       g.addGroupMember,
+		"delGroupMember":       g.delGroupMember,
 	}
 }
 
 // export functions
 func (g *GroupManagement) hasGroupOpPermission() (int32, error) {
-	if hasGroupCreatePermission(g.stateDB, g.caller) {
+	if hasGroupCreatePermission(g.stateDB, g.caller, g.blockNumber) {
 		return 1, nil
 	}
 	return 0, nil
@@ -101,7 +122,11 @@ func (g *GroupManagement) createGroup(groupInfo string) (int32, error) {
 	if err != nil {
 		return -1, err
 	}
+	if _, err := discover.ParseNode(group.CreatorEnode); err != nil {
+		return -1, err
+	}
 	group.Creator = g.Caller().String()
+	group.CreateBlock = g.blockNumber.Uint64()
 	if err := g.addGroup(group); err != nil {
 		return -1, err
 	}
@@ -162,7 +187,7 @@ func (g *GroupManagement) addBootNode(groupID uint64, node string) (int32, error
 	}
 	for _, n := range group.BootNodes {
 		if n == node {
-			return -1, nil
+			return -1, ErrBootNodeExists
 		}
 	}
 	group.BootNodes = append(group.BootNodes, node)
@@ -196,6 +221,49 @@ func (g *GroupManagement) delBootNode(groupID uint64, node string) (int32, error
 	return 0, nil
 }
 
+func (g *GroupManagement) addGroupMember(groupID uint64, member common.Address) (int32, error) {
+	group, err := g.getGroupInfo(groupID)
+	if err != nil {
+		return -1, err
+	}
+	if group.Creator != g.Caller().String() {
+		return -1, errNoPermission
+	}
+	if group.IsMember(member) {
+		return -1, ErrGroupMemberExists
+	}
+	group.Members = append(group.Members, member.String())
+
+	if err := g.updateGroupInfo(*group); err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+func (g *GroupManagement) delGroupMember(groupID uint64, member common.Address) (int32, error) {
+	group, err := g.getGroupInfo(groupID)
+	if err != nil {
+		return -1, err
+	}
+	if group.Creator != g.Caller().String() {
+		return -1, errNoPermission
+	}
+	pos := -1
+	for i, m := range group.Members {
+		if common.HexToAddress(m) == member {
+			pos = i
+		}
+	}
+	if pos != -1 {
+		group.Members = append(group.Members[:pos], group.Members[pos+1:]...)
+		if err := g.updateGroupInfo(*group); err != nil {
+			return -1, err
+		}
+	}
+
+	return 0, nil
+}
+
 // internal functions
 func (g *GroupManagement) addGroup(info GroupInfo) error {
 	groups, err := g.getGroupList()
@@ -255,6 +323,9 @@ func (g *GroupManagement) getGroupInfo(id uint64) (*GroupInfo, error) {
 	groupKey := generateGroupKey(id)
 
 	rawData := g.getState(groupKey)
+	if len(rawData) == 0 {
+		return nil, ErrGroupNotFound
+	}
 	group := &GroupInfo{}
 
 	if err := json.Unmarshal(rawData, group); err != nil {
@@ -344,6 +415,25 @@ func (g *GroupManagement) getGroupList() ([]GroupInfo, error) {
 	return groups, nil
 }
 
+// GetGroupInfo reads the record of the given group from the group management
+// contract storage, it's used by the nodes hosting the group ledger.
+func GetGroupInfo(stateDB StateDB, groupID uint64) (*GroupInfo, error) {
+	g := &GroupManagement{
+		stateDB:      stateDB,
+		contractAddr: syscontracts.GroupManagementAddress,
+	}
+	return g.getGroupInfo(groupID)
+}
+
+// isGroupMember reports whether the account is a member of the group.
+func isGroupMember(stateDB StateDB, groupID uint64, addr common.Address) bool {
+	group, err := GetGroupInfo(stateDB, groupID)
+	if err != nil {
+		return false
+	}
+	return group.IsMember(addr)
+}
+
 func (g *GroupManagement) emitEvent(topic string, code CodeType, msg string) {
 	emitEvent(syscontracts.GroupManagementAddress, g.stateDB, g.blockNumber.Uint64(), topic, code, msg)
 }
@@ -0,0 +1,36 @@
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupManagementAddExisting(t *testing.T) {
+	var (
+		db      = newMockStateDB()
+		creator = common.HexToAddress(fwTestAddr1)
+		member  = common.HexToAddress(fwTestAddr2)
+		groupID = uint64(7)
+		node    = "enode://0000@127.0.0.1:16791"
+	)
+	gm := &GroupManagement{stateDB: db, caller: creator, blockNumber: big.NewInt(1), contractAddr: syscontracts.GroupManagementAddress}
+	assert.NoError(t, gm.storeGroupInfo(&GroupInfo{GroupID: groupID, Creator: creator.String()}))
+
+	ret, err := gm.addGroupMember(groupID, member)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), ret)
+	_, err = gm.addGroupMember(groupID, member)
+	assert.Equal(t, ErrGroupMemberExists, err)
+	_, err = gm.addGroupMember(groupID, creator)
+	assert.Equal(t, ErrGroupMemberExists, err)
+
+	ret, err = gm.addBootNode(groupID, node)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), ret)
+	_, err = gm.addBootNode(groupID, node)
+	assert.Equal(t, ErrBootNodeExists, err)
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
 	"sort"
 
 	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/byteutil"
 	"github.com/Venachain/Venachain/common/syscontracts"
 	"github.com/Venachain/Venachain/log"
 	"github.com/Venachain/Venachain/rlp"
@@ -36,9 +38,16 @@ const (
 )
 
 const (
-	keyOfNodesNameDB         = "nodes-name-key"
-	prefixNodeName           = "sc-node-name"
-	keyOfConsensisNodeNameDB = "consensis-nodes-name-key"
+	defaultNodeWeight = uint64(1)
+	nodeWeightMax     = uint64(math.MaxUint32)
+)
+
+const (
+	keyOfNodesNameDB          = "nodes-name-key"
+	prefixNodeName            = "sc-node-name"
+	prefixNodeWeight          = "sc-node-weight"
+	keyOfConsensisNodeNameDB  = "consensis-nodes-name-key"
+	keyOfElectionTranscriptDB = "vrf-election-transcript-key"
 )
 
 var (
@@ -52,6 +61,8 @@ var (
 	errNodeNotFound   = errors.New("node not found")
 )
 
+var errNodeWeightInvalid = fmt.Errorf("the weight of node must be within [%d, %d]", defaultNodeWeight, nodeWeightMax)
+
 const (
 	addNodeSuccess      CodeType = 0
 	addNodeBadParameter CodeType = 1
@@ -137,6 +148,13 @@ func checkNodeTypeForObserverOrLight(typ uint32) error {
 	return nil
 }
 
+func checkNodeWeight(weight uint64) error {
+	if weight < defaultNodeWeight || weight > nodeWeightMax {
+		return errNodeWeightInvalid
+	}
+	return nil
+}
+
 func checkNodeDescLen(desc string) error {
 	if len(bytes.Runes([]byte(desc))) > nodeDescMaxLenInCharacter {
 		return errors.New(fmt.Sprintf("The length of node name must be less than %d", nodeDescMaxLenInCharacter))
@@ -157,6 +175,10 @@ func genNodeName(name string) string {
 	return fmt.Sprintf("%s-%s", prefixNodeName, name)
 }
 
+func genNodeWeight(name string) string {
+	return fmt.Sprintf("%s-%s", prefixNodeWeight, name)
+}
+
 func fromNodes(nodes []*syscontracts.NodeInfo) []*eNode {
 	var enodes []*eNode
 	for _, n := range nodes {
@@ -219,6 +241,13 @@ func (n *SCNode) checkParamsOfAddNode(node *syscontracts.NodeInfo) error {
 		return err
 	}
 
+	// the weight is optional, an unset weight is the default one
+	if node.Weight != 0 {
+		if err := checkNodeWeight(node.Weight); err != nil {
+			return err
+		}
+	}
+
 	names, err := n.getNames()
 	if err != nil {
 		if errNodeNotFound != err {
@@ -283,6 +312,13 @@ func (n *SCNode) checkParamsOfUpdateNodeAndReturnUpdatedNode(name string, update
 		node.DelayNum = *update.DelayNum
 	}
 
+	if nil != update.Weight {
+		if err := checkNodeWeight(*update.Weight); err != nil {
+			return nil, err
+		}
+		node.Weight = *update.Weight
+	}
+
 	return node, nil
 }
 
@@ -310,7 +346,7 @@ func (n *SCNode) checkPermissionForAdd() error {
 		return nil
 	}
 
-	if !hasNodeOpPermission(n.stateDB, n.caller) {
+	if !hasNodeOpPermission(n.stateDB, n.caller, n.blockNumber) {
 		log.Error("Failed to add node.", "error", n.caller.String()+" has no permission to add node.")
 		return errNoPermissionManageSCNode
 	}
@@ -344,6 +380,9 @@ func (n *SCNode) add(node *syscontracts.NodeInfo) error {
 		return err
 	}
 	n.setState(genNodeName(node.Name), encodedBin)
+	if node.Weight != 0 {
+		n.setState(genNodeWeight(node.Name), common.Uint64ToBytes(node.Weight))
+	}
 
 	n.emitNotifyEvent(addNodeSuccess, fmt.Sprintf("add node success. node:%s", node.String()))
 	log.Info("add node success.", "node", node.String())
@@ -370,6 +409,9 @@ func (n *SCNode) update(name string, update *syscontracts.UpdateNode) error {
 		return err
 	}
 	n.setState(genNodeName(node.Name), encodedBin)
+	if nil != update.Weight {
+		n.setState(genNodeWeight(node.Name), common.Uint64ToBytes(node.Weight))
+	}
 
 	n.emitNotifyEvent(updateNodeSuccess, fmt.Sprintf("update node success. info:%s", update.String()))
 	log.Info("update node success. ", "update info", update.String())
@@ -452,6 +494,9 @@ func (n *SCNode) getNodeByName(name string) (*syscontracts.NodeInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	if bin := n.getState(genNodeWeight(name)); len(bin) != 0 {
+		node.Weight = byteutil.BytesToUint64(bin)
+	}
 
 	return &node, nil
 }
@@ -608,6 +653,14 @@ func (n *SCNode) VrfElection(nonce []byte) (int32, error) {
 		return 0, nil
 	}
 
+	election, err := scParam.getVRFElectionParams()
+	if err != nil {
+		return 0, err
+	}
+	if !election.IsLegacy() {
+		return n.weightedElection(nonce, vrf.ValidatorCount, election)
+	}
+
 	h1 := common.RlpHash(nonce)
 
 	consensusNodes := NodesForElection{}
@@ -0,0 +1,195 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/rlp"
+)
+
+var errElectionTranscriptNotFound = errors.New("election transcript not found")
+
+// ElectionCandidate is a consensus node running for the committee.
+type ElectionCandidate struct {
+	Name      string `json:"name"`
+	Weight    uint64 `json:"weight"`    // 0 for the default weight
+	Incumbent bool   `json:"incumbent"` // on the previous committee
+}
+
+// ElectionDraw is the weighted draw of a seat of the committee.
+type ElectionDraw struct {
+	Random   common.Hash `json:"random"`   // rlp hash of the seed and the seat
+	Total    uint64      `json:"total"`    // total weight of the candidates drawn from
+	Newcomer bool        `json:"newcomer"` // drawn among the candidates out of the previous committee
+	Elected  string      `json:"elected"`
+}
+
+// ElectionTranscript records the inputs and the draws of a VRF election, the
+// committee can be recomputed from it with ElectCommittee.
+type ElectionTranscript struct {
+	Number     uint64                   `json:"number"`
+	Seed       common.Hash              `json:"seed"` // rlp hash of the nonce of the parent block
+	Seats      uint64                   `json:"seats"`
+	Params     common.VRFElectionParams `json:"params"`
+	Candidates []ElectionCandidate      `json:"candidates"`
+	Draws      []ElectionDraw           `json:"draws"`
+	Committee  []string                 `json:"committee"`
+}
+
+// ElectCommittee elects at most seats candidates. The guaranteed seats are
+// filled first, the others are drawn one by one in proportion to the weights
+// of the remaining candidates, or uniformly if the election isn't weighted.
+// The first draws are restricted to the candidates out of the previous
+// committee until params.MinRotation percent of the drawn seats are theirs.
+func ElectCommittee(seed common.Hash, candidates []ElectionCandidate, seats uint64, params common.VRFElectionParams) *ElectionTranscript {
+	t := &ElectionTranscript{
+		Seed:       seed,
+		Seats:      seats,
+		Params:     params,
+		Candidates: candidates,
+		Draws:      []ElectionDraw{},
+		Committee:  []string{},
+	}
+
+	elected := make(map[string]bool, seats)
+	running := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		running[c.Name] = true
+	}
+	for _, name := range params.GuaranteedSeats {
+		if uint64(len(t.Committee)) == seats {
+			break
+		}
+		if running[name] && !elected[name] {
+			elected[name] = true
+			t.Committee = append(t.Committee, name)
+		}
+	}
+
+	var open, newcomers uint64
+	for _, c := range candidates {
+		if !elected[c.Name] {
+			open++
+			if !c.Incumbent {
+				newcomers++
+			}
+		}
+	}
+	if left := seats - uint64(len(t.Committee)); open > left {
+		open = left
+	}
+	rotation := (open*params.MinRotation + 99) / 100
+	if rotation > newcomers {
+		rotation = newcomers
+	}
+
+	weight := func(c ElectionCandidate) uint64 {
+		if params.Weighted && c.Weight != 0 {
+			return c.Weight
+		}
+		return defaultNodeWeight
+	}
+	for seat := uint64(0); seat < open; seat++ {
+		draw := ElectionDraw{
+			Random:   common.RlpHash([]interface{}{seed, seat}),
+			Newcomer: seat < rotation,
+		}
+		eligible := func(c ElectionCandidate) bool {
+			return !elected[c.Name] && !(draw.Newcomer && c.Incumbent)
+		}
+		for _, c := range candidates {
+			if eligible(c) {
+				draw.Total += weight(c)
+			}
+		}
+		r := new(big.Int).Mod(draw.Random.Big(), new(big.Int).SetUint64(draw.Total)).Uint64()
+		for _, c := range candidates {
+			if !eligible(c) {
+				continue
+			}
+			if r < weight(c) {
+				draw.Elected = c.Name
+				break
+			}
+			r -= weight(c)
+		}
+		elected[draw.Elected] = true
+		t.Committee = append(t.Committee, draw.Elected)
+		t.Draws = append(t.Draws, draw)
+	}
+	return t
+}
+
+// weightedElection elects the committee with ElectCommittee and keeps the
+// transcript of the election.
+func (n *SCNode) weightedElection(nonce []byte, seats uint64, params common.VRFElectionParams) (int32, error) {
+	nodes, err := n.GetAllNodes()
+	if err != nil {
+		return 0, err
+	}
+	previous, err := n.getConsensusNodeNames()
+	if err != nil {
+		return 0, err
+	}
+	incumbents := make(map[string]bool, len(previous))
+	for _, name := range previous {
+		incumbents[name] = true
+	}
+
+	var candidates []ElectionCandidate
+	for _, node := range nodes {
+		if node.Status == NodeStatusNormal && node.Typ == NodeTypeValidator && node.DelayNum <= n.blockNumber.Uint64() {
+			candidates = append(candidates, ElectionCandidate{
+				Name:   node.Name,
+				Weight: node.Weight,
+				// all the nodes were on the committee before the first election
+				Incumbent: len(previous) == 0 || incumbents[node.Name],
+			})
+		}
+	}
+	n.emitEvent("consensusNodeInfo", operateSuccess, fmt.Sprint(len(candidates)))
+
+	transcript := ElectCommittee(common.RlpHash(nonce), candidates, seats, params)
+	transcript.Number = n.blockNumber.Uint64()
+
+	encodedNames, err := rlp.EncodeToBytes(transcript.Committee)
+	if err != nil {
+		return 0, err
+	}
+	encodedTranscript, err := rlp.EncodeToBytes(transcript)
+	if err != nil {
+		return 0, err
+	}
+	n.setState(keyOfConsensisNodeNameDB, encodedNames)
+	n.setState(keyOfElectionTranscriptDB, encodedTranscript)
+	return 0, nil
+}
+
+func (n *SCNode) getConsensusNodeNames() ([]string, error) {
+	bin := n.getState(keyOfConsensisNodeNameDB)
+	if len(bin) == 0 {
+		return nil, nil
+	}
+
+	var names []string
+	if err := rlp.DecodeBytes(bin, &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// GetElectionTranscript returns the transcript of the latest weighted election.
+func (n *SCNode) GetElectionTranscript() (*ElectionTranscript, error) {
+	bin := n.getState(keyOfElectionTranscriptDB)
+	if len(bin) == 0 {
+		return nil, errElectionTranscriptNotFound
+	}
+
+	var transcript ElectionTranscript
+	if err := rlp.DecodeBytes(bin, &transcript); err != nil {
+		return nil, err
+	}
+	return &transcript, nil
+}
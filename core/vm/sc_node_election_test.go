@@ -0,0 +1,118 @@
+package vm
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElectCommittee(t *testing.T) {
+	var candidates []ElectionCandidate
+	for i, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		candidates = append(candidates, ElectionCandidate{Name: name, Incumbent: i < 3})
+	}
+	params := common.VRFElectionParams{GuaranteedSeats: []string{"c", "x"}, MinRotation: 50}
+	seed := common.RlpHash([]byte("nonce"))
+
+	transcript := ElectCommittee(seed, candidates, 3, params)
+	assert.Equal(t, transcript, ElectCommittee(seed, candidates, 3, params))
+	assert.Len(t, transcript.Committee, 3)
+	assert.Equal(t, "c", transcript.Committee[0])
+	assert.Len(t, transcript.Draws, 2)
+
+	// half of the 2 drawn seats go to the nodes out of the previous committee
+	assert.True(t, transcript.Draws[0].Newcomer)
+	assert.Contains(t, []string{"d", "e", "f"}, transcript.Draws[0].Elected)
+	assert.Equal(t, uint64(3), transcript.Draws[0].Total)
+	assert.False(t, transcript.Draws[1].Newcomer)
+
+	seen := make(map[string]bool)
+	for _, name := range transcript.Committee {
+		assert.False(t, seen[name])
+		seen[name] = true
+	}
+
+	// less candidates than seats
+	transcript = ElectCommittee(seed, candidates[:2], 3, params)
+	assert.Len(t, transcript.Committee, 2)
+}
+
+func TestElectCommitteeWeighted(t *testing.T) {
+	candidates := []ElectionCandidate{
+		{Name: "light", Weight: 1, Incumbent: true},
+		{Name: "heavy", Weight: nodeWeightMax, Incumbent: true},
+	}
+	for i := 0; i < 20; i++ {
+		seed := common.RlpHash(i)
+		transcript := ElectCommittee(seed, candidates, 1, common.VRFElectionParams{Weighted: true})
+		assert.Equal(t, []string{"heavy"}, transcript.Committee)
+		assert.Equal(t, nodeWeightMax+1, transcript.Draws[0].Total)
+
+		// the weights are ignored by an unweighted election
+		transcript = ElectCommittee(seed, candidates, 1, common.VRFElectionParams{MinRotation: 100})
+		assert.Equal(t, uint64(2), transcript.Draws[0].Total)
+	}
+}
+
+func TestSCNode_VrfElectionWeighted(t *testing.T) {
+	db := newMockStateDB()
+	caller := common.HexToAddress("0x62fb664c49cfa4fa35931760c704f9b3ab664666")
+	um := UserManagement{stateDB: db, caller: caller, contractAddr: syscontracts.UserManagementAddress, blockNumber: big.NewInt(100)}
+	um.setSuperAdmin()
+	um.addChainAdminByAddress(caller)
+	addr := syscontracts.ParameterManagementAddress
+	p := scParamManagerWrapper{base: &ParamManager{contractAddr: &addr, stateDB: db, caller: caller, blockNumber: big.NewInt(100)}}
+	_, err := p.setVRFParams(`{"electionEpoch": 10, "validatorCount": 3}`)
+	assert.NoError(t, err)
+	_, err = p.setVRFElectionParams(`{"weighted": true, "guaranteedSeats": ["node0"], "minRotation": 101}`)
+	assert.Equal(t, errMinRotationInvalid, err)
+	_, err = p.setVRFElectionParams(`{"weighted": true, "guaranteedSeats": ["node0"]}`)
+	assert.NoError(t, err)
+
+	n := NewSCNode(db)
+	for i := 0; i < 5; i++ {
+		ni := randFakeNodeInfo()
+		ni.Name = fmt.Sprintf("node%d", i)
+		assert.NoError(t, n.add(ni))
+
+		update := &syscontracts.UpdateNode{}
+		update.SetTyp(NodeTypeValidator)
+		weight := uint64(i + 1)
+		update.Weight = &weight
+		assert.NoError(t, n.update(ni.Name, update))
+	}
+	invalid := &syscontracts.UpdateNode{}
+	zero := uint64(0)
+	invalid.Weight = &zero
+	assert.Equal(t, errNodeWeightInvalid, n.update("node1", invalid))
+
+	node, err := n.getNodeByName("node3")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), node.Weight)
+
+	n.SetBlockNumber(big.NewInt(20))
+	_, err = n.VrfElection([]byte("nonce"))
+	assert.NoError(t, err)
+
+	transcript, err := n.GetElectionTranscript()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), transcript.Number)
+	assert.Len(t, transcript.Candidates, 5)
+	assert.Equal(t, "node0", transcript.Committee[0])
+
+	// anyone can recompute the committee from the transcript
+	recomputed := ElectCommittee(transcript.Seed, transcript.Candidates, transcript.Seats, transcript.Params)
+	assert.Equal(t, transcript.Committee, recomputed.Committee)
+
+	nodes, err := n.GetVrfConsensusNodes()
+	assert.NoError(t, err)
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	assert.Equal(t, transcript.Committee, names)
+}
@@ -21,6 +21,7 @@ var (
 	IsApproveDeployedContractKey       string = "IsApproveDeployedContract"
 	IsTxUseGasKey                      string = "IsTxUseGas"
 	VrfParamsKey                       string = "VRFParams"
+	VrfElectionParamsKey               string = "VRFElectionParams"
 	IsBlockUseTrieHashKey              string = "IsBlockUseTrieHash"
 	IsUseDAG                           string = "IsUseDAG"
 )
@@ -34,6 +35,7 @@ var preDefinedParamKeys = map[string]paramType{
 	IsApproveDeployedContractKey:       &IsApproveDeployedContractype{},
 	IsTxUseGasKey:                      &IsTxUseGastype{},
 	VrfParamsKey:                       &VRFParamsType{},
+	VrfElectionParamsKey:               &VRFElectionParamsType{},
 	IsBlockUseTrieHashKey:              &IsBlockUseTrieHashType{},
 	IsUseDAG:                           &IsUseDAGType{},
 }
@@ -255,6 +257,34 @@ func (c *VRFParamsType) decodeAndCheck(ctx *ParamManager, b []byte) (interface{}
 	return params, nil
 }
 
+// ======VRFElectionParams=====================================================================
+type VRFElectionParamsType struct{}
+
+func (c *VRFElectionParamsType) defalutVal() interface{} {
+	return common.VRFElectionParams{}
+}
+
+func (c *VRFElectionParamsType) decodeAndCheck(ctx *ParamManager, b []byte) (interface{}, error) {
+	var params common.VRFElectionParams
+	if err := json.Unmarshal(b, &params); nil != err {
+		return params, err
+	}
+
+	if params.MinRotation > 100 {
+		ctx.emitNotifyEventInParam(VrfElectionParamsKey, paramInvalid, errMinRotationInvalid.Error())
+		return params, errMinRotationInvalid
+	}
+	seats := make(map[string]struct{}, len(params.GuaranteedSeats))
+	for _, name := range params.GuaranteedSeats {
+		if _, ok := seats[name]; ok || name == "" {
+			ctx.emitNotifyEventInParam(VrfElectionParamsKey, paramInvalid, errGuaranteedSeatsInvalid.Error())
+			return params, errGuaranteedSeatsInvalid
+		}
+		seats[name] = struct{}{}
+	}
+	return params, nil
+}
+
 //IsUseDAGType =============================================================================
 type IsUseDAGType struct{}
 
@@ -326,7 +356,7 @@ func (u *ParamManager) getParam(key string) (interface{}, error) {
 }
 
 func (u *ParamManager) doParamSet(key string, value interface{}) (int32, error) {
-	if !hasParamOpPermission(u.stateDB, u.caller) {
+	if !hasParamOpPermission(u.stateDB, u.caller, u.blockNumber) {
 		u.emitNotifyEventInParam(key, callerHasNoPermission, fmt.Sprintf("%s has no permission to adjust param.", u.caller.String()))
 		return failFlag, errNoPermission
 	}
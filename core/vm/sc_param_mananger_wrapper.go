@@ -88,6 +88,11 @@ func (u *scParamManagerWrapper) setVRFParams(params string) (int32, error) {
 	return u.base.setParam(VrfParamsKey, []byte(params))
 }
 
+// Deprecated: Use setParam() instead
+func (u *scParamManagerWrapper) setVRFElectionParams(params string) (int32, error) {
+	return u.base.setParam(VrfElectionParamsKey, []byte(params))
+}
+
 // Deprecated: Use setParam() instead
 // 1:  header 使用trie hash  // 0:
 func (u *scParamManagerWrapper) setIsBlockUseTrieHash(isBlockUseTrieHash uint32) (int32, error) {
@@ -163,6 +168,12 @@ func (u *scParamManagerWrapper) getVRFParams() (common.VRFParams, error) {
 	return data.(common.VRFParams), err
 }
 
+// Deprecated: Use getParam() instead
+func (u *scParamManagerWrapper) getVRFElectionParams() (common.VRFElectionParams, error) {
+	data, err := u.base.getParam(VrfElectionParamsKey)
+	return data.(common.VRFElectionParams), err
+}
+
 // Deprecated: Use getParam() instead
 // 获取header是否使用trie hash
 func (u *scParamManagerWrapper) getIsBlockUseTrieHash() (uint32, error) {
@@ -219,6 +230,8 @@ func (u *scParamManagerWrapper) AllExportFns() SCExportFns {
 		"getIsTxUseGas":                    u.getIsTxUseGas,
 		"setVRFParams":                     u.setVRFParams,
 		"getVRFParams":                     u.getVRFParams,
+		"setVRFElectionParams":             u.setVRFElectionParams,
+		"getVRFElectionParams":             u.getVRFElectionParams,
 		"setIsBlockUseTrieHash":            u.setIsBlockUseTrieHash,
 		"getIsBlockUseTrieHash":            u.getIsBlockUseTrieHash,
 		"getIntParam":                      u.getIntParam,
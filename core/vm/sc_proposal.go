@@ -0,0 +1,481 @@
+package vm
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/byteutil"
+	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/Venachain/Venachain/rlp"
+)
+
+const (
+	proposalRulesKey = "proposalRules"
+	proposalCountKey = "proposalCount"
+	prefixProposal   = "proposal"
+
+	// defaultProposalDuration is the number of blocks a proposal can be
+	// approved in if the proposer sets none.
+	defaultProposalDuration uint64 = 7200
+)
+
+const (
+	proposalPending   = "pending"
+	proposalExecuted  = "executed"
+	proposalFailed    = "failed"
+	proposalRejected  = "rejected"
+	proposalCancelled = "cancelled"
+	proposalExpired   = "expired"
+)
+
+var (
+	errProposalRequired         = errors.New("the operation requires an approved proposal")
+	errProposalRuleNotFound     = errors.New("Proposal Rule Not Found")
+	errProposalRuleInvalid      = errors.New("Proposal Rule Invalid")
+	errProposalThresholdInvalid = errors.New("Proposal Threshold Invalid")
+	errProposalNotFound         = errors.New("Proposal Not Found")
+	errProposalNotPending       = errors.New("Proposal Not Pending")
+	errProposalExpired          = errors.New("Proposal Expired")
+	errProposalAlreadyVoted     = errors.New("Proposal Already Voted")
+)
+
+// ProposalRule requires the approvals of Threshold holders of Role before a
+// function of a system contract runs.
+type ProposalRule struct {
+	Contract  common.Address `json:"contract"`
+	Function  string         `json:"function"`
+	Role      string         `json:"role"`
+	Threshold uint32         `json:"threshold"`
+}
+
+// Proposal is an operation waiting for the approvals required by its rule,
+// the rule is recorded when the operation is proposed.
+type Proposal struct {
+	ID          uint64           `json:"id"`
+	Proposer    common.Address   `json:"proposer"`
+	Contract    common.Address   `json:"contract"`
+	Function    string           `json:"function"`
+	Input       hexutil.Bytes    `json:"input"`
+	Description string           `json:"description"`
+	Role        string           `json:"role"`
+	Threshold   uint32           `json:"threshold"`
+	Created     uint64           `json:"created"` // block number of the proposal
+	Expiry      uint64           `json:"expiry"`  // last block number it can be voted at
+	Approvals   []common.Address `json:"approvals"`
+	Rejections  []common.Address `json:"rejections"`
+	Status      string           `json:"status"`
+	Result      string           `json:"result,omitempty"` // error of a failed execution
+}
+
+// SCProposal keeps the proposal rules of the system contract operations and
+// the proposals with their votes. An operation under a rule can only be run by
+// a proposal, when the approval reaching its threshold is recorded the
+// operation runs with the permissions of the approver.
+type SCProposal struct {
+	stateDB      StateDB
+	contractAddr common.Address
+	caller       common.Address
+	blockNumber  *big.Int
+
+	// exec runs the proposed operation
+	exec func(contract common.Address, input []byte) error
+}
+
+func NewSCProposal(db StateDB) *SCProposal {
+	return &SCProposal{
+		stateDB:      db,
+		contractAddr: syscontracts.ProposalManagementAddress,
+		blockNumber:  big.NewInt(0),
+	}
+}
+
+// setRule adds or replaces the rule of a function. The rules are tightened by
+// the chain admins, relaxing an existing rule requires a proposal approved
+// under that rule.
+func (p *SCProposal) setRule(rule *ProposalRule) error {
+	if !hasProposalOpPermission(p.stateDB, p.caller, p.blockNumber) {
+		return errNoPermission
+	}
+	if err := p.checkRule(rule); err != nil {
+		return err
+	}
+	rules, err := p.getRules()
+	if err != nil {
+		return err
+	}
+	for i, r := range rules {
+		if r.Contract == rule.Contract && r.Function == rule.Function {
+			rules[i] = rule
+			return p.putRules(rules)
+		}
+	}
+	return p.putRules(append(rules, rule))
+}
+
+func (p *SCProposal) removeRule(contract common.Address, function string) error {
+	if !hasProposalOpPermission(p.stateDB, p.caller, p.blockNumber) {
+		return errNoPermission
+	}
+	rules, err := p.getRules()
+	if err != nil {
+		return err
+	}
+	for i, r := range rules {
+		if r.Contract == contract && r.Function == function {
+			return p.putRules(append(rules[:i], rules[i+1:]...))
+		}
+	}
+	return errProposalRuleNotFound
+}
+
+func (p *SCProposal) checkRule(rule *ProposalRule) error {
+	if _, ok := VenachainPrecompiledContracts[rule.Contract]; !ok || rule.Function == "" {
+		return errProposalRuleInvalid
+	}
+	// the votes can't wait for proposals
+	if rule.Contract == p.contractAddr && rule.Function != "setProposalRule" && rule.Function != "removeProposalRule" {
+		return errProposalRuleInvalid
+	}
+	holders, err := p.roleHolders(rule.Role)
+	if err != nil {
+		return err
+	}
+	if rule.Threshold == 0 || int(rule.Threshold) > len(holders) {
+		return errProposalThresholdInvalid
+	}
+	return nil
+}
+
+func (p *SCProposal) getRule(contract common.Address, function string) (*ProposalRule, error) {
+	rules, err := p.getRules()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if r.Contract == contract && r.Function == function {
+			return r, nil
+		}
+	}
+	return nil, errProposalRuleNotFound
+}
+
+func (p *SCProposal) getRules() ([]*ProposalRule, error) {
+	var rules []*ProposalRule
+	value := p.getState([]byte(proposalRulesKey))
+	if len(value) == 0 {
+		return rules, nil
+	}
+	if err := rlp.DecodeBytes(value, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (p *SCProposal) putRules(rules []*ProposalRule) error {
+	value, err := rlp.EncodeToBytes(rules)
+	if err != nil {
+		return err
+	}
+	p.setState([]byte(proposalRulesKey), value)
+	return nil
+}
+
+// governingRule returns the rule an operation of contract with input runs
+// under, nil if it runs without proposal.
+func (p *SCProposal) governingRule(contract common.Address, input []byte) (*ProposalRule, error) {
+	fnName, args, err := decodeSCInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if contract == p.contractAddr {
+		if rule, err := p.relaxedRule(fnName, args); rule != nil || err != nil {
+			return rule, err
+		}
+	}
+	rule, err := p.getRule(contract, fnName)
+	if err == errProposalRuleNotFound {
+		return nil, nil
+	}
+	return rule, err
+}
+
+// relaxedRule returns the rule relaxed by a call of the proposal contract,
+// nil if the call doesn't relax any rule.
+func (p *SCProposal) relaxedRule(fnName string, args [][]byte) (*ProposalRule, error) {
+	if (fnName != "setProposalRule" || len(args) != 4) && (fnName != "removeProposalRule" || len(args) != 2) {
+		return nil, nil
+	}
+	contract := common.HexToAddress(byteutil.BytesToString(args[0]))
+	rule, err := p.getRule(contract, byteutil.BytesToString(args[1]))
+	if err == errProposalRuleNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if fnName == "setProposalRule" &&
+		byteutil.BytesToString(args[2]) == rule.Role && byteutil.BytesToUint32(args[3]) >= rule.Threshold {
+		return nil, nil
+	}
+	return rule, nil
+}
+
+// propose records an operation under a rule, approved by the proposer.
+func (p *SCProposal) propose(contract common.Address, input []byte, description string, duration uint64) (*Proposal, error) {
+	rule, err := p.governingRule(contract, input)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, errProposalRuleNotFound
+	}
+	if !hasUserRole(p.stateDB, p.caller, rule.Role, p.blockNumber) {
+		return nil, errNoPermission
+	}
+	fnName, _, _ := decodeSCInput(input)
+	if duration == 0 {
+		duration = defaultProposalDuration
+	}
+
+	id, err := p.nextID()
+	if err != nil {
+		return nil, err
+	}
+	proposal := &Proposal{
+		ID:          id,
+		Proposer:    p.caller,
+		Contract:    contract,
+		Function:    fnName,
+		Input:       input,
+		Description: description,
+		Role:        rule.Role,
+		Threshold:   rule.Threshold,
+		Created:     p.blockNumber.Uint64(),
+		Expiry:      p.blockNumber.Uint64() + duration,
+		Status:      proposalPending,
+	}
+	if err := p.putProposal(proposal); err != nil {
+		return nil, err
+	}
+	return proposal, p.approve(id)
+}
+
+// approve records the approval of the caller and runs the operation if the
+// threshold is reached. A failed operation fails the proposal, the votes are
+// kept.
+func (p *SCProposal) approve(id uint64) error {
+	proposal, err := p.votable(id)
+	if err != nil {
+		return err
+	}
+	proposal.Approvals = append(proposal.Approvals, p.caller)
+
+	if p.countVotes(proposal.Role, proposal.Approvals) >= int(proposal.Threshold) {
+		proposal.Status = proposalExecuted
+		if err := p.exec(proposal.Contract, proposal.Input); err != nil {
+			proposal.Status, proposal.Result = proposalFailed, err.Error()
+		}
+	}
+	return p.putProposal(proposal)
+}
+
+// reject records the rejection of the caller and rejects the proposal once the
+// threshold can't be reached anymore.
+func (p *SCProposal) reject(id uint64) error {
+	proposal, err := p.votable(id)
+	if err != nil {
+		return err
+	}
+	proposal.Rejections = append(proposal.Rejections, p.caller)
+
+	holders, err := p.roleHolders(proposal.Role)
+	if err != nil {
+		return err
+	}
+	if len(holders)-p.countVotes(proposal.Role, proposal.Rejections) < int(proposal.Threshold) {
+		proposal.Status = proposalRejected
+	}
+	return p.putProposal(proposal)
+}
+
+// cancel withdraws a pending proposal of the caller.
+func (p *SCProposal) cancel(id uint64) error {
+	proposal, err := p.getProposal(id)
+	if err != nil {
+		return err
+	}
+	if proposal.Proposer != p.caller {
+		return errNoPermission
+	}
+	if proposal.Status != proposalPending {
+		return errProposalNotPending
+	}
+	proposal.Status = proposalCancelled
+	return p.putProposal(proposal)
+}
+
+// votable returns the proposal the caller may vote on.
+func (p *SCProposal) votable(id uint64) (*Proposal, error) {
+	proposal, err := p.getProposal(id)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status == proposalExpired {
+		return nil, errProposalExpired
+	}
+	if proposal.Status != proposalPending {
+		return nil, errProposalNotPending
+	}
+	if !hasUserRole(p.stateDB, p.caller, proposal.Role, p.blockNumber) {
+		return nil, errNoPermission
+	}
+	if hasVoted(proposal.Approvals, p.caller) || hasVoted(proposal.Rejections, p.caller) {
+		return nil, errProposalAlreadyVoted
+	}
+	return proposal, nil
+}
+
+func hasVoted(voters []common.Address, account common.Address) bool {
+	for _, voter := range voters {
+		if voter == account {
+			return true
+		}
+	}
+	return false
+}
+
+// countVotes counts the voters still holding role.
+func (p *SCProposal) countVotes(role string, voters []common.Address) int {
+	count := 0
+	for _, voter := range voters {
+		if hasUserRole(p.stateDB, voter, role, p.blockNumber) {
+			count++
+		}
+	}
+	return count
+}
+
+func (p *SCProposal) roleHolders(role string) ([]common.Address, error) {
+	um := &UserManagement{stateDB: p.stateDB, contractAddr: syscontracts.UserManagementAddress, blockNumber: p.blockNumber}
+	return um.roleHoldersByName(role)
+}
+
+// getProposal returns the proposal of id, a pending proposal past its expiry
+// is returned expired.
+func (p *SCProposal) getProposal(id uint64) (*Proposal, error) {
+	value := p.getState(proposalKey(id))
+	if len(value) == 0 {
+		return nil, errProposalNotFound
+	}
+	var proposal Proposal
+	if err := rlp.DecodeBytes(value, &proposal); err != nil {
+		return nil, err
+	}
+	if proposal.Status == proposalPending && p.blockNumber.Uint64() > proposal.Expiry {
+		proposal.Status = proposalExpired
+	}
+	return &proposal, nil
+}
+
+// getProposals returns the proposals with status, all of them if status is
+// empty.
+func (p *SCProposal) getProposals(status string) ([]*Proposal, error) {
+	count, err := p.count()
+	if err != nil {
+		return nil, err
+	}
+	proposals := make([]*Proposal, 0)
+	for id := uint64(1); id <= count; id++ {
+		proposal, err := p.getProposal(id)
+		if err != nil {
+			return nil, err
+		}
+		if status == "" || proposal.Status == status {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals, nil
+}
+
+func (p *SCProposal) putProposal(proposal *Proposal) error {
+	value, err := rlp.EncodeToBytes(proposal)
+	if err != nil {
+		return err
+	}
+	p.setState(proposalKey(proposal.ID), value)
+	return nil
+}
+
+func (p *SCProposal) count() (uint64, error) {
+	value := p.getState([]byte(proposalCountKey))
+	if len(value) == 0 {
+		return 0, nil
+	}
+	var count uint64
+	err := rlp.DecodeBytes(value, &count)
+	return count, err
+}
+
+func (p *SCProposal) nextID() (uint64, error) {
+	count, err := p.count()
+	if err != nil {
+		return 0, err
+	}
+	count++
+	value, err := rlp.EncodeToBytes(count)
+	if err != nil {
+		return 0, err
+	}
+	p.setState([]byte(proposalCountKey), value)
+	return count, nil
+}
+
+func proposalKey(id uint64) []byte {
+	return append([]byte(prefixProposal), common.Uint64ToBytes(id)...)
+}
+
+func (p *SCProposal) setState(key []byte, value []byte) {
+	p.stateDB.SetState(p.contractAddr, key, value)
+}
+
+func (p *SCProposal) getState(key []byte) []byte {
+	return p.stateDB.GetState(p.contractAddr, key)
+}
+
+func (p *SCProposal) emitNotifyEvent(code CodeType, msg string) {
+	topic := "Notify"
+	p.emitEvent(topic, code, msg)
+}
+
+func (p *SCProposal) emitEvent(topic string, code CodeType, msg string) {
+	emitEvent(p.contractAddr, p.stateDB, p.blockNumber.Uint64(), topic, code, msg)
+}
+
+// decodeSCInput returns the function name and the raw arguments of the input
+// of a system contract call.
+func decodeSCInput(input []byte) (string, [][]byte, error) {
+	var args [][]byte
+	if err := rlp.DecodeBytes(input, &args); err != nil {
+		return "", nil, err
+	}
+	if len(args) < 2 {
+		return "", nil, errParamsNumInvalid
+	}
+	return string(args[1]), args[2:], nil
+}
+
+// checkProposalRule checks whether a call of a system contract by caller may
+// run, the operations under a rule only run from an approved proposal.
+func checkProposalRule(state StateDB, contract, caller common.Address, input []byte) error {
+	if caller == syscontracts.ProposalManagementAddress {
+		return nil
+	}
+	rule, err := NewSCProposal(state).governingRule(contract, input)
+	if err != nil || rule == nil {
+		// malformed inputs are rejected by the contracts themselves
+		return nil
+	}
+	return errProposalRequired
+}
@@ -0,0 +1,159 @@
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/Venachain/Venachain/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeSCInput(fnName string, args ...string) []byte {
+	data := [][]byte{common.Int64ToBytes(2), []byte(fnName)}
+	for _, arg := range args {
+		data = append(data, []byte(arg))
+	}
+	input, _ := rlp.EncodeToBytes(data)
+	return input
+}
+
+func TestSCProposal_Votes(t *testing.T) {
+	db := newMockStateDB()
+	admins := []common.Address{
+		common.HexToAddress("0x62fb664c49cfa4fa35931760c704f9b3ab664666"),
+		common.HexToAddress("0x01"),
+		common.HexToAddress("0x02"),
+	}
+	um := UserManagement{stateDB: db, caller: admins[0], contractAddr: syscontracts.UserManagementAddress, blockNumber: big.NewInt(1)}
+	um.setSuperAdmin()
+	for _, admin := range admins {
+		_, err := um.addChainAdminByAddress(admin)
+		assert.NoError(t, err)
+	}
+
+	var executed [][]byte
+	execErr := error(nil)
+	p := NewProposalWrapper(db)
+	p.base.exec = func(contract common.Address, input []byte) error {
+		assert.Equal(t, syscontracts.NodeManagementAddress, contract)
+		executed = append(executed, input)
+		return execErr
+	}
+	as := func(caller common.Address) *SCProposalWrapper {
+		p.base.caller = caller
+		return p
+	}
+	node := syscontracts.NodeManagementAddress.String()
+
+	_, err := as(admins[0]).setProposalRule(node, "add", "CHAIN_ADMIN", 4)
+	assert.Equal(t, errProposalThresholdInvalid, err)
+	_, err = as(admins[0]).setProposalRule(node, "add", "NO_ROLE", 2)
+	assert.Equal(t, errUnsupportedRole, err)
+	_, err = as(common.HexToAddress("0x03")).setProposalRule(node, "add", "CHAIN_ADMIN", 2)
+	assert.Equal(t, errNoPermission, err)
+	_, err = as(admins[0]).setProposalRule(node, "add", "CHAIN_ADMIN", 2)
+	assert.NoError(t, err)
+
+	add := encodeSCInput("add", `{"name":"node1"}`)
+	assert.Equal(t, errProposalRequired, checkProposalRule(db, syscontracts.NodeManagementAddress, admins[0], add))
+	assert.NoError(t, checkProposalRule(db, syscontracts.NodeManagementAddress, syscontracts.ProposalManagementAddress, add))
+	assert.NoError(t, checkProposalRule(db, syscontracts.NodeManagementAddress, admins[0], encodeSCInput("update", "node1", "{}")))
+
+	// 2 of the 3 chain admins approve
+	_, err = as(admins[0]).propose(node, hexutil.Encode(encodeSCInput("update")), "", 10)
+	assert.Equal(t, errProposalRuleNotFound, err)
+	id, err := as(admins[0]).propose(node, hexutil.Encode(add), "add node1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), id)
+	_, err = as(admins[0]).approveProposal(id)
+	assert.Equal(t, errProposalAlreadyVoted, err)
+	_, err = as(common.HexToAddress("0x03")).approveProposal(id)
+	assert.Equal(t, errNoPermission, err)
+	assert.Len(t, executed, 0)
+	_, err = as(admins[1]).approveProposal(id)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{add}, executed)
+
+	proposal, err := p.base.getProposal(id)
+	assert.NoError(t, err)
+	assert.Equal(t, proposalExecuted, proposal.Status)
+	assert.Equal(t, "add", proposal.Function)
+	assert.Equal(t, []common.Address{admins[0], admins[1]}, proposal.Approvals)
+	_, err = as(admins[2]).approveProposal(id)
+	assert.Equal(t, errProposalNotPending, err)
+
+	// the threshold can't be reached after 2 rejections
+	id, _ = as(admins[0]).propose(node, hexutil.Encode(add), "", 10)
+	_, err = as(admins[1]).rejectProposal(id)
+	assert.NoError(t, err)
+	proposal, _ = p.base.getProposal(id)
+	assert.Equal(t, proposalPending, proposal.Status)
+	_, err = as(admins[2]).rejectProposal(id)
+	assert.NoError(t, err)
+	proposal, _ = p.base.getProposal(id)
+	assert.Equal(t, proposalRejected, proposal.Status)
+
+	// expiry and cancellation
+	id, _ = as(admins[0]).propose(node, hexutil.Encode(add), "", 10)
+	p.base.blockNumber = big.NewInt(12)
+	_, err = as(admins[1]).approveProposal(id)
+	assert.Equal(t, errProposalExpired, err)
+	id, _ = as(admins[0]).propose(node, hexutil.Encode(add), "", 10)
+	_, err = as(admins[1]).cancelProposal(id)
+	assert.Equal(t, errNoPermission, err)
+	_, err = as(admins[0]).cancelProposal(id)
+	assert.NoError(t, err)
+
+	// a failed operation fails the proposal
+	execErr = errors.New("node exists")
+	id, _ = as(admins[0]).propose(node, hexutil.Encode(add), "", 10)
+	_, err = as(admins[2]).approveProposal(id)
+	assert.NoError(t, err)
+	proposal, _ = p.base.getProposal(id)
+	assert.Equal(t, proposalFailed, proposal.Status)
+	assert.Equal(t, "node exists", proposal.Result)
+
+	for status, count := range map[string]int{"": 5, proposalExpired: 1, proposalCancelled: 1, proposalFailed: 1} {
+		proposals, err := p.base.getProposals(status)
+		assert.NoError(t, err)
+		assert.Len(t, proposals, count, status)
+	}
+}
+
+func TestSCProposal_RelaxRule(t *testing.T) {
+	db := newMockStateDB()
+	admin := common.HexToAddress("0x62fb664c49cfa4fa35931760c704f9b3ab664666")
+	um := UserManagement{stateDB: db, caller: admin, contractAddr: syscontracts.UserManagementAddress, blockNumber: big.NewInt(1)}
+	um.setSuperAdmin()
+	um.addChainAdminByAddress(admin)
+	um.addChainAdminByAddress(common.HexToAddress("0x01"))
+
+	p := NewProposalWrapper(db)
+	p.base.caller = admin
+	node := syscontracts.NodeManagementAddress.String()
+	_, err := p.setProposalRule(node, "add", "CHAIN_ADMIN", 1)
+	assert.NoError(t, err)
+	_, err = p.setProposalRule(syscontracts.ProposalManagementAddress.String(), "propose", "CHAIN_ADMIN", 1)
+	assert.Equal(t, errProposalRuleInvalid, err)
+
+	tests := []struct {
+		input []byte
+		err   error
+	}{
+		{encodeSCInput("setProposalRule", node, "add", "CHAIN_ADMIN", string(common.Uint32ToBytes(2))), nil},
+		{encodeSCInput("setProposalRule", node, "update", "CHAIN_ADMIN", string(common.Uint32ToBytes(1))), nil},
+		{encodeSCInput("setProposalRule", node, "add", "CHAIN_ADMIN", string(common.Uint32ToBytes(0))), errProposalRequired},
+		{encodeSCInput("setProposalRule", node, "add", "NODE_ADMIN", string(common.Uint32ToBytes(2))), errProposalRequired},
+		{encodeSCInput("removeProposalRule", node, "add"), errProposalRequired},
+		{encodeSCInput("removeProposalRule", node, "update"), nil},
+		{encodeSCInput("getProposalRules"), nil},
+	}
+	for i, test := range tests {
+		err := checkProposalRule(db, syscontracts.ProposalManagementAddress, admin, test.input)
+		assert.Equal(t, test.err, err, "test %d", i)
+	}
+}
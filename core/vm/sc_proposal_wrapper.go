@@ -0,0 +1,172 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/params"
+)
+
+const (
+	proposalSuccess CodeType = 0
+	proposalFail    CodeType = 1
+)
+
+type SCProposalWrapper struct {
+	base *SCProposal
+}
+
+func (p *SCProposalWrapper) RequiredGas(input []byte) uint64 {
+	if common.IsBytesEmpty(input) {
+		return 0
+	}
+	return params.SCProposalGas
+}
+
+func (p *SCProposalWrapper) Run(input []byte) ([]byte, error) {
+	fnName, ret, err := execSC(input, p.allExportFns())
+	if err != nil {
+		if fnName == "" {
+			fnName = "Notify"
+		}
+		p.base.emitEvent(fnName, operateFail, err.Error())
+
+		if strings.Contains(fnName, "get") {
+			return MakeReturnBytes([]byte(newInternalErrorResult(err).String())), err
+		}
+	}
+	return ret, err
+}
+
+func NewProposalWrapper(db StateDB) *SCProposalWrapper {
+	return &SCProposalWrapper{NewSCProposal(db)}
+}
+
+// setProposalRule requires threshold approvals of the holders of role before
+// function of contract runs.
+func (p *SCProposalWrapper) setProposalRule(contract string, function string, role string, threshold uint32) (int, error) {
+	if !common.IsHexAddress(contract) {
+		return int(proposalFail), errParamInvalid
+	}
+	rule := &ProposalRule{
+		Contract:  common.HexToAddress(contract),
+		Function:  function,
+		Role:      role,
+		Threshold: threshold,
+	}
+	if err := p.base.setRule(rule); err != nil {
+		return int(proposalFail), err
+	}
+	p.base.emitNotifyEvent(proposalSuccess, "set proposal rule success")
+	return int(proposalSuccess), nil
+}
+
+func (p *SCProposalWrapper) removeProposalRule(contract string, function string) (int, error) {
+	if !common.IsHexAddress(contract) {
+		return int(proposalFail), errParamInvalid
+	}
+	if err := p.base.removeRule(common.HexToAddress(contract), function); err != nil {
+		return int(proposalFail), err
+	}
+	p.base.emitNotifyEvent(proposalSuccess, "remove proposal rule success")
+	return int(proposalSuccess), nil
+}
+
+func (p *SCProposalWrapper) getProposalRules() (string, error) {
+	rules, err := p.base.getRules()
+	if err != nil {
+		return "", err
+	}
+	if rules == nil {
+		rules = make([]*ProposalRule, 0)
+	}
+	return newSuccessResult(rules).String(), nil
+}
+
+// propose records the call of contract with input, the hex encoded input of
+// the transaction running it, for duration blocks and returns its id.
+func (p *SCProposalWrapper) propose(contract string, input string, description string, duration uint64) (uint64, error) {
+	if !common.IsHexAddress(contract) {
+		return 0, errParamInvalid
+	}
+	data, err := hexutil.Decode(input)
+	if err != nil {
+		return 0, errParamInvalid
+	}
+	proposal, err := p.base.propose(common.HexToAddress(contract), data, description, duration)
+	if err != nil {
+		return 0, err
+	}
+	p.base.emitNotifyEvent(proposalSuccess, fmt.Sprintf("proposal %d %s", proposal.ID, p.statusOf(proposal.ID)))
+	return proposal.ID, nil
+}
+
+func (p *SCProposalWrapper) approveProposal(id uint64) (int, error) {
+	if err := p.base.approve(id); err != nil {
+		return int(proposalFail), err
+	}
+	p.base.emitNotifyEvent(proposalSuccess, fmt.Sprintf("proposal %d %s", id, p.statusOf(id)))
+	return int(proposalSuccess), nil
+}
+
+func (p *SCProposalWrapper) rejectProposal(id uint64) (int, error) {
+	if err := p.base.reject(id); err != nil {
+		return int(proposalFail), err
+	}
+	p.base.emitNotifyEvent(proposalSuccess, fmt.Sprintf("proposal %d %s", id, p.statusOf(id)))
+	return int(proposalSuccess), nil
+}
+
+func (p *SCProposalWrapper) cancelProposal(id uint64) (int, error) {
+	if err := p.base.cancel(id); err != nil {
+		return int(proposalFail), err
+	}
+	p.base.emitNotifyEvent(proposalSuccess, "cancel proposal success")
+	return int(proposalSuccess), nil
+}
+
+func (p *SCProposalWrapper) getProposal(id uint64) (string, error) {
+	proposal, err := p.base.getProposal(id)
+	if err != nil {
+		return "", err
+	}
+	return newSuccessResult(proposal).String(), nil
+}
+
+// getProposals returns the proposals with status, all of them if status is
+// empty.
+func (p *SCProposalWrapper) getProposals(status string) (string, error) {
+	proposals, err := p.base.getProposals(status)
+	if err != nil {
+		return "", err
+	}
+	return newSuccessResult(proposals).String(), nil
+}
+
+// statusOf describes the status of the proposal of id after a vote.
+func (p *SCProposalWrapper) statusOf(id uint64) string {
+	proposal, err := p.base.getProposal(id)
+	if err != nil {
+		return err.Error()
+	}
+	if proposal.Result != "" {
+		return proposal.Status + ": " + proposal.Result
+	}
+	return proposal.Status
+}
+
+func (p *SCProposalWrapper) allExportFns() SCExportFns {
+	return SCExportFns{
+		"setProposalRule":    p.setProposalRule,
+		"removeProposalRule": p.removeProposalRule,
+		"getProposalRules":   p.getProposalRules,
+		"propose":            p.propose,
+		"approveProposal":    p.approveProposal,
+		"rejectProposal":     p.rejectProposal,
+		"cancelProposal":     p.cancelProposal,
+		"getProposal":        p.getProposal,
+		"getProposals":       p.getProposals,
+	}
+}
@@ -0,0 +1,182 @@
+package vm
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/Venachain/Venachain/rlp"
+)
+
+const prefixSponsorLimit = "sponsorLimit"
+
+var (
+	// ErrSponsorLimitExceeded is returned if the fees of a sponsored transaction
+	// exceed the limit its sponsor set on its sender or on the called contract.
+	ErrSponsorLimitExceeded = errors.New("sponsor limit exceeded")
+
+	errSponsorLimitNotFound = errors.New("Sponsor Limit Not Found")
+)
+
+// SponsorLimit caps the gas a sponsor pays for the transactions of an account
+// or calling a contract.
+type SponsorLimit struct {
+	Limit uint64 `json:"limit"` // gas the sponsor pays at most
+	Used  uint64 `json:"used"`  // gas paid so far
+}
+
+// Remaining returns the gas the sponsor still pays.
+func (l *SponsorLimit) Remaining() uint64 {
+	if l.Used >= l.Limit {
+		return 0
+	}
+	return l.Limit - l.Used
+}
+
+// SCSponsor keeps the limits set by the sponsors of transactions. A sponsor
+// without limits pays for any transaction it signs, the limits set on the
+// sender and on the called contract of a transaction are checked and charged
+// together.
+type SCSponsor struct {
+	stateDB      StateDB
+	contractAddr common.Address
+	caller       common.Address
+	blockNumber  *big.Int
+}
+
+func NewSCSponsor(db StateDB) *SCSponsor {
+	return &SCSponsor{
+		stateDB:      db,
+		contractAddr: syscontracts.SponsorManagementAddress,
+		blockNumber:  big.NewInt(0),
+	}
+}
+
+// setLimit sets the gas the caller pays at most for target, the gas already
+// paid is kept.
+func (s *SCSponsor) setLimit(target common.Address, limit uint64) error {
+	l, err := s.getLimit(s.caller, target)
+	if err == errSponsorLimitNotFound {
+		l, err = &SponsorLimit{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	l.Limit = limit
+	return s.putLimit(s.caller, target, l)
+}
+
+// removeLimit removes the limit of the caller on target.
+func (s *SCSponsor) removeLimit(target common.Address) error {
+	if _, err := s.getLimit(s.caller, target); err != nil {
+		return err
+	}
+	s.setState(sponsorLimitKey(s.caller, target), nil)
+	return nil
+}
+
+func (s *SCSponsor) getLimit(sponsor, target common.Address) (*SponsorLimit, error) {
+	value := s.getState(sponsorLimitKey(sponsor, target))
+	if len(value) == 0 {
+		return nil, errSponsorLimitNotFound
+	}
+	var l SponsorLimit
+	if err := rlp.DecodeBytes(value, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (s *SCSponsor) putLimit(sponsor, target common.Address, l *SponsorLimit) error {
+	value, err := rlp.EncodeToBytes(l)
+	if err != nil {
+		return err
+	}
+	s.setState(sponsorLimitKey(sponsor, target), value)
+	return nil
+}
+
+// limits returns the limits of sponsor on the sender and the called contract
+// of a transaction.
+func (s *SCSponsor) limits(sponsor, from common.Address, to *common.Address) (map[common.Address]*SponsorLimit, error) {
+	targets := []common.Address{from}
+	if to != nil && *to != from {
+		targets = append(targets, *to)
+	}
+	limits := make(map[common.Address]*SponsorLimit)
+	for _, target := range targets {
+		l, err := s.getLimit(sponsor, target)
+		if err == errSponsorLimitNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		limits[target] = l
+	}
+	return limits, nil
+}
+
+func sponsorLimitKey(sponsor, target common.Address) []byte {
+	key := append([]byte(prefixSponsorLimit), sponsor.Bytes()...)
+	return append(key, target.Bytes()...)
+}
+
+func (s *SCSponsor) setState(key []byte, value []byte) {
+	s.stateDB.SetState(s.contractAddr, key, value)
+}
+
+func (s *SCSponsor) getState(key []byte) []byte {
+	return s.stateDB.GetState(s.contractAddr, key)
+}
+
+func (s *SCSponsor) emitNotifyEvent(code CodeType, msg string) {
+	topic := "Notify"
+	s.emitEvent(topic, code, msg)
+}
+
+func (s *SCSponsor) emitEvent(topic string, code CodeType, msg string) {
+	emitEvent(s.contractAddr, s.stateDB, s.blockNumber.Uint64(), topic, code, msg)
+}
+
+// CheckSponsorLimits checks whether sponsor pays gas for a transaction of from
+// calling to, nil for a contract creation.
+func CheckSponsorLimits(state StateDB, sponsor, from common.Address, to *common.Address, gas uint64) error {
+	limits, err := NewSCSponsor(state).limits(sponsor, from, to)
+	if err != nil {
+		return err
+	}
+	for _, l := range limits {
+		if gas > l.Remaining() {
+			return ErrSponsorLimitExceeded
+		}
+	}
+	return nil
+}
+
+// ChargeSponsorLimits counts the gas paid by sponsor for a transaction of from
+// calling to against its limits.
+func ChargeSponsorLimits(state StateDB, sponsor, from common.Address, to *common.Address, gas uint64) error {
+	s := NewSCSponsor(state)
+	limits, err := s.limits(sponsor, from, to)
+	if err != nil {
+		return err
+	}
+	for target, l := range limits {
+		l.Used += gas
+		if err := s.putLimit(sponsor, target, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSponsorLimit returns the limit of sponsor on target, nil if it has none.
+func GetSponsorLimit(state StateDB, sponsor, target common.Address) (*SponsorLimit, error) {
+	l, err := NewSCSponsor(state).getLimit(sponsor, target)
+	if err == errSponsorLimitNotFound {
+		return nil, nil
+	}
+	return l, err
+}
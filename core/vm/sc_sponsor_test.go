@@ -0,0 +1,62 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSCSponsor_Limits(t *testing.T) {
+	db := newMockStateDB()
+	sponsor := common.HexToAddress("0x62fb664c49cfa4fa35931760c704f9b3ab664666")
+	from := common.HexToAddress("0x01")
+	contract := common.HexToAddress("0x02")
+
+	s := NewSponsorWrapper(db)
+	s.base.caller = sponsor
+
+	// without limits the sponsor pays for any transaction
+	assert.NoError(t, CheckSponsorLimits(db, sponsor, from, &contract, 1000000))
+	assert.NoError(t, ChargeSponsorLimits(db, sponsor, from, &contract, 1000000))
+
+	_, err := s.setSponsorLimit(from.String(), 50000)
+	assert.NoError(t, err)
+	_, err = s.setSponsorLimit(contract.String(), 30000)
+	assert.NoError(t, err)
+	_, err = s.setSponsorLimit("invalid", 30000)
+	assert.Equal(t, errParamInvalid, err)
+
+	assert.Equal(t, ErrSponsorLimitExceeded, CheckSponsorLimits(db, sponsor, from, &contract, 40000))
+	assert.NoError(t, CheckSponsorLimits(db, sponsor, from, nil, 40000))
+	assert.NoError(t, ChargeSponsorLimits(db, sponsor, from, &contract, 20000))
+
+	l, err := GetSponsorLimit(db, sponsor, from)
+	assert.NoError(t, err)
+	assert.Equal(t, &SponsorLimit{Limit: 50000, Used: 20000}, l)
+	l, err = GetSponsorLimit(db, sponsor, contract)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10000), l.Remaining())
+	assert.Equal(t, ErrSponsorLimitExceeded, CheckSponsorLimits(db, sponsor, from, &contract, 20000))
+
+	// the limits of another sponsor are independent
+	other := common.HexToAddress("0x03")
+	assert.NoError(t, CheckSponsorLimits(db, other, from, &contract, 40000))
+
+	// raising a limit keeps the gas already paid
+	_, err = s.setSponsorLimit(contract.String(), 60000)
+	assert.NoError(t, err)
+	assert.NoError(t, CheckSponsorLimits(db, sponsor, from, &contract, 30000))
+
+	ret, err := s.getSponsorLimit(sponsor.String(), contract.String())
+	assert.NoError(t, err)
+	assert.Contains(t, ret, `"used":20000`)
+
+	_, err = s.removeSponsorLimit(from.String())
+	assert.NoError(t, err)
+	_, err = s.removeSponsorLimit(from.String())
+	assert.Equal(t, errSponsorLimitNotFound, err)
+	l, err = GetSponsorLimit(db, sponsor, from)
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+}
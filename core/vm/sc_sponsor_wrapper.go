@@ -0,0 +1,86 @@
+package vm
+
+import (
+	"strings"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/params"
+)
+
+const (
+	sponsorSuccess CodeType = 0
+	sponsorFailed  CodeType = 1
+)
+
+type SCSponsorWrapper struct {
+	base *SCSponsor
+}
+
+func (s *SCSponsorWrapper) RequiredGas(input []byte) uint64 {
+	if common.IsBytesEmpty(input) {
+		return 0
+	}
+	return params.SCSponsorGas
+}
+
+func (s *SCSponsorWrapper) Run(input []byte) ([]byte, error) {
+	fnName, ret, err := execSC(input, s.allExportFns())
+	if err != nil {
+		if fnName == "" {
+			fnName = "Notify"
+		}
+		s.base.emitEvent(fnName, operateFail, err.Error())
+
+		if strings.Contains(fnName, "get") {
+			return MakeReturnBytes([]byte(newInternalErrorResult(err).String())), err
+		}
+	}
+	return ret, err
+}
+
+func NewSponsorWrapper(db StateDB) *SCSponsorWrapper {
+	return &SCSponsorWrapper{NewSCSponsor(db)}
+}
+
+// setSponsorLimit sets the gas the caller pays at most for the transactions
+// of an account or calling a contract.
+func (s *SCSponsorWrapper) setSponsorLimit(target string, limit uint64) (int, error) {
+	if !common.IsHexAddress(target) {
+		return int(sponsorFailed), errParamInvalid
+	}
+	if err := s.base.setLimit(common.HexToAddress(target), limit); err != nil {
+		return int(sponsorFailed), err
+	}
+	s.base.emitNotifyEvent(sponsorSuccess, "set sponsor limit success")
+	return int(sponsorSuccess), nil
+}
+
+func (s *SCSponsorWrapper) removeSponsorLimit(target string) (int, error) {
+	if !common.IsHexAddress(target) {
+		return int(sponsorFailed), errParamInvalid
+	}
+	if err := s.base.removeLimit(common.HexToAddress(target)); err != nil {
+		return int(sponsorFailed), err
+	}
+	s.base.emitNotifyEvent(sponsorSuccess, "remove sponsor limit success")
+	return int(sponsorSuccess), nil
+}
+
+func (s *SCSponsorWrapper) getSponsorLimit(sponsor string, target string) (string, error) {
+	if !common.IsHexAddress(sponsor) || !common.IsHexAddress(target) {
+		return "", errParamInvalid
+	}
+	l, err := s.base.getLimit(common.HexToAddress(sponsor), common.HexToAddress(target))
+	if err != nil {
+		return "", err
+	}
+	return newSuccessResult(l).String(), nil
+}
+
+func (s *SCSponsorWrapper) allExportFns() SCExportFns {
+	return SCExportFns{
+		"setSponsorLimit":    s.setSponsorLimit,
+		"removeSponsorLimit": s.removeSponsorLimit,
+		"getSponsorLimit":    s.getSponsorLimit,
+	}
+}
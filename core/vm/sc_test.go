@@ -36,6 +36,15 @@ func (m *mockStateDB) CloneAccount(src common.Address, dest common.Address) erro
 	panic("implement me")
 }
 
+func (m *mockStateDB) HasStorage(addr common.Address) bool {
+	for _, value := range m.mockDB[addr] {
+		if len(value) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *mockStateDB) GetState(addr common.Address, key []byte) []byte {
 
 	return m.mockDB[addr][string(key)]
@@ -174,6 +183,10 @@ func (m *mockStateDB) FwSet(contractAddr common.Address, action state.Action, li
 	panic("implement me")
 }
 
+func (m *mockStateDB) FwSetRules(contractAddr common.Address, rules []state.FwRule) {
+	panic("implement me")
+}
+
 func (m *mockStateDB) SetFwStatus(contractAddr common.Address, status state.FwStatus) {
 	panic("implement me")
 }
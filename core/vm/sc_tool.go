@@ -51,7 +51,9 @@ var (
 	errEmailUnsupported       = errors.New("Unsupported email address ")
 	errPhoneUnsupported       = errors.New("Unsupported phone number ")
 
-	errValidatorCountInvalid = errors.New("Validator Count Invalid")
+	errValidatorCountInvalid  = errors.New("Validator Count Invalid")
+	errMinRotationInvalid     = errors.New("Min Rotation Invalid")
+	errGuaranteedSeatsInvalid = errors.New("Guaranteed Seats Invalid")
 
 	//errAuthenticationFailed = errors.New("Authentication failed !!!")
 	errAlreadySetEvidence = errors.New("This id Already exsit")
@@ -0,0 +1,292 @@
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/rlp"
+)
+
+const (
+	// customRolesKey = sha3("customRoles")
+	customRolesKey = "027dc84275131fcd9deda4b257269e37"
+	// customRoleHoldersKey = sha3("customRoleHolders")
+	customRoleHoldersKey = "728133fc40a480eba0bba6d91dd1e6eb"
+	// userCustomRolesKey = sha3("userCustomRoles")
+	userCustomRolesKey = "d14c83aeffa34da911fe03e06c675a5a"
+
+	// customRoleRegPattern is the format of the custom role names, e.g. AUDITOR
+	customRoleRegPattern = `^[A-Z][A-Z0-9_]{1,31}$`
+)
+
+var (
+	errCustomRoleInvalid  = errors.New("custom role name is invalid")
+	errCustomRoleExist    = errors.New("custom role already exists")
+	errCustomRoleNotExist = errors.New("custom role not exists")
+	errCustomRoleInUse    = errors.New("custom role is held by accounts")
+)
+
+// CustomRole is a business role defined on the chain by a CHAIN_ADMIN, the
+// contracts check it with the hasRole of the user management contract or the
+// hasRole host function of the WASM contracts.
+type CustomRole struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Creator     common.Address `json:"creator"`
+	Created     uint64         `json:"created"`
+}
+
+func (u *UserManagement) createCustomRole(name string, description string) (int32, error) {
+	topic := "createCustomRole"
+	if !hasCustomRoleOpPermission(u.stateDB, u.caller, u.blockNumber) {
+		return u.returnFail(topic, errNoPermission)
+	}
+	if ok, _ := regexp.MatchString(customRoleRegPattern, name); !ok {
+		return u.returnFail(topic, errCustomRoleInvalid)
+	}
+	if _, ok := rolesMap[name]; ok {
+		return u.returnFail(topic, errCustomRoleExist)
+	}
+
+	roles, err := u.getCustomRoles()
+	if err != nil {
+		return u.returnFail(topic, err)
+	}
+	if indexCustomRole(roles, name) >= 0 {
+		return u.returnFail(topic, errCustomRoleExist)
+	}
+	roles = append(roles, &CustomRole{
+		Name:        name,
+		Description: description,
+		Creator:     u.Caller(),
+		Created:     u.currentBlock(),
+	})
+	if err := u.setCustomRoles(roles); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+// removeCustomRole removes the definition of a custom role no longer held by
+// any account.
+func (u *UserManagement) removeCustomRole(name string) (int32, error) {
+	topic := "removeCustomRole"
+	if !hasCustomRoleOpPermission(u.stateDB, u.caller, u.blockNumber) {
+		return u.returnFail(topic, errNoPermission)
+	}
+
+	roles, err := u.getCustomRoles()
+	if err != nil {
+		return u.returnFail(topic, err)
+	}
+	i := indexCustomRole(roles, name)
+	if i < 0 {
+		return u.returnFail(topic, errCustomRoleNotExist)
+	}
+	holders, err := u.getAddrList(generateCustomRoleHoldersKey(name))
+	if err != nil {
+		return u.returnFail(topic, err)
+	}
+	if len(holders) != 0 {
+		return u.returnFail(topic, errCustomRoleInUse)
+	}
+
+	roles = append(roles[:i], roles[i+1:]...)
+	if err := u.setCustomRoles(roles); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+func (u *UserManagement) getAllCustomRoles() (string, error) {
+	roles, err := u.getCustomRoles()
+	if err != nil {
+		return "", err
+	}
+	if roles == nil {
+		roles = make([]*CustomRole, 0)
+	}
+	str, err := json.Marshal(roles)
+	if err != nil {
+		return "", err
+	}
+	return string(str), nil
+}
+
+func (u *UserManagement) addCustomRoleByAddress(addr common.Address, roleName string) (int32, error) {
+	topic := "addCustomRoleByAddress"
+	if err := u.setCustomRoleWithPermissionCheck(addr, roleName, roleActive); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+func (u *UserManagement) addCustomRoleByName(name string, roleName string) (int32, error) {
+	topic := "addCustomRoleByName"
+	addr, err := u.getAddrByName(name)
+	if err != nil {
+		return u.returnFail(topic, err)
+	}
+	if err := u.setCustomRoleWithPermissionCheck(addr, roleName, roleActive); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+func (u *UserManagement) delCustomRoleByAddress(addr common.Address, roleName string) (int32, error) {
+	topic := "delCustomRoleByAddress"
+	if err := u.setCustomRoleWithPermissionCheck(addr, roleName, roleDeactive); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+func (u *UserManagement) delCustomRoleByName(name string, roleName string) (int32, error) {
+	topic := "delCustomRoleByName"
+	addr, err := u.getAddrByName(name)
+	if err != nil {
+		return u.returnFail(topic, err)
+	}
+	if err := u.setCustomRoleWithPermissionCheck(addr, roleName, roleDeactive); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+func (u *UserManagement) setCustomRoleWithPermissionCheck(addr common.Address, roleName string, status uint8) error {
+	if !hasCustomRoleOpPermission(u.stateDB, u.caller, u.blockNumber) {
+		return errNoPermission
+	}
+	if !u.isCustomRole(roleName) {
+		return errCustomRoleNotExist
+	}
+
+	names, err := u.getUserCustomRoles(addr)
+	if err != nil {
+		return err
+	}
+	i := indexString(names, roleName)
+	key := generateCustomRoleHoldersKey(roleName)
+	if status == roleActive {
+		if i >= 0 {
+			return nil
+		}
+		names = append(names, roleName)
+		if err := u.addAddrList(key, addr); err != nil {
+			return err
+		}
+	} else {
+		if i < 0 {
+			return nil
+		}
+		names = append(names[:i], names[i+1:]...)
+		if err := u.delAddrList(key, addr); err != nil {
+			return err
+		}
+	}
+	if err := u.setUserCustomRoles(addr, names); err != nil {
+		return err
+	}
+
+	record := &RoleRecord{Action: roleGrantAction, Role: roleName}
+	if status == roleDeactive {
+		record.Action = roleRevokeAction
+	}
+	return u.addRoleRecord(addr, record)
+}
+
+func (u *UserManagement) isCustomRole(roleName string) bool {
+	roles, err := u.getCustomRoles()
+	return err == nil && indexCustomRole(roles, roleName) >= 0
+}
+
+func (u *UserManagement) hasCustomRole(addr common.Address, roleName string) (bool, error) {
+	names, err := u.getUserCustomRoles(addr)
+	if err != nil {
+		return false, err
+	}
+	return indexString(names, roleName) >= 0, nil
+}
+
+// roleHoldersByName returns the holders of a built-in or custom role.
+func (u *UserManagement) roleHoldersByName(roleName string) ([]common.Address, error) {
+	if role, ok := rolesMap[roleName]; ok {
+		return u.getRoleHolders(role)
+	}
+	if !u.isCustomRole(roleName) {
+		return nil, errUnsupportedRole
+	}
+	return u.getAddrList(generateCustomRoleHoldersKey(roleName))
+}
+
+func (u *UserManagement) getCustomRoles() ([]*CustomRole, error) {
+	var roles []*CustomRole
+
+	data := u.getState(generateStateKey(customRolesKey))
+	if len(data) == 0 {
+		return roles, nil
+	}
+	if err := rlp.DecodeBytes(data, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (u *UserManagement) setCustomRoles(roles []*CustomRole) error {
+	data, err := rlp.EncodeToBytes(roles)
+	if err != nil {
+		return err
+	}
+	u.setState(generateStateKey(customRolesKey), data)
+	return nil
+}
+
+func (u *UserManagement) getUserCustomRoles(addr common.Address) ([]string, error) {
+	var names []string
+
+	data := u.getState(generateUserCustomRolesKey(addr))
+	if len(data) == 0 {
+		return names, nil
+	}
+	if err := rlp.DecodeBytes(data, &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func (u *UserManagement) setUserCustomRoles(addr common.Address, names []string) error {
+	data, err := rlp.EncodeToBytes(names)
+	if err != nil {
+		return err
+	}
+	u.setState(generateUserCustomRolesKey(addr), data)
+	return nil
+}
+
+func indexCustomRole(roles []*CustomRole, name string) int {
+	for i, role := range roles {
+		if role.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexString(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func generateCustomRoleHoldersKey(roleName string) []byte {
+	return generateStateKey(customRoleHoldersKey + roleName)
+}
+
+func generateUserCustomRolesKey(addr common.Address) []byte {
+	return generateStateKey(addr.String() + userCustomRolesKey)
+}
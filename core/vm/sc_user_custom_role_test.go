@@ -0,0 +1,59 @@
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserManagement_customRole(t *testing.T) {
+	db := newMockStateDB()
+	admin := common.HexToAddress("0x62fb664c49cfa4fa35931760c704f9b3ab664666")
+	auditor := common.HexToAddress("0x01")
+
+	u := &UserManagement{stateDB: db, caller: admin, contractAddr: syscontracts.UserManagementAddress, blockNumber: big.NewInt(100)}
+	u.setSuperAdmin()
+
+	_, err := u.createCustomRole("AUDITOR", "audits the reports")
+	assert.Equal(t, errNoPermission, err)
+	u.addChainAdminByAddress(admin)
+
+	for name, want := range map[string]error{
+		"AUDITOR":     nil,
+		"auditor":     errCustomRoleInvalid,
+		"CHAIN_ADMIN": errCustomRoleExist,
+	} {
+		_, err := u.createCustomRole(name, "audits the reports")
+		assert.Equal(t, want, err, name)
+	}
+	_, err = u.createCustomRole("AUDITOR", "")
+	assert.Equal(t, errCustomRoleExist, err)
+
+	_, err = u.addCustomRoleByAddress(auditor, "REVIEWER")
+	assert.Equal(t, errCustomRoleNotExist, err)
+	_, err = u.addCustomRoleByAddress(auditor, "AUDITOR")
+	assert.NoError(t, err)
+	assert.True(t, hasUserRole(db, auditor, "AUDITOR", big.NewInt(101)))
+	assert.False(t, hasUserRole(db, admin, "AUDITOR", big.NewInt(101)))
+
+	roles, err := GetUserRoles(db, auditor, big.NewInt(101))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AUDITOR"}, roles)
+	holders, err := u.getAddrListOfRoleStr("AUDITOR")
+	assert.NoError(t, err)
+	assert.Equal(t, `["`+auditor.String()+`"]`, holders)
+
+	_, err = u.removeCustomRole("AUDITOR")
+	assert.Equal(t, errCustomRoleInUse, err)
+	_, err = u.delCustomRoleByAddress(auditor, "AUDITOR")
+	assert.NoError(t, err)
+	assert.False(t, hasUserRole(db, auditor, "AUDITOR", big.NewInt(101)))
+	_, err = u.removeCustomRole("AUDITOR")
+	assert.NoError(t, err)
+	all, err := u.getAllCustomRoles()
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", all)
+}
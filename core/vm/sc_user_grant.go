@@ -0,0 +1,338 @@
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/byteutil"
+	"github.com/Venachain/Venachain/rlp"
+)
+
+const (
+	// roleGrantsKey = sha3("roleGrants")
+	roleGrantsKey = "0935244b688348c6132b4ebe22db9381"
+	// roleHistoryKey = sha3("roleHistory"), holds the number of records of
+	// the account, each record is stored under its own index
+	roleHistoryKey = "1078a390996d206e398dd4a9c758ca0e"
+)
+
+const (
+	roleGrantAction  = "grant"
+	roleRevokeAction = "revoke"
+	roleExpireAction = "expire"
+)
+
+var (
+	errRoleGrantedPermanently = errors.New("the role is granted permanently")
+	errRoleNotGranted         = errors.New("the role is not granted")
+)
+
+// RoleGrant is a grant of a role which expires at the block Expiry.
+type RoleGrant struct {
+	Role    string         `json:"role"`
+	Grantor common.Address `json:"grantor"`
+	Granted uint64         `json:"granted"`
+	Expiry  uint64         `json:"expiry"`
+	Reason  string         `json:"reason"`
+}
+
+func (g *RoleGrant) expired(blockNumber uint64) bool {
+	return g.Expiry <= blockNumber
+}
+
+// RoleRecord records a change of the roles of an account.
+type RoleRecord struct {
+	Action   string         `json:"action"`
+	Role     string         `json:"role"`
+	Operator common.Address `json:"operator"`
+	Block    uint64         `json:"block"`
+	Expiry   uint64         `json:"expiry"`
+	Reason   string         `json:"reason"`
+}
+
+// grantRoleByAddress grants the role to the account for blocks blocks, e.g. a
+// CONTRACT_DEPLOYER for a contractor. The caller needs the permission to add
+// the role.
+func (u *UserManagement) grantRoleByAddress(addr common.Address, roleName string, blocks uint64, reason string) (int32, error) {
+	topic := "grantRoleByAddress"
+	if err := u.grantRole(addr, roleName, blocks, reason); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+func (u *UserManagement) grantRoleByName(name string, roleName string, blocks uint64, reason string) (int32, error) {
+	topic := "grantRoleByName"
+	addr, err := u.getAddrByName(name)
+	if err != nil {
+		return u.returnFail(topic, err)
+	}
+	if err := u.grantRole(addr, roleName, blocks, reason); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+func (u *UserManagement) revokeRoleByAddress(addr common.Address, roleName string, reason string) (int32, error) {
+	topic := "revokeRoleByAddress"
+	if err := u.revokeRole(addr, roleName, reason); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+func (u *UserManagement) revokeRoleByName(name string, roleName string, reason string) (int32, error) {
+	topic := "revokeRoleByName"
+	addr, err := u.getAddrByName(name)
+	if err != nil {
+		return u.returnFail(topic, err)
+	}
+	if err := u.revokeRole(addr, roleName, reason); err != nil {
+		return u.returnFail(topic, err)
+	}
+	return u.returnSuccess(topic)
+}
+
+// getRoleGrantsByAddress returns the unexpired time-boxed grants of the account.
+func (u *UserManagement) getRoleGrantsByAddress(addr common.Address) (string, error) {
+	grants, err := u.getRoleGrants(addr)
+	if err != nil {
+		return "", err
+	}
+	active := make([]*RoleGrant, 0, len(grants))
+	for _, grant := range grants {
+		if !grant.expired(u.currentBlock()) {
+			active = append(active, grant)
+		}
+	}
+	str, err := json.Marshal(active)
+	if err != nil {
+		return "", err
+	}
+	return string(str), nil
+}
+
+// getRoleHistoryByAddress returns the grants, revocations and expiries of the
+// roles of the account.
+func (u *UserManagement) getRoleHistoryByAddress(addr common.Address) (string, error) {
+	records, err := u.getRoleHistory(addr)
+	if err != nil {
+		return "", err
+	}
+	if records == nil {
+		records = make([]*RoleRecord, 0)
+	}
+	str, err := json.Marshal(records)
+	if err != nil {
+		return "", err
+	}
+	return string(str), nil
+}
+
+func (u *UserManagement) grantRole(addr common.Address, roleName string, blocks uint64, reason string) error {
+	role, ok := rolesMap[roleName]
+	if !ok || role == superAdmin {
+		return errUnsupportedRole
+	}
+	// the expiry block has to fit in an uint64
+	if blocks == 0 || blocks > math.MaxUint64-u.currentBlock() {
+		return errParamInvalid
+	}
+
+	ur, err := u.getRole(addr)
+	if err != nil {
+		return err
+	}
+	grants, err := u.getRoleGrants(addr)
+	if err != nil {
+		return err
+	}
+	// a time-boxed grant doesn't replace a permanent one
+	if ur.hasRole(role) && indexRoleGrant(grants, roleName) < 0 {
+		return errRoleGrantedPermanently
+	}
+	return u.setRoleWithGrant(addr, role, roleActive, u.currentBlock()+blocks, reason)
+}
+
+func (u *UserManagement) revokeRole(addr common.Address, roleName string, reason string) error {
+	role, ok := rolesMap[roleName]
+	if !ok || role == superAdmin {
+		return errUnsupportedRole
+	}
+
+	ur, err := u.getRole(addr)
+	if err != nil {
+		return err
+	}
+	if !ur.hasRole(role) {
+		return errRoleNotGranted
+	}
+	return u.setRoleWithGrant(addr, role, roleDeactive, 0, reason)
+}
+
+// updateRoleGrant replaces the time-boxed grant of the role after a change of
+// the role and records the change.
+func (u *UserManagement) updateRoleGrant(addr common.Address, targetRole int32, status uint8, expiry uint64, reason string) error {
+	grants, err := u.getRoleGrants(addr)
+	if err != nil {
+		return err
+	}
+	roleName := rolesName[targetRole]
+	if i := indexRoleGrant(grants, roleName); i >= 0 {
+		grants = append(grants[:i], grants[i+1:]...)
+	}
+
+	record := &RoleRecord{Role: roleName, Reason: reason}
+	if status == roleActive {
+		record.Action = roleGrantAction
+		if expiry != 0 {
+			record.Expiry = expiry
+			grants = append(grants, &RoleGrant{
+				Role:    roleName,
+				Grantor: u.Caller(),
+				Granted: u.currentBlock(),
+				Expiry:  expiry,
+				Reason:  reason,
+			})
+		}
+	} else {
+		record.Action = roleRevokeAction
+	}
+
+	if err := u.setRoleGrants(addr, grants); err != nil {
+		return err
+	}
+	return u.addRoleRecord(addr, record)
+}
+
+// pruneExpiredRoles removes the roles of the expired grants of the account
+// from its roles and the address lists of the roles.
+func (u *UserManagement) pruneExpiredRoles(addr common.Address) error {
+	grants, err := u.getRoleGrants(addr)
+	if err != nil {
+		return err
+	}
+	ur, err := u.getStoredRole(addr)
+	if err != nil {
+		return err
+	}
+
+	active := make([]*RoleGrant, 0, len(grants))
+	for _, grant := range grants {
+		if !grant.expired(u.currentBlock()) {
+			active = append(active, grant)
+			continue
+		}
+		role := rolesMap[grant.Role]
+		if err := ur.unsetRole(role); err != nil {
+			return err
+		}
+		if err := u.delAddrListOfRole(addr, role); err != nil {
+			return err
+		}
+		record := &RoleRecord{Action: roleExpireAction, Role: grant.Role, Block: grant.Expiry, Expiry: grant.Expiry}
+		if err := u.addRoleRecord(addr, record); err != nil {
+			return err
+		}
+	}
+	if len(active) == len(grants) {
+		return nil
+	}
+
+	if err := u.setRole(addr, ur); err != nil {
+		return err
+	}
+	return u.setRoleGrants(addr, active)
+}
+
+func (u *UserManagement) getRoleGrants(addr common.Address) ([]*RoleGrant, error) {
+	var grants []*RoleGrant
+
+	data := u.getState(generateRoleGrantsKey(addr))
+	if len(data) == 0 {
+		return grants, nil
+	}
+	if err := rlp.DecodeBytes(data, &grants); err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+func (u *UserManagement) setRoleGrants(addr common.Address, grants []*RoleGrant) error {
+	data, err := rlp.EncodeToBytes(grants)
+	if err != nil {
+		return err
+	}
+	u.setState(generateRoleGrantsKey(addr), data)
+	return nil
+}
+
+func (u *UserManagement) getRoleHistory(addr common.Address) ([]*RoleRecord, error) {
+	count := u.roleHistoryLen(addr)
+	records := make([]*RoleRecord, 0, count)
+	for i := uint64(0); i < count; i++ {
+		record := new(RoleRecord)
+		if err := rlp.DecodeBytes(u.getState(generateRoleRecordKey(addr, i)), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (u *UserManagement) roleHistoryLen(addr common.Address) uint64 {
+	data := u.getState(generateRoleHistoryKey(addr))
+	if len(data) == 0 {
+		return 0
+	}
+	return byteutil.BytesToUint64(data)
+}
+
+// addRoleRecord appends the record to the role history of the account, the
+// operator and block of an expiry are left as recorded. The record is stored
+// under its own index so that appending doesn't rewrite the history.
+func (u *UserManagement) addRoleRecord(addr common.Address, record *RoleRecord) error {
+	if record.Action != roleExpireAction {
+		record.Operator = u.Caller()
+		record.Block = u.currentBlock()
+	}
+	data, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		return err
+	}
+	count := u.roleHistoryLen(addr)
+	u.setState(generateRoleRecordKey(addr, count), data)
+	u.setState(generateRoleHistoryKey(addr), byteutil.Uint64ToBytes(count+1))
+	return nil
+}
+
+func (u *UserManagement) currentBlock() uint64 {
+	if u.blockNumber == nil {
+		return 0
+	}
+	return u.blockNumber.Uint64()
+}
+
+func indexRoleGrant(grants []*RoleGrant, roleName string) int {
+	for i, grant := range grants {
+		if grant.Role == roleName {
+			return i
+		}
+	}
+	return -1
+}
+
+func generateRoleGrantsKey(addr common.Address) []byte {
+	return generateStateKey(addr.String() + roleGrantsKey)
+}
+
+func generateRoleHistoryKey(addr common.Address) []byte {
+	return generateStateKey(addr.String() + roleHistoryKey)
+}
+
+func generateRoleRecordKey(addr common.Address, index uint64) []byte {
+	return generateStateKey(addr.String() + roleHistoryKey + strconv.FormatUint(index, 10))
+}
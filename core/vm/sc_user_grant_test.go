@@ -0,0 +1,97 @@
+package vm
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/syscontracts"
+	"github.com/Venachain/Venachain/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserManagement_grantRole(t *testing.T) {
+	db := newMockStateDB()
+	admin := common.HexToAddress("0x62fb664c49cfa4fa35931760c704f9b3ab664666")
+	chainAdminAddr := common.HexToAddress("0x01")
+	contractor := common.HexToAddress("0x02")
+
+	u := &UserManagement{stateDB: db, caller: admin, contractAddr: syscontracts.UserManagementAddress, blockNumber: big.NewInt(100)}
+	u.setSuperAdmin()
+	u.addChainAdminByAddress(chainAdminAddr)
+
+	u.caller = contractor
+	_, err := u.grantRoleByAddress(contractor, "CONTRACT_DEPLOYER", 10, "")
+	assert.Equal(t, errNoPermission, err)
+
+	u.caller = chainAdminAddr
+	_, err = u.grantRoleByAddress(contractor, "SUPER_ADMIN", 10, "")
+	assert.Equal(t, errUnsupportedRole, err)
+	_, err = u.grantRoleByAddress(contractor, "CONTRACT_DEPLOYER", 0, "")
+	assert.Equal(t, errParamInvalid, err)
+	_, err = u.grantRoleByAddress(contractor, "CONTRACT_DEPLOYER", math.MaxUint64-99, "")
+	assert.Equal(t, errParamInvalid, err)
+	_, err = u.grantRoleByAddress(contractor, "CONTRACT_DEPLOYER", 10, "audit contract")
+	assert.NoError(t, err)
+
+	grants, err := u.getRoleGrantsByAddress(contractor)
+	assert.NoError(t, err)
+	var got []*RoleGrant
+	json.Unmarshal([]byte(grants), &got)
+	assert.Equal(t, []*RoleGrant{{Role: "CONTRACT_DEPLOYER", Grantor: chainAdminAddr, Granted: 100, Expiry: 110, Reason: "audit contract"}}, got)
+
+	// the grant is valid for 10 blocks
+	for number, deployer := range map[int64]bool{100: true, 109: true, 110: false, 200: false} {
+		assert.Equal(t, deployer, HasContractDeployPermission(db, contractor, big.NewInt(number)), "block %d", number)
+	}
+	assert.True(t, HasContractDeployPermission(db, contractor, nil))
+
+	u.blockNumber = big.NewInt(110)
+	holders, err := u.getAddrListOfRole(contractDeployer)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", holders)
+
+	// a later grant prunes the expired one
+	u.blockNumber = big.NewInt(120)
+	_, err = u.grantRoleByAddress(contractor, "NODE_ADMIN", 10, "")
+	assert.NoError(t, err)
+	roles, err := u.getRolesByAddress(contractor)
+	assert.NoError(t, err)
+	assert.Equal(t, `["NODE_ADMIN"]`, roles)
+	_, err = u.revokeRoleByAddress(contractor, "CONTRACT_DEPLOYER", "")
+	assert.Equal(t, errRoleNotGranted, err)
+	_, err = u.revokeRoleByAddress(contractor, "NODE_ADMIN", "finished")
+	assert.NoError(t, err)
+	assert.False(t, hasNodeOpPermission(db, contractor, big.NewInt(121)))
+
+	// a permanent role isn't replaced by a time-boxed grant
+	_, err = u.addContractDeployerByAddress(contractor)
+	assert.NoError(t, err)
+	_, err = u.grantRoleByAddress(contractor, "CONTRACT_DEPLOYER", 10, "")
+	assert.Equal(t, errRoleGrantedPermanently, err)
+	assert.True(t, HasContractDeployPermission(db, contractor, big.NewInt(1000)))
+
+	history, err := u.getRoleHistory(contractor)
+	assert.NoError(t, err)
+	actions := make([]string, 0, len(history))
+	for _, record := range history {
+		actions = append(actions, record.Action+" "+record.Role+" "+record.Reason)
+	}
+	assert.Equal(t, []string{
+		"grant CONTRACT_DEPLOYER audit contract",
+		"expire CONTRACT_DEPLOYER ",
+		"grant NODE_ADMIN ",
+		"revoke NODE_ADMIN finished",
+		"grant CONTRACT_DEPLOYER ",
+	}, actions)
+	assert.Equal(t, uint64(110), history[1].Block)
+	assert.Equal(t, chainAdminAddr, history[3].Operator)
+
+	// every record is stored on its own, appending doesn't rewrite the history
+	assert.Equal(t, uint64(5), u.roleHistoryLen(contractor))
+	record := new(RoleRecord)
+	assert.NoError(t, rlp.DecodeBytes(u.getState(generateRoleRecordKey(contractor, 4)), record))
+	assert.Equal(t, history[4], record)
+}
@@ -355,5 +355,5 @@ func (u *UserManagement) setUserList(addrs []common.Address) error {
 }
 
 func (u *UserManagement) callerPermissionCheck() bool {
-	return hasUserOpPermission(u.stateDB, u.caller)
+	return hasUserOpPermission(u.stateDB, u.caller, u.blockNumber)
 }
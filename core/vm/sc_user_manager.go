@@ -94,6 +94,21 @@ func (u *UserManagement) AllExportFns() SCExportFns {
 		"getRolesByName":    u.getRolesByName,
 		"hasRole":           u.hasRole,
 
+		"grantRoleByAddress":      u.grantRoleByAddress,
+		"grantRoleByName":         u.grantRoleByName,
+		"revokeRoleByAddress":     u.revokeRoleByAddress,
+		"revokeRoleByName":        u.revokeRoleByName,
+		"getRoleGrantsByAddress":  u.getRoleGrantsByAddress,
+		"getRoleHistoryByAddress": u.getRoleHistoryByAddress,
+
+		"createCustomRole":       u.createCustomRole,
+		"removeCustomRole":       u.removeCustomRole,
+		"getAllCustomRoles":      u.getAllCustomRoles,
+		"addCustomRoleByAddress": u.addCustomRoleByAddress,
+		"addCustomRoleByName":    u.addCustomRoleByName,
+		"delCustomRoleByAddress": u.delCustomRoleByAddress,
+		"delCustomRoleByName":    u.delCustomRoleByName,
+
 		"addUser":            u.addUser,
 		"updateUserDescInfo": u.updateUserDescInfo,
 
@@ -1,6 +1,8 @@
 package vm
 
 import (
+	"math/big"
+
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/common/syscontracts"
 )
@@ -11,6 +13,9 @@ const (
 	nodeOpPermission
 	contractDeployPermission
 	paramOpPermission
+	cnsOpPermission
+	proposalOpPermission
+	customRoleOpPermission
 )
 
 var PermissionMap = map[int32]UserRoles{
@@ -19,12 +24,18 @@ var PermissionMap = map[int32]UserRoles{
 	nodeOpPermission:         1<<chainAdmin | 1<<nodeAdmin,
 	contractDeployPermission: 1<<chainAdmin | 1<<contractAdmin | 1<<contractDeployer,
 	paramOpPermission:        1 << chainAdmin,
+	cnsOpPermission:          1 << chainAdmin,
+	proposalOpPermission:     1 << chainAdmin,
+	customRoleOpPermission:   1 << chainAdmin,
 }
 
-func checkPermission(state StateDB, user common.Address, permission int32) bool {
+// checkPermission reports whether the user has the permission at the block,
+// the roles of the grants expired at the block don't count.
+func checkPermission(state StateDB, user common.Address, permission int32, blockNumber *big.Int) bool {
 	um := &UserManagement{
 		stateDB:      state,
 		contractAddr: syscontracts.UserManagementAddress,
+		blockNumber:  blockNumber,
 	}
 
 	userRole, err := um.getRole(user)
@@ -38,22 +49,53 @@ func checkPermission(state StateDB, user common.Address, permission int32) bool
 	return true
 }
 
-func hasUserOpPermission(state StateDB, addr common.Address) bool {
-	return checkPermission(state, addr, userOpPermission)
+// hasUserRole reports whether the user has the named role at the block.
+func hasUserRole(state StateDB, user common.Address, roleName string, blockNumber *big.Int) bool {
+	um := &UserManagement{
+		stateDB:      state,
+		contractAddr: syscontracts.UserManagementAddress,
+		blockNumber:  blockNumber,
+	}
+
+	ok, err := um.hasRole(user, roleName)
+	return err == nil && ok == roleActive
+}
+
+func hasUserOpPermission(state StateDB, addr common.Address, blockNumber *big.Int) bool {
+	return checkPermission(state, addr, userOpPermission, blockNumber)
+}
+
+func hasNodeOpPermission(state StateDB, addr common.Address, blockNumber *big.Int) bool {
+	return checkPermission(state, addr, nodeOpPermission, blockNumber)
 }
 
-func hasNodeOpPermission(state StateDB, addr common.Address) bool {
-	return checkPermission(state, addr, nodeOpPermission)
+func HasContractDeployPermission(state StateDB, addr common.Address, blockNumber *big.Int) bool {
+	return checkPermission(state, addr, contractDeployPermission, blockNumber)
 }
 
-func HasContractDeployPermission(state StateDB, addr common.Address) bool {
-	return checkPermission(state, addr, contractDeployPermission)
+func hasParamOpPermission(state StateDB, addr common.Address, blockNumber *big.Int) bool {
+	return checkPermission(state, addr, paramOpPermission, blockNumber)
 }
 
-func hasParamOpPermission(state StateDB, addr common.Address) bool {
-	return checkPermission(state, addr, paramOpPermission)
+func hasProposalOpPermission(state StateDB, addr common.Address, blockNumber *big.Int) bool {
+	return checkPermission(state, addr, proposalOpPermission, blockNumber)
 }
 
-func hasGroupCreatePermission(state StateDB, addr common.Address) bool {
-	return checkPermission(state, addr, groupCreatePermission)
+func hasCustomRoleOpPermission(state StateDB, addr common.Address, blockNumber *big.Int) bool {
+	return checkPermission(state, addr, customRoleOpPermission, blockNumber)
+}
+
+func hasGroupCreatePermission(state StateDB, addr common.Address, blockNumber *big.Int) bool {
+	return checkPermission(state, addr, groupCreatePermission, blockNumber)
+}
+
+// GetUserRoles returns the names of the roles of the user at the block.
+func GetUserRoles(state StateDB, user common.Address, blockNumber *big.Int) ([]string, error) {
+	um := &UserManagement{
+		stateDB:      state,
+		contractAddr: syscontracts.UserManagementAddress,
+		blockNumber:  blockNumber,
+	}
+
+	return um.getRoleNames(user)
 }
@@ -141,6 +141,9 @@ func (u *UserManagement) setSuperAdmin() (int32, error) {
 	if err := u.setRole(u.Caller(), ur); err != nil {
 		return u.returnFail(topic, err)
 	}
+	if err := u.addRoleRecord(u.Caller(), &RoleRecord{Action: roleGrantAction, Role: rolesName[superAdmin]}); err != nil {
+		return u.returnFail(topic, err)
+	}
 
 	return u.returnSuccess(topic)
 }
@@ -336,11 +339,10 @@ func (u *UserManagement) getRolesByName(name string) (string, error) {
 }
 
 func (u *UserManagement) getRolesByAddress(addr common.Address) (string, error) {
-	ur, err := u.getRole(addr)
+	roles, err := u.getRoleNames(addr)
 	if err != nil {
 		return "", err
 	}
-	roles := ur.Strings()
 	str, err := json.Marshal(roles)
 	if err != nil {
 		return "", err
@@ -351,17 +353,22 @@ func (u *UserManagement) getAddrListOfRoleStr(targetRole string) (string, error)
 	if role, ok := rolesMap[targetRole]; ok {
 		return u.getAddrListOfRole(role)
 	}
+	if u.isCustomRole(targetRole) {
+		addrs, err := u.roleHoldersByName(targetRole)
+		if err != nil {
+			return "", err
+		}
+		str, err := json.Marshal(addrs)
+		if err != nil {
+			return "", err
+		}
+		return string(str), nil
+	}
 	return fmt.Sprintf("Unsupported Role: %s", targetRole), errUnsupportedRole
 }
 func (u *UserManagement) getAddrListOfRole(targetRole int32) (string, error) {
-	var key []byte
-	var err error
-	addrs := make([]common.Address, 0)
-
-	if key, err = generateAddressListKey(targetRole); err != nil {
-		return "", err
-	}
-	if addrs, err = u.getAddrList(key); err != nil {
+	addrs, err := u.getRoleHolders(targetRole)
+	if err != nil {
 		return "", err
 	}
 	if len(addrs) == 0 {
@@ -379,14 +386,80 @@ func (u *UserManagement) hasRole(addr common.Address, roleName string) (int32, e
 	if err != nil {
 		return roleDeactive, err
 	}
-	if role, ok := rolesMap[roleName]; ok && ur.hasRole(role) {
-		return roleActive, nil
+	if role, ok := rolesMap[roleName]; ok {
+		if ur.hasRole(role) {
+			return roleActive, nil
+		}
+		return roleDeactive, nil
+	}
+	if ok, err := u.hasCustomRole(addr, roleName); err != nil || !ok {
+		return roleDeactive, err
 	}
-	return roleDeactive, nil
+	return roleActive, nil
+}
+
+// getRoleNames returns the names of the built-in and custom roles of the
+// account.
+func (u *UserManagement) getRoleNames(addr common.Address) ([]string, error) {
+	ur, err := u.getRole(addr)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := u.getUserCustomRoles(addr)
+	if err != nil {
+		return nil, err
+	}
+	return append(ur.Strings(), custom...), nil
 }
 
 //internal function
+// getRoleHolders returns the accounts holding the role, leaving out the
+// expired grants.
+func (u *UserManagement) getRoleHolders(targetRole int32) ([]common.Address, error) {
+	key, err := generateAddressListKey(targetRole)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := u.getAddrList(key)
+	if err != nil {
+		return nil, err
+	}
+	holders := make([]common.Address, 0, len(addrs))
+	for _, addr := range addrs {
+		ur, err := u.getRole(addr)
+		if err != nil {
+			return nil, err
+		}
+		if ur.hasRole(targetRole) {
+			holders = append(holders, addr)
+		}
+	}
+	return holders, nil
+}
+
+// getRole returns the roles of the account, the roles of the grants expired at
+// the current block are left out.
 func (u *UserManagement) getRole(addr common.Address) (UserRoles, error) {
+	ur, err := u.getStoredRole(addr)
+	if err != nil {
+		return ur, err
+	}
+	if u.blockNumber == nil {
+		return ur, nil
+	}
+	grants, err := u.getRoleGrants(addr)
+	if err != nil {
+		return ur, err
+	}
+	for _, grant := range grants {
+		if grant.expired(u.blockNumber.Uint64()) {
+			ur.unsetRole(rolesMap[grant.Role])
+		}
+	}
+	return ur, nil
+}
+
+func (u *UserManagement) getStoredRole(addr common.Address) (UserRoles, error) {
 	key := generateRoleKey(addr)
 	data := u.getState(key)
 	if len(data) == 0 {
@@ -421,6 +494,13 @@ func (u *UserManagement) setRoleWithPermissionCheckByName(name string, targetRol
 }
 
 func (u *UserManagement) setRoleWithPermissionCheckByAddress(addr common.Address, targetRole int32, status uint8) error {
+	return u.setRoleWithGrant(addr, targetRole, status, 0, "")
+}
+
+// setRoleWithGrant sets the role of the account, an active role with a non-zero
+// expiry is granted until the block expiry. The change is recorded in the role
+// history of the account with the reason.
+func (u *UserManagement) setRoleWithGrant(addr common.Address, targetRole int32, status uint8, expiry uint64, reason string) error {
 	// 调用者权限判断
 	caller := u.Caller()
 	callerRole, err := u.getRole(caller)
@@ -433,6 +513,9 @@ func (u *UserManagement) setRoleWithPermissionCheckByAddress(addr common.Address
 		return errNoPermission
 	}
 
+	if err := u.pruneExpiredRoles(addr); err != nil {
+		return err
+	}
 	ur, err := u.getRole(addr)
 	if err != nil {
 		return err
@@ -457,7 +540,7 @@ func (u *UserManagement) setRoleWithPermissionCheckByAddress(addr common.Address
 	if err := u.setRole(addr, ur); err != nil {
 		return err
 	}
-	return nil
+	return u.updateRoleGrant(addr, targetRole, status, expiry, reason)
 }
 
 func (u *UserManagement) addAddrListOfRole(addr common.Address, targetRole int32) error {
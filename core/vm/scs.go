@@ -2,6 +2,7 @@ package vm
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/common/syscontracts"
@@ -27,6 +28,8 @@ var VenachainPrecompiledContracts = map[common.Address]PrecompiledContract{
 	syscontracts.EvidenceManagementAddress:    &SCEvidenceWrapper{},
 	syscontracts.BulletProofAddress:           &SCBulletProofWrapper{},
 	syscontracts.PaillierAddress:              &SCPaillierWrapper{},
+	syscontracts.SponsorManagementAddress:     &SCSponsorWrapper{},
+	syscontracts.ProposalManagementAddress:    &SCProposalWrapper{},
 }
 
 func RunVenachainPrecompiledSC(p PrecompiledContract, input []byte, contract *Contract, evm *EVM) (ret []byte, err error) {
@@ -40,11 +43,15 @@ func RunVenachainPrecompiledSC(p PrecompiledContract, input []byte, contract *Co
 	gas := p.RequiredGas(input)
 
 	if contract.UseGas(gas) {
+		if err := checkProposalRule(evm.StateDB, *contract.CodeAddr, contract.Caller(), input); err != nil {
+			return nil, err
+		}
+
 		switch p.(type) {
 		case *UserManagement:
 			um := &UserManagement{
 				stateDB:      evm.StateDB,
-				caller:       contract.Caller(),
+				caller:       sysCaller(contract, evm),
 				contractAddr: syscontracts.UserManagementAddress,
 				blockNumber:  evm.BlockNumber,
 			}
@@ -82,7 +89,7 @@ func RunVenachainPrecompiledSC(p PrecompiledContract, input []byte, contract *Co
 			gm := &GroupManagement{
 				stateDB:      evm.StateDB,
 				contractAddr: contract.self.Address(),
-				caller:       contract.caller.Address(),
+				caller:       sysCaller(contract, evm),
 				blockNumber:  evm.BlockNumber,
 			}
 			return gm.Run(input)
@@ -92,6 +99,7 @@ func RunVenachainPrecompiledSC(p PrecompiledContract, input []byte, contract *Co
 				contractAddr: contract.self.Address(),
 				caller:       contract.caller.Address(),
 				blockNumber:  evm.BlockNumber,
+				getHash:      evm.GetHash,
 			}
 			return dp.Run(input)
 		case *CnsInvoke:
@@ -120,6 +128,23 @@ func RunVenachainPrecompiledSC(p PrecompiledContract, input []byte, contract *Co
 			plw.base.blockNumber = evm.BlockNumber
 			plw.base.contractAddr = *contract.CodeAddr
 			return plw.Run(input)
+		case *SCSponsorWrapper:
+			sw := NewSponsorWrapper(evm.StateDB)
+			sw.base.caller = evm.Context.Origin
+			sw.base.blockNumber = evm.BlockNumber
+			sw.base.contractAddr = *contract.CodeAddr
+			return sw.Run(input)
+		case *SCProposalWrapper:
+			pw := NewProposalWrapper(evm.StateDB)
+			pw.base.caller = evm.Context.Origin
+			pw.base.blockNumber = evm.BlockNumber
+			pw.base.contractAddr = *contract.CodeAddr
+			pw.base.exec = func(to common.Address, input []byte) error {
+				_, leftOverGas, err := evm.Call(contract, to, input, contract.Gas, big.NewInt(0))
+				contract.Gas = leftOverGas
+				return err
+			}
+			return pw.Run(input)
 		default:
 			panic("system contract handler not found")
 		}
@@ -127,3 +152,13 @@ func RunVenachainPrecompiledSC(p PrecompiledContract, input []byte, contract *Co
 
 	return nil, ErrOutOfGas
 }
+
+// sysCaller returns the caller of a system contract checking the permissions
+// of the msg sender, the operations of an approved proposal are run by the
+// account approving it last.
+func sysCaller(contract *Contract, evm *EVM) common.Address {
+	if contract.Caller() == syscontracts.ProposalManagementAddress {
+		return evm.Context.Origin
+	}
+	return contract.Caller()
+}
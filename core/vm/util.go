@@ -72,15 +72,17 @@ func MakeReturnBytes(ret []byte) []byte {
 	return finalData
 }
 
-func FwCheck(stateDb StateDB, contractAddr common.Address, caller common.Address, input []byte) ([]byte, bool) {
-	return fwCheck(stateDb, contractAddr, caller, input)
+func FwCheck(evm *EVM, contractAddr common.Address, caller common.Address, input []byte) ([]byte, bool) {
+	return fwCheck(evm, contractAddr, caller, input)
 }
 
 // 合约防火墙的检查：
 //  1. 如果账户结构体code字段为空，pass
 //  2. 如果账户data字段为空，pass
-// 	3. 黑名单优先于白名单，后续只有不在黑名单列表，同时在白名单列表里的账户才能pass
-func fwCheck(stateDb StateDB, contractAddr common.Address, caller common.Address, input []byte) ([]byte, bool) {
+// 	3. 条件规则按优先级顺序检查，第一条匹配的规则决定是否pass
+// 	4. 黑名单优先于白名单，后续只有不在黑名单列表，同时在白名单列表里的账户才能pass
+func fwCheck(evm *EVM, contractAddr common.Address, caller common.Address, input []byte) ([]byte, bool) {
+	stateDb := evm.StateDB
 	if stateDb.IsFwOpened(contractAddr) == false {
 		return nil, true
 	}
@@ -109,6 +111,13 @@ func fwCheck(stateDb StateDB, contractAddr common.Address, caller common.Address
 
 	fwLog := "FW : Access to contract:" + contractAddr.String() + " by " + funcName + "is refused by firewall."
 
+	if matched, accepted := fwRuleCheck(evm, fwStatus.Rules, contractAddr, caller, funcName); matched {
+		if accepted {
+			return nil, true
+		}
+		return MakeReturnBytes([]byte(fwLog)), false
+	}
+
 	if fwStatus.IsRejected(funcName, caller) {
 		return MakeReturnBytes([]byte(fwLog)), false
 	}
@@ -0,0 +1,227 @@
+package vm
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/life/exec"
+)
+
+// WasmTracer is a Tracer which also observes the execution of WASM contracts.
+// The WASM interpreter reports the contract calls, the function frames and
+// the host function calls of the contracts to the configured tracer if it
+// implements this interface.
+type WasmTracer interface {
+	Tracer
+	exec.Tracer
+	CaptureWasmStart(env *EVM, contract *Contract, function string, depth int)
+	CaptureWasmEnd(vm *exec.VirtualMachine, output []byte, err error)
+}
+
+// Kinds of the nodes of a WASM trace.
+const (
+	WasmTraceContract = "contract"
+	WasmTraceFunction = "function"
+	WasmTraceHost     = "host"
+)
+
+// WasmTraceNode is a contract call, a function frame or a host function call
+// in the trace of a WASM execution.
+type WasmTraceNode struct {
+	Kind     string           `json:"kind"`
+	Name     string           `json:"name"`
+	Contract *common.Address  `json:"contract,omitempty"`
+	Depth    int              `json:"depth,omitempty"`
+	Gas      uint64           `json:"gas,omitempty"`    // gas available to a contract call
+	GasUsed  uint64           `json:"gasUsed"`          // gas used by the node and its children
+	Output   hexutil.Bytes    `json:"output,omitempty"` // return value of a contract call
+	Error    string           `json:"error,omitempty"`
+	Stack    []string         `json:"stack,omitempty"` // function stack of the trapped frame, innermost first
+	Calls    []*WasmTraceNode `json:"calls,omitempty"`
+
+	startGas uint64 // gas used by the vm when the node was entered
+}
+
+// WasmTraceResult is the result of a WasmTraceLogger.
+type WasmTraceResult struct {
+	From    common.Address   `json:"from"`
+	To      common.Address   `json:"to"`
+	Create  bool             `json:"create,omitempty"`
+	Gas     uint64           `json:"gas"`
+	GasUsed uint64           `json:"gasUsed"`
+	Output  hexutil.Bytes    `json:"output"`
+	Error   string           `json:"error,omitempty"`
+	Calls   []*WasmTraceNode `json:"calls"`
+}
+
+// WasmTraceLogger builds the call tree of the WASM contracts run by a
+// transaction: the contract calls, their function frames and host function
+// calls, with the gas used by each of them.
+type WasmTraceLogger struct {
+	result WasmTraceResult
+	stack  []*WasmTraceNode // open nodes, innermost last
+}
+
+// NewWasmTraceLogger returns a new WASM trace logger.
+func NewWasmTraceLogger() *WasmTraceLogger {
+	return &WasmTraceLogger{}
+}
+
+// CaptureStart implements the Tracer interface.
+func (l *WasmTraceLogger) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	l.result.From, l.result.To, l.result.Create, l.result.Gas = from, to, create, gas
+	return nil
+}
+
+// CaptureState implements the Tracer interface, EVM steps are not traced.
+func (l *WasmTraceLogger) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureFault implements the Tracer interface.
+func (l *WasmTraceLogger) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements the Tracer interface.
+func (l *WasmTraceLogger) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
+	l.result.Output = common.CopyBytes(output)
+	l.result.GasUsed = gasUsed
+	if err != nil {
+		l.result.Error = err.Error()
+	}
+	return nil
+}
+
+// CaptureWasmStart opens the node of a WASM contract call.
+func (l *WasmTraceLogger) CaptureWasmStart(env *EVM, contract *Contract, function string, depth int) {
+	addr := contract.Address()
+	l.push(&WasmTraceNode{
+		Kind:     WasmTraceContract,
+		Name:     function,
+		Contract: &addr,
+		Depth:    depth,
+		Gas:      contract.Gas,
+	})
+}
+
+// CaptureWasmEnd closes the node of a WASM contract call. If the contract
+// trapped, the innermost open frame is marked as the failing one and the
+// function stack of the vm is recorded.
+func (l *WasmTraceLogger) CaptureWasmEnd(vm *exec.VirtualMachine, output []byte, err error) {
+	var gasUsed uint64
+	if vm != nil {
+		gasUsed = vm.Context.GasUsed
+	}
+	// close the frames left open by a trap, only the innermost one is
+	// reported as failing, its parents just unwind
+	innermost := true
+	for len(l.stack) > 0 {
+		node := l.pop()
+		node.GasUsed = gasUsed - node.startGas
+		if node.Kind == WasmTraceContract {
+			node.Output = common.CopyBytes(output)
+			if err != nil {
+				node.Error = err.Error()
+			}
+			return
+		}
+		if err != nil && innermost {
+			node.Error = err.Error()
+			if vm != nil {
+				node.Stack = wasmStack(vm)
+			}
+		}
+		innermost = false
+	}
+}
+
+// CaptureEnter implements the exec.Tracer interface.
+func (l *WasmTraceLogger) CaptureEnter(vm *exec.VirtualMachine, functionID int) {
+	l.push(&WasmTraceNode{
+		Kind:     WasmTraceFunction,
+		Name:     wasmFunctionName(vm, functionID),
+		startGas: vm.Context.GasUsed,
+	})
+}
+
+// CaptureExit implements the exec.Tracer interface.
+func (l *WasmTraceLogger) CaptureExit(vm *exec.VirtualMachine, functionID int) {
+	if node := l.pop(); node != nil {
+		node.GasUsed = vm.Context.GasUsed - node.startGas
+	}
+}
+
+// CaptureHostEnter implements the exec.Tracer interface. The cost of the
+// call was charged before the host function is entered.
+func (l *WasmTraceLogger) CaptureHostEnter(vm *exec.VirtualMachine, importID int, cost uint64) {
+	name := fmt.Sprintf("import#%d", importID)
+	if importID < len(vm.ImportNames) {
+		name = vm.ImportNames[importID]
+	}
+	l.push(&WasmTraceNode{
+		Kind:     WasmTraceHost,
+		Name:     name,
+		startGas: vm.Context.GasUsed - cost,
+	})
+}
+
+// CaptureHostExit implements the exec.Tracer interface.
+func (l *WasmTraceLogger) CaptureHostExit(vm *exec.VirtualMachine, importID int) {
+	if node := l.pop(); node != nil {
+		node.GasUsed = vm.Context.GasUsed - node.startGas
+	}
+}
+
+// GetResult returns the collected trace.
+func (l *WasmTraceLogger) GetResult() *WasmTraceResult {
+	if l.result.Calls == nil {
+		l.result.Calls = []*WasmTraceNode{}
+	}
+	return &l.result
+}
+
+func (l *WasmTraceLogger) push(node *WasmTraceNode) {
+	if len(l.stack) == 0 {
+		l.result.Calls = append(l.result.Calls, node)
+	} else {
+		parent := l.stack[len(l.stack)-1]
+		parent.Calls = append(parent.Calls, node)
+	}
+	l.stack = append(l.stack, node)
+}
+
+func (l *WasmTraceLogger) pop() *WasmTraceNode {
+	if len(l.stack) == 0 {
+		return nil
+	}
+	node := l.stack[len(l.stack)-1]
+	l.stack = l.stack[:len(l.stack)-1]
+	return node
+}
+
+func wasmFunctionName(vm *exec.VirtualMachine, functionID int) string {
+	if name, ok := vm.Module.FunctionNames[functionID]; ok && name != "" {
+		return name
+	}
+	return fmt.Sprintf("func#%d", functionID)
+}
+
+// wasmStack returns the function stack of the vm, innermost first.
+func wasmStack(vm *exec.VirtualMachine) []string {
+	if vm.CurrentFrame < 0 {
+		return nil
+	}
+	top := vm.CurrentFrame
+	if top >= len(vm.CallStack) {
+		top = len(vm.CallStack) - 1
+	}
+	stack := make([]string, 0, top+1)
+	for i := top; i >= 0; i-- {
+		stack = append(stack, wasmFunctionName(vm, vm.CallStack[i].FunctionID))
+	}
+	return stack
+}
@@ -0,0 +1,97 @@
+package vm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/life/compiler"
+	"github.com/Venachain/Venachain/life/exec"
+)
+
+func newTracedVM() *exec.VirtualMachine {
+	return &exec.VirtualMachine{
+		Context:      &exec.VMContext{},
+		Module:       &compiler.Module{FunctionNames: map[int]string{0: "transfer", 1: "checkBalance"}},
+		ImportNames:  []string{"getState", "setState"},
+		CallStack:    make([]exec.Frame, 4),
+		CurrentFrame: -1,
+	}
+}
+
+func TestWasmTraceLogger(t *testing.T) {
+	var (
+		l        = NewWasmTraceLogger()
+		vm       = newTracedVM()
+		contract = NewContract(AccountRef(common.Address{1}), AccountRef(common.Address{2}), nil, 1000)
+	)
+	l.CaptureWasmStart(nil, contract, "transfer", 1)
+
+	l.CaptureEnter(vm, 0)
+	vm.Context.GasUsed += 10
+	l.CaptureEnter(vm, 1)
+	vm.Context.GasUsed += 26 // host call cost charged before the call
+	l.CaptureHostEnter(vm, 0, 26)
+	vm.Context.GasUsed += 4
+	l.CaptureHostExit(vm, 0)
+	l.CaptureExit(vm, 1)
+	vm.Context.GasUsed += 100
+	l.CaptureHostEnter(vm, 1, 100)
+	l.CaptureHostExit(vm, 1)
+	l.CaptureExit(vm, 0)
+	l.CaptureWasmEnd(vm, []byte{1}, nil)
+
+	res := l.GetResult()
+	if len(res.Calls) != 1 {
+		t.Fatalf("expected one contract call, got %d", len(res.Calls))
+	}
+	call := res.Calls[0]
+	if call.Kind != WasmTraceContract || *call.Contract != (common.Address{2}) || call.GasUsed != 140 || call.Gas != 1000 {
+		t.Fatalf("contract node mismatch: %+v", call)
+	}
+	entry := call.Calls[0]
+	if entry.Name != "transfer" || entry.GasUsed != 140 || len(entry.Calls) != 2 {
+		t.Fatalf("entry node mismatch: %+v", entry)
+	}
+	inner := entry.Calls[0]
+	if inner.Name != "checkBalance" || inner.GasUsed != 30 {
+		t.Fatalf("inner function node mismatch: %+v", inner)
+	}
+	if host := inner.Calls[0]; host.Kind != WasmTraceHost || host.Name != "getState" || host.GasUsed != 30 {
+		t.Fatalf("host node mismatch: %+v", host)
+	}
+	if host := entry.Calls[1]; host.Name != "setState" || host.GasUsed != 100 {
+		t.Fatalf("host node mismatch: %+v", host)
+	}
+}
+
+func TestWasmTraceLoggerTrap(t *testing.T) {
+	var (
+		l        = NewWasmTraceLogger()
+		vm       = newTracedVM()
+		contract = NewContract(AccountRef(common.Address{1}), AccountRef(common.Address{2}), nil, 1000)
+	)
+	l.CaptureWasmStart(nil, contract, "transfer", 1)
+
+	vm.CurrentFrame = 0
+	vm.CallStack[0].FunctionID = 0
+	l.CaptureEnter(vm, 0)
+	vm.CurrentFrame = 1
+	vm.CallStack[1].FunctionID = 1
+	l.CaptureEnter(vm, 1)
+	vm.Context.GasUsed += 50
+	l.CaptureWasmEnd(vm, nil, errors.New("wasm: unreachable executed"))
+
+	call := l.GetResult().Calls[0]
+	if call.Error == "" || call.Calls[0].Error != "" {
+		t.Fatalf("expected only the failing frame and the contract to carry errors: %+v", call)
+	}
+	failing := call.Calls[0].Calls[0]
+	if failing.Error != "wasm: unreachable executed" || failing.GasUsed != 50 {
+		t.Fatalf("failing frame mismatch: %+v", failing)
+	}
+	if want := []string{"checkBalance", "transfer"}; !reflect.DeepEqual(failing.Stack, want) {
+		t.Fatalf("stack mismatch: have %v, want %v", failing.Stack, want)
+	}
+}
@@ -41,14 +41,17 @@ func (self *WasmStateDB) BlockNumber() *big.Int {
 }
 
 func (self *WasmStateDB) GasLimimt() uint64 {
+	self.evm.readHeader()
 	return self.evm.GasLimit
 }
 
 func (self *WasmStateDB) Time() *big.Int {
+	self.evm.readHeader()
 	return self.evm.Time
 }
 
 func (self *WasmStateDB) Coinbase() common.Address {
+	self.evm.readHeader()
 	return self.evm.Coinbase
 }
 
@@ -80,6 +83,10 @@ func (self *WasmStateDB) IsOwner(contractAddress common.Address, accountAddress
 	}
 }
 
+func (self *WasmStateDB) HasRole(account common.Address, role string) bool {
+	return hasUserRole(self.evm.StateDB, account, role, self.evm.BlockNumber)
+}
+
 /*func (self *WasmStateDB) AddLog(log *types.Log)  {
 	self.evm.StateDB.AddLog(log)
 }*/
@@ -31,6 +31,7 @@ import (
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/common/math"
 	"github.com/Venachain/Venachain/crypto/sha3"
+	"github.com/Venachain/Venachain/crypto/sm2"
 	"github.com/Venachain/Venachain/rlp"
 )
 
@@ -100,14 +101,14 @@ func ToECDSAUnsafe(d []byte) *ecdsa.PrivateKey {
 // it can also accept legacy encodings (0 prefixes).
 func toECDSA(d []byte, strict bool) (*ecdsa.PrivateKey, error) {
 	priv := new(ecdsa.PrivateKey)
-	priv.PublicKey.Curve = S256()
+	priv.PublicKey.Curve = Curve()
 	if strict && 8*len(d) != priv.Params().BitSize {
 		return nil, fmt.Errorf("invalid length, need %d bits", priv.Params().BitSize)
 	}
 	priv.D = new(big.Int).SetBytes(d)
 
 	// The priv.D must < N
-	if priv.D.Cmp(secp256k1N) >= 0 {
+	if priv.D.Cmp(curveN()) >= 0 {
 		return nil, fmt.Errorf("invalid private key, >=N")
 	}
 	// The priv.D must not be zero or negative.
@@ -130,20 +131,20 @@ func FromECDSA(priv *ecdsa.PrivateKey) []byte {
 	return math.PaddedBigBytes(priv.D, priv.Params().BitSize/8)
 }
 
-// UnmarshalPubkey converts bytes to a secp256k1 public key.
+// UnmarshalPubkey converts bytes to a public key on the curve of the crypto suite.
 func UnmarshalPubkey(pub []byte) (*ecdsa.PublicKey, error) {
-	x, y := elliptic.Unmarshal(S256(), pub)
+	x, y := elliptic.Unmarshal(Curve(), pub)
 	if x == nil {
 		return nil, errInvalidPubkey
 	}
-	return &ecdsa.PublicKey{Curve: S256(), X: x, Y: y}, nil
+	return &ecdsa.PublicKey{Curve: Curve(), X: x, Y: y}, nil
 }
 
 func FromECDSAPub(pub *ecdsa.PublicKey) []byte {
 	if pub == nil || pub.X == nil || pub.Y == nil {
 		return nil
 	}
-	return elliptic.Marshal(S256(), pub.X, pub.Y)
+	return elliptic.Marshal(Curve(), pub.X, pub.Y)
 }
 
 // HexToECDSA parses a secp256k1 private key.
@@ -182,12 +183,29 @@ func SaveECDSA(file string, key *ecdsa.PrivateKey) error {
 }
 
 func GenerateKey() (*ecdsa.PrivateKey, error) {
+	if IsSMSuite() {
+		return sm2.GenerateKey(rand.Reader)
+	}
 	return ecdsa.GenerateKey(S256(), rand.Reader)
 }
 
 // ValidateSignatureValues verifies whether the signature values are valid with
 // the given chain rules. The v value is assumed to be either 0 or 1.
 func ValidateSignatureValues(v byte, r, s *big.Int, homestead bool) bool {
+	if r.Cmp(common.Big1) < 0 || s.Cmp(common.Big1) < 0 {
+		return false
+	}
+	if IsSMSuite() {
+		// SM2 signatures aren't malleable through s
+		n := curveN()
+		return r.Cmp(n) < 0 && s.Cmp(n) < 0 && (v == 0 || v == 1)
+	}
+	return ValidateSecp256k1SignatureValues(v, r, s, homestead)
+}
+
+// ValidateSecp256k1SignatureValues verifies whether the signature values are
+// valid for a secp256k1 signature, whatever the crypto suite.
+func ValidateSecp256k1SignatureValues(v byte, r, s *big.Int, homestead bool) bool {
 	if r.Cmp(common.Big1) < 0 || s.Cmp(common.Big1) < 0 {
 		return false
 	}
@@ -202,7 +220,7 @@ func ValidateSignatureValues(v byte, r, s *big.Int, homestead bool) bool {
 
 func PubkeyToAddress(p ecdsa.PublicKey) common.Address {
 	pubBytes := FromECDSAPub(&p)
-	return common.BytesToAddress(Keccak256(pubBytes[1:])[12:])
+	return PubkeyBytesToAddress(pubBytes[1:])
 }
 
 func zeroBytes(bytes []byte) {
@@ -43,6 +43,7 @@ import (
 	"hash"
 
 	ethcrypto "github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/crypto/sm2"
 )
 
 var (
@@ -104,6 +105,7 @@ var paramsFromCurve = map[elliptic.Curve]*ECIESParams{
 	elliptic.P256():  ECIES_AES128_SHA256,
 	elliptic.P384():  ECIES_AES256_SHA384,
 	elliptic.P521():  ECIES_AES256_SHA512,
+	sm2.P256Sm2():    ECIES_AES128_SHA256,
 }
 
 func AddParamsForCurve(curve elliptic.Curve, params *ECIESParams) {
@@ -25,15 +25,32 @@ import (
 
 	"github.com/Venachain/Venachain/common/math"
 	"github.com/Venachain/Venachain/crypto/secp256k1"
+	"github.com/Venachain/Venachain/crypto/sm2"
 )
 
 // Ecrecover returns the uncompressed public key that created the given signature.
 func Ecrecover(hash, sig []byte) ([]byte, error) {
+	if IsSMSuite() {
+		return sm2.RecoverPubkey(hash, sig)
+	}
+	return secp256k1.RecoverPubkey(hash, sig)
+}
+
+// EcrecoverSecp256k1 returns the uncompressed secp256k1 public key that
+// created the given signature, whatever the crypto suite.
+func EcrecoverSecp256k1(hash, sig []byte) ([]byte, error) {
 	return secp256k1.RecoverPubkey(hash, sig)
 }
 
 // SigToPub returns the public key that created the given signature.
 func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
+	if IsSMSuite() {
+		s, err := sm2.RecoverPubkey(hash, sig)
+		if err != nil {
+			return nil, err
+		}
+		return UnmarshalPubkey(s)
+	}
 	s, err := Ecrecover(hash, sig)
 	if err != nil {
 		return nil, err
@@ -55,6 +72,9 @@ func Sign(hash []byte, prv *ecdsa.PrivateKey) (sig []byte, err error) {
 	if len(hash) != 32 {
 		return nil, fmt.Errorf("hash is required to be exactly 32 bytes (%d)", len(hash))
 	}
+	if IsSMSuite() {
+		return sm2.Sign(hash, prv)
+	}
 	seckey := math.PaddedBigBytes(prv.D, prv.Params().BitSize/8)
 	defer zeroBytes(seckey)
 	return secp256k1.Sign(hash, seckey)
@@ -64,11 +84,21 @@ func Sign(hash []byte, prv *ecdsa.PrivateKey) (sig []byte, err error) {
 // The public key should be in compressed (33 bytes) or uncompressed (65 bytes) format.
 // The signature should have the 64 byte [R || S] format.
 func VerifySignature(pubkey, hash, signature []byte) bool {
+	if IsSMSuite() {
+		return sm2.VerifySignature(pubkey, hash, signature)
+	}
 	return secp256k1.VerifySignature(pubkey, hash, signature)
 }
 
 // DecompressPubkey parses a public key in the 33-byte compressed format.
 func DecompressPubkey(pubkey []byte) (*ecdsa.PublicKey, error) {
+	if IsSMSuite() {
+		x, y := sm2.DecompressPubkey(pubkey)
+		if x == nil {
+			return nil, fmt.Errorf("invalid public key")
+		}
+		return &ecdsa.PublicKey{X: x, Y: y, Curve: Curve()}, nil
+	}
 	x, y := secp256k1.DecompressPubkey(pubkey)
 	if x == nil {
 		return nil, fmt.Errorf("invalid public key")
@@ -78,6 +108,9 @@ func DecompressPubkey(pubkey []byte) (*ecdsa.PublicKey, error) {
 
 // CompressPubkey encodes a public key to the 33-byte compressed format.
 func CompressPubkey(pubkey *ecdsa.PublicKey) []byte {
+	if IsSMSuite() {
+		return sm2.CompressPubkey(pubkey.X, pubkey.Y)
+	}
 	return secp256k1.CompressPubkey(pubkey.X, pubkey.Y)
 }
 
@@ -25,11 +25,15 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/Venachain/Venachain/crypto/sm2"
 	"github.com/btcsuite/btcd/btcec"
 )
 
 // Ecrecover returns the uncompressed public key that created the given signature.
 func Ecrecover(hash, sig []byte) ([]byte, error) {
+	if IsSMSuite() {
+		return sm2.RecoverPubkey(hash, sig)
+	}
 	pub, err := SigToPub(hash, sig)
 	if err != nil {
 		return nil, err
@@ -38,8 +42,29 @@ func Ecrecover(hash, sig []byte) ([]byte, error) {
 	return bytes, err
 }
 
+// EcrecoverSecp256k1 returns the uncompressed secp256k1 public key that
+// created the given signature, whatever the crypto suite.
+func EcrecoverSecp256k1(hash, sig []byte) ([]byte, error) {
+	btcsig := make([]byte, 65)
+	btcsig[0] = sig[64] + 27
+	copy(btcsig[1:], sig)
+
+	pub, _, err := btcec.RecoverCompact(btcec.S256(), btcsig, hash)
+	if err != nil {
+		return nil, err
+	}
+	return pub.SerializeUncompressed(), nil
+}
+
 // SigToPub returns the public key that created the given signature.
 func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
+	if IsSMSuite() {
+		s, err := sm2.RecoverPubkey(hash, sig)
+		if err != nil {
+			return nil, err
+		}
+		return UnmarshalPubkey(s)
+	}
 	// Convert to btcec input format with 'recovery id' v at the beginning.
 	btcsig := make([]byte, 65)
 	btcsig[0] = sig[64] + 27
@@ -61,6 +86,9 @@ func Sign(hash []byte, prv *ecdsa.PrivateKey) ([]byte, error) {
 	if len(hash) != 32 {
 		return nil, fmt.Errorf("hash is required to be exactly 32 bytes (%d)", len(hash))
 	}
+	if IsSMSuite() {
+		return sm2.Sign(hash, prv)
+	}
 	if prv.Curve != btcec.S256() {
 		return nil, fmt.Errorf("private key curve is not secp256k1")
 	}
@@ -79,6 +107,9 @@ func Sign(hash []byte, prv *ecdsa.PrivateKey) ([]byte, error) {
 // The public key should be in compressed (33 bytes) or uncompressed (65 bytes) format.
 // The signature should have the 64 byte [R || S] format.
 func VerifySignature(pubkey, hash, signature []byte) bool {
+	if IsSMSuite() {
+		return sm2.VerifySignature(pubkey, hash, signature)
+	}
 	if len(signature) != 64 {
 		return false
 	}
@@ -96,6 +127,13 @@ func VerifySignature(pubkey, hash, signature []byte) bool {
 
 // DecompressPubkey parses a public key in the 33-byte compressed format.
 func DecompressPubkey(pubkey []byte) (*ecdsa.PublicKey, error) {
+	if IsSMSuite() {
+		x, y := sm2.DecompressPubkey(pubkey)
+		if x == nil {
+			return nil, fmt.Errorf("invalid public key")
+		}
+		return &ecdsa.PublicKey{X: x, Y: y, Curve: Curve()}, nil
+	}
 	if len(pubkey) != 33 {
 		return nil, errors.New("invalid compressed public key length")
 	}
@@ -108,6 +146,9 @@ func DecompressPubkey(pubkey []byte) (*ecdsa.PublicKey, error) {
 
 // CompressPubkey encodes a public key to the 33-byte compressed format.
 func CompressPubkey(pubkey *ecdsa.PublicKey) []byte {
+	if IsSMSuite() {
+		return sm2.CompressPubkey(pubkey.X, pubkey.Y)
+	}
 	return (*btcec.PublicKey)(pubkey).SerializeCompressed()
 }
 
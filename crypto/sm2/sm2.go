@@ -0,0 +1,371 @@
+// Package sm2 implements SM2 signatures (GB/T 32918-2016) over the
+// recommended 256 bit curve.
+//
+// SignWithID and VerifyWithID implement the standard scheme: the message is
+// hashed with SM3 together with ZA, the digest of the user identity and the
+// public key of the signer, so the signatures interoperate with the other SM2
+// implementations. The verifier has to know the public key of the signer.
+//
+// Sign and RecoverPubkey implement a recoverable variant for the protocols
+// which identify the signer by its signature, like the secp256k1 signatures
+// used by the rest of the code base. They sign a 32 byte digest supplied by
+// the caller without ZA, since ZA depends on the public key which has to be
+// recovered, and carry a recovery id. These signatures are not standard SM2
+// signatures of a message.
+package sm2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/Venachain/Venachain/common/math"
+	"github.com/Venachain/Venachain/crypto/sm3"
+)
+
+var (
+	ErrInvalidSignatureLen = errors.New("invalid signature length")
+	ErrInvalidMsgLen       = errors.New("invalid message length, need 32 bytes")
+	ErrInvalidRecoveryID   = errors.New("invalid signature recovery id")
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrInvalidPrivateKey   = errors.New("invalid sm2 private key")
+	ErrInvalidUID          = errors.New("sm2 user identity too long")
+)
+
+// DefaultUID is the user identity of the signers which don't have a
+// distinguishing one, as recommended by GM/T 0009-2012.
+var DefaultUID = []byte("1234567812345678")
+
+var (
+	initOnce sync.Once
+	sm2P256  *elliptic.CurveParams
+	one      = big.NewInt(1)
+	three    = big.NewInt(3)
+)
+
+func initP256Sm2() {
+	sm2P256 = &elliptic.CurveParams{Name: "SM2-P-256", BitSize: 256}
+	sm2P256.P, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF00000000FFFFFFFFFFFFFFFF", 16)
+	sm2P256.N, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF7203DF6B21C6052B53BBF40939D54123", 16)
+	sm2P256.B, _ = new(big.Int).SetString("28E9FA9E9D9F5E344D5A9E4BCF6509A7F39789F515AB8F92DDBCBD414D940E93", 16)
+	sm2P256.Gx, _ = new(big.Int).SetString("32C4AE2C1F1981195F9904466A39C9948FE30BBFF2660BE1715A4589334C74C7", 16)
+	sm2P256.Gy, _ = new(big.Int).SetString("BC3736A2F4F6779C59BDCEE36B692153D0A9877CC62A474002DF32E52139F0A0", 16)
+}
+
+// P256Sm2 returns the SM2 recommended curve. Its a parameter is p-3, so the
+// generic elliptic.CurveParams arithmetic applies.
+func P256Sm2() elliptic.Curve {
+	initOnce.Do(initP256Sm2)
+	return sm2P256
+}
+
+// GenerateKey generates a new SM2 private key.
+func GenerateKey(rand io.Reader) (*ecdsa.PrivateKey, error) {
+	curve := P256Sm2()
+	k, err := randScalar(rand, curve.Params().N)
+	if err != nil {
+		return nil, err
+	}
+	priv := new(ecdsa.PrivateKey)
+	priv.Curve = curve
+	priv.D = k
+	priv.X, priv.Y = curve.ScalarBaseMult(k.Bytes())
+	return priv, nil
+}
+
+// Sign calculates a recoverable SM2 signature of the 32 byte digest. The
+// produced signature is in the [R || S || V] format where V is 0 or 1.
+func Sign(digest []byte, prv *ecdsa.PrivateKey) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, ErrInvalidMsgLen
+	}
+	r, s, v, err := sign(rand.Reader, new(big.Int).SetBytes(digest), prv)
+	if err != nil {
+		return nil, err
+	}
+	sig := make([]byte, 65)
+	math.ReadBits(r, sig[:32])
+	math.ReadBits(s, sig[32:64])
+	sig[64] = v
+	return sig, nil
+}
+
+// ZA returns the digest of the user identity uid and the public key pub, which
+// is hashed with the message by the standard SM2 signatures.
+func ZA(pub *ecdsa.PublicKey, uid []byte) ([]byte, error) {
+	if len(uid) >= 8192 {
+		return nil, ErrInvalidUID
+	}
+	params := P256Sm2().Params()
+	a := new(big.Int).Sub(params.P, three)
+	entl := uint16(len(uid) * 8)
+
+	d := sm3.New()
+	d.Write([]byte{byte(entl >> 8), byte(entl)})
+	d.Write(uid)
+	for _, v := range []*big.Int{a, params.B, params.Gx, params.Gy, pub.X, pub.Y} {
+		d.Write(math.PaddedBigBytes(v, 32))
+	}
+	return d.Sum(nil), nil
+}
+
+// messageDigest returns e = SM3(ZA || msg), the digest signed by the standard
+// SM2 signatures.
+func messageDigest(pub *ecdsa.PublicKey, msg, uid []byte) (*big.Int, error) {
+	za, err := ZA(pub, uid)
+	if err != nil {
+		return nil, err
+	}
+	d := sm3.New()
+	d.Write(za)
+	d.Write(msg)
+	return new(big.Int).SetBytes(d.Sum(nil)), nil
+}
+
+// SignWithID calculates the standard SM2 signature of msg by the signer with
+// the user identity uid. The produced signature is in the [R || S] format.
+func SignWithID(prv *ecdsa.PrivateKey, msg, uid []byte) ([]byte, error) {
+	e, err := messageDigest(&prv.PublicKey, msg, uid)
+	if err != nil {
+		return nil, err
+	}
+	r, s, _, err := sign(rand.Reader, e, prv)
+	if err != nil {
+		return nil, err
+	}
+	sig := make([]byte, 64)
+	math.ReadBits(r, sig[:32])
+	math.ReadBits(s, sig[32:])
+	return sig, nil
+}
+
+// VerifyWithID checks that the signer with the public key pub and the user
+// identity uid created the standard SM2 signature of msg. The signature should
+// have the 64 byte [R || S] format.
+func VerifyWithID(pub *ecdsa.PublicKey, msg, sig, uid []byte) bool {
+	if len(sig) != 64 || pub == nil || pub.X == nil || pub.Y == nil || !P256Sm2().IsOnCurve(pub.X, pub.Y) {
+		return false
+	}
+	e, err := messageDigest(pub, msg, uid)
+	if err != nil {
+		return false
+	}
+	return verify(pub.X, pub.Y, e, sig)
+}
+
+// sign calculates the SM2 signature (r, s) of the digest e with a random k,
+// along with the parity of the y coordinate of kG.
+func sign(rand io.Reader, e *big.Int, prv *ecdsa.PrivateKey) (r, s *big.Int, v byte, err error) {
+	n := P256Sm2().Params().N
+	// 1 + d must be invertible
+	if prv.D == nil || prv.D.Sign() <= 0 || new(big.Int).Add(prv.D, one).Cmp(n) >= 0 {
+		return nil, nil, 0, ErrInvalidPrivateKey
+	}
+	for {
+		k, err := randScalar(rand, n)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if r, s, v, ok := signWithK(e, k, prv.D); ok {
+			return r, s, v, nil
+		}
+	}
+}
+
+// signWithK calculates the SM2 signature of the digest e with the scalar k,
+// it fails if k doesn't fit and another one has to be drawn.
+func signWithK(e, k, d *big.Int) (r, s *big.Int, v byte, ok bool) {
+	curve := P256Sm2()
+	n := curve.Params().N
+
+	x1, y1 := curve.ScalarBaseMult(k.Bytes())
+	// the recovery id only encodes the parity of y1, retry in the negligible
+	// case the x coordinate doesn't fit below n
+	if x1.Cmp(n) >= 0 {
+		return nil, nil, 0, false
+	}
+	r = new(big.Int).Add(e, x1)
+	r.Mod(r, n)
+	if r.Sign() == 0 || new(big.Int).Add(r, k).Cmp(n) == 0 {
+		return nil, nil, 0, false
+	}
+	// s = (1 + d)^-1 * (k - r * d)
+	dInv := new(big.Int).Add(d, one)
+	dInv.ModInverse(dInv, n)
+	s = new(big.Int).Mul(r, d)
+	s.Sub(k, s)
+	s.Mul(s, dInv)
+	s.Mod(s, n)
+	if s.Sign() == 0 {
+		return nil, nil, 0, false
+	}
+	return r, s, byte(y1.Bit(0)), true
+}
+
+// RecoverPubkey returns the uncompressed public key that created the given
+// signature.
+func RecoverPubkey(digest, sig []byte) ([]byte, error) {
+	x, y, err := recoverPoint(digest, sig)
+	if err != nil {
+		return nil, err
+	}
+	return elliptic.Marshal(P256Sm2(), x, y), nil
+}
+
+func recoverPoint(digest, sig []byte) (*big.Int, *big.Int, error) {
+	if len(digest) != 32 {
+		return nil, nil, ErrInvalidMsgLen
+	}
+	if len(sig) != 65 {
+		return nil, nil, ErrInvalidSignatureLen
+	}
+	if sig[64] > 1 {
+		return nil, nil, ErrInvalidRecoveryID
+	}
+	curve := P256Sm2()
+	n := curve.Params().N
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if !validScalar(r, n) || !validScalar(s, n) {
+		return nil, nil, ErrInvalidSignature
+	}
+	t := new(big.Int).Add(r, s)
+	t.Mod(t, n)
+	if t.Sign() == 0 {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	// x1 = r - e, recover the kG point from it and the parity of y1
+	x1 := new(big.Int).Sub(r, new(big.Int).SetBytes(digest))
+	x1.Mod(x1, n)
+	y1 := decompressY(x1, uint(sig[64]))
+	if y1 == nil {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	// kG = sG + tP, so P = t^-1 * kG - s * t^-1 * G
+	tInv := new(big.Int).ModInverse(t, n)
+	u2 := new(big.Int).Mul(s, tInv)
+	u2.Neg(u2)
+	u2.Mod(u2, n)
+	qx, qy := curve.ScalarMult(x1, y1, tInv.Bytes())
+	gx, gy := curve.ScalarBaseMult(u2.Bytes())
+	px, py := curve.Add(qx, qy, gx, gy)
+	if px.Sign() == 0 && py.Sign() == 0 {
+		return nil, nil, ErrInvalidSignature
+	}
+	return px, py, nil
+}
+
+// VerifySignature checks that the given public key created the recoverable
+// signature over digest. The public key should be in compressed (33 bytes) or
+// uncompressed (65 bytes) format. The signature should have the 64 byte
+// [R || S] format.
+func VerifySignature(pubkey, digest, signature []byte) bool {
+	if len(signature) != 64 || len(digest) != 32 {
+		return false
+	}
+	var px, py *big.Int
+	switch len(pubkey) {
+	case 33:
+		px, py = DecompressPubkey(pubkey)
+	case 65:
+		px, py = elliptic.Unmarshal(P256Sm2(), pubkey)
+	}
+	if px == nil {
+		return false
+	}
+	return verify(px, py, new(big.Int).SetBytes(digest), signature)
+}
+
+// verify checks the [R || S] signature of the digest e by the public key.
+func verify(px, py, e *big.Int, signature []byte) bool {
+	curve := P256Sm2()
+	n := curve.Params().N
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if !validScalar(r, n) || !validScalar(s, n) {
+		return false
+	}
+	t := new(big.Int).Add(r, s)
+	t.Mod(t, n)
+	if t.Sign() == 0 {
+		return false
+	}
+	sx, sy := curve.ScalarBaseMult(s.Bytes())
+	tx, ty := curve.ScalarMult(px, py, t.Bytes())
+	x1, _ := curve.Add(sx, sy, tx, ty)
+
+	expected := new(big.Int).Add(e, x1)
+	expected.Mod(expected, n)
+	return expected.Cmp(r) == 0
+}
+
+// CompressPubkey encodes a public key to the 33-byte compressed format.
+func CompressPubkey(x, y *big.Int) []byte {
+	out := make([]byte, 33)
+	out[0] = 2 + byte(y.Bit(0))
+	math.ReadBits(x, out[1:])
+	return out
+}
+
+// DecompressPubkey parses a public key in the 33-byte compressed format. It
+// returns nil coordinates if the key is invalid.
+func DecompressPubkey(pubkey []byte) (x, y *big.Int) {
+	if len(pubkey) != 33 || (pubkey[0] != 2 && pubkey[0] != 3) {
+		return nil, nil
+	}
+	x = new(big.Int).SetBytes(pubkey[1:])
+	if x.Cmp(P256Sm2().Params().P) >= 0 {
+		return nil, nil
+	}
+	y = decompressY(x, uint(pubkey[0]&1))
+	if y == nil {
+		return nil, nil
+	}
+	return x, y
+}
+
+// decompressY returns the y coordinate with the given parity of the curve
+// point with the x coordinate, or nil if there is no such point.
+func decompressY(x *big.Int, parity uint) *big.Int {
+	params := P256Sm2().Params()
+	p := params.P
+
+	// y² = x³ - 3x + b
+	y2 := new(big.Int).Mul(x, x)
+	y2.Mul(y2, x)
+	y2.Sub(y2, new(big.Int).Mul(three, x))
+	y2.Add(y2, params.B)
+	y2.Mod(y2, p)
+
+	// p = 3 mod 4, so the square root is y2^((p+1)/4)
+	exp := new(big.Int).Add(p, one)
+	exp.Rsh(exp, 2)
+	y := new(big.Int).Exp(y2, exp, p)
+	if new(big.Int).Exp(y, big.NewInt(2), p).Cmp(y2) != 0 {
+		return nil
+	}
+	if y.Bit(0) != parity {
+		y.Sub(p, y)
+	}
+	return y
+}
+
+func validScalar(k, n *big.Int) bool {
+	return k.Sign() > 0 && k.Cmp(n) < 0
+}
+
+// randScalar returns a uniformly random scalar in [1, n-1].
+func randScalar(rand io.Reader, n *big.Int) (*big.Int, error) {
+	b := make([]byte, n.BitLen()/8+8)
+	if _, err := io.ReadFull(rand, b); err != nil {
+		return nil, err
+	}
+	k := new(big.Int).SetBytes(b)
+	nMinusOne := new(big.Int).Sub(n, one)
+	k.Mod(k, nMinusOne)
+	return k.Add(k, one), nil
+}
@@ -0,0 +1,131 @@
+package sm2
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestSignRecover(t *testing.T) {
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := elliptic.Marshal(P256Sm2(), key.X, key.Y)
+
+	for i := 0; i < 16; i++ {
+		digest := make([]byte, 32)
+		rand.Read(digest)
+
+		sig, err := Sign(digest, key)
+		if err != nil {
+			t.Fatalf("sign failed: %v", err)
+		}
+		recovered, err := RecoverPubkey(digest, sig)
+		if err != nil {
+			t.Fatalf("recover failed: %v", err)
+		}
+		if !bytes.Equal(recovered, pub) {
+			t.Fatalf("recovered pubkey mismatch: have %x, want %x", recovered, pub)
+		}
+		if !VerifySignature(pub, digest, sig[:64]) {
+			t.Fatalf("signature not verified")
+		}
+		if !VerifySignature(CompressPubkey(key.X, key.Y), digest, sig[:64]) {
+			t.Fatalf("signature not verified with compressed key")
+		}
+
+		digest[0] ^= 0xff
+		if VerifySignature(pub, digest, sig[:64]) {
+			t.Fatalf("signature verified for a different digest")
+		}
+		if recovered, _ := RecoverPubkey(digest, sig); bytes.Equal(recovered, pub) {
+			t.Fatalf("recovered signer for a different digest")
+		}
+	}
+}
+
+func TestCompressPubkey(t *testing.T) {
+	key, _ := GenerateKey(rand.Reader)
+	x, y := DecompressPubkey(CompressPubkey(key.X, key.Y))
+	if x == nil || x.Cmp(key.X) != 0 || y.Cmp(key.Y) != 0 {
+		t.Fatalf("decompressed key mismatch")
+	}
+	if x, _ := DecompressPubkey(make([]byte, 33)); x != nil {
+		t.Fatalf("decompressed invalid key")
+	}
+}
+
+func TestInvalidSignature(t *testing.T) {
+	digest := make([]byte, 32)
+	if _, err := RecoverPubkey(digest, make([]byte, 64)); err != ErrInvalidSignatureLen {
+		t.Errorf("expected %v, got %v", ErrInvalidSignatureLen, err)
+	}
+	sig := make([]byte, 65)
+	if _, err := RecoverPubkey(digest, sig); err != ErrInvalidSignature {
+		t.Errorf("expected %v, got %v", ErrInvalidSignature, err)
+	}
+	sig[64] = 2
+	if _, err := RecoverPubkey(digest, sig); err != ErrInvalidRecoveryID {
+		t.Errorf("expected %v, got %v", ErrInvalidRecoveryID, err)
+	}
+}
+
+// TestSignWithIDVector checks the signature example over the recommended curve
+// of GM/T 0003.5 with the default user identity.
+func TestSignWithIDVector(t *testing.T) {
+	d, _ := new(big.Int).SetString("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8", 16)
+	k, _ := new(big.Int).SetString("59276E27D506861A16680F3AD9C02DCCEF3CC1FA3CDBE4CE6D54B80DEAC1BC21", 16)
+	prv := &ecdsa.PrivateKey{D: d}
+	prv.Curve = P256Sm2()
+	prv.X, prv.Y = prv.Curve.ScalarBaseMult(d.Bytes())
+	msg := []byte("message digest")
+
+	za, err := ZA(&prv.PublicKey, DefaultUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "b2e14c5c79c6df5b85f4fe7ed8db7a262b9da7e07ccb0ea9f4747b8ccda8a4f3"; hex.EncodeToString(za) != want {
+		t.Fatalf("ZA mismatch: have %x, want %s", za, want)
+	}
+	e, _ := messageDigest(&prv.PublicKey, msg, DefaultUID)
+	r, s, _, ok := signWithK(e, k, d)
+	if !ok {
+		t.Fatal("sign failed")
+	}
+	sig := append(r.Bytes(), s.Bytes()...)
+	want := "f5a03b0648d2c4630eeac513e1bb81a15944da3827d5b74143ac7eaceee720b3b1b6aa29df212fd8763182bc0d421ca1bb9038fd1f7f42d4840b69c485bbc1aa"
+	if hex.EncodeToString(sig) != want {
+		t.Fatalf("signature mismatch: have %x, want %s", sig, want)
+	}
+	if !VerifyWithID(&prv.PublicKey, msg, sig, DefaultUID) {
+		t.Fatal("reference signature not verified")
+	}
+	if VerifyWithID(&prv.PublicKey, msg, sig, []byte("ALICE123@YAHOO.COM")) {
+		t.Fatal("signature verified with another user identity")
+	}
+}
+
+func TestSignWithID(t *testing.T) {
+	key, _ := GenerateKey(rand.Reader)
+	other, _ := GenerateKey(rand.Reader)
+	msg := []byte("transaction")
+
+	sig, err := SignWithID(key, msg, DefaultUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyWithID(&key.PublicKey, msg, sig, DefaultUID) {
+		t.Fatal("signature not verified")
+	}
+	if VerifyWithID(&other.PublicKey, msg, sig, DefaultUID) {
+		t.Fatal("signature verified with another key")
+	}
+	if VerifyWithID(&key.PublicKey, []byte("transactioN"), sig, DefaultUID) {
+		t.Fatal("signature verified for another message")
+	}
+}
@@ -0,0 +1,36 @@
+package sm3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		// GB/T 32905-2016 appendix A
+		{"abc", "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+		{string(bytes.Repeat([]byte("abcd"), 16)), "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732"},
+	}
+	for _, test := range tests {
+		sum := Sum([]byte(test.in))
+		if have := hex.EncodeToString(sum[:]); have != test.out {
+			t.Errorf("Sum(%q) = %s, want %s", test.in, have, test.out)
+		}
+
+		// write in pieces to exercise the buffering
+		h := New()
+		for i := 0; i < len(test.in); i += 7 {
+			end := i + 7
+			if end > len(test.in) {
+				end = len(test.in)
+			}
+			h.Write([]byte(test.in[i:end]))
+		}
+		if have := hex.EncodeToString(h.Sum(nil)); have != test.out {
+			t.Errorf("streamed %q = %s, want %s", test.in, have, test.out)
+		}
+	}
+}
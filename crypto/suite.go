@@ -0,0 +1,80 @@
+package crypto
+
+import (
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/crypto/sm2"
+	"github.com/Venachain/Venachain/crypto/sm3"
+)
+
+// Crypto suites a chain can be configured with in its genesis.
+const (
+	SuiteSecp256k1 = "secp256k1" // secp256k1 signatures, Keccak256 digests
+	SuiteSM        = "sm"        // SM2 signatures, SM3 digests
+)
+
+// suite is the crypto suite of the running node. It is selected once at
+// startup from the chain config, before any key is loaded or generated.
+var suite = SuiteSecp256k1
+
+// SetCryptoSuite selects the signature scheme and digest used for keys,
+// signatures and addresses. An empty name selects secp256k1.
+func SetCryptoSuite(name string) error {
+	switch name {
+	case "", SuiteSecp256k1:
+		suite = SuiteSecp256k1
+	case SuiteSM:
+		suite = SuiteSM
+	default:
+		return fmt.Errorf("unknown crypto suite %q", name)
+	}
+	return nil
+}
+
+// CryptoSuite returns the name of the selected crypto suite.
+func CryptoSuite() string {
+	return suite
+}
+
+// IsSMSuite reports whether the SM2/SM3 suite is selected.
+func IsSMSuite() bool {
+	return suite == SuiteSM
+}
+
+// Curve returns the curve of the selected crypto suite.
+func Curve() elliptic.Curve {
+	if IsSMSuite() {
+		return sm2.P256Sm2()
+	}
+	return S256()
+}
+
+// curveN returns the order of the curve of the selected crypto suite.
+func curveN() *big.Int {
+	if IsSMSuite() {
+		return sm2.P256Sm2().Params().N
+	}
+	return secp256k1N
+}
+
+// SignatureHash calculates the digest signed by the selected crypto suite,
+// Keccak256 or SM3.
+func SignatureHash(data ...[]byte) []byte {
+	if !IsSMSuite() {
+		return Keccak256(data...)
+	}
+	d := sm3.New()
+	for _, b := range data {
+		d.Write(b)
+	}
+	return d.Sum(nil)
+}
+
+// PubkeyBytesToAddress derives the account address from an uncompressed
+// public key without its 0x04 prefix.
+func PubkeyBytesToAddress(pub []byte) common.Address {
+	return common.BytesToAddress(SignatureHash(pub)[12:])
+}
@@ -0,0 +1,56 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Venachain/Venachain/crypto/sm2"
+)
+
+func TestSMSuite(t *testing.T) {
+	if err := SetCryptoSuite("unknown"); err == nil {
+		t.Fatal("expected error for unknown crypto suite")
+	}
+	if err := SetCryptoSuite(SuiteSM); err != nil {
+		t.Fatal(err)
+	}
+	defer SetCryptoSuite(SuiteSecp256k1)
+
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Curve != sm2.P256Sm2() {
+		t.Fatalf("generated key on the wrong curve")
+	}
+	loaded, err := ToECDSA(FromECDSA(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if PubkeyToAddress(loaded.PublicKey) != PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("address mismatch after reloading the key")
+	}
+
+	msg := SignatureHash([]byte("foo"))
+	if bytes.Equal(msg, Keccak256([]byte("foo"))) {
+		t.Fatalf("expected an SM3 digest")
+	}
+	sig, err := Sign(msg, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := SigToPub(msg, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if PubkeyToAddress(*pub) != PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("recovered address mismatch")
+	}
+	if !VerifySignature(CompressPubkey(&key.PublicKey), msg, sig[:64]) {
+		t.Fatalf("signature not verified")
+	}
+	decompressed, err := DecompressPubkey(CompressPubkey(&key.PublicKey))
+	if err != nil || decompressed.X.Cmp(key.X) != 0 || decompressed.Y.Cmp(key.Y) != 0 {
+		t.Fatalf("decompressed key mismatch: %v", err)
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/Venachain/Venachain/common/math"
 	"github.com/Venachain/Venachain/core"
 	"github.com/Venachain/Venachain/core/rawdb"
+	"github.com/Venachain/Venachain/core/state"
 	"github.com/Venachain/Venachain/core/types"
 	"github.com/Venachain/Venachain/core/vm"
 	"github.com/Venachain/Venachain/crypto"
@@ -631,6 +632,23 @@ func (s *PublicBlockChainAPI) GetCode(ctx context.Context, address common.Addres
 	return code, state.Error()
 }
 
+// GetAbi returns the ABI stored with the contract at the given address in the
+// state for the given block number. WASM contracts carry their ABI in the
+// deployed code, which is returned when none was stored in the state.
+func (s *PublicBlockChainAPI) GetAbi(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (hexutil.Bytes, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	abi := state.GetAbi(address)
+	if len(abi) == 0 {
+		if ok, _, wasmAbi, _ := common.IsWasmContractCode(state.GetCode(address)); ok {
+			abi = wasmAbi
+		}
+	}
+	return abi, state.Error()
+}
+
 // GetStorageAt returns the storage from the state at the given address, key and
 // block number. The rpc.LatestBlockNumber and rpc.PendingBlockNumber meta block
 // numbers are also allowed.
@@ -643,6 +661,75 @@ func (s *PublicBlockChainAPI) GetStorageAt(ctx context.Context, address common.A
 	return res[:], state.Error()
 }
 
+// ExportContract returns the storage, code, ABI and firewall status of the
+// contract in the state of the given block, with the block header and the
+// Merkle proofs of the account and of the storage entries against its state
+// root.
+func (s *PublicBlockChainAPI) ExportContract(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (*state.ContractExport, error) {
+	statedb, header, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	export, err := statedb.ExportContract(address)
+	if err != nil {
+		return nil, err
+	}
+	export.Header = header
+	return export, statedb.Error()
+}
+
+// GetElectionTranscript returns the transcript of the latest weighted VRF
+// election in the state of the given block, the committee can be recomputed
+// from it with vm.ElectCommittee.
+func (s *PublicBlockChainAPI) GetElectionTranscript(ctx context.Context, blockNr rpc.BlockNumber) (*vm.ElectionTranscript, error) {
+	statedb, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	transcript, err := vm.NewSCNode(statedb).GetElectionTranscript()
+	if err != nil {
+		return nil, err
+	}
+	return transcript, statedb.Error()
+}
+
+// GetSponsorLimit returns the limit set by sponsor on the fees it pays for the
+// transactions of the target account or calling the target contract, in the
+// state of the given block. It returns nil if the sponsor set no limit.
+func (s *PublicBlockChainAPI) GetSponsorLimit(ctx context.Context, sponsor, target common.Address, blockNr rpc.BlockNumber) (*vm.SponsorLimit, error) {
+	statedb, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	limit, err := vm.GetSponsorLimit(statedb, sponsor, target)
+	if err != nil {
+		return nil, err
+	}
+	return limit, statedb.Error()
+}
+
+// GetEvidenceProof returns the inclusion proof of the document hash in the
+// evidence batch anchoring it, in the state of the given block, with the time
+// of the anchoring block.
+func (s *PublicBlockChainAPI) GetEvidenceProof(ctx context.Context, hash common.Hash, blockNr rpc.BlockNumber) (*vm.EvidenceProof, error) {
+	statedb, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	proof, err := vm.GetEvidenceProof(statedb, hash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := s.b.HeaderByNumber(ctx, rpc.BlockNumber(proof.Batch.BlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	if header != nil {
+		proof.Timestamp = header.Time.Uint64()
+	}
+	return proof, statedb.Error()
+}
+
 // CallArgs represents the arguments for a call.
 type CallArgs struct {
 	From     common.Address  `json:"from"`
@@ -890,44 +977,44 @@ func (s *PublicBlockChainAPI) rpcOutputBlock(b *types.Block, inclTx bool, fullTx
 
 // RPCTransaction represents a transaction that will serialize to the RPC representation of a transaction
 type RPCTransaction struct {
-	BlockHash        common.Hash     `json:"blockHash"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
-	Gas              hexutil.Uint64  `json:"gas"`
-	GasPrice         *hexutil.Big    `json:"gasPrice"`
-	Hash             common.Hash     `json:"hash"`
-	Input            hexutil.Bytes   `json:"input"`
-	Nonce            hexutil.Uint64  `json:"nonce"`
-	To               *common.Address `json:"to"`
-	TransactionIndex hexutil.Uint    `json:"transactionIndex"`
-	Value            *hexutil.Big    `json:"value"`
-	V                *hexutil.Big    `json:"v"`
-	R                *hexutil.Big    `json:"r"`
-	S                *hexutil.Big    `json:"s"`
+	BlockHash        common.Hash          `json:"blockHash"`
+	BlockNumber      *hexutil.Big         `json:"blockNumber"`
+	From             common.Address       `json:"from"`
+	Gas              hexutil.Uint64       `json:"gas"`
+	GasPrice         *hexutil.Big         `json:"gasPrice"`
+	Hash             common.Hash          `json:"hash"`
+	Input            hexutil.Bytes        `json:"input"`
+	Nonce            hexutil.Uint64       `json:"nonce"`
+	To               *common.Address      `json:"to"`
+	TransactionIndex hexutil.Uint         `json:"transactionIndex"`
+	Value            *hexutil.Big         `json:"value"`
+	V                *hexutil.Big         `json:"v"`
+	R                *hexutil.Big         `json:"r"`
+	S                *hexutil.Big         `json:"s"`
+	Validity         *types.TxValidity    `json:"validity,omitempty"`
+	Sponsorship      *types.TxSponsorship `json:"sponsorship,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
 // representation, with the given location metadata set (if available).
 func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber uint64, index uint64) *RPCTransaction {
-	var signer types.Signer = types.FrontierSigner{}
-	if tx.Protected() {
-		signer = types.NewEIP155Signer(tx.ChainId())
-	}
-	from, _ := types.Sender(signer, tx)
+	from, _ := types.Sender(types.TxSigner(tx), tx)
 	v, r, s := tx.RawSignatureValues()
 
 	result := &RPCTransaction{
-		From:     from,
-		Gas:      hexutil.Uint64(tx.Gas()),
-		GasPrice: (*hexutil.Big)(tx.GasPrice()),
-		Hash:     tx.Hash(),
-		Input:    hexutil.Bytes(tx.Data()),
-		Nonce:    hexutil.Uint64(tx.Nonce()),
-		To:       tx.To(),
-		Value:    (*hexutil.Big)(tx.Value()),
-		V:        (*hexutil.Big)(v),
-		R:        (*hexutil.Big)(r),
-		S:        (*hexutil.Big)(s),
+		From:        from,
+		Gas:         hexutil.Uint64(tx.Gas()),
+		GasPrice:    (*hexutil.Big)(tx.GasPrice()),
+		Hash:        tx.Hash(),
+		Input:       hexutil.Bytes(tx.Data()),
+		Nonce:       hexutil.Uint64(tx.Nonce()),
+		To:          tx.To(),
+		Value:       (*hexutil.Big)(tx.Value()),
+		V:           (*hexutil.Big)(v),
+		R:           (*hexutil.Big)(r),
+		S:           (*hexutil.Big)(s),
+		Validity:    tx.Validity(),
+		Sponsorship: tx.Sponsorship(),
 		//TxType:   hexutil.Uint64(tx.Type()),
 	}
 	if blockHash != (common.Hash{}) {
@@ -1137,7 +1224,7 @@ func (s *PublicTransactionPoolAPI) GetBlockReceipts(ctx context.Context, blockNr
 			resReceipts[i].To = *cp.To
 		}
 	} else {
-		var signer types.Signer = types.NewEIP155Signer(s.b.ChainConfig().ChainID)
+		var signer types.Signer = types.MakeSigner(s.b.ChainConfig())
 		body := rawdb.ReadBody(s.b.ChainDb(), blockHash, uint64(blockNr))
 		for i, tx := range body.Transactions {
 			from, _ := types.Sender(signer, tx)
@@ -1244,6 +1331,8 @@ type SendTxArgs struct {
 	// newer name and should be preferred by clients.
 	Data  *hexutil.Bytes `json:"data"`
 	Input *hexutil.Bytes `json:"input"`
+	// Optional window restricting the blocks the transaction can be included in
+	Validity *types.TxValidity `json:"validity"`
 }
 
 // setDefaults is a helper function that fills in default values for unspecified tx fields.
@@ -1296,10 +1385,16 @@ func (args *SendTxArgs) toTransaction() *types.Transaction {
 	} else if args.Input != nil {
 		input = *args.Input
 	}
+	var tx *types.Transaction
 	if args.To == nil {
-		return types.NewContractCreation(uint64(*args.Nonce), (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
+		tx = types.NewContractCreation(uint64(*args.Nonce), (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
+	} else {
+		tx = types.NewTransaction(uint64(*args.Nonce), *args.To, (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
+	}
+	if args.Validity != nil {
+		tx = tx.WithValidity(args.Validity)
 	}
-	return types.NewTransaction(uint64(*args.Nonce), *args.To, (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
+	return tx
 }
 
 // submitTransaction is a helper function that submits tx to txPool and logs a message.
@@ -1450,11 +1545,7 @@ func (s *PublicTransactionPoolAPI) PendingTransactions() ([]*RPCTransaction, err
 	}
 	transactions := make([]*RPCTransaction, 0, len(pending))
 	for _, tx := range pending {
-		var signer types.Signer = types.HomesteadSigner{}
-		if tx.Protected() {
-			signer = types.NewEIP155Signer(tx.ChainId())
-		}
-		from, _ := types.Sender(signer, tx)
+		from, _ := types.Sender(types.MakeSigner(s.b.ChainConfig()), tx)
 		if _, exists := accounts[from]; exists {
 			transactions = append(transactions, newRPCPendingTransaction(tx))
 		}
@@ -1485,11 +1576,8 @@ func (s *PublicTransactionPoolAPI) Resend(ctx context.Context, sendArgs SendTxAr
 		return common.Hash{}, err
 	}
 
+	signer := types.MakeSigner(s.b.ChainConfig())
 	for _, p := range pending {
-		var signer types.Signer = types.HomesteadSigner{}
-		if p.Protected() {
-			signer = types.NewEIP155Signer(p.ChainId())
-		}
 		wantSigHash := signer.Hash(matchTx)
 
 		if pFrom, err := types.Sender(signer, p); err == nil && pFrom == sendArgs.From && signer.Hash(p) == wantSigHash {
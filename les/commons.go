@@ -68,6 +68,9 @@ func (c *lesCommons) makeProtocols(versions []uint) []p2p.Protocol {
 				}
 				return nil
 			},
+			UpdatePeer: func(info *common.NodeInfo) {
+				c.protocolManager.updatePeer(info)
+			},
 		}
 	}
 	return protos
@@ -66,6 +66,8 @@ const (
 	disableClientRemovePeer = false
 )
 
+var errUnregisteredLightNode = errors.New("peer isn't registered as a light node")
+
 func errResp(code errCode, format string, v ...interface{}) error {
 	return fmt.Errorf("%v - %v", code, fmt.Sprintf(format, v...))
 }
@@ -172,6 +174,22 @@ func (pm *ProtocolManager) removePeer(id string) {
 	pm.peers.Unregister(id)
 }
 
+// updatePeer disconnects the served peer of the node info if the node is no
+// longer registered as a light node.
+func (pm *ProtocolManager) updatePeer(info *common.NodeInfo) {
+	if pm.lightSync || len(info.PublicKey) < 16 {
+		return
+	}
+	p := pm.peers.Peer(info.PublicKey[:16])
+	if p == nil || p.Peer.Info().Network.Trusted {
+		return
+	}
+	if !common.SysCfg.IsValidLightNode(info.PublicKey) {
+		p.Log().Info("Peer no longer registered as a light node, disconnecting")
+		p.Disconnect(p2p.DiscUselessPeer)
+	}
+}
+
 func (pm *ProtocolManager) Start(maxPeers int) {
 	pm.maxPeers = maxPeers
 
@@ -251,6 +269,11 @@ func (pm *ProtocolManager) handle(p *peer) error {
 		return p2p.DiscTooManyPeers
 	}
 
+	// In server mode only the registered light nodes are served
+	if !pm.lightSync && !p.Peer.Info().Network.Trusted && !common.SysCfg.IsValidLightNode(p.ID().String()) {
+		return errUnregisteredLightNode
+	}
+
 	p.Log().Debug("Light Ethereum peer connected", "name", p.Name())
 
 	// Execute the LES handshake
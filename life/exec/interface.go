@@ -20,6 +20,9 @@ type StateDB interface {
 	Address() common.Address
 	CallValue() *big.Int
 	IsOwner(contractAddress common.Address, accountAddress common.Address) int64
+	// HasRole reports whether the account holds the built-in or custom role
+	// in the user management contract.
+	HasRole(account common.Address, role string) bool
 	AddLog(address common.Address, topics []common.Hash, data []byte, bn uint64)
 	SetState(key []byte, value []byte)
 	GetState(key []byte) []byte
@@ -29,3 +32,13 @@ type StateDB interface {
 	DelegateCall(addr, params []byte) ([]byte, error)
 	Call(addr, params []byte) ([]byte, error)
 }
+
+// Tracer observes the execution of a VirtualMachine. It is notified when a
+// function frame is entered or left and around every host function call, the
+// gas spent so far is available in vm.Context.GasUsed.
+type Tracer interface {
+	CaptureEnter(vm *VirtualMachine, functionID int)
+	CaptureExit(vm *VirtualMachine, functionID int)
+	CaptureHostEnter(vm *VirtualMachine, importID int, cost uint64)
+	CaptureHostExit(vm *VirtualMachine, importID int)
+}
@@ -65,6 +65,7 @@ type VirtualMachine struct {
 	Module          *compiler.Module
 	FunctionCode    []compiler.InterpreterCode
 	FunctionImports []*FunctionImport
+	ImportNames     []string
 	JumpTable       [256]Instruction
 	CallStack       []Frame
 	CurrentFrame    int
@@ -82,6 +83,10 @@ type VirtualMachine struct {
 	Gas            uint64
 	ExternalParams []int64
 	InitEntryID    int
+
+	// Compiled is the closure compiled form of FunctionCode. When set, it is
+	// executed in place of the interpreter loop unless the JIT is enabled.
+	Compiled *CompiledModule
 }
 
 // VMConfig denotes a set of options passed to a single VirtualMachine insta.ce
@@ -106,6 +111,7 @@ type VMContext struct {
 
 	StateDB StateDB
 	Log     log.Logger
+	Tracer  Tracer
 }
 
 type VMMemory struct {
@@ -153,12 +159,14 @@ func NewVirtualMachineWithModule(m *compiler.Module, functionCode []compiler.Int
 	table := make([]uint32, 0)
 	globals := make([]int64, 0)
 	funcImports := make([]*FunctionImport, 0)
+	importNames := make([]string, 0)
 
 	if m.Base.Import != nil && impResolver != nil {
 		for _, imp := range m.Base.Import.Entries {
 			switch imp.Type.Kind() {
 			case wasm.ExternalFunction:
 				funcImports = append(funcImports, impResolver.ResolveFunc(imp.ModuleName, imp.FieldName))
+				importNames = append(importNames, imp.FieldName)
 			case wasm.ExternalGlobal:
 				globals = append(globals, impResolver.ResolveGlobal(imp.ModuleName, imp.FieldName))
 			case wasm.ExternalMemory:
@@ -279,6 +287,7 @@ func NewVirtualMachineWithModule(m *compiler.Module, functionCode []compiler.Int
 		Context:         context,
 		FunctionCode:    functionCode,
 		FunctionImports: funcImports,
+		ImportNames:     importNames,
 		JumpTable:       GasTable,
 		CallStack:       make([]Frame, DefaultCallStackSize),
 		CurrentFrame:    -1,
@@ -427,6 +436,22 @@ func (vm *VirtualMachine) Ignite(functionID int, params ...int64) {
 		code,
 	)
 	copy(frame.Locals, params)
+	if vm.Context.Tracer != nil {
+		vm.Context.Tracer.CaptureEnter(vm, functionID)
+	}
+}
+
+// invokeImport runs the host function with the given import id, the cost
+// charged for the call is reported to the tracer.
+func (vm *VirtualMachine) invokeImport(importID int, cost uint64) int64 {
+	tracer := vm.Context.Tracer
+	if tracer == nil {
+		return vm.FunctionImports[importID].Execute(vm)
+	}
+	tracer.CaptureHostEnter(vm, importID, cost)
+	ret := vm.FunctionImports[importID].Execute(vm)
+	tracer.CaptureHostExit(vm, importID)
+	return ret
 }
 
 func (vm *VirtualMachine) AddAndCheckGas(delta uint64) {
@@ -467,6 +492,10 @@ func (vm *VirtualMachine) Execute() {
 	}()
 
 	frame := vm.GetCurrentFrame()
+	if vm.Compiled != nil && !vm.Context.Config.EnableJIT {
+		vm.executeCompiled(frame)
+		return
+	}
 
 	for {
 		if frame.JITInfo != nil {
@@ -1489,6 +1518,9 @@ func (vm *VirtualMachine) Execute() {
 			}
 		case opcodes.ReturnValue:
 			val := frame.Regs[int(LE.Uint32(frame.Code[frame.IP:frame.IP+4]))]
+			if vm.Context.Tracer != nil {
+				vm.Context.Tracer.CaptureExit(vm, frame.FunctionID)
+			}
 			frame.Destroy(vm)
 			vm.CurrentFrame--
 			if vm.CurrentFrame == -1 {
@@ -1500,6 +1532,9 @@ func (vm *VirtualMachine) Execute() {
 				frame.Regs[frame.ReturnReg] = val
 			}
 		case opcodes.ReturnVoid:
+			if vm.Context.Tracer != nil {
+				vm.Context.Tracer.CaptureExit(vm, frame.FunctionID)
+			}
 			frame.Destroy(vm)
 			vm.CurrentFrame--
 			if vm.CurrentFrame == -1 {
@@ -1545,6 +1580,9 @@ func (vm *VirtualMachine) Execute() {
 			for i := 0; i < argCount; i++ {
 				frame.Locals[i] = oldRegs[int(LE.Uint32(argsRaw[i*4:i*4+4]))]
 			}
+			if vm.Context.Tracer != nil {
+				vm.Context.Tracer.CaptureEnter(vm, functionID)
+			}
 
 		case opcodes.CallIndirect:
 			typeID := int(LE.Uint32(frame.Code[frame.IP : frame.IP+4]))
@@ -1575,12 +1613,15 @@ func (vm *VirtualMachine) Execute() {
 			for i := 0; i < argCount; i++ {
 				frame.Locals[i] = oldRegs[int(LE.Uint32(argsRaw[i*4:i*4+4]))]
 			}
+			if vm.Context.Tracer != nil {
+				vm.Context.Tracer.CaptureEnter(vm, functionID)
+			}
 
 		case opcodes.InvokeImport:
 			importID := int(LE.Uint32(frame.Code[frame.IP : frame.IP+4]))
 			frame.IP += 4
 			vm.Delegate = func() {
-				frame.Regs[valueID] = vm.FunctionImports[importID].Execute(vm)
+				frame.Regs[valueID] = vm.invokeImport(importID, cost)
 			}
 			return
 
@@ -1612,3 +1653,238 @@ func (vm *VirtualMachine) Execute() {
 		}
 	}
 }
+
+// The softfloat header can only be included by a single file of the package,
+// the compiled closures of vm_compile.go call the library through these.
+
+func softfloatF32Add(a, b float32) float32 {
+	return float32(C.platone_f32_add(C.float(a), C.float(b)))
+}
+
+func softfloatF32Sub(a, b float32) float32 {
+	return float32(C.platone_f32_sub(C.float(a), C.float(b)))
+}
+
+func softfloatF32Mul(a, b float32) float32 {
+	return float32(C.platone_f32_mul(C.float(a), C.float(b)))
+}
+
+func softfloatF32Div(a, b float32) float32 {
+	return float32(C.platone_f32_div(C.float(a), C.float(b)))
+}
+
+func softfloatF32Min(a, b float32) float32 {
+	return float32(C.platone_f32_min(C.float(a), C.float(b)))
+}
+
+func softfloatF32Max(a, b float32) float32 {
+	return float32(C.platone_f32_max(C.float(a), C.float(b)))
+}
+
+func softfloatF32Copysign(a, b float32) float32 {
+	return float32(C.platone_f32_copysign(C.float(a), C.float(b)))
+}
+
+func softfloatF32Sqrt(val float32) float32 {
+	return float32(C.platone_f32_sqrt(C.float(val)))
+}
+
+func softfloatF32Ceil(val float32) float32 {
+	return float32(C.platone_f32_ceil(C.float(val)))
+}
+
+func softfloatF32Floor(val float32) float32 {
+	return float32(C.platone_f32_floor(C.float(val)))
+}
+
+func softfloatF32Trunc(val float32) float32 {
+	return float32(C.platone_f32_trunc(C.float(val)))
+}
+
+func softfloatF32Nearest(val float32) float32 {
+	return float32(C.platone_f32_nearest(C.float(val)))
+}
+
+func softfloatF32Abs(val float32) float32 {
+	return float32(C.platone_f32_abs(C.float(val)))
+}
+
+func softfloatF32Neg(val float32) float32 {
+	return float32(C.platone_f32_neg(C.float(val)))
+}
+
+func softfloatF32Eq(a, b float32) bool {
+	return bool(C.platone_f32_eq(C.float(a), C.float(b)))
+}
+
+func softfloatF32Ne(a, b float32) bool {
+	return bool(C.platone_f32_ne(C.float(a), C.float(b)))
+}
+
+func softfloatF32Lt(a, b float32) bool {
+	return bool(C.platone_f32_lt(C.float(a), C.float(b)))
+}
+
+func softfloatF32Le(a, b float32) bool {
+	return bool(C.platone_f32_le(C.float(a), C.float(b)))
+}
+
+func softfloatF32Gt(a, b float32) bool {
+	return bool(C.platone_f32_gt(C.float(a), C.float(b)))
+}
+
+func softfloatF32Ge(a, b float32) bool {
+	return bool(C.platone_f32_ge(C.float(a), C.float(b)))
+}
+
+func softfloatF64Add(a, b float64) float64 {
+	return float64(C.platone_f64_add(C.double(a), C.double(b)))
+}
+
+func softfloatF64Sub(a, b float64) float64 {
+	return float64(C.platone_f64_sub(C.double(a), C.double(b)))
+}
+
+func softfloatF64Mul(a, b float64) float64 {
+	return float64(C.platone_f64_mul(C.double(a), C.double(b)))
+}
+
+func softfloatF64Div(a, b float64) float64 {
+	return float64(C.platone_f64_div(C.double(a), C.double(b)))
+}
+
+func softfloatF64Min(a, b float64) float64 {
+	return float64(C.platone_f64_min(C.double(a), C.double(b)))
+}
+
+func softfloatF64Max(a, b float64) float64 {
+	return float64(C.platone_f64_max(C.double(a), C.double(b)))
+}
+
+func softfloatF64Copysign(a, b float64) float64 {
+	return float64(C.platone_f64_copysign(C.double(a), C.double(b)))
+}
+
+func softfloatF64Sqrt(val float64) float64 {
+	return float64(C.platone_f64_sqrt(C.double(val)))
+}
+
+func softfloatF64Ceil(val float64) float64 {
+	return float64(C.platone_f64_ceil(C.double(val)))
+}
+
+func softfloatF64Floor(val float64) float64 {
+	return float64(C.platone_f64_floor(C.double(val)))
+}
+
+func softfloatF64Trunc(val float64) float64 {
+	return float64(C.platone_f64_trunc(C.double(val)))
+}
+
+func softfloatF64Nearest(val float64) float64 {
+	return float64(C.platone_f64_nearest(C.double(val)))
+}
+
+func softfloatF64Abs(val float64) float64 {
+	return float64(C.platone_f64_abs(C.double(val)))
+}
+
+func softfloatF64Neg(val float64) float64 {
+	return float64(C.platone_f64_neg(C.double(val)))
+}
+
+func softfloatF64Eq(a, b float64) bool {
+	return bool(C.platone_f64_eq(C.double(a), C.double(b)))
+}
+
+func softfloatF64Ne(a, b float64) bool {
+	return bool(C.platone_f64_ne(C.double(a), C.double(b)))
+}
+
+func softfloatF64Lt(a, b float64) bool {
+	return bool(C.platone_f64_lt(C.double(a), C.double(b)))
+}
+
+func softfloatF64Le(a, b float64) bool {
+	return bool(C.platone_f64_le(C.double(a), C.double(b)))
+}
+
+func softfloatF64Gt(a, b float64) bool {
+	return bool(C.platone_f64_gt(C.double(a), C.double(b)))
+}
+
+func softfloatF64Ge(a, b float64) bool {
+	return bool(C.platone_f64_ge(C.double(a), C.double(b)))
+}
+
+func softfloatF32TruncI32s(v float32) int32 {
+	return int32(C.platone_f32_trunc_i32s(C.float(v)))
+}
+
+func softfloatF32TruncI32u(v float32) uint32 {
+	return uint32(C.platone_f32_trunc_i32u(C.float(v)))
+}
+
+func softfloatF64TruncI32s(v float64) int32 {
+	return int32(C.platone_f64_trunc_i32s(C.double(v)))
+}
+
+func softfloatF64TruncI32u(v float64) uint32 {
+	return uint32(C.platone_f64_trunc_i32u(C.double(v)))
+}
+
+func softfloatF32TruncI64s(v float32) int64 {
+	return int64(C.platone_f32_trunc_i64s(C.float(v)))
+}
+
+func softfloatF32TruncI64u(v float32) uint64 {
+	return uint64(C.platone_f32_trunc_i64u(C.float(v)))
+}
+
+func softfloatF64TruncI64s(v float64) int64 {
+	return int64(C.platone_f64_trunc_i64s(C.double(v)))
+}
+
+func softfloatF64TruncI64u(v float64) uint64 {
+	return uint64(C.platone_f64_trunc_i64u(C.double(v)))
+}
+
+func softfloatF64Demote(v float64) float32 {
+	return float32(C.platone_f64_demote(C.double(v)))
+}
+
+func softfloatF32Promote(v float32) float64 {
+	return float64(C.platone_f32_promote(C.float(v)))
+}
+
+func softfloatI32ToF32(v int32) float32 {
+	return float32(C.platone_i32_to_f32(C.int32_t(v)))
+}
+
+func softfloatUi32ToF32(v uint32) float32 {
+	return float32(C.platone_ui32_to_f32(C.uint32_t(v)))
+}
+
+func softfloatI64ToF32(v int64) float32 {
+	return float32(C.platone_i64_to_f32(C.int64_t(v)))
+}
+
+func softfloatUi64ToF32(v uint64) float32 {
+	return float32(C.platone_ui64_to_f32(C.uint64_t(v)))
+}
+
+func softfloatI32ToF64(v int32) float64 {
+	return float64(C.platone_i32_to_f64(C.int32_t(v)))
+}
+
+func softfloatUi32ToF64(v uint32) float64 {
+	return float64(C.platone_ui32_to_f64(C.uint32_t(v)))
+}
+
+func softfloatI64ToF64(v int64) float64 {
+	return float64(C.platone_i64_to_f64(C.int64_t(v)))
+}
+
+func softfloatUi64ToF64(v uint64) float64 {
+	return float64(C.platone_ui64_to_f64(C.uint64_t(v)))
+}
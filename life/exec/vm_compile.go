@@ -0,0 +1,1547 @@
+package exec
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/Venachain/Venachain/life/compiler"
+	"github.com/Venachain/Venachain/life/compiler/opcodes"
+	"github.com/Venachain/Venachain/life/utils"
+)
+
+// Results of a compiled instruction, telling the execution loop how to go on.
+const (
+	compiledNext   = iota // run the next instruction of the frame
+	compiledSwitch        // the current frame changed on a call or a return
+	compiledLeave         // leave Execute, the vm exited or waits for a delegate
+)
+
+// CompiledModule is the closure compiled form of the interpreter code of a
+// module. Every instruction is decoded once into a Go closure bound to its
+// operands, so the execution loop no longer decodes the byte code. It holds no
+// state of a VirtualMachine and can be shared by all the VMs running the module.
+type CompiledModule struct {
+	functions [][]compiledInstr
+}
+
+type compiledInstr struct {
+	cost       uint64 // static gas cost of the instruction, taken from GasTable
+	dynamicGas bool   // the instruction charges its own gas, as InvokeImport does
+	exec       func(vm *VirtualMachine, frame *Frame) int
+}
+
+// Compile translates the interpreter code of the module functions into
+// closures. The gas charged for every instruction is the cost found in
+// GasTable, so running the compiled form consumes exactly the gas of the
+// interpreter. An error is returned if the code can't be decoded, the
+// functions must then be run in the interpreter.
+func Compile(functionCode []compiler.InterpreterCode) (_retCM *CompiledModule, retErr error) {
+	defer utils.CatchPanic(&retErr)
+
+	cm := &CompiledModule{functions: make([][]compiledInstr, len(functionCode))}
+	for i, code := range functionCode {
+		cm.functions[i] = compileFunction(code.Bytes)
+	}
+	return cm, nil
+}
+
+// codeReader decodes the operands of the interpreter code.
+type codeReader struct {
+	code    []byte
+	pos     int
+	targets []*int // jump targets to relocate from byte offsets to instruction indexes
+}
+
+func (r *codeReader) skip(n int) {
+	if n < 0 || r.pos+n > len(r.code) {
+		panic(fmt.Sprintf("truncated instruction at %d", r.pos))
+	}
+	r.pos += n
+}
+
+func (r *codeReader) u32() uint32 {
+	r.skip(4)
+	return LE.Uint32(r.code[r.pos-4 : r.pos])
+}
+
+func (r *codeReader) u64() uint64 {
+	r.skip(8)
+	return LE.Uint64(r.code[r.pos-8 : r.pos])
+}
+
+func (r *codeReader) reg() int {
+	return int(r.u32())
+}
+
+func (r *codeReader) regs(n int) []int {
+	if n < 0 || n > (len(r.code)-r.pos)/4 {
+		panic(fmt.Sprintf("truncated instruction at %d", r.pos))
+	}
+	regs := make([]int, n)
+	for i := range regs {
+		regs[i] = r.reg()
+	}
+	return regs
+}
+
+func (r *codeReader) target() *int {
+	target := int(r.u32())
+	r.targets = append(r.targets, &target)
+	return &target
+}
+
+func compileFunction(code []byte) []compiledInstr {
+	var (
+		r       = &codeReader{code: code}
+		instrs  []compiledInstr
+		indexes = make(map[int]int)
+	)
+	for r.pos < len(code) {
+		indexes[r.pos] = len(instrs)
+
+		valueID := r.reg()
+		r.skip(1)
+		op := opcodes.Opcode(code[r.pos-1])
+
+		instr := compiledInstr{exec: compileInstr(r, op, valueID)}
+		if op == opcodes.InvokeImport {
+			instr.dynamicGas = true
+		} else {
+			instr.cost, _ = GasTable[op].GasCost(nil, nil)
+		}
+		instrs = append(instrs, instr)
+	}
+	for _, target := range r.targets {
+		index, ok := indexes[*target]
+		if !ok {
+			panic(fmt.Sprintf("invalid jump target %d", *target))
+		}
+		*target = index
+	}
+	return instrs
+}
+
+// executeCompiled runs the compiled form of the functions from the current
+// frame, where the IP of the frames is the index of the next instruction. It
+// mirrors the interpreter loop of Execute.
+func (vm *VirtualMachine) executeCompiled(frame *Frame) {
+	code := vm.Compiled.functions[frame.FunctionID]
+	for {
+		instr := &code[frame.IP]
+		frame.IP++
+
+		if !instr.dynamicGas {
+			vm.useGas(instr.cost, nil)
+		}
+		switch instr.exec(vm, frame) {
+		case compiledSwitch:
+			frame = &vm.CallStack[vm.CurrentFrame]
+			code = vm.Compiled.functions[frame.FunctionID]
+		case compiledLeave:
+			return
+		}
+	}
+}
+
+func (vm *VirtualMachine) useGas(cost uint64, err error) {
+	if err != nil || (cost+vm.Context.GasUsed) > vm.Context.GasLimit {
+		panic(fmt.Sprintf("out of gas  cost:%d GasUsed:%d GasLimit:%d", cost, vm.Context.GasUsed, vm.Context.GasLimit))
+	}
+	vm.Context.GasUsed += cost
+}
+
+// compileInstr binds the operands of an instruction to the closure executing
+// it, the expressions are the ones of the interpreter loop.
+func compileInstr(r *codeReader, op opcodes.Opcode, valueID int) func(vm *VirtualMachine, frame *Frame) int {
+	switch op {
+	case opcodes.Nop:
+		return func(vm *VirtualMachine, frame *Frame) int {
+			return compiledNext
+		}
+	case opcodes.Unreachable:
+		return func(vm *VirtualMachine, frame *Frame) int {
+			panic("wasm: unreachable executed")
+		}
+	case opcodes.Select:
+		ra, rb, rc := r.reg(), r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			if int32(frame.Regs[rc]) != 0 {
+				frame.Regs[valueID] = frame.Regs[ra]
+			} else {
+				frame.Regs[valueID] = frame.Regs[rb]
+			}
+			return compiledNext
+		}
+	case opcodes.I32Const:
+		val := int64(r.u32())
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = val
+			return compiledNext
+		}
+	case opcodes.I64Const:
+		val := int64(r.u64())
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = val
+			return compiledNext
+		}
+	case opcodes.I32Add:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a + b)
+			return compiledNext
+		}
+	case opcodes.I32Sub:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a - b)
+			return compiledNext
+		}
+	case opcodes.I32Mul:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a * b)
+			return compiledNext
+		}
+	case opcodes.I32DivS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			if b == 0 {
+				panic("integer division by zero")
+			}
+			if a == math.MinInt32 && b == -1 {
+				panic("signed integer overflow")
+			}
+			frame.Regs[valueID] = int64(a / b)
+			return compiledNext
+		}
+	case opcodes.I32DivU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			if b == 0 {
+				panic("integer division by zero")
+			}
+			frame.Regs[valueID] = int64(a / b)
+			return compiledNext
+		}
+	case opcodes.I32RemS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			if b == 0 {
+				panic("integer division by zero")
+			}
+			frame.Regs[valueID] = int64(a % b)
+			return compiledNext
+		}
+	case opcodes.I32RemU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			if b == 0 {
+				panic("integer division by zero")
+			}
+			frame.Regs[valueID] = int64(a % b)
+			return compiledNext
+		}
+	case opcodes.I32And:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a & b)
+			return compiledNext
+		}
+	case opcodes.I32Or:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a | b)
+			return compiledNext
+		}
+	case opcodes.I32Xor:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a ^ b)
+			return compiledNext
+		}
+	case opcodes.I32Shl:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a << (b % 32))
+			return compiledNext
+		}
+	case opcodes.I32ShrS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a >> (b % 32))
+			return compiledNext
+		}
+	case opcodes.I32ShrU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a >> (b % 32))
+			return compiledNext
+		}
+	case opcodes.I32Rotl:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(bits.RotateLeft32(a, int(b)))
+			return compiledNext
+		}
+	case opcodes.I32Rotr:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			frame.Regs[valueID] = int64(bits.RotateLeft32(a, -int(b)))
+			return compiledNext
+		}
+	case opcodes.I32Clz:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := uint32(frame.Regs[rv])
+			frame.Regs[valueID] = int64(bits.LeadingZeros32(val))
+			return compiledNext
+		}
+	case opcodes.I32Ctz:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := uint32(frame.Regs[rv])
+			frame.Regs[valueID] = int64(bits.TrailingZeros32(val))
+			return compiledNext
+		}
+	case opcodes.I32PopCnt:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := uint32(frame.Regs[rv])
+			frame.Regs[valueID] = int64(bits.OnesCount32(val))
+			return compiledNext
+		}
+	case opcodes.I32EqZ:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := uint32(frame.Regs[rv])
+			if val == 0 {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32Eq:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			if a == b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32Ne:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			if a != b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32LtS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			if a < b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32LtU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			if a < b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32LeS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			if a <= b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32LeU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			if a <= b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32GtS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			if a > b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32GtU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			if a > b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32GeS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := int32(frame.Regs[ra])
+			b := int32(frame.Regs[rb])
+			if a >= b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32GeU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint32(frame.Regs[ra])
+			b := uint32(frame.Regs[rb])
+			if a >= b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64Add:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			frame.Regs[valueID] = a + b
+			return compiledNext
+		}
+	case opcodes.I64Sub:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			frame.Regs[valueID] = a - b
+			return compiledNext
+		}
+	case opcodes.I64Mul:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			frame.Regs[valueID] = a * b
+			return compiledNext
+		}
+	case opcodes.I64DivS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			if b == 0 {
+				panic("integer division by zero")
+			}
+			if a == math.MinInt64 && b == -1 {
+				panic("signed integer overflow")
+			}
+			frame.Regs[valueID] = a / b
+			return compiledNext
+		}
+	case opcodes.I64DivU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint64(frame.Regs[ra])
+			b := uint64(frame.Regs[rb])
+			if b == 0 {
+				panic("integer division by zero")
+			}
+			frame.Regs[valueID] = int64(a / b)
+			return compiledNext
+		}
+	case opcodes.I64RemS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			if b == 0 {
+				panic("integer division by zero")
+			}
+			frame.Regs[valueID] = a % b
+			return compiledNext
+		}
+	case opcodes.I64RemU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint64(frame.Regs[ra])
+			b := uint64(frame.Regs[rb])
+			if b == 0 {
+				panic("integer division by zero")
+			}
+			frame.Regs[valueID] = int64(a % b)
+			return compiledNext
+		}
+	case opcodes.I64And:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			frame.Regs[valueID] = a & b
+			return compiledNext
+		}
+	case opcodes.I64Or:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			frame.Regs[valueID] = a | b
+			return compiledNext
+		}
+	case opcodes.I64Xor:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			frame.Regs[valueID] = a ^ b
+			return compiledNext
+		}
+	case opcodes.I64Shl:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := uint64(frame.Regs[rb])
+			frame.Regs[valueID] = a << (b % 64)
+			return compiledNext
+		}
+	case opcodes.I64ShrS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := uint64(frame.Regs[rb])
+			frame.Regs[valueID] = a >> (b % 64)
+			return compiledNext
+		}
+	case opcodes.I64ShrU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint64(frame.Regs[ra])
+			b := uint64(frame.Regs[rb])
+			frame.Regs[valueID] = int64(a >> (b % 64))
+			return compiledNext
+		}
+	case opcodes.I64Rotl:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint64(frame.Regs[ra])
+			b := uint64(frame.Regs[rb])
+			frame.Regs[valueID] = int64(bits.RotateLeft64(a, int(b)))
+			return compiledNext
+		}
+	case opcodes.I64Rotr:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint64(frame.Regs[ra])
+			b := uint64(frame.Regs[rb])
+			frame.Regs[valueID] = int64(bits.RotateLeft64(a, -int(b)))
+			return compiledNext
+		}
+	case opcodes.I64Clz:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := uint64(frame.Regs[rv])
+			frame.Regs[valueID] = int64(bits.LeadingZeros64(val))
+			return compiledNext
+		}
+	case opcodes.I64Ctz:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := uint64(frame.Regs[rv])
+			frame.Regs[valueID] = int64(bits.TrailingZeros64(val))
+			return compiledNext
+		}
+	case opcodes.I64PopCnt:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := uint64(frame.Regs[rv])
+			frame.Regs[valueID] = int64(bits.OnesCount64(val))
+			return compiledNext
+		}
+	case opcodes.I64EqZ:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := uint64(frame.Regs[rv])
+			if val == 0 {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64Eq:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			if a == b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64Ne:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			if a != b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64LtS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			if a < b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64LtU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint64(frame.Regs[ra])
+			b := uint64(frame.Regs[rb])
+			if a < b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64LeS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			if a <= b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64LeU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint64(frame.Regs[ra])
+			b := uint64(frame.Regs[rb])
+			if a <= b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64GtS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			if a > b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64GtU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint64(frame.Regs[ra])
+			b := uint64(frame.Regs[rb])
+			if a > b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64GeS:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := frame.Regs[ra]
+			b := frame.Regs[rb]
+			if a >= b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I64GeU:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := uint64(frame.Regs[ra])
+			b := uint64(frame.Regs[rb])
+			if a >= b {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F32Add:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Add(a, b)))
+			return compiledNext
+		}
+	case opcodes.F32Sub:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Sub(a, b)))
+			return compiledNext
+		}
+	case opcodes.F32Mul:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Mul(a, b)))
+			return compiledNext
+		}
+	case opcodes.F32Div:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Div(a, b)))
+			return compiledNext
+		}
+	case opcodes.F32Min:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Min(a, b)))
+			return compiledNext
+		}
+	case opcodes.F32Max:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Max(a, b)))
+			return compiledNext
+		}
+	case opcodes.F32CopySign:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Copysign(a, b)))
+			return compiledNext
+		}
+	case opcodes.F32Sqrt:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Sqrt(val)))
+			return compiledNext
+		}
+	case opcodes.F32Ceil:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Ceil(val)))
+			return compiledNext
+		}
+	case opcodes.F32Floor:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Floor(val)))
+			return compiledNext
+		}
+	case opcodes.F32Trunc:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Trunc(val)))
+			return compiledNext
+		}
+	case opcodes.F32Nearest:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Nearest(val)))
+			return compiledNext
+		}
+	case opcodes.F32Abs:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Abs(val)))
+			return compiledNext
+		}
+	case opcodes.F32Neg:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF32Neg(val)))
+			return compiledNext
+		}
+	case opcodes.F32Eq:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			if softfloatF32Eq(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F32Ne:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			if softfloatF32Ne(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F32Lt:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			if softfloatF32Lt(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F32Le:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			if softfloatF32Le(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F32Gt:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			if softfloatF32Gt(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F32Ge:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float32frombits(uint32(frame.Regs[ra]))
+			b := math.Float32frombits(uint32(frame.Regs[rb]))
+			if softfloatF32Ge(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F64Add:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Add(a, b)))
+			return compiledNext
+		}
+	case opcodes.F64Sub:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Sub(a, b)))
+			return compiledNext
+		}
+	case opcodes.F64Mul:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Mul(a, b)))
+			return compiledNext
+		}
+	case opcodes.F64Div:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Div(a, b)))
+			return compiledNext
+		}
+	case opcodes.F64Min:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Min(a, b)))
+			return compiledNext
+		}
+	case opcodes.F64Max:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Max(a, b)))
+			return compiledNext
+		}
+	case opcodes.F64CopySign:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Copysign(a, b)))
+			return compiledNext
+		}
+	case opcodes.F64Sqrt:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Sqrt(val)))
+			return compiledNext
+		}
+	case opcodes.F64Ceil:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Ceil(val)))
+			return compiledNext
+		}
+	case opcodes.F64Floor:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Floor(val)))
+			return compiledNext
+		}
+	case opcodes.F64Trunc:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Trunc(val)))
+			return compiledNext
+		}
+	case opcodes.F64Nearest:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Nearest(val)))
+			return compiledNext
+		}
+	case opcodes.F64Abs:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Abs(val)))
+			return compiledNext
+		}
+	case opcodes.F64Neg:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF64Neg(val)))
+			return compiledNext
+		}
+	case opcodes.F64Eq:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			if softfloatF64Eq(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F64Ne:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			if softfloatF64Ne(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F64Lt:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			if softfloatF64Lt(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F64Le:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			if softfloatF64Le(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F64Gt:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			if softfloatF64Gt(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.F64Ge:
+		ra, rb := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			a := math.Float64frombits(uint64(frame.Regs[ra]))
+			b := math.Float64frombits(uint64(frame.Regs[rb]))
+			if softfloatF64Ge(a, b) {
+				frame.Regs[valueID] = 1
+			} else {
+				frame.Regs[valueID] = 0
+			}
+			return compiledNext
+		}
+	case opcodes.I32WrapI64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := uint32(frame.Regs[rv])
+			frame.Regs[valueID] = int64(v)
+			return compiledNext
+		}
+	case opcodes.I32TruncSF32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(int32(softfloatF32TruncI32s(v)))
+			return compiledNext
+		}
+	case opcodes.I32TruncUF32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(int32(softfloatF32TruncI32u(v)))
+			return compiledNext
+		}
+	case opcodes.I32TruncSF64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(int32(softfloatF64TruncI32s(v)))
+			return compiledNext
+		}
+	case opcodes.I32TruncUF64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(int32(softfloatF64TruncI32u(v)))
+			return compiledNext
+		}
+	case opcodes.I64TruncSF32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(softfloatF32TruncI64s(v))
+			return compiledNext
+		}
+	case opcodes.I64TruncUF32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(softfloatF32TruncI64u(v))
+			return compiledNext
+		}
+	case opcodes.I64TruncSF64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(softfloatF64TruncI64s(v))
+			return compiledNext
+		}
+	case opcodes.I64TruncUF64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(softfloatF64TruncI64u(v))
+			return compiledNext
+		}
+	case opcodes.F32DemoteF64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float64frombits(uint64(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatF64Demote(v)))
+			return compiledNext
+		}
+	case opcodes.F64PromoteF32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := math.Float32frombits(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatF32Promote(v)))
+			return compiledNext
+		}
+	case opcodes.F32ConvertSI32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := int32(frame.Regs[rv])
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatI32ToF32(v)))
+			return compiledNext
+		}
+	case opcodes.F32ConvertUI32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := uint32(frame.Regs[rv])
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatUi32ToF32(v)))
+			return compiledNext
+		}
+	case opcodes.F32ConvertSI64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := int64(frame.Regs[rv])
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatI64ToF32(v)))
+			return compiledNext
+		}
+	case opcodes.F32ConvertUI64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := uint64(frame.Regs[rv])
+			frame.Regs[valueID] = int64(math.Float32bits(softfloatUi64ToF32(v)))
+			return compiledNext
+		}
+	case opcodes.F64ConvertSI32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := int32(frame.Regs[rv])
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatI32ToF64(v)))
+			return compiledNext
+		}
+	case opcodes.F64ConvertUI32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := uint32(frame.Regs[rv])
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatUi32ToF64(v)))
+			return compiledNext
+		}
+	case opcodes.F64ConvertSI64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := int64(frame.Regs[rv])
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatI64ToF64(v)))
+			return compiledNext
+		}
+	case opcodes.F64ConvertUI64:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := uint64(frame.Regs[rv])
+			frame.Regs[valueID] = int64(math.Float64bits(softfloatUi64ToF64(v)))
+			return compiledNext
+		}
+	case opcodes.I64ExtendUI32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := uint32(frame.Regs[rv])
+			frame.Regs[valueID] = int64(v)
+			return compiledNext
+		}
+	case opcodes.I64ExtendSI32:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			v := int32(uint32(frame.Regs[rv]))
+			frame.Regs[valueID] = int64(v)
+			return compiledNext
+		}
+	case opcodes.I32Load, opcodes.I64Load32U:
+		r.skip(4) // Memory alignment flags
+		offset, rbase := uint64(r.u32()), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			frame.Regs[valueID] = int64(uint32(LE.Uint32(vm.Memory.Memory[effective : effective+4])))
+			return compiledNext
+		}
+	case opcodes.I64Load32S:
+		r.skip(4) // Memory alignment flags
+		offset, rbase := uint64(r.u32()), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			frame.Regs[valueID] = int64(int32(LE.Uint32(vm.Memory.Memory[effective : effective+4])))
+			return compiledNext
+		}
+	case opcodes.I64Load:
+		r.skip(4) // Memory alignment flags
+		offset, rbase := uint64(r.u32()), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			frame.Regs[valueID] = int64(LE.Uint64(vm.Memory.Memory[effective : effective+8]))
+			return compiledNext
+		}
+	case opcodes.I32Load8S, opcodes.I64Load8S:
+		r.skip(4) // Memory alignment flags
+		offset, rbase := uint64(r.u32()), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			frame.Regs[valueID] = int64(int8(vm.Memory.Memory[effective]))
+			return compiledNext
+		}
+	case opcodes.I32Load8U, opcodes.I64Load8U:
+		r.skip(4) // Memory alignment flags
+		offset, rbase := uint64(r.u32()), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			frame.Regs[valueID] = int64(uint8(vm.Memory.Memory[effective]))
+			return compiledNext
+		}
+	case opcodes.I32Load16S, opcodes.I64Load16S:
+		r.skip(4) // Memory alignment flags
+		offset, rbase := uint64(r.u32()), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			frame.Regs[valueID] = int64(int16(LE.Uint16(vm.Memory.Memory[effective : effective+2])))
+			return compiledNext
+		}
+	case opcodes.I32Load16U, opcodes.I64Load16U:
+		r.skip(4) // Memory alignment flags
+		offset, rbase := uint64(r.u32()), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			frame.Regs[valueID] = int64(uint16(LE.Uint16(vm.Memory.Memory[effective : effective+2])))
+			return compiledNext
+		}
+	case opcodes.I32Store, opcodes.I64Store32:
+		r.skip(4) // Memory alignment flags
+		offset, rbase, rvalue := uint64(r.u32()), r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			LE.PutUint32(vm.Memory.Memory[effective:effective+4], uint32(frame.Regs[rvalue]))
+			return compiledNext
+		}
+	case opcodes.I64Store:
+		r.skip(4) // Memory alignment flags
+		offset, rbase, rvalue := uint64(r.u32()), r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			LE.PutUint64(vm.Memory.Memory[effective:effective+8], uint64(frame.Regs[rvalue]))
+			return compiledNext
+		}
+	case opcodes.I32Store8, opcodes.I64Store8:
+		r.skip(4) // Memory alignment flags
+		offset, rbase, rvalue := uint64(r.u32()), r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			vm.Memory.Memory[effective] = byte(frame.Regs[rvalue])
+			return compiledNext
+		}
+	case opcodes.I32Store16, opcodes.I64Store16:
+		r.skip(4) // Memory alignment flags
+		offset, rbase, rvalue := uint64(r.u32()), r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			effective := int(uint64(uint32(frame.Regs[rbase])) + offset)
+			LE.PutUint16(vm.Memory.Memory[effective:effective+2], uint16(frame.Regs[rvalue]))
+			return compiledNext
+		}
+	case opcodes.Jmp:
+		target, yieldedReg := r.target(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			vm.Yielded = frame.Regs[yieldedReg]
+			frame.IP = *target
+			return compiledNext
+		}
+	case opcodes.JmpEither:
+		targetA, targetB, cond, yieldedReg := r.target(), r.target(), r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			vm.Yielded = frame.Regs[yieldedReg]
+			if frame.Regs[cond] != 0 {
+				frame.IP = *targetA
+			} else {
+				frame.IP = *targetB
+			}
+			return compiledNext
+		}
+	case opcodes.JmpIf:
+		target, cond, yieldedReg := r.target(), r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			if frame.Regs[cond] != 0 {
+				vm.Yielded = frame.Regs[yieldedReg]
+				frame.IP = *target
+			}
+			return compiledNext
+		}
+	case opcodes.JmpTable:
+		targetCount := int(r.u32())
+		if targetCount < 0 || targetCount > len(r.code)/4 {
+			panic(fmt.Sprintf("truncated instruction at %d", r.pos))
+		}
+		targets := make([]*int, targetCount)
+		for i := range targets {
+			targets[i] = r.target()
+		}
+		defaultTarget, cond, yieldedReg := r.target(), r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			vm.Yielded = frame.Regs[yieldedReg]
+			val := int(frame.Regs[cond])
+			if val >= 0 && val < len(targets) {
+				frame.IP = *targets[val]
+			} else {
+				frame.IP = *defaultTarget
+			}
+			return compiledNext
+		}
+	case opcodes.ReturnValue:
+		rv := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			val := frame.Regs[rv]
+			if vm.Context.Tracer != nil {
+				vm.Context.Tracer.CaptureExit(vm, frame.FunctionID)
+			}
+			frame.Destroy(vm)
+			vm.CurrentFrame--
+			if vm.CurrentFrame == -1 {
+				vm.Exited = true
+				vm.ReturnValue = val
+				return compiledLeave
+			}
+			frame = vm.GetCurrentFrame()
+			frame.Regs[frame.ReturnReg] = val
+			return compiledSwitch
+		}
+	case opcodes.ReturnVoid:
+		return func(vm *VirtualMachine, frame *Frame) int {
+			if vm.Context.Tracer != nil {
+				vm.Context.Tracer.CaptureExit(vm, frame.FunctionID)
+			}
+			frame.Destroy(vm)
+			vm.CurrentFrame--
+			if vm.CurrentFrame == -1 {
+				vm.Exited = true
+				vm.ReturnValue = 0
+				return compiledLeave
+			}
+			vm.GetCurrentFrame()
+			return compiledSwitch
+		}
+	case opcodes.GetLocal:
+		id := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = frame.Locals[id]
+			return compiledNext
+		}
+	case opcodes.SetLocal:
+		id, rv := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Locals[id] = frame.Regs[rv]
+			return compiledNext
+		}
+	case opcodes.GetGlobal:
+		id := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = vm.Globals[id]
+			return compiledNext
+		}
+	case opcodes.SetGlobal:
+		id, rv := r.reg(), r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			vm.Globals[id] = frame.Regs[rv]
+			return compiledNext
+		}
+	case opcodes.Call:
+		functionID := r.reg()
+		args := r.regs(r.reg())
+		return func(vm *VirtualMachine, frame *Frame) int {
+			oldRegs := frame.Regs
+			frame.ReturnReg = valueID
+
+			vm.CurrentFrame++
+			frame = vm.GetCurrentFrame()
+			frame.Init(vm, functionID, vm.FunctionCode[functionID])
+			for i, arg := range args {
+				frame.Locals[i] = oldRegs[arg]
+			}
+			if vm.Context.Tracer != nil {
+				vm.Context.Tracer.CaptureEnter(vm, functionID)
+			}
+			return compiledSwitch
+		}
+	case opcodes.CallIndirect:
+		typeID := r.reg()
+		args := r.regs(r.reg() - 1)
+		tableReg := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			tableItemID := frame.Regs[tableReg]
+			sig := &vm.Module.Base.Types.Entries[typeID]
+
+			functionID := int(vm.Table[tableItemID])
+			code := vm.FunctionCode[functionID]
+
+			if code.NumParams != len(sig.ParamTypes) || code.NumReturns != len(sig.ReturnTypes) {
+				panic("type mismatch")
+			}
+
+			oldRegs := frame.Regs
+			frame.ReturnReg = valueID
+
+			vm.CurrentFrame++
+			frame = vm.GetCurrentFrame()
+			frame.Init(vm, functionID, code)
+			for i, arg := range args {
+				frame.Locals[i] = oldRegs[arg]
+			}
+			if vm.Context.Tracer != nil {
+				vm.Context.Tracer.CaptureEnter(vm, functionID)
+			}
+			return compiledSwitch
+		}
+	case opcodes.InvokeImport:
+		importID := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			gas, err := vm.FunctionImports[importID].GasCost(vm)
+			cost := gas + 6
+			vm.useGas(cost, err)
+
+			vm.Delegate = func() {
+				frame.Regs[valueID] = vm.invokeImport(importID, cost)
+			}
+			return compiledLeave
+		}
+	case opcodes.CurrentMemory:
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = int64(len(vm.Memory.Memory) / DefaultPageSize)
+			return compiledNext
+		}
+	case opcodes.GrowMemory:
+		rn := r.reg()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			n := int(uint32(frame.Regs[rn]))
+
+			current := len(vm.Memory.Memory) / DefaultPageSize
+			if vm.Context.Config.MaxMemoryPages == 0 || (current+n >= current && current+n <= vm.Context.Config.MaxMemoryPages) {
+				frame.Regs[valueID] = int64(current)
+				vm.Memory.Memory = append(vm.Memory.Memory, make([]byte, n*DefaultPageSize)...)
+			} else {
+				frame.Regs[valueID] = -1
+			}
+			return compiledNext
+		}
+	case opcodes.Phi:
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = vm.Yielded
+			return compiledNext
+		}
+	case opcodes.AddGas:
+		delta := r.u64()
+		return func(vm *VirtualMachine, frame *Frame) int {
+			vm.AddAndCheckGas(delta)
+			return compiledNext
+		}
+	default:
+		panic(fmt.Sprintf("unknown instruction %d", op))
+	}
+}
@@ -0,0 +1,100 @@
+package exec
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/Venachain/Venachain/life/compiler"
+)
+
+// compileTestCode is a module exporting run(n i32) i64: it stores the squares
+// of 0..n-1 in memory, sums them with a direct and an indirect call into a
+// global and picks the result with a br_table on n%3.
+const compileTestCode = "" +
+	"0061736d01000000010b0260017f017f60017f017e0303020001040401700001" +
+	"05030100010606017e0142000b0707010372756e00010907010041000b01000a" +
+	"6b020700200020006c0b6101017f02400340200120004e0d01200141086c2001" +
+	"1000ad3703002300200141086c2903007c20014100110000ac7c240020014101" +
+	"6a21010c000b0b02400240024020004103700e020001020b230042017c0f0b23" +
+	"0042027e0f0b23004207890b"
+
+func runCompileTest(t *testing.T, compiled bool, gasLimit uint64, n int64) (int64, error, uint64) {
+	code, _ := hex.DecodeString(compileTestCode)
+	m, functionCode, err := ParseModuleAndFunc(code, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	context := &VMContext{
+		Config: VMConfig{
+			DefaultMemoryPages: DefaultMemoryPages,
+			DynamicMemoryPages: DynamicMemoryPages,
+		},
+		GasLimit: gasLimit,
+	}
+	vm, err := NewVirtualMachineWithModule(m, functionCode, context, &NopResolver{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compiled {
+		if vm.Compiled, err = Compile(functionCode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entryID, ok := vm.GetFunctionExport("run")
+	if !ok {
+		t.Fatal("run is not exported")
+	}
+	ret, err := vm.Run(entryID, n)
+	return ret, err, context.GasUsed
+}
+
+func TestCompiledExecution(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want int64
+	}{
+		{0, 1},
+		{9, 409},
+		{10, 1140},
+		{11, 98560},
+	}
+	for _, test := range tests {
+		ret, err, gasUsed := runCompileTest(t, false, 1000000, test.n)
+		if err != nil || ret != test.want {
+			t.Fatalf("run(%d) interpreted: have %d, %v, want %d", test.n, ret, err, test.want)
+		}
+		compiledRet, err, compiledGasUsed := runCompileTest(t, true, 1000000, test.n)
+		if err != nil || compiledRet != test.want {
+			t.Errorf("run(%d) compiled: have %d, %v, want %d", test.n, compiledRet, err, test.want)
+		}
+		if compiledGasUsed != gasUsed {
+			t.Errorf("run(%d) gas mismatch: compiled %d, interpreted %d", test.n, compiledGasUsed, gasUsed)
+		}
+	}
+}
+
+func TestCompiledOutOfGas(t *testing.T) {
+	_, err, gasUsed := runCompileTest(t, false, 500, 10)
+	if err == nil {
+		t.Fatal("expected out of gas in the interpreter")
+	}
+	_, compiledErr, compiledGasUsed := runCompileTest(t, true, 500, 10)
+	if compiledErr == nil || compiledErr.Error() != err.Error() {
+		t.Errorf("error mismatch: compiled %v, interpreted %v", compiledErr, err)
+	}
+	if compiledGasUsed != gasUsed {
+		t.Errorf("gas mismatch: compiled %d, interpreted %d", compiledGasUsed, gasUsed)
+	}
+}
+
+func TestCompileInvalidCode(t *testing.T) {
+	for _, code := range [][]byte{
+		{0, 0, 0, 0, 0xff},                         // unknown opcode
+		{0, 0, 0, 0, 0x03, 1, 0},                   // truncated i32.const
+		{0, 0, 0, 0, 0x8f, 3, 0, 0, 0, 0, 0, 0, 0}, // jump into an instruction
+	} {
+		if _, err := Compile([]compiler.InterpreterCode{{Bytes: code}}); err == nil {
+			t.Errorf("expected an error compiling %x", code)
+		}
+	}
+}
@@ -173,6 +173,7 @@ func newCfcSet() map[string]map[string]*exec.FunctionImport {
 			"origin":     &exec.FunctionImport{Execute: envOrigin, GasCost: envOriginGasCost},
 			"caller":     &exec.FunctionImport{Execute: envCaller, GasCost: envCallerGasCost},
 			"isOwner":    &exec.FunctionImport{Execute: envIsOwner, GasCost: envIsOwnerGasCost},
+			"hasRole":    &exec.FunctionImport{Execute: envHasRole, GasCost: envHasRoleGasCost},
 			"isFromInit": &exec.FunctionImport{Execute: envIsFromInit, GasCost: envIsFromInitGasCost},
 			"callValue":  &exec.FunctionImport{Execute: envCallValue, GasCost: envCallValueGasCost},
 			"address":    &exec.FunctionImport{Execute: envAddress, GasCost: envAddressGasCost},
@@ -639,6 +640,22 @@ func envIsOwner(vm *exec.VirtualMachine) int64 {
 	return vm.Context.StateDB.IsOwner(contractAddress, accountAddress)
 }
 
+// define: int64_t hasRole(const char *addr, size_t addrLen, const char *role, size_t roleLen);
+func envHasRole(vm *exec.VirtualMachine) int64 {
+	account := int(int32(vm.GetCurrentFrame().Locals[0]))
+	accountLen := int(int32(vm.GetCurrentFrame().Locals[1]))
+	role := int(int32(vm.GetCurrentFrame().Locals[2]))
+	roleLen := int(int32(vm.GetCurrentFrame().Locals[3]))
+
+	accountAddress := common.BytesToAddress(vm.Memory.Memory[account : account+accountLen])
+	roleName := string(vm.Memory.Memory[role : role+roleLen])
+
+	if vm.Context.StateDB.HasRole(accountAddress, roleName) {
+		return 1
+	}
+	return 0
+}
+
 // define: int64_t isFromInit();
 func envIsFromInit(vm *exec.VirtualMachine) int64 {
 	if vm.InitEntryID != -1 {
@@ -708,6 +725,10 @@ func envSm3(vm *exec.VirtualMachine) int64 {
 	return 0
 }
 
+// envEcrecover recovers the account which signed the hash. It follows the
+// crypto suite of the chain: on the chains of the SM suite it recovers the
+// signer of a recoverable SM2 signature and its SM3 address, unlike the EVM
+// ecrecover precompile which always recovers secp256k1 signers.
 func envEcrecover(vm *exec.VirtualMachine) int64 {
 	hashOffset := int(int32(vm.GetCurrentFrame().Locals[0]))
 	rsOffset := int(int32(vm.GetCurrentFrame().Locals[1]))
@@ -1118,6 +1139,10 @@ func envIsOwnerGasCost(vm *exec.VirtualMachine) (uint64, error) {
 	return 5077, nil
 }
 
+func envHasRoleGasCost(vm *exec.VirtualMachine) (uint64, error) {
+	return 5077, nil
+}
+
 func envIsFromInitGasCost(vm *exec.VirtualMachine) (uint64, error) {
 	return 4, nil
 }
@@ -511,7 +511,7 @@ func (w *worker) taskLoop() {
 			}
 
 			isEmpty := task.block.Transactions().Len() == 0
-			isProduceEmptyBlock := common.SysCfg.IsProduceEmptyBlock()
+			isProduceEmptyBlock := w.chain.SystemConfig().IsProduceEmptyBlock()
 
 			if !isEmpty || isProduceEmptyBlock {
 				w.pendingMu.Lock()
@@ -627,7 +627,7 @@ func (w *worker) makeCurrent(parent *types.Block, header *types.Header) error {
 	}
 
 	env := &environment{
-		signer: types.NewEIP155Signer(w.config.ChainID),
+		signer: types.MakeSigner(w.config),
 		state:  state,
 		header: header,
 	}
@@ -752,6 +752,10 @@ func (w *worker) commitTransactionsWithHeader(header *types.Header, txs types.Tr
 			// Reorg notification data race between the transaction pool and miner, skip account =
 			log.Warn("Skipping account with hight nonce", "blockNumber", header.Number, "blockParentHash", header.ParentHash, "tx.hash", tx.Hash(), "sender", from, "senderCurNonce", w.current.state.GetNonce(from), "tx.nonce", tx.Nonce())
 			rpc.MonitorWriteData(rpc.TransactionExecuteStatus, tx.Hash().String(), "false", w.extdb)
+		case core.ErrTxExpired, core.ErrTxValidityTooLong, core.ErrUnknownRefBlock:
+			// The transaction can't be included in this block, skip it
+			log.Warn("Skipping transaction outside its validity window", "blockNumber", header.Number, "blockParentHash", header.ParentHash, "tx.hash", tx.Hash(), "err", err)
+			rpc.MonitorWriteData(rpc.TransactionExecuteStatus, tx.Hash().String(), "false", w.extdb)
 		case nil:
 			// Everything ok, collect the logs and shift in the next transaction from the same account
 			coalescedLogs = append(coalescedLogs, logs...)
@@ -867,12 +871,11 @@ func (w *worker) commitNewWork(interrupt *int32, timestamp int64, commitBlock *t
 		return
 	}
 
-	containsCreate := isContainsCreateContract(pending)
-	// pending里面是否包含创建合约的交易，如果包含则串行执行
-
 	startTime = time.Now()
 
-	if containsCreate || !common.SysCfg.IsUseDAG() {
+	// contract creations are scheduled in the DAG like the other transactions,
+	// the simulator tracks the nonce and code they read to derive the address
+	if !common.SysCfg.IsUseDAG() {
 		if ok := w.commitTransactionsWithHeader(header, pending, w.coinbase, interrupt); ok {
 			return
 		}
@@ -961,15 +964,6 @@ func (w *worker) resetDone() bool {
 	return false
 }
 
-func isContainsCreateContract(pending []*types.Transaction) bool {
-	for _, tx := range pending {
-		if tx.To() == nil {
-			return true
-		}
-	}
-	return false
-}
-
 func (w *worker) adjustGlobalTxCount() uint64 {
 	originTxCount := w.eth.TxPool().GetTxPoolConfig().GlobalTxCount.Load()
 	if !w.eth.TxPool().GetTxPoolConfig().IsAutoAdjustTxCount {
@@ -154,6 +154,20 @@ type Config struct {
 	// private APIs to untrusted users is a major security risk.
 	WSExposeAll bool `toml:",omitempty"`
 
+	// RPCAuth requires the callers of the HTTP and websocket RPC interfaces to
+	// authenticate with tokens signed by their accounts, and authorizes their
+	// calls by the roles of the accounts in the user management contract.
+	RPCAuth bool `toml:",omitempty"`
+
+	// RPCAuthRules maps the methods ("namespace_method") or the namespaces
+	// ("namespace_*") to the roles allowed to call them remotely, a method mapped
+	// to no role is disabled. rpc.DefaultAccessRules are used if empty.
+	RPCAuthRules map[string][]string `toml:",omitempty"`
+
+	// RPCAuditLog is the file the calls denied to remote callers are appended
+	// to, as JSON lines. The denied calls are logged if empty.
+	RPCAuditLog string `toml:",omitempty"`
+
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
 }
@@ -17,6 +17,7 @@
 package node
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -69,6 +70,9 @@ type Node struct {
 	wsListener net.Listener // Websocket RPC listener socket to server API requests
 	wsHandler  *rpc.Server  // Websocket RPC request handler to process the API requests
 
+	accessPolicy *rpc.AccessPolicy // Access policy of the HTTP and websocket callers (nil = all allowed)
+	auditLog     *os.File          // File the calls denied by the access policy are appended to
+
 	stop chan struct{} // Channel to wait for termination notifications
 	lock sync.RWMutex
 
@@ -264,23 +268,30 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 	for _, service := range services {
 		apis = append(apis, service.APIs()...)
 	}
+	if err := n.startAccessPolicy(services); err != nil {
+		return err
+	}
 	// Start the various API endpoints, terminating all in case of errors
 	if err := n.startInProc(apis); err != nil {
+		n.stopAccessPolicy()
 		return err
 	}
 	if err := n.startIPC(apis); err != nil {
 		n.stopInProc()
+		n.stopAccessPolicy()
 		return err
 	}
 	if err := n.startHTTP(n.httpEndpoint, apis, n.config.HTTPModules, n.config.HTTPCors, n.config.HTTPVirtualHosts, n.config.HTTPTimeouts); err != nil {
 		n.stopIPC()
 		n.stopInProc()
+		n.stopAccessPolicy()
 		return err
 	}
 	if err := n.startWS(n.wsEndpoint, apis, n.config.WSModules, n.config.WSOrigins, n.config.WSExposeAll); err != nil {
 		n.stopHTTP()
 		n.stopIPC()
 		n.stopInProc()
+		n.stopAccessPolicy()
 		return err
 	}
 	// All API endpoints started successfully
@@ -288,6 +299,56 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 	return nil
 }
 
+// startAccessPolicy sets up the access policy of the HTTP and websocket callers
+// if the RPC authentication is enabled. The roles of the callers are read by the
+// first service implementing rpc.RoleReader.
+func (n *Node) startAccessPolicy(services map[reflect.Type]Service) error {
+	if !n.config.RPCAuth {
+		return nil
+	}
+	policy := &rpc.AccessPolicy{Rules: n.config.RPCAuthRules}
+	if len(policy.Rules) == 0 {
+		policy.Rules = rpc.DefaultAccessRules
+	}
+	for _, service := range services {
+		if roles, ok := service.(rpc.RoleReader); ok {
+			policy.Roles = roles
+			break
+		}
+	}
+	if policy.Roles == nil {
+		n.log.Warn("No service reads the account roles, role restricted RPC methods are denied")
+	}
+	if n.config.RPCAuditLog != "" {
+		file, err := os.OpenFile(n.config.ResolvePath(n.config.RPCAuditLog), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		var lock sync.Mutex
+		encoder := json.NewEncoder(file)
+		policy.Audit = func(entry *rpc.AuditEntry) {
+			lock.Lock()
+			defer lock.Unlock()
+			if err := encoder.Encode(entry); err != nil {
+				n.log.Error("Failed to write RPC audit log", "err", err)
+			}
+		}
+		n.auditLog = file
+	}
+	n.accessPolicy = policy
+	n.log.Info("RPC authentication enabled", "rules", len(policy.Rules), "audit", n.config.RPCAuditLog)
+	return nil
+}
+
+// stopAccessPolicy drops the access policy and closes the audit log.
+func (n *Node) stopAccessPolicy() {
+	if n.auditLog != nil {
+		n.auditLog.Close()
+		n.auditLog = nil
+	}
+	n.accessPolicy = nil
+}
+
 // startInProc initializes an in-process RPC endpoint.
 func (n *Node) startInProc(apis []rpc.API) error {
 	// Register all the APIs exposed by the services
@@ -346,7 +407,7 @@ func (n *Node) startHTTP(endpoint string, apis []rpc.API, modules []string, cors
 	if endpoint == "" {
 		return nil
 	}
-	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts)
+	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts, n.accessPolicy)
 	if err != nil {
 		return err
 	}
@@ -379,7 +440,7 @@ func (n *Node) startWS(endpoint string, apis []rpc.API, modules []string, wsOrig
 	if endpoint == "" {
 		return nil
 	}
-	listener, handler, err := rpc.StartWSEndpoint(endpoint, apis, modules, wsOrigins, exposeAll)
+	listener, handler, err := rpc.StartWSEndpoint(endpoint, apis, modules, wsOrigins, exposeAll, n.accessPolicy)
 	if err != nil {
 		return err
 	}
@@ -421,6 +482,7 @@ func (n *Node) Stop() error {
 	n.stopWS()
 	n.stopHTTP()
 	n.stopIPC()
+	n.stopAccessPolicy()
 	n.rpcAPIs = nil
 	failure := &StopError{
 		Services: make(map[reflect.Type]error),
@@ -508,7 +508,7 @@ func encodePacket(priv *ecdsa.PrivateKey, ptype byte, req interface{}) (packet,
 		return nil, nil, err
 	}
 	packet = b.Bytes()
-	sig, err := crypto.Sign(crypto.Keccak256(packet[headSize:]), priv)
+	sig, err := crypto.Sign(crypto.SignatureHash(packet[headSize:]), priv)
 	if err != nil {
 		log.Error("Can't sign discv4 packet", "err", err)
 		return nil, nil, err
@@ -572,7 +572,7 @@ func decodePacket(buf []byte) (packet, NodeID, []byte, error) {
 	if !bytes.Equal(hash, shouldhash) {
 		return nil, NodeID{}, nil, errBadHash
 	}
-	fromID, err := recoverNodeID(crypto.Keccak256(buf[headSize:]), sig)
+	fromID, err := recoverNodeID(crypto.SignatureHash(buf[headSize:]), sig)
 	if err != nil {
 		return nil, NodeID{}, hash, err
 	}
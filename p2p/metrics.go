@@ -29,6 +29,8 @@ var (
 	ingressTrafficMeter = metrics.NewRegisteredMeter("p2p/InboundTraffic", nil)
 	egressConnectMeter  = metrics.NewRegisteredMeter("p2p/OutboundConnects", nil)
 	egressTrafficMeter  = metrics.NewRegisteredMeter("p2p/OutboundTraffic", nil)
+	revokedPeerMeter    = metrics.NewRegisteredMeter("p2p/RevokedPeers", nil)
+	admittedNodeMeter   = metrics.NewRegisteredMeter("p2p/AdmittedNodes", nil)
 )
 
 // meteredConn is a wrapper around a net.Conn that meters both the
@@ -27,6 +27,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/common/mclock"
 	"github.com/Venachain/Venachain/event"
 	"github.com/Venachain/Venachain/log"
@@ -80,6 +81,14 @@ const (
 	// dropped from a p2p.Server
 	PeerEventTypeDrop PeerEventType = "drop"
 
+	// PeerEventTypeRevoke is the type of event emitted when a peer is
+	// disconnected because its node was deleted from the node contract
+	PeerEventTypeRevoke PeerEventType = "revoke"
+
+	// PeerEventTypeAdmit is the type of event emitted when a node becomes
+	// a consensus node in the node contract and is dialed
+	PeerEventTypeAdmit PeerEventType = "admit"
+
 	// PeerEventTypeMsgSend is the type of event emitted when a
 	// message is successfully sent to a peer
 	PeerEventTypeMsgSend PeerEventType = "msgsend"
@@ -493,3 +502,11 @@ func (p *Peer) Info() *PeerInfo {
 
 	return info
 }
+
+// updateNode notifies the running protocol of the registration changes of the
+// remote node.
+func (p *Peer) updateNode(info *common.NodeInfo) {
+	if update := p.running.Protocol.UpdatePeer; update != nil {
+		update(info)
+	}
+}
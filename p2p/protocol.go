@@ -55,6 +55,8 @@ type Protocol struct {
 	// but returns nil, it is assumed that the protocol handshake is still running.
 	PeerInfo func(id discover.NodeID) interface{}
 
+	// UpdatePeer is an optional helper method notifying the protocol of the
+	// registration changes, such as a new node type, of a connected node.
 	UpdatePeer func(nodeInfo *common.NodeInfo)
 }
 
@@ -37,7 +37,6 @@ import (
 
 	"github.com/Venachain/Venachain/crypto"
 	"github.com/Venachain/Venachain/crypto/ecies"
-	"github.com/Venachain/Venachain/crypto/secp256k1"
 	"github.com/Venachain/Venachain/crypto/sha3"
 	"github.com/Venachain/Venachain/p2p/discover"
 	"github.com/Venachain/Venachain/rlp"
@@ -318,7 +317,7 @@ func (h *encHandshake) makeAuthMsg(prv *ecdsa.PrivateKey) (*authMsgV4, error) {
 		return nil, err
 	}
 	// Generate random keypair to for ECDH.
-	h.randomPrivKey, err = ecies.GenerateKey(rand.Reader, crypto.S256(), nil)
+	h.randomPrivKey, err = ecies.GenerateKey(rand.Reader, crypto.Curve(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -395,7 +394,7 @@ func (h *encHandshake) handleAuthMsg(msg *authMsgV4, prv *ecdsa.PrivateKey) erro
 	// Generate random keypair for ECDH.
 	// If a private key is already set, use it instead of generating one (for testing).
 	if h.randomPrivKey == nil {
-		h.randomPrivKey, err = ecies.GenerateKey(rand.Reader, crypto.S256(), nil)
+		h.randomPrivKey, err = ecies.GenerateKey(rand.Reader, crypto.Curve(), nil)
 		if err != nil {
 			return err
 		}
@@ -407,7 +406,7 @@ func (h *encHandshake) handleAuthMsg(msg *authMsgV4, prv *ecdsa.PrivateKey) erro
 		return err
 	}
 	signedMsg := xor(token, h.initNonce)
-	remoteRandomPub, err := secp256k1.RecoverPubkey(signedMsg, msg.Signature[:])
+	remoteRandomPub, err := crypto.Ecrecover(signedMsg, msg.Signature[:])
 	if err != nil {
 		return err
 	}
@@ -381,47 +381,96 @@ func (srv *Server) RemovePeer(node *discover.Node) {
 	}
 }
 
+// UpdatePeer re-evaluates the connected peers against the registered nodes.
 func UpdatePeer() {
 	if server != nil {
-		go server.updatePeer()
+		go server.updatePeer(common.NodeSetChange{})
 	}
 }
 
-func (srv *Server) updatePeer() {
+// nodeSetLoop applies the refreshes of the registered nodes to the peers as
+// soon as they are posted.
+func (srv *Server) nodeSetLoop() {
+	defer srv.loopWG.Done()
+
+	changes := make(chan common.NodeSetChange, 16)
+	sub := common.SysCfg.SubscribeNodeSetChange(changes)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case change := <-changes:
+			srv.updatePeer(change)
+		case <-sub.Err():
+			return
+		case <-srv.quit:
+			return
+		}
+	}
+}
+
+func (srv *Server) updatePeer(change common.NodeSetChange) {
 	joinNodes := srv.Peers()
 
-	delNodes := common.SysCfg.GetDeletedNodes()
+	var delNodes []*common.NodeInfo
+	delNodes = append(delNodes, change.Revoked...)
+	delNodes = append(delNodes, common.SysCfg.GetDeletedNodes()...)
 	log.Info("********** current joinNodes length **********", "len", len(joinNodes))
-	if err := server.removeDelNodes(delNodes, joinNodes); err != nil {
+	if err := srv.removeDelNodes(delNodes, joinNodes); err != nil {
 		log.Warn(err.Error())
 	}
 
+	// the type changes of the connected nodes take effect on their protocols
+	for _, joinNode := range joinNodes {
+		if info := common.SysCfg.GetNodeInfo(joinNode.ID().String()); info != nil && info.Status == 1 {
+			joinNode.updateNode(info)
+		}
+	}
+
 	consensusNodes := common.SysCfg.GetConsensusNodes()
 	log.Info("********** current consensus Len **********", "len", len(consensusNodes))
-	if err := server.updateConsensusNodes(consensusNodes, joinNodes); err != nil {
+	if err := srv.updateConsensusNodes(consensusNodes, joinNodes); err != nil {
 		log.Warn(err.Error())
 	}
+	for _, eNode := range change.Consensus {
+		if id, err := discover.HexID(eNode.PublicKey); err == nil && id != srv.Self().ID {
+			admittedNodeMeter.Mark(1)
+			srv.peerFeed.Send(&PeerEvent{Type: PeerEventTypeAdmit, Peer: id})
+		}
+	}
 }
 
+// removeDelNodes stops dialing the deleted nodes and disconnects them.
 func (srv *Server) removeDelNodes(delENodes []*common.NodeInfo, joinNodes []*Peer) (err error) {
-next:
+	connected := make(map[string]bool, len(joinNodes))
+	for _, joinNode := range joinNodes {
+		connected[joinNode.ID().String()] = true
+	}
+	removed := make(map[string]bool, len(delENodes))
 	for _, eNode := range delENodes {
-		for _, joinNode := range joinNodes {
-			if eNode.PublicKey == joinNode.ID().String() {
-				delEn := fmt.Sprintf("enode://%s@%s:%d", eNode.PublicKey, eNode.ExternalIP, eNode.P2pPort)
-				if node, err := discover.ParseNode(delEn); err == nil {
-					if IsNodeInBootNodes(eNode.PublicKey) {
-						if !BootNodesNotExempt {
-							continue next
-						} else {
-							srv.RemovePeer(node)
-						}
-					} else {
-						srv.RemoveConsensusPeer(node)
-					}
-					log.Info("remove del node", "nodePubKey", eNode.PublicKey)
-				}
+		if removed[eNode.PublicKey] {
+			continue
+		}
+		removed[eNode.PublicKey] = true
+
+		// the address of the node isn't needed to drop it
+		id, err := discover.HexID(eNode.PublicKey)
+		if err != nil {
+			continue
+		}
+		node := &discover.Node{ID: id}
+		if IsNodeInBootNodes(eNode.PublicKey) {
+			if !BootNodesNotExempt {
+				continue
 			}
+			srv.RemovePeer(node)
+		} else {
+			srv.RemoveConsensusPeer(node)
+		}
+		if connected[eNode.PublicKey] {
+			log.Info("remove del node", "nodePubKey", eNode.PublicKey)
+			revokedPeerMeter.Mark(1)
+			srv.peerFeed.Send(&PeerEvent{Type: PeerEventTypeRevoke, Peer: id})
 		}
 	}
 	return
@@ -433,7 +482,6 @@ next:
 		curPubKey := eNode.PublicKey
 		for _, joinNode := range joinNodes {
 			if curPubKey == joinNode.ID().String() {
-				joinNode.running.UpdatePeer(eNode)
 				continue next
 			}
 		}
@@ -714,8 +762,9 @@ func (srv *Server) Start() (err error) {
 		srv.log.Warn("P2P server will be useless, neither dialing nor listening")
 	}
 
-	srv.loopWG.Add(1)
+	srv.loopWG.Add(2)
 	go srv.run(dialer)
+	go srv.nodeSetLoop()
 	srv.running = true
 	return nil
 }
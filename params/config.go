@@ -39,7 +39,7 @@ var (
 		BloomRoot:    common.HexToHash("0xd38be1a06aabd568e10957fee4fcc523bc64996bcf31bae3f55f86e0a583919f"),
 	}
 
-	TestChainConfig = &ChainConfig{big.NewInt(1), nil, "", false}
+	TestChainConfig = &ChainConfig{big.NewInt(1), nil, "", false, ""}
 )
 
 // TrustedCheckpoint represents a set of post-processed trie roots (CHT and
@@ -69,6 +69,11 @@ type ChainConfig struct {
 	VMInterpreter string `json:"interpreter,omitempty"`
 
 	LicenseCheck bool `json:"licenseCheck"`
+
+	// Crypto suite for keys, signatures and addresses, "sm" selects SM2/SM3,
+	// empty means secp256k1/Keccak256. The ecrecover precompile recovers
+	// secp256k1 signers under both suites.
+	CryptoSuite string `json:"cryptoSuite,omitempty"`
 }
 
 // EthashConfig is the consensus engine configs for proof-of-work based sealing.
@@ -24,6 +24,7 @@ const (
 	GenesisGasLimit      uint64 = 4712388 // Gas limit of the Genesis block.
 
 	MaximumExtraDataSize uint64 = 32    // Maximum size extra data may be after Genesis.
+	TxValidityMaxBlocks  uint64 = 1024  // Maximum number of blocks a transaction validity window may span.
 	ExpByteGas           uint64 = 10    // Times ceil(log256(exponent)) for the EXP instruction.
 	SloadGas             uint64 = 50    // Multiplied by the number of 32-byte words that are copied (round up) for any *COPY operation and added.
 	CallValueTransferGas uint64 = 9000  // Paid for CALL when the value transfer is non-zero.
@@ -96,8 +97,12 @@ const (
 	FireWall          uint64 = 10000 //
 	CnsInvokeGas      uint64 = 80000 //
 	SCEvidenceGas     uint64 = 80000 //
+	SCEvidenceLeafGas uint64 = 1000  // Per-hash price for anchoring an evidence batch
 	SCBulletProofGas  uint64 = 80000 //
 	SCPaillierProofGas  uint64 = 80000 //
+	SCSponsorGas      uint64 = 80000 //
+	SCProposalGas     uint64 = 80000 //
+	SCImportEntryGas  uint64 = 20000 // Per-entry price for importing contract storage
 )
 
 var (
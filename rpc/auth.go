@@ -0,0 +1,289 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/log"
+)
+
+const (
+	// authScheme is the scheme of the Authorization header carrying a token.
+	authScheme = "Vena"
+
+	// MaxAuthTokenLifetime is the longest validity accepted for a token.
+	MaxAuthTokenLifetime = time.Hour
+
+	// defaultAuthTokenLifetime is the validity of the tokens signed by the
+	// clients for each HTTP request.
+	defaultAuthTokenLifetime = time.Minute
+
+	// wsAuthTokenLifetime is the validity of the tokens authenticating a
+	// websocket connection, the calls over the connection are denied once it
+	// elapsed.
+	wsAuthTokenLifetime = 30 * time.Minute
+
+	// minNoncePrune is the number of used nonces from which the expired ones
+	// are dropped.
+	minNoncePrune = 1024
+)
+
+var (
+	errMissingAuthToken = errors.New("missing authorization token")
+	errInvalidAuthToken = errors.New("invalid authorization token")
+	errAuthTokenExpired = errors.New("authorization token expired")
+	errAuthTokenTooLong = errors.New("authorization token lifetime too long")
+	errMethodDisabled   = errors.New("method disabled for remote callers")
+	errNoRole           = errors.New("account has none of the roles allowed to call the method")
+	errAuthTokenDigest  = errors.New("authorization token signed for another request")
+	errAuthTokenReplay  = errors.New("authorization token already used")
+)
+
+// wsAuthDigest is the digest signed by the tokens authenticating a websocket
+// connection, instead of the body of a request.
+var wsAuthDigest = crypto.Keccak256Hash([]byte("websocket"))
+
+// DefaultAccessRules are the access rules of the remote callers if none are
+// configured: the administration of the node is reserved to the chain admins
+// and the accounts of the node can't be used remotely.
+var DefaultAccessRules = map[string][]string{
+	"admin_*":    {"CHAIN_ADMIN"},
+	"miner_*":    {"CHAIN_ADMIN"},
+	"debug_*":    {"CHAIN_ADMIN"},
+	"personal_*": {},
+}
+
+// AuthToken is the payload of a token, signed with the key of the account.
+// A token authenticates a single request: it's bound to the digest of the
+// request body, so to its method and parameters, and its nonce can't be used
+// twice.
+type AuthToken struct {
+	Account common.Address `json:"account"`
+	Expiry  int64          `json:"expiry"`
+	Nonce   hexutil.Bytes  `json:"nonce"`
+	Digest  common.Hash    `json:"digest"`
+}
+
+// NewAuthToken signs a token authenticating the account of key to the RPC
+// servers for the request of the given body digest, until lifetime elapsed.
+func NewAuthToken(key *ecdsa.PrivateKey, lifetime time.Duration, digest common.Hash) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(&AuthToken{
+		Account: crypto.PubkeyToAddress(key.PublicKey),
+		Expiry:  time.Now().Add(lifetime).Unix(),
+		Nonce:   nonce,
+		Digest:  digest,
+	})
+	if err != nil {
+		return "", err
+	}
+	sig, err := crypto.Sign(crypto.Keccak256(payload), key)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// VerifyAuthToken checks token is valid at now for the request of the given
+// body digest, it returns the payload of the token. It doesn't check whether
+// the nonce was used before.
+func VerifyAuthToken(token string, digest common.Hash, now time.Time) (*AuthToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, errInvalidAuthToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errInvalidAuthToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errInvalidAuthToken
+	}
+	var t AuthToken
+	if err := json.Unmarshal(payload, &t); err != nil || len(t.Nonce) == 0 {
+		return nil, errInvalidAuthToken
+	}
+	pub, err := crypto.SigToPub(crypto.Keccak256(payload), sig)
+	if err != nil || crypto.PubkeyToAddress(*pub) != t.Account {
+		return nil, errInvalidAuthToken
+	}
+	if t.Digest != digest {
+		return nil, errAuthTokenDigest
+	}
+
+	expiry := time.Unix(t.Expiry, 0)
+	if !now.Before(expiry) {
+		return nil, errAuthTokenExpired
+	}
+	if expiry.Sub(now) > MaxAuthTokenLifetime {
+		return nil, errAuthTokenTooLong
+	}
+	return &t, nil
+}
+
+// remoteCaller is the caller of a request received over HTTP or websocket.
+type remoteCaller struct {
+	remote  string
+	account common.Address
+	expiry  time.Time // end of the authorization of a websocket connection
+	err     error     // why the caller isn't authenticated
+}
+
+type remoteCallerKey struct{}
+
+// withRemoteCaller returns a copy of ctx carrying the caller authenticated by
+// the token of r, signed for the request body of the given digest.
+func (p *AccessPolicy) withRemoteCaller(ctx context.Context, r *http.Request, digest common.Hash) context.Context {
+	caller := &remoteCaller{remote: r.RemoteAddr}
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, authScheme+" ") {
+		caller.err = errMissingAuthToken
+	} else {
+		now := time.Now()
+		t, err := VerifyAuthToken(strings.TrimPrefix(header, authScheme+" "), digest, now)
+		if err == nil && !p.useNonce(t, now) {
+			err = errAuthTokenReplay
+		}
+		if caller.err = err; err == nil {
+			caller.account = t.Account
+			caller.expiry = time.Unix(t.Expiry, 0)
+		}
+	}
+	return context.WithValue(ctx, remoteCallerKey{}, caller)
+}
+
+// useNonce records the nonce of the token, it returns false if it was used
+// before. The nonces are kept until their tokens expire.
+func (p *AccessPolicy) useNonce(t *AuthToken, now time.Time) bool {
+	p.noncesMu.Lock()
+	defer p.noncesMu.Unlock()
+
+	if p.nonces == nil {
+		p.nonces = make(map[string]time.Time)
+	}
+	if len(p.nonces) >= p.noncePrune {
+		for nonce, expiry := range p.nonces {
+			if !now.Before(expiry) {
+				delete(p.nonces, nonce)
+			}
+		}
+		p.noncePrune = 2 * len(p.nonces)
+		if p.noncePrune < minNoncePrune {
+			p.noncePrune = minNoncePrune
+		}
+	}
+	key := string(t.Account.Bytes()) + string(t.Nonce)
+	if _, used := p.nonces[key]; used {
+		return false
+	}
+	p.nonces[key] = time.Unix(t.Expiry, 0)
+	return true
+}
+
+// RoleReader reads the roles of the accounts in the user management contract.
+type RoleReader interface {
+	AccountRoles(account common.Address) ([]string, error)
+}
+
+// AuditEntry records a call denied to a remote caller.
+type AuditEntry struct {
+	Time    time.Time      `json:"time"`
+	Remote  string         `json:"remote"`
+	Account common.Address `json:"account"`
+	Method  string         `json:"method"`
+	Reason  string         `json:"reason"`
+}
+
+// AccessPolicy authenticates the remote callers with their tokens and
+// authorizes their calls by the roles of their accounts.
+type AccessPolicy struct {
+	// Rules maps a method ("namespace_method") or the methods of a namespace
+	// ("namespace_*") to the roles allowed to call it, a method mapped to no
+	// role is disabled. The methods out of the rules are allowed to any
+	// authenticated account.
+	Rules map[string][]string
+
+	Roles RoleReader
+	Audit func(*AuditEntry) // records the denied calls, logged if nil
+
+	noncesMu   sync.Mutex
+	nonces     map[string]time.Time // used token nonces to their expiry
+	noncePrune int
+}
+
+// authorize checks whether the caller of ctx may call method. The local
+// callers, over IPC or in process, are always allowed.
+func (p *AccessPolicy) authorize(ctx context.Context, method string) error {
+	caller, ok := ctx.Value(remoteCallerKey{}).(*remoteCaller)
+	if !ok {
+		return nil
+	}
+	err := caller.err
+	if err == nil && !caller.expiry.IsZero() && !time.Now().Before(caller.expiry) {
+		err = errAuthTokenExpired
+	}
+	if err == nil {
+		err = p.authorizeAccount(caller.account, method)
+	}
+	if err != nil {
+		p.audit(&AuditEntry{
+			Time:    time.Now(),
+			Remote:  caller.remote,
+			Account: caller.account,
+			Method:  method,
+			Reason:  err.Error(),
+		})
+	}
+	return err
+}
+
+func (p *AccessPolicy) authorizeAccount(account common.Address, method string) error {
+	allowed, ok := p.Rules[method]
+	if !ok {
+		namespace := strings.SplitN(method, serviceMethodSeparator, 2)[0]
+		if allowed, ok = p.Rules[namespace+serviceMethodSeparator+"*"]; !ok {
+			return nil
+		}
+	}
+	if len(allowed) == 0 {
+		return errMethodDisabled
+	}
+	if p.Roles == nil {
+		return errNoRole
+	}
+	roles, err := p.Roles.AccountRoles(account)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		for _, a := range allowed {
+			if role == a {
+				return nil
+			}
+		}
+	}
+	return errNoRole
+}
+
+func (p *AccessPolicy) audit(entry *AuditEntry) {
+	if p.Audit != nil {
+		p.Audit(entry)
+		return
+	}
+	log.Warn("RPC call denied", "remote", entry.Remote, "account", entry.Account, "method", entry.Method, "reason", entry.Reason)
+}
@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/crypto"
+)
+
+type testRoleReader map[common.Address][]string
+
+func (r testRoleReader) AccountRoles(account common.Address) ([]string, error) {
+	return r[account], nil
+}
+
+func TestAuthToken(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	account := crypto.PubkeyToAddress(key.PublicKey)
+
+	digest := crypto.Keccak256Hash([]byte(`{"method":"test_echo"}`))
+	token, err := NewAuthToken(key, time.Minute, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := VerifyAuthToken(token, digest, time.Now()); err != nil || got.Account != account {
+		t.Fatalf("verify: got %+v, %v, want %x", got, err, account)
+	}
+	if _, err := VerifyAuthToken(token, digest, time.Now().Add(2*time.Minute)); err != errAuthTokenExpired {
+		t.Fatalf("expired token: got %v", err)
+	}
+	if _, err := VerifyAuthToken(token, crypto.Keccak256Hash([]byte(`{"method":"test_sleep"}`)), time.Now()); err != errAuthTokenDigest {
+		t.Fatalf("token of another request: got %v", err)
+	}
+
+	long, _ := NewAuthToken(key, 2*MaxAuthTokenLifetime, digest)
+	if _, err := VerifyAuthToken(long, digest, time.Now()); err != errAuthTokenTooLong {
+		t.Fatalf("long token: got %v", err)
+	}
+
+	// the payload of another token under the same signature
+	other, _ := NewAuthToken(key, 30*time.Second, digest)
+	forged := strings.Split(other, ".")[0] + "." + strings.Split(token, ".")[1]
+	for _, invalid := range []string{"", "x", "a.b.c", forged} {
+		if _, err := VerifyAuthToken(invalid, digest, time.Now()); err != errInvalidAuthToken {
+			t.Fatalf("token %q: got %v", invalid, err)
+		}
+	}
+}
+
+func TestAuthTokenReplay(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	policy := &AccessPolicy{Audit: func(*AuditEntry) {}}
+	digest := crypto.Keccak256Hash([]byte("body"))
+	token, _ := NewAuthToken(key, time.Minute, digest)
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("Authorization", authScheme+" "+token)
+	if err := policy.authorize(policy.withRemoteCaller(context.Background(), r, digest), "test_echo"); err != nil {
+		t.Fatalf("first use: got %v", err)
+	}
+	if err := policy.authorize(policy.withRemoteCaller(context.Background(), r, digest), "test_echo"); err != errAuthTokenReplay {
+		t.Fatalf("replayed token: got %v", err)
+	}
+}
+
+func TestAccessPolicy(t *testing.T) {
+	admin, user := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	policy := &AccessPolicy{
+		Rules: map[string][]string{
+			"test_*":     {"CHAIN_ADMIN"},
+			"test_echo":  {"CHAIN_ADMIN", "NODE_ADMIN"},
+			"test_sleep": {},
+			"other_rets": {"NODE_ADMIN"},
+		},
+		Roles: testRoleReader{admin: {"CHAIN_ADMIN"}, user: {"NODE_ADMIN"}},
+	}
+	var denied []*AuditEntry
+	policy.Audit = func(entry *AuditEntry) { denied = append(denied, entry) }
+
+	tests := []struct {
+		account common.Address
+		method  string
+		err     error
+	}{
+		{admin, "test_rets", nil},
+		{user, "test_rets", errNoRole},
+		{user, "test_echo", nil},
+		{admin, "test_sleep", errMethodDisabled},
+		{admin, "other_rets", errNoRole},
+		{user, "other_rets", nil},
+		{user, "other_echo", nil},
+	}
+	for _, test := range tests {
+		ctx := context.WithValue(context.Background(), remoteCallerKey{}, &remoteCaller{account: test.account})
+		if err := policy.authorize(ctx, test.method); err != test.err {
+			t.Errorf("%x calling %s: got %v, want %v", test.account, test.method, err, test.err)
+		}
+	}
+	if len(denied) != 3 {
+		t.Fatalf("got %d audit entries, want 3", len(denied))
+	}
+	if denied[0].Account != user || denied[0].Method != "test_rets" || denied[0].Reason != errNoRole.Error() {
+		t.Errorf("wrong audit entry %+v", denied[0])
+	}
+
+	// local callers aren't restricted
+	if err := policy.authorize(context.Background(), "test_sleep"); err != nil {
+		t.Errorf("local caller: got %v", err)
+	}
+
+	// the authorization of a websocket connection expires
+	ctx := context.WithValue(context.Background(), remoteCallerKey{}, &remoteCaller{account: admin, expiry: time.Now().Add(-time.Second)})
+	if err := policy.authorize(ctx, "test_rets"); err != errAuthTokenExpired {
+		t.Errorf("expired connection: got %v", err)
+	}
+}
+
+func TestHTTPAccessPolicy(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	server := newTestServer("test", new(Service))
+	server.SetAccessPolicy(&AccessPolicy{
+		Rules: map[string][]string{"test_echo": {"CHAIN_ADMIN"}},
+		Roles: testRoleReader{crypto.PubkeyToAddress(key.PublicKey): {"CHAIN_ADMIN"}},
+		Audit: func(*AuditEntry) {},
+	})
+	hs := httptest.NewServer(server)
+	defer hs.Close()
+
+	call := func(client *Client, method string, args ...interface{}) error {
+		var result interface{}
+		return client.Call(&result, method, args...)
+	}
+	admin, _ := DialHTTPWithAuth(hs.URL, key)
+	if err := call(admin, "test_echo", "hello", 10, &Args{"world"}); err != nil {
+		t.Fatalf("admin call failed: %v", err)
+	}
+	anonymous, _ := DialHTTP(hs.URL)
+	if err := call(anonymous, "test_rets"); err == nil || !strings.Contains(err.Error(), errMissingAuthToken.Error()) {
+		t.Fatalf("anonymous call: got %v", err)
+	}
+	user, _ := DialHTTPWithAuth(hs.URL, other)
+	if err := call(user, "test_rets"); err != nil {
+		t.Fatalf("user call failed: %v", err)
+	}
+	err := call(user, "test_echo", "hello", 10, &Args{"world"})
+	if e, ok := err.(Error); !ok || e.ErrorCode() != (&accessDeniedError{}).ErrorCode() {
+		t.Fatalf("user call to a restricted method: got %v", err)
+	}
+}
+
+func TestWebsocketUnsubscribeAccessPolicy(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	server := newTestServer("test", new(Service))
+	server.SetAccessPolicy(&AccessPolicy{
+		Rules: map[string][]string{"test_unsubscribe": {}},
+		Audit: func(*AuditEntry) {},
+	})
+	hs := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	defer hs.Close()
+
+	client, err := DialWebsocketWithAuth(context.Background(), "ws"+strings.TrimPrefix(hs.URL, "http"), "", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	var result interface{}
+	err = client.Call(&result, "test_unsubscribe", "0x1")
+	if e, ok := err.(Error); !ok || e.ErrorCode() != (&accessDeniedError{}).ErrorCode() {
+		t.Fatalf("unsubscribe of a disabled namespace: got %v", err)
+	}
+}
@@ -58,6 +58,18 @@ func (e *callbackError) ErrorCode() int { return -32000 }
 
 func (e *callbackError) Error() string { return e.message }
 
+// the access policy denied the call to the remote caller
+type accessDeniedError struct {
+	method string
+	err    error
+}
+
+func (e *accessDeniedError) ErrorCode() int { return -32001 }
+
+func (e *accessDeniedError) Error() string {
+	return fmt.Sprintf("access to %s denied: %v", e.method, e.err)
+}
+
 // issued when a request is received after the server is issued to stop.
 type shutdownError struct{}
 
@@ -19,6 +19,7 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,6 +32,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Venachain/Venachain/crypto"
 	"github.com/Venachain/Venachain/log"
 	"github.com/rs/cors"
 )
@@ -45,6 +47,7 @@ var nullAddr, _ = net.ResolveTCPAddr("tcp", "127.0.0.1:0")
 type httpConn struct {
 	client    *http.Client
 	req       *http.Request
+	key       *ecdsa.PrivateKey // signs the authorization token of each request, if set
 	closeOnce sync.Once
 	closed    chan struct{}
 }
@@ -115,6 +118,22 @@ func DialHTTPWithClient(endpoint string, client *http.Client) (*Client, error) {
 	})
 }
 
+// DialHTTPWithAuth creates a new RPC client that connects to an RPC server over
+// HTTP and authenticates its requests with the account of key.
+func DialHTTPWithAuth(endpoint string, key *ecdsa.PrivateKey) (*Client, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+
+	initctx := context.Background()
+	return newClient(initctx, func(context.Context) (net.Conn, error) {
+		return &httpConn{client: new(http.Client), req: req, key: key, closed: make(chan struct{})}, nil
+	})
+}
+
 // DialHTTP creates a new RPC client that connects to an RPC server over HTTP.
 func DialHTTP(endpoint string) (*Client, error) {
 	return DialHTTPWithClient(endpoint, new(http.Client))
@@ -169,6 +188,14 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 	req := hc.req.WithContext(ctx)
 	req.Body = ioutil.NopCloser(bytes.NewReader(body))
 	req.ContentLength = int64(len(body))
+	if hc.key != nil {
+		token, err := NewAuthToken(hc.key, defaultAuthTokenLifetime, crypto.Keccak256Hash(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header = req.Header.Clone()
+		req.Header.Set("Authorization", authScheme+" "+token)
+	}
 
 	resp, err := hc.client.Do(req)
 	if err != nil {
@@ -238,8 +265,17 @@ func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx = context.WithValue(ctx, "remote", r.RemoteAddr)
 	ctx = context.WithValue(ctx, "scheme", r.Proto)
 	ctx = context.WithValue(ctx, "local", r.Host)
-
 	body := io.LimitReader(r.Body, maxRequestContentLength)
+	if srv.access != nil {
+		// the token is signed for the body of the request
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctx = srv.access.withRemoteCaller(ctx, r, crypto.Keccak256Hash(data))
+		body = bytes.NewReader(data)
+	}
 	codec := NewJSONCodec(&httpReadWriteNopCloser{body, w})
 	defer codec.Close()
 
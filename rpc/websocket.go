@@ -19,6 +19,7 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
@@ -69,7 +70,15 @@ func (srv *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			decoder := func(v interface{}) error {
 				return websocketJSONCodec.Receive(conn, v)
 			}
-			srv.ServeCodec(NewCodec(conn, encoder, decoder), OptionMethodInvocation|OptionSubscriptions)
+			// the token of the handshake authenticates the connection until
+			// it expires
+			ctx := context.Background()
+			if srv.access != nil {
+				ctx = srv.access.withRemoteCaller(ctx, conn.Request(), wsAuthDigest)
+			}
+			codec := NewCodec(conn, encoder, decoder)
+			defer codec.Close()
+			srv.serveRequest(ctx, codec, false, OptionMethodInvocation|OptionSubscriptions)
 		},
 	}
 }
@@ -160,6 +169,26 @@ func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error
 	})
 }
 
+// DialWebsocketWithAuth creates a new RPC client that communicates with a JSON-RPC
+// server listening on the given endpoint, authenticated with the account of key.
+// The server denies the calls once the authorization expired, after 30 minutes,
+// the client must reconnect.
+func DialWebsocketWithAuth(ctx context.Context, endpoint, origin string, key *ecdsa.PrivateKey) (*Client, error) {
+	config, err := wsGetConfig(endpoint, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(ctx, func(ctx context.Context) (net.Conn, error) {
+		token, err := NewAuthToken(key, wsAuthTokenLifetime, wsAuthDigest)
+		if err != nil {
+			return nil, err
+		}
+		config.Header.Set("Authorization", authScheme+" "+token)
+		return wsDialContext(ctx, config)
+	})
+}
+
 func wsDialContext(ctx context.Context, config *websocket.Config) (*websocket.Conn, error) {
 	var conn net.Conn
 	var err error
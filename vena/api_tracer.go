@@ -50,6 +50,9 @@ const (
 	// and reexecute to produce missing historical state necessary to run a specific
 	// trace.
 	defaultTraceReexec = uint64(128)
+
+	// wasmTracerName selects the native tracer of WASM contract executions
+	wasmTracerName = "wasmTracer"
 )
 
 // TraceConfig holds extra parameters to trace functions.
@@ -571,6 +574,9 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message core.Message, v
 		err    error
 	)
 	switch {
+	case config != nil && config.Tracer != nil && *config.Tracer == wasmTracerName:
+		tracer = vm.NewWasmTraceLogger()
+
 	case config != nil && config.Tracer != nil:
 		// Define a meaningful timeout of a single transaction trace
 		timeout := defaultTraceTimeout
@@ -617,6 +623,9 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message core.Message, v
 	case *tracers.Tracer:
 		return tracer.GetResult()
 
+	case *vm.WasmTraceLogger:
+		return tracer.GetResult(), nil
+
 	default:
 		panic(fmt.Sprintf("bad tracer type %T", tracer))
 	}
@@ -83,6 +83,7 @@ type Ethereum struct {
 
 	// Handlers
 	txPool          *core.TxPool
+	preExecutor     *core.TxPreExecutor
 	blockchain      *core.BlockChain
 	protocolManager *ProtocolManager
 	lesServer       LesServer
@@ -109,6 +110,8 @@ type Ethereum struct {
 	networkID     uint64
 	netRPCService *venaapi.PublicNetAPI
 
+	groups map[uint64]*groupChain // Group ledgers hosted next to the main chain
+
 	lock sync.RWMutex // Protects the variadic fields (e.g. gas price and etherbase)
 }
 
@@ -185,6 +188,7 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 		EnablePreimageRecording: config.EnablePreimageRecording,
 		EWASMInterpreter:        config.EWASMInterpreter,
 		EVMInterpreter:          config.EVMInterpreter,
+		WasmCompiled:            config.WasmCompiled,
 	}
 	cacheConfig := &core.CacheConfig{Disabled: config.NoPruning, TrieNodeLimit: config.TrieCache, TrieTimeLimit: config.TrieTimeout}
 	common.SetCurrentInterpreterType(chainConfig.VMInterpreter)
@@ -222,6 +226,11 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 	//eth.txPool = core.NewTxPool(config.TxPool, eth.chainConfig, eth.blockchain)
 	eth.txPool = core.NewTxPool(config.TxPool, eth.chainConfig, blockChainCache, chainDb, eth.extDb, ctx.NodeKey())
 	log.Debug("Transaction pool info", "pool", eth.txPool)
+	if config.PreExecute {
+		if eth.preExecutor, err = core.NewTxPreExecutor(eth.blockchain, eth.txPool); err != nil {
+			return nil, err
+		}
+	}
 
 	recommit := config.MinerRecommit
 	eth.miner = miner.New(eth, eth.chainConfig, eth.EventMux(), eth.engine, recommit, config.MinerGasFloor, config.MinerGasCeil, eth.isLocalBlock, highestLogicalBlockCh, blockChainCache)
@@ -232,6 +241,18 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 		return nil, err
 	}
 
+	eth.groups = make(map[uint64]*groupChain, len(config.Groups))
+	for _, groupID := range config.Groups {
+		if _, ok := eth.groups[groupID]; ok {
+			continue
+		}
+		gc, err := newGroupChain(ctx, eth, groupID)
+		if err != nil {
+			return nil, err
+		}
+		eth.groups[groupID] = gc
+	}
+
 	if chainConfig.LicenseCheck {
 		log.Info("license", "enable", chainConfig.LicenseCheck)
 		log.Info("Start license check right now.")
@@ -389,6 +410,11 @@ func (s *Ethereum) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   s.netRPCService,
 			Public:    true,
+		}, {
+			Namespace: "group",
+			Version:   "1.0",
+			Service:   NewPublicGroupAPI(s),
+			Public:    true,
 		},
 	}...)
 }
@@ -546,13 +572,30 @@ func (s *Ethereum) EthVersion() int                    { return int(s.protocolMa
 func (s *Ethereum) NetVersion() uint64                 { return s.networkID }
 func (s *Ethereum) Downloader() *downloader.Downloader { return s.protocolManager.downloader }
 
+// AccountRoles implements rpc.RoleReader, returning the roles of the account in
+// the user management contract at the head of the chain.
+func (s *Ethereum) AccountRoles(account common.Address) ([]string, error) {
+	head := s.blockchain.CurrentBlock()
+	state, err := s.blockchain.StateAt(head.Root())
+	if err != nil {
+		return nil, err
+	}
+	return vm.GetUserRoles(state, account, head.Number())
+}
+
 // Protocols implements node.Service, returning all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
+	protos := append([]p2p.Protocol{}, s.protocolManager.SubProtocols...)
+	for _, groupID := range s.config.Groups {
+		if gc, ok := s.groups[groupID]; ok {
+			protos = append(protos, gc.protocolManager.SubProtocols...)
+		}
+	}
 	if s.lesServer == nil {
-		return s.protocolManager.SubProtocols
+		return protos
 	}
-	return append(s.protocolManager.SubProtocols, s.lesServer.Protocols()...)
+	return append(protos, s.lesServer.Protocols()...)
 }
 
 // Start implements node.Service, starting all internal goroutines needed by the
@@ -586,12 +629,20 @@ func (s *Ethereum) Start(srvr *p2p.Server) error {
 	if s.lesServer != nil {
 		s.lesServer.Start(srvr)
 	}
+
+	etherbase, _ := s.Etherbase()
+	for _, gc := range s.groups {
+		gc.start(maxPeers, etherbase)
+	}
 	return nil
 }
 
 // Stop implements node.Service, terminating all internal goroutines used by the
 // Ethereum protocol.
 func (s *Ethereum) Stop() error {
+	for _, gc := range s.groups {
+		gc.stop()
+	}
 	s.bloomIndexer.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
@@ -599,6 +650,9 @@ func (s *Ethereum) Stop() error {
 	if s.lesServer != nil {
 		s.lesServer.Stop()
 	}
+	if s.preExecutor != nil {
+		s.preExecutor.Stop()
+	}
 	s.txPool.Stop()
 	s.miner.Stop()
 	s.eventMux.Stop()
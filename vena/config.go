@@ -97,8 +97,15 @@ type Config struct {
 	EWASMInterpreter string
 	// Type of the EVM interpreter ("" for default)
 	EVMInterpreter string
+	// Execute the WASM contracts with the closure compiled executor
+	WasmCompiled bool
 	// Type of parallel process transactions
 	ParallelSize int
+	// Speculatively execute the pending transactions for the parallel processing
+	PreExecute bool
+
+	// Groups lists the GroupManagement groups whose ledgers this node hosts
+	Groups []uint64 `toml:",omitempty"`
 }
 
 type configMarshaling struct {
@@ -73,6 +73,9 @@ type downloadTester struct {
 
 	peerMissingStates map[string]map[common.Hash]bool // State entries that fast sync should not return
 
+	forgedSeals  map[common.Hash]bool // Headers whose seals the snapshot sync rejects
+	forgedPivots map[common.Hash]bool // Pivot blocks whose states the snapshot sync rejects
+
 	lock sync.RWMutex
 }
 
@@ -123,6 +126,8 @@ func newTester() *downloadTester {
 		peerReceipts:      make(map[string]map[common.Hash]types.Receipts),
 		peerChainTds:      make(map[string]map[common.Hash]*big.Int),
 		peerMissingStates: make(map[string]map[common.Hash]bool),
+		forgedSeals:       make(map[common.Hash]bool),
+		forgedPivots:      make(map[common.Hash]bool),
 	}
 	tester.stateDb = memorydb.NewMemDatabase()
 	tester.stateDb.Put(genesis.Root().Bytes(), []byte{0x00})
@@ -321,6 +326,21 @@ func (dl *downloadTester) FastSyncCommitHead(hash common.Hash) error {
 	return fmt.Errorf("non existent block: %x", hash[:4])
 }
 
+// VerifySnapPivot checks that the header extends a known pivot block whose
+// state isn't forged.
+func (dl *downloadTester) VerifySnapPivot(header *types.Header) error {
+	if dl.GetHeaderByHash(header.ParentHash) == nil {
+		return fmt.Errorf("unknown pivot block: %x", header.ParentHash[:4])
+	}
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.forgedPivots[header.ParentHash] {
+		return fmt.Errorf("forged pivot state: %x", header.ParentHash[:4])
+	}
+	return nil
+}
+
 // GetTd retrieves the block's total difficulty from the canonical chain.
 func (dl *downloadTester) GetTd(hash common.Hash, number uint64) *big.Int {
 	dl.lock.RLock()
@@ -358,6 +378,21 @@ func (dl *downloadTester) InsertHeaderChain(headers []*types.Header, checkFreq i
 	return len(headers), nil
 }
 
+// InsertSnapHeaderChain rejects the headers with forged seals, and injects the
+// others into the simulated chain.
+func (dl *downloadTester) InsertSnapHeaderChain(headers []*types.Header) (int, error) {
+	dl.lock.RLock()
+	for i, header := range headers {
+		if dl.forgedSeals[header.Hash()] {
+			dl.lock.RUnlock()
+			return i, fmt.Errorf("forged seals: %x", header.Hash().Bytes()[:4])
+		}
+	}
+	dl.lock.RUnlock()
+
+	return dl.InsertHeaderChain(headers, 1)
+}
+
 // InsertChain injects a new batch of blocks into the simulated chain.
 func (dl *downloadTester) InsertChain(blocks types.Blocks) (int, error) {
 	dl.lock.Lock()
@@ -573,14 +608,14 @@ func (dlp *downloadTesterPeer) RequestBodies(hashes []common.Hash) error {
 
 	blocks := dlp.dl.peerBlocks[dlp.id]
 
-	transactions := make([][]*types.Transaction, 0, len(hashes))
+	bodies := make([]*types.Body, 0, len(hashes))
 
 	for _, hash := range hashes {
 		if block, ok := blocks[hash]; ok {
-			transactions = append(transactions, block.Transactions())
+			bodies = append(bodies, block.Body())
 		}
 	}
-	go dlp.dl.downloader.DeliverBodies(dlp.id, transactions)
+	go dlp.dl.downloader.DeliverBodies(dlp.id, bodies)
 
 	return nil
 }
@@ -698,6 +733,7 @@ func assertOwnForkedChain(t *testing.T, tester *downloadTester, common int, leng
 func TestCanonicalSynchronisationFull(t *testing.T)  { testCanonicalSynchronisation(t, 1, FullSync) }
 func TestCanonicalSynchronisationFast(t *testing.T)  { testCanonicalSynchronisation(t, 1, FastSync) }
 func TestCanonicalSynchronisationLight(t *testing.T) { testCanonicalSynchronisation(t, 1, LightSync) }
+func TestCanonicalSynchronisationSnap(t *testing.T)  { testCanonicalSynchronisation(t, 1, SnapSync) }
 
 func testCanonicalSynchronisation(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
@@ -718,6 +754,60 @@ func testCanonicalSynchronisation(t *testing.T, protocol int, mode SyncMode) {
 	assertOwnChain(t, tester, targetBlocks+1)
 }
 
+// Tests that a snapshot sync stops on a pivot whose state doesn't carry the
+// verified validators, without committing the pivot or anything after it.
+func TestForgedPivotSnap(t *testing.T) {
+	t.Parallel()
+
+	tester := newTester()
+	defer tester.terminate()
+
+	targetBlocks := blockCacheItems - 15
+	hashes, headers, blocks, receipts := tester.makeChain(targetBlocks, 0, tester.genesis, nil, false)
+	pivot := targetBlocks - fsMinFullBlocks
+	tester.forgedPivots[hashes[len(hashes)-1-pivot]] = true
+
+	tester.newPeer("peer", 1, hashes, headers, blocks, receipts)
+	if err := tester.sync("peer", nil, SnapSync); err != errInvalidPivot {
+		t.Fatalf("synchronisation error mismatch: have %v, want %v", err, errInvalidPivot)
+	}
+	if head := tester.CurrentFastBlock().NumberU64(); head >= uint64(pivot) {
+		t.Fatalf("blocks committed from the forged pivot on: head %d, pivot %d", head, pivot)
+	}
+	for _, hash := range hashes[:len(hashes)-1-pivot] {
+		if _, ok := tester.ownReceipts[hash]; ok {
+			t.Fatalf("receipts committed from the forged pivot on: %x", hash[:4])
+		}
+	}
+}
+
+// Tests that a snapshot sync stops on a header with forged seals, without
+// inserting it or committing anything on it.
+func TestForgedSealsSnap(t *testing.T) {
+	t.Parallel()
+
+	tester := newTester()
+	defer tester.terminate()
+
+	targetBlocks := blockCacheItems - 15
+	hashes, headers, blocks, receipts := tester.makeChain(targetBlocks, 0, tester.genesis, nil, false)
+	forged := targetBlocks / 2
+	tester.forgedSeals[hashes[len(hashes)-1-forged]] = true
+
+	tester.newPeer("peer", 1, hashes, headers, blocks, receipts)
+	if err := tester.sync("peer", nil, SnapSync); err != errInvalidChain {
+		t.Fatalf("synchronisation error mismatch: have %v, want %v", err, errInvalidChain)
+	}
+	for _, hash := range hashes[:len(hashes)-1-forged] {
+		if _, ok := tester.ownHeaders[hash]; ok {
+			t.Fatalf("header inserted from the forged one on: %x", hash[:4])
+		}
+	}
+	if head := tester.CurrentFastBlock().NumberU64(); head >= uint64(forged) {
+		t.Fatalf("blocks committed from the forged header on: head %d, forged %d", head, forged)
+	}
+}
+
 // Tests that if a large batch of blocks are being downloaded, it is throttled
 // until the cached blocks are retrieved.
 func TestThrottlingFull(t *testing.T) { testThrottling(t, 1, FullSync) }
@@ -812,7 +902,7 @@ func TestInactiveDownloader(t *testing.T) {
 	if err := tester.downloader.DeliverHeaders("bad peer", []*types.Header{}); err != errNoSyncActive {
 		t.Errorf("error mismatch: have %v, want %v", err, errNoSyncActive)
 	}
-	if err := tester.downloader.DeliverBodies("bad peer", [][]*types.Transaction{}); err != errNoSyncActive {
+	if err := tester.downloader.DeliverBodies("bad peer", []*types.Body{}); err != errNoSyncActive {
 		t.Errorf("error mismatch: have %v, want %v", err, errNoSyncActive)
 	}
 	if err := tester.downloader.DeliverReceipts("bad peer", [][]*types.Receipt{}); err != errNoSyncActive {
@@ -0,0 +1,225 @@
+package vena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/common/hexutil"
+	"github.com/Venachain/Venachain/consensus"
+	"github.com/Venachain/Venachain/core"
+	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/core/vm"
+	"github.com/Venachain/Venachain/event"
+	"github.com/Venachain/Venachain/log"
+	"github.com/Venachain/Venachain/miner"
+	"github.com/Venachain/Venachain/node"
+	"github.com/Venachain/Venachain/params"
+	"github.com/Venachain/Venachain/rlp"
+	"github.com/Venachain/Venachain/venadb/dbhandle"
+)
+
+var errUnknownGroup = errors.New("group ledger not hosted by this node")
+
+// groupProtocolName returns the name of the vena protocol instance serving
+// the ledger of the given group.
+func groupProtocolName(groupID uint64) string {
+	return fmt.Sprintf("%s-g%d", ProtocolNameArr[0], groupID)
+}
+
+// groupDataDir returns the directory, relative to the instance directory,
+// holding the databases of the given group ledger.
+func groupDataDir(groupID uint64) string {
+	return fmt.Sprintf("groups/%d", groupID)
+}
+
+// groupChain is the ledger of a GroupManagement group hosted next to the main
+// chain. It shares the p2p server of the node, but has its own genesis,
+// database, consensus engine, validator set and protocol instance.
+type groupChain struct {
+	id          uint64
+	chainConfig *params.ChainConfig
+	sysConfig   *common.SystemConfig
+
+	chainDb dbhandle.Database
+	extDb   dbhandle.Database
+
+	eventMux        *event.TypeMux
+	engine          consensus.Engine
+	blockchain      *core.BlockChain
+	txPool          *core.TxPool
+	protocolManager *ProtocolManager
+	miner           *miner.Miner
+}
+
+// newGroupChain sets up the ledger of the given group, the group record is
+// read from the current state of the main chain.
+func newGroupChain(ctx *node.ServiceContext, eth *Ethereum, groupID uint64) (*groupChain, error) {
+	mainState, err := eth.blockchain.State()
+	if err != nil {
+		return nil, err
+	}
+	group, err := vm.GetGroupInfo(mainState, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load group %d: %v", groupID, err)
+	}
+	genesis, err := core.DeriveGroupGenesis(eth.chainConfig, eth.blockchain.Genesis().Header(), group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive genesis of group %d: %v", groupID, err)
+	}
+
+	config := eth.config
+	chainDb, err := CreateDB(ctx, config, groupDataDir(groupID)+"/chaindata")
+	if err != nil {
+		return nil, err
+	}
+	extDb, err := CreateExtDB(ctx, config, groupDataDir(groupID)+"/extdb")
+	if err != nil {
+		return nil, err
+	}
+	chainConfig, genesisHash, err := core.SetupGenesisBlock(chainDb, genesis)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Initialised group ledger", "group", groupID, "genesis", genesisHash, "config", chainConfig)
+
+	gc := &groupChain{
+		id:          groupID,
+		chainConfig: chainConfig,
+		sysConfig:   common.NewSystemConfig(),
+		chainDb:     chainDb,
+		extDb:       extDb,
+		eventMux:    new(event.TypeMux),
+		engine:      CreateConsensusEngine(ctx, chainConfig, chainDb),
+	}
+	// the node set and parameters of the group ledger must not leak into the
+	// main chain config, neither through the chain nor through the engine
+	if iris, ok := gc.engine.(consensus.Iris); ok {
+		iris.SetSystemConfig(gc.sysConfig)
+	}
+
+	vmConfig := vm.Config{
+		EnablePreimageRecording: config.EnablePreimageRecording,
+		EWASMInterpreter:        config.EWASMInterpreter,
+		EVMInterpreter:          config.EVMInterpreter,
+		WasmCompiled:            config.WasmCompiled,
+	}
+	cacheConfig := &core.CacheConfig{Disabled: config.NoPruning, TrieNodeLimit: config.TrieCache, TrieTimeLimit: config.TrieTimeout}
+	if gc.blockchain, _, err = core.NewBlockChainWithSystemConfig(chainDb, extDb, cacheConfig, chainConfig, gc.engine, vmConfig, nil, gc.sysConfig); err != nil {
+		return nil, err
+	}
+	blockChainCache := core.NewBlockChainCache(gc.blockchain)
+
+	txPoolConfig := config.TxPool
+	txPoolConfig.Journal = ""
+	gc.txPool = core.NewTxPool(txPoolConfig, chainConfig, blockChainCache, chainDb, extDb, ctx.NodeKey())
+
+	gc.miner = miner.New(gc, chainConfig, gc.eventMux, gc.engine, config.MinerRecommit, config.MinerGasFloor, config.MinerGasCeil, nil, make(chan *types.Block), blockChainCache)
+	gc.miner.SetExtra(makeExtraData(config.MinerExtraData))
+
+	if gc.protocolManager, err = NewProtocolManager(chainConfig, config.SyncMode, config.NetworkId, gc.eventMux, gc.txPool, gc.engine, gc.blockchain, chainDb); err != nil {
+		return nil, err
+	}
+	for i := range gc.protocolManager.SubProtocols {
+		gc.protocolManager.SubProtocols[i].Name = groupProtocolName(groupID)
+	}
+	return gc, nil
+}
+
+func (gc *groupChain) BlockChain() *core.BlockChain  { return gc.blockchain }
+func (gc *groupChain) TxPool() *core.TxPool          { return gc.txPool }
+func (gc *groupChain) ExtendedDb() dbhandle.Database { return gc.extDb }
+
+// start launches the protocol handler and the miner of the group ledger.
+func (gc *groupChain) start(maxPeers int, etherbase common.Address) {
+	gc.protocolManager.Start(maxPeers)
+	atomic.StoreUint32(&gc.protocolManager.acceptTxs, 1)
+	go gc.miner.Start(etherbase)
+}
+
+// stop terminates the group ledger and closes its databases.
+func (gc *groupChain) stop() {
+	gc.blockchain.Stop()
+	gc.engine.Close()
+	gc.protocolManager.Stop()
+	gc.txPool.Stop()
+	gc.miner.Stop()
+	gc.eventMux.Stop()
+
+	gc.chainDb.Close()
+	gc.extDb.Close()
+}
+
+// GroupChainInfo describes a group ledger hosted by this node.
+type GroupChainInfo struct {
+	GroupID  uint64         `json:"groupID"`
+	ChainID  *hexutil.Big   `json:"chainId"`
+	Protocol string         `json:"protocol"`
+	Genesis  common.Hash    `json:"genesis"`
+	Head     common.Hash    `json:"head"`
+	Number   hexutil.Uint64 `json:"number"`
+	Peers    int            `json:"peers"`
+}
+
+// PublicGroupAPI provides access to the group ledgers hosted by this node.
+type PublicGroupAPI struct {
+	e *Ethereum
+}
+
+// NewPublicGroupAPI creates a new group ledger API.
+func NewPublicGroupAPI(e *Ethereum) *PublicGroupAPI {
+	return &PublicGroupAPI{e}
+}
+
+// Groups returns the group ledgers hosted by this node.
+func (api *PublicGroupAPI) Groups() []GroupChainInfo {
+	infos := make([]GroupChainInfo, 0, len(api.e.groups))
+	for _, id := range api.e.config.Groups {
+		if gc, ok := api.e.groups[id]; ok {
+			infos = append(infos, gc.info())
+		}
+	}
+	return infos
+}
+
+// BlockNumber returns the head block number of the given group ledger.
+func (api *PublicGroupAPI) BlockNumber(groupID uint64) (hexutil.Uint64, error) {
+	gc, ok := api.e.groups[groupID]
+	if !ok {
+		return 0, errUnknownGroup
+	}
+	return hexutil.Uint64(gc.blockchain.CurrentBlock().NumberU64()), nil
+}
+
+// SendRawTransaction adds a signed transaction to the pool of the given group
+// ledger and returns its hash.
+func (api *PublicGroupAPI) SendRawTransaction(ctx context.Context, groupID uint64, encodedTx hexutil.Bytes) (common.Hash, error) {
+	gc, ok := api.e.groups[groupID]
+	if !ok {
+		return common.Hash{}, errUnknownGroup
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := gc.txPool.AddLocal(tx); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Submitted group transaction", "group", groupID, "fullhash", tx.Hash().Hex())
+	return tx.Hash(), nil
+}
+
+func (gc *groupChain) info() GroupChainInfo {
+	head := gc.blockchain.CurrentBlock()
+	return GroupChainInfo{
+		GroupID:  gc.id,
+		ChainID:  (*hexutil.Big)(gc.chainConfig.ChainID),
+		Protocol: groupProtocolName(gc.id),
+		Genesis:  gc.blockchain.Genesis().Hash(),
+		Head:     head.Hash(),
+		Number:   hexutil.Uint64(head.NumberU64()),
+		Peers:    gc.protocolManager.peers.Len(),
+	}
+}
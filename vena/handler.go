@@ -72,6 +72,7 @@ type ProtocolManager struct {
 	networkID uint64
 
 	fastSync  uint32 // Flag whether fast sync is enabled (gets disabled if we already have blocks)
+	snapSync  uint32 // Flag whether the fast sync verifies its pivot as a snapshot sync
 	acceptTxs uint32 // Flag whether we're considered synchronised (enables transaction processing)
 
 	txpool      txPool
@@ -106,6 +107,11 @@ type ProtocolManager struct {
 	wg sync.WaitGroup
 
 	engine consensus.Engine
+
+	// the node set the types of the peers are looked up in, the one of the
+	// chain served, a group ledger has its own
+	sysConfig  *common.SystemConfig
+	nodeSetSub event.Subscription
 }
 
 // NewProtocolManager returns a new Ethereum sub protocol manager. The Ethereum sub protocol manages peers capable
@@ -125,6 +131,7 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 		txsyncCh:    make(chan *txsync),
 		quitSync:    make(chan struct{}),
 		engine:      engine,
+		sysConfig:   blockchain.SystemConfig(),
 	}
 
 	if handler, ok := manager.engine.(consensus.Handler); ok {
@@ -132,13 +139,16 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 	}
 
 	// Figure out whether to allow fast sync or not
-	if mode == downloader.FastSync && blockchain.CurrentBlock().NumberU64() > 0 {
-		log.Warn("Blockchain not empty, fast sync disabled")
+	if (mode == downloader.FastSync || mode == downloader.SnapSync) && blockchain.CurrentBlock().NumberU64() > 0 {
+		log.Warn("Blockchain not empty, fast sync disabled", "mode", mode)
 		mode = downloader.FullSync
 	}
-	if mode == downloader.FastSync {
+	if mode == downloader.FastSync || mode == downloader.SnapSync {
 		manager.fastSync = uint32(1)
 	}
+	if mode == downloader.SnapSync {
+		manager.snapSync = uint32(1)
+	}
 	// Initiate a sub-protocol for every implemented version we can handle
 	manager.SubProtocols = make([]p2p.Protocol, 0, len(ProtocolVersions))
 	for i, version := range ProtocolVersions {
@@ -168,13 +178,16 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 				}
 				return nil
 			},
-			UpdatePeer: func(info *common.NodeInfo) {
-				id := info.PublicKey[:16]
-				if p := manager.peers.Peer(id); p != nil {
-					p.setTypes(info.Types)
-				}
-			},
 		})
+		// the p2p server posts the changes of the main chain node set, the
+		// group ledgers follow their own in nodeSetLoop
+		if manager.sysConfig == common.SysCfg {
+			manager.SubProtocols[len(manager.SubProtocols)-1].UpdatePeer = func(info *common.NodeInfo) {
+				if p := manager.peers.Peer(info.PublicKey[:16]); p != nil {
+					p.updateTypes(info.Types)
+				}
+			}
+		}
 	}
 	if len(manager.SubProtocols) == 0 {
 		return nil, errIncompatibleConfig
@@ -250,6 +263,37 @@ func (pm *ProtocolManager) Start(maxPeers int) {
 	// start sync handlers
 	go pm.syncer()
 	go pm.txsyncLoop()
+
+	if pm.sysConfig != common.SysCfg {
+		changes := make(chan common.NodeSetChange, 16)
+		pm.nodeSetSub = pm.sysConfig.SubscribeNodeSetChange(changes)
+		go pm.nodeSetLoop(changes)
+	}
+}
+
+// nodeSetLoop applies the changes of the node set of a group ledger to the
+// types of its peers.
+func (pm *ProtocolManager) nodeSetLoop(changes chan common.NodeSetChange) {
+	for {
+		select {
+		case <-changes:
+			pm.updatePeerTypes()
+		case <-pm.nodeSetSub.Err():
+			return
+		}
+	}
+}
+
+// updatePeerTypes looks the types of the peers up again in the node set, the
+// nodes disabled or removed are no longer registered.
+func (pm *ProtocolManager) updatePeerTypes() {
+	for _, p := range pm.peers.Peers() {
+		var types int32
+		if info := pm.sysConfig.GetNodeInfo(p.ID().String()); info != nil && info.Status == 1 {
+			types = info.Types
+		}
+		p.updateTypes(types)
+	}
 }
 
 func (pm *ProtocolManager) Stop() {
@@ -257,6 +301,9 @@ func (pm *ProtocolManager) Stop() {
 
 	pm.txsSub.Unsubscribe()        // quits txBroadcastLoop
 	pm.minedBlockSub.Unsubscribe() // quits blockBroadcastLoop
+	if pm.nodeSetSub != nil {
+		pm.nodeSetSub.Unsubscribe() // quits nodeSetLoop
+	}
 
 	// Quit the sync loop.
 	// After this send has completed, no new peers will be accepted.
@@ -278,13 +325,15 @@ func (pm *ProtocolManager) Stop() {
 }
 
 func (pm *ProtocolManager) newPeer(pv int, p *p2p.Peer, rw p2p.MsgReadWriter) *peer {
-	return newPeer(pv, p, newMeteredMsgWriter(rw))
+	peer := newPeer(pv, p, newMeteredMsgWriter(rw))
+	peer.setTypes(pm.sysConfig.GetNodeTypes(p.ID().String()))
+	return peer
 }
 
 // handle is the callback invoked to manage the life cycle of an eth peer. When
 // this function terminates, the peer is disconnected.
 func (pm *ProtocolManager) handle(p *peer) error {
-	if p.types == int32(vm.NodeTypeLight) {
+	if p.nodeType() == int32(vm.NodeTypeLight) {
 		return errors.New("light node imitates full/fast node, deny connection")
 	}
 
@@ -376,6 +425,13 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 	}
 	defer msg.Discard()
 
+	// Only the registered consensus nodes take part in the consensus, the
+	// observers and the unregistered nodes just receive the blocks
+	if (msg.Code == IstanbulMsg || msg.Code == PrepareBlockMsg) && !p.IsConsensus() {
+		p.Log().Debug("Discard consensus message from non consensus node", "code", msg.Code, "type", p.nodeType())
+		return nil
+	}
+
 	if handler, ok := pm.engine.(consensus.Handler); ok {
 		pubKey, err := p.ID().Pubkey()
 		if err != nil {
@@ -725,6 +781,10 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		pm.txFetcher.Notify(p.id, hashes)
 
 	case msg.Code == GetPooledTxMsg:
+		// The transactions are gossiped among the consensus nodes only
+		if p.IsObserver() {
+			return p.SendPooledTransactionsRLP(nil, nil)
+		}
 		// Decode the retrieval message
 		msgStream := rlp.NewStream(msg.Payload, uint64(msg.Size))
 		if _, err := msgStream.List(); err != nil {
@@ -0,0 +1,100 @@
+package vena
+
+import (
+	"testing"
+
+	"github.com/Venachain/Venachain/common"
+	"github.com/Venachain/Venachain/consensus"
+	"github.com/Venachain/Venachain/crypto"
+	"github.com/Venachain/Venachain/p2p"
+	"github.com/Venachain/Venachain/p2p/discover"
+)
+
+// testHandlerEngine is a consensus engine recording the messages it's handed
+type testHandlerEngine struct {
+	consensus.Engine
+	handled []uint64
+}
+
+func (e *testHandlerEngine) NewChainHead() error                  { return nil }
+func (e *testHandlerEngine) SetBroadcaster(consensus.Broadcaster) {}
+
+func (e *testHandlerEngine) HandleMsg(address common.Address, msg p2p.Msg) (bool, error) {
+	e.handled = append(e.handled, msg.Code)
+	return true, nil
+}
+
+// Tests that only the registered consensus nodes get their consensus messages
+// through to the engine.
+func TestConsensusMsgFromNodeTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		types   int32
+		handled bool
+	}{
+		{"unregistered", 0, false},
+		{"consensus", 1, true},
+		{"observer", 2, false},
+	}
+	for _, tt := range tests {
+		for _, code := range []uint64{IstanbulMsg, PrepareBlockMsg} {
+			engine := new(testHandlerEngine)
+			pm := &ProtocolManager{engine: engine, sysConfig: common.NewSystemConfig()}
+
+			key, _ := crypto.GenerateKey()
+			app, net := p2p.MsgPipe()
+			p := pm.newPeer(int(venachainV1), p2p.NewPeer(discover.PubkeyID(&key.PublicKey), tt.name, nil), net)
+			p.setTypes(tt.types)
+
+			go p2p.Send(app, code, []byte{0x01})
+			if err := pm.handleMsg(p); err != nil {
+				t.Fatalf("%s peer, code %#x: unexpected error: %v", tt.name, code, err)
+			}
+			if handled := len(engine.handled) == 1 && engine.handled[0] == code; handled != tt.handled {
+				t.Errorf("%s peer, code %#x: handled %v, want %v", tt.name, code, handled, tt.handled)
+			}
+			app.Close()
+		}
+	}
+}
+
+// Tests that the peers of a group ledger take their types from the node set of
+// the group, not from the one of the main chain.
+func TestConsensusMsgFromGroupNode(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	id := discover.PubkeyID(&key.PublicKey)
+	if common.SysCfg.GetNodeTypes(id.String()) != 0 {
+		t.Fatal("group node registered on the main chain")
+	}
+	sysConfig := common.NewSystemConfig()
+	sysConfig.SetNodes([]common.NodeInfo{{PublicKey: id.String(), Types: 1, Status: 1}})
+
+	engine := new(testHandlerEngine)
+	pm := &ProtocolManager{engine: engine, sysConfig: sysConfig, peers: newPeerSet()}
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	p := pm.newPeer(int(venachainV1), p2p.NewPeer(id, "group", nil), net)
+	if err := pm.peers.Register(p, func(string) {}); err != nil {
+		t.Fatal(err)
+	}
+	defer p.close()
+
+	go p2p.Send(app, IstanbulMsg, []byte{0x01})
+	if err := pm.handleMsg(p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(engine.handled) != 1 || engine.handled[0] != IstanbulMsg {
+		t.Fatalf("group consensus message not handled: %v", engine.handled)
+	}
+
+	// the node turned into an observer of the group is no longer heard
+	sysConfig.SetNodes([]common.NodeInfo{{PublicKey: id.String(), Types: 2, Status: 1}})
+	pm.updatePeerTypes()
+	go p2p.Send(app, IstanbulMsg, []byte{0x01})
+	if err := pm.handleMsg(p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(engine.handled) != 1 {
+		t.Fatalf("message of group observer handled: %v", engine.handled)
+	}
+}
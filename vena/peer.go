@@ -22,12 +22,14 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Venachain/Venachain/consensus"
 
 	"github.com/Venachain/Venachain/common"
 	"github.com/Venachain/Venachain/core/types"
+	"github.com/Venachain/Venachain/core/vm"
 	"github.com/Venachain/Venachain/p2p"
 	"github.com/Venachain/Venachain/rlp"
 	mapset "github.com/deckarep/golang-set"
@@ -109,7 +111,7 @@ type peer struct {
 	queuedAnns         chan *types.Block         // Queue of blocks to announce to the peer
 	term               chan struct{}             // Termination channel to stop the broadcaster
 	queuedPreBlock     chan *preBlockEvent
-	types              int32 // remote node's types   consensus(1) / observer(0)
+	types              int32 // remote node's types   consensus(1) / observer(2) / light(3), accessed atomically
 	replayParam        common.ReplayParam
 }
 
@@ -127,7 +129,6 @@ func newPeer(version int, p *p2p.Peer, rw p2p.MsgReadWriter) *peer {
 		queuedAnns:     make(chan *types.Block, maxQueuedAnns),
 		term:           make(chan struct{}),
 		queuedPreBlock: make(chan *preBlockEvent, maxQueuedPreBlock),
-		types:          common.SysCfg.GetNodeTypes(p.ID().String()),
 	}
 }
 
@@ -210,11 +211,33 @@ func (p *peer) close() {
 }
 
 func (p *peer) setTypes(types int32) {
-	p.types = types
+	atomic.StoreInt32(&p.types, types)
+}
+
+// updateTypes applies a registration change of the remote node, the light
+// nodes are served by the les protocol only.
+func (p *peer) updateTypes(types int32) {
+	p.setTypes(types)
+	if types == int32(vm.NodeTypeLight) {
+		p.Log().Info("Peer registered as a light node, disconnecting")
+		p.Disconnect(p2p.DiscUselessPeer)
+	}
+}
+
+// nodeType returns the type the remote node is registered with, 0 if it isn't
+// registered.
+func (p *peer) nodeType() int32 {
+	return atomic.LoadInt32(&p.types)
 }
 
 func (p *peer) IsConsensus() bool {
-	return p.types == 1
+	return p.nodeType() == 1
+}
+
+// IsObserver checks whether the remote node is registered as an observer,
+// which receives the blocks but takes no part in the consensus.
+func (p *peer) IsObserver() bool {
+	return p.nodeType() == 2
 }
 
 // Info gathers and returns a collection of metadata known about a peer.
@@ -61,3 +61,7 @@ func (s *senderFromServer) SignatureValues(tx *types.Transaction, sig []byte) (R
 func (s *senderFromServer) SignatureAndSender(tx *types.Transaction) (common.Address, []byte, error) {
 	panic("can't SignatureAndSender with senderFromServer")
 }
+
+func (s *senderFromServer) SponsorHash(tx *types.Transaction) common.Hash {
+	panic("can't sign with senderFromServer")
+}
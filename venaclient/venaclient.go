@@ -88,7 +88,41 @@ func (ec *Client) getBlock(ctx context.Context, method string, args ...interface
 	err := ec.c.CallContext(ctx, &raw, method, args...)
 	if err != nil {
 		return nil, err
-	} else if len(raw) == 0 {
+	}
+	return decodeBlock(raw)
+}
+
+// BlocksByNumber returns the blocks with the given numbers from the current
+// canonical chain, they are fetched in a single batch request.
+func (ec *Client) BlocksByNumber(ctx context.Context, numbers []*big.Int) ([]*types.Block, error) {
+	raws := make([]json.RawMessage, len(numbers))
+	reqs := make([]rpc.BatchElem, len(numbers))
+	for i, number := range numbers {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{toBlockNumArg(number), true},
+			Result: &raws[i],
+		}
+	}
+	if err := ec.c.BatchCallContext(ctx, reqs); err != nil {
+		return nil, err
+	}
+	blocks := make([]*types.Block, len(numbers))
+	for i := range reqs {
+		if reqs[i].Error != nil {
+			return nil, reqs[i].Error
+		}
+		block, err := decodeBlock(raws[i])
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = block
+	}
+	return blocks, nil
+}
+
+func decodeBlock(raw json.RawMessage) (*types.Block, error) {
+	if len(raw) == 0 || string(raw) == "null" {
 		return nil, ethereum.NotFound
 	}
 	// Decode header and transactions.
@@ -245,6 +279,32 @@ func (ec *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*
 	return r, err
 }
 
+// TransactionReceipts returns the receipts of the transactions, they are fetched
+// in a single batch request.
+func (ec *Client) TransactionReceipts(ctx context.Context, txHashes []common.Hash) ([]*types.Receipt, error) {
+	receipts := make([]*types.Receipt, len(txHashes))
+	reqs := make([]rpc.BatchElem, len(txHashes))
+	for i, hash := range txHashes {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{hash},
+			Result: &receipts[i],
+		}
+	}
+	if err := ec.c.BatchCallContext(ctx, reqs); err != nil {
+		return nil, err
+	}
+	for i := range reqs {
+		if reqs[i].Error != nil {
+			return nil, reqs[i].Error
+		}
+		if receipts[i] == nil {
+			return nil, ethereum.NotFound
+		}
+	}
+	return receipts, nil
+}
+
 func (ec *Client) RunBenchmark(ctx context.Context, paras interface{}) error {
 	return ec.c.CallContext(ctx, nil, "eth_runBenchmark", paras)
 }
@@ -334,6 +394,14 @@ func (ec *Client) CodeAt(ctx context.Context, account common.Address, blockNumbe
 	return result, err
 }
 
+// AbiAt returns the ABI stored with the contract of the given account.
+// The block number can be nil, in which case the ABI is taken from the latest known block.
+func (ec *Client) AbiAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var result hexutil.Bytes
+	err := ec.c.CallContext(ctx, &result, "eth_getAbi", account, toBlockNumArg(blockNumber))
+	return result, err
+}
+
 // NonceAt returns the account nonce of the given account.
 // The block number can be nil, in which case the nonce is taken from the latest known block.
 func (ec *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
@@ -342,6 +410,22 @@ func (ec *Client) NonceAt(ctx context.Context, account common.Address, blockNumb
 	return uint64(result), err
 }
 
+// SponsorLimit is the gas a sponsor pays at most for the transactions of an
+// account or calling a contract, and the gas it already paid.
+type SponsorLimit struct {
+	Limit uint64 `json:"limit"`
+	Used  uint64 `json:"used"`
+}
+
+// SponsorLimitAt returns the limit set by sponsor for the target account or contract.
+// The block number can be nil, in which case the limit is taken from the latest known block.
+// It returns nil if the sponsor set no limit for target.
+func (ec *Client) SponsorLimitAt(ctx context.Context, sponsor, target common.Address, blockNumber *big.Int) (*SponsorLimit, error) {
+	var result *SponsorLimit
+	err := ec.c.CallContext(ctx, &result, "eth_getSponsorLimit", sponsor, target, toBlockNumArg(blockNumber))
+	return result, err
+}
+
 // Filters
 
 // FilterLogs executes a filter query.